@@ -56,13 +56,13 @@ func signBundle(args []string) {
 		Value:             bib,
 	}
 
-	err = b.AddExtensionBlock(eb)
+	blockNumber, err := b.AddExtensionBlockNumbered(eb)
 	if err != nil {
 		printFatal(err, "Add Extension Block failed")
 
 	}
 
-	bibBlockAdded, _ := b.ExtensionBlock(bib.BlockTypeCode())
+	bibBlockAdded, _ := b.GetExtensionBlockByBlockNumber(blockNumber)
 
 	err = bibBlockAdded.Value.(*bpv7.BIBIOPHMACSHA2).SignTargets(b, bibBlockAdded.BlockNumber, []byte(psk))
 	if err != nil {
@@ -168,13 +168,13 @@ func encryptBundle(args []string) {
 		Value:             bcb,
 	}
 
-	err = b.AddExtensionBlock(eb)
+	blockNumber, err := b.AddExtensionBlockNumbered(eb)
 	if err != nil {
 		printFatal(err, "Add Extension Block failed")
 
 	}
 
-	bcbBlockAdded, _ := b.ExtensionBlock(bcb.BlockTypeCode())
+	bcbBlockAdded, _ := b.GetExtensionBlockByBlockNumber(blockNumber)
 
 	err = bcbBlockAdded.Value.(*bpv7.BCBIOPAESGCM).EncryptTarget(b, bcbBlockAdded.BlockNumber, []byte(psk))
 	if err != nil {
@@ -5,29 +5,20 @@
 package main
 
 import (
-	"encoding/hex"
-	"math"
 	"os"
 	"os/signal"
-	"path"
-	"path/filepath"
-	"sync"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/fsnotify/fsnotify"
-
 	"github.com/dtn7/dtn7-go/pkg/agent"
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
-// exchange Bundles between an user and a dtnd over the filesystem.
+// exchange Bundles between an user and a dtnd over the filesystem, bridging an agent.DirectoryAgent to a dtnd's
+// WebSocket agent.
 type exchange struct {
-	directory     string
-	knownFiles    sync.Map
-	websocketConn *agent.WebSocketAgentConnector
-	watcher       *fsnotify.Watcher
+	websocketConn  *agent.WebSocketAgentConnector
+	directoryAgent *agent.DirectoryAgent
 
 	closeChan      chan os.Signal
 	bundleReadChan chan bpv7.Bundle
@@ -47,9 +38,13 @@ func startExchange(args []string) {
 		err error
 	)
 
+	eid, err := bpv7.NewEndpointID(endpointId)
+	if err != nil {
+		printFatal(err, "Parsing endpoint ID erred")
+	}
+
 	ex := &exchange{
-		directory:      directory,
-		closeChan:      make(chan os.Signal),
+		closeChan:      make(chan os.Signal, 1),
 		bundleReadChan: make(chan bpv7.Bundle),
 	}
 
@@ -59,30 +54,18 @@ func startExchange(args []string) {
 		printFatal(err, "Starting WebSocketAgentConnector erred")
 	}
 
-	if ex.watcher, err = fsnotify.NewWatcher(); err != nil {
-		printFatal(err, "Starting file watcher erred")
-	}
-	if err = ex.watcher.Add(directory); err != nil {
-		printFatal(err, "Adding directory to file watcher erred")
+	if ex.directoryAgent, err = agent.NewDirectoryAgent(eid, directory); err != nil {
+		printFatal(err, "Starting DirectoryAgent erred")
 	}
 
 	go ex.handleBundleRead()
+	go ex.handleBundleWrite()
 	ex.handler()
 }
 
-// cleanFilepath creates a relative path from the initial path to a new file's path.
-func (ex *exchange) cleanFilepath(f string) string {
-	if rel, err := filepath.Rel(ex.directory, f); err != nil {
-		log.WithField("path", f).WithError(err).Fatal("Failed to clean file path")
-		return ""
-	} else {
-		return rel
-	}
-}
-
 func (ex *exchange) handler() {
 	defer func() {
-		_ = ex.watcher.Close()
+		ex.directoryAgent.MessageReceiver() <- agent.ShutdownMessage{}
 		ex.websocketConn.Close()
 	}()
 
@@ -92,92 +75,31 @@ func (ex *exchange) handler() {
 			log.Info("Received interrupt signal")
 			return
 
-		case e, ok := <-ex.watcher.Events:
-			if !ok {
-				log.Error("fsnotify's Event channel was closed")
-				return
-			}
-
-			if _, ok := ex.knownFiles.Load(ex.cleanFilepath(e.Name)); ok {
-				log.WithField("file", e.Name).Debug("Skipping file; already known")
-				continue
-			}
-
-			if e.Op&fsnotify.Create == 0 {
-				log.WithFields(log.Fields{
-					"file":      e.Name,
-					"operation": e.Op.String(),
-				}).Debug("Ignoring fsnotify event")
-				continue
-			}
-
-			ex.readNewFile(e)
-
-		case err, ok := <-ex.watcher.Errors:
-			if !ok {
-				log.Error("fsnotify's Errors channel was closed")
-				return
-			}
-
-			log.WithError(err).Error("fsnotify erred")
-			return
-
 		case b, ok := <-ex.bundleReadChan:
 			if !ok {
 				log.Error("Bundle reader channel was closed")
 				return
 			}
 
-			filePath := path.Join(ex.directory, hex.EncodeToString([]byte(b.ID().String())))
-			logger := log.WithFields(log.Fields{
-				"bundle": b.ID(),
-				"file":   filePath,
-			})
-
-			if f, err := os.Create(filePath); err != nil {
-				logger.WithError(err).Error("Creating file erred")
-				return
-			} else if err := b.MarshalCbor(f); err != nil {
-				logger.WithError(err).Error("Marshalling Bundle erred")
-			} else if err := f.Close(); err != nil {
-				logger.WithError(err).Error("Closing file erred")
-			}
-
-			ex.knownFiles.Store(ex.cleanFilepath(filePath), struct{}{})
-
-			logger.Info("Saved received Bundle")
+			ex.directoryAgent.MessageReceiver() <- agent.BundleMessage{Bundle: b}
 		}
 	}
 }
 
-func (ex *exchange) readNewFile(e fsnotify.Event) {
-	for i := 0; i < 5; i++ {
-		var b bpv7.Bundle
-
-		if f, err := os.Open(e.Name); err != nil {
-			log.WithError(err).WithField("file", e.Name).Warn("Opening file erred, retrying..")
-		} else if err := b.UnmarshalCbor(f); err != nil {
-			log.WithError(err).WithField("file", e.Name).Warn("Unmarshalling Bundle erred, retrying..")
-		} else if err := f.Close(); err != nil {
-			log.WithError(err).WithField("file", e.Name).Warn("Closing file erred, retrying..")
-		} else if err := ex.websocketConn.WriteBundle(b); err != nil {
-			log.WithError(err).WithFields(log.Fields{
-				"file":   e.Name,
-				"bundle": b.ID().String(),
-			}).Error("Sending Bundle erred")
-			return
-		} else {
-			log.WithError(err).WithFields(log.Fields{
-				"file":   e.Name,
-				"bundle": b.ID().String(),
-			}).Info("Sent Bundle")
-			return
+// handleBundleWrite sends the DirectoryAgent's Bundles to the dtnd.
+func (ex *exchange) handleBundleWrite() {
+	for m := range ex.directoryAgent.MessageSender() {
+		bm, ok := m.(agent.BundleMessage)
+		if !ok {
+			continue
 		}
 
-		time.Sleep(time.Duration(math.Pow(2, float64(i))) * 100 * time.Millisecond)
+		if err := ex.websocketConn.WriteBundle(bm.Bundle); err != nil {
+			log.WithError(err).WithField("bundle", bm.Bundle.ID().String()).Error("Sending Bundle erred")
+		} else {
+			log.WithField("bundle", bm.Bundle.ID().String()).Info("Sent Bundle")
+		}
 	}
-
-	log.WithField("file", e.Name).Error("Failed to process file, giving up.")
 }
 
 func (ex *exchange) handleBundleRead() {
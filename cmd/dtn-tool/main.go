@@ -11,7 +11,7 @@ import (
 
 // printUsage of dtn-tool and exit with an error code afterwards.
 func printUsage() {
-	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s create|exchange|sign|verify|encrypt|decrypt|ping|show:\n\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "Usage of %s create|exchange|sign|verify|encrypt|decrypt|ping|traceroute|pipe|show|watch:\n\n", os.Args[0])
 
 	_, _ = fmt.Fprintf(os.Stderr, "%s create sender receiver -|filename [-|filename]\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  Creates a new Bundle, addressed from sender to receiver with the stdin (-)\n")
@@ -23,7 +23,8 @@ func printUsage() {
 	_, _ = fmt.Fprintf(os.Stderr, "%s exchange websocket endpoint-id directory\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  %s registeres itself as an agent on the given websocket and writes\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  incoming Bundles in the directory. If the user dropps a new Bundle in the\n")
-	_, _ = fmt.Fprintf(os.Stderr, "  directory, it will be sent to the server.\n\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  directory, it will be sent to the server. Write it under a temporary\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  name, starting with a dot or ending with .tmp, and rename it afterwards.\n\n")
 
 	_, _ = fmt.Fprintf(os.Stderr, "%s sign bundle key filename\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  signs a bundle with the given key and writes the signed bundle to the given filename.\n")
@@ -40,8 +41,23 @@ func printUsage() {
 	_, _ = fmt.Fprintf(os.Stderr, "%s ping websocket sender receiver\n", os.Args[0])
 	_, _ = fmt.Fprintf(os.Stderr, "  Send continuously bundles from sender to receiver over a websocket.\n\n")
 
-	_, _ = fmt.Fprintf(os.Stderr, "%s show -|filename\n", os.Args[0])
-	_, _ = fmt.Fprintf(os.Stderr, "  Prints a JSON version of a Bundle, read from stdin (-) or filename.\n\n")
+	_, _ = fmt.Fprintf(os.Stderr, "%s traceroute websocket sender receiver [timeout]\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Sends a probe bundle from sender to receiver over a websocket and prints\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  each node reporting its forwarding or delivery, together with the latency.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s pipe websocket source destination\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Reads payloads from stdin, each prefixed by its length as a big-endian\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  uint32, and sends them as bundles from source to destination over a\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  websocket. Received payloads are written to stdout in the same format.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s show [-base64|-hex] -|filename\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Prints a JSON version of a Bundle, read from stdin (-) or filename. The\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  Bundle might be encoded in base64 (-base64) or hex (-hex) instead of CBOR.\n\n")
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s watch websocket endpoint-id\n", os.Args[0])
+	_, _ = fmt.Fprintf(os.Stderr, "  Registers itself as an agent on the given websocket and prints a JSON line\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  for each Bundle delivered to the endpoint, e.g., to be piped into jq. A\n")
+	_, _ = fmt.Fprintf(os.Stderr, "  dropped websocket will be reconnected.\n\n")
 
 	os.Exit(1)
 }
@@ -79,9 +95,18 @@ func main() {
 	case "ping":
 		ping(os.Args[2:])
 
+	case "traceroute":
+		traceroute(os.Args[2:])
+
+	case "pipe":
+		pipe(os.Args[2:])
+
 	case "show":
 		showBundle(os.Args[2:])
 
+	case "watch":
+		watch(os.Args[2:])
+
 	default:
 		printUsage()
 	}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// maxPipePayload limits a single payload of the pipe's length-delimited stream.
+const maxPipePayload = 64 * 1024 * 1024
+
+// bundleConn is a bidirectional Bundle connection, implemented by the agent.WebSocketAgentConnector.
+type bundleConn interface {
+	ReadBundle() (bpv7.Bundle, error)
+	WriteBundle(bpv7.Bundle) error
+}
+
+// readPayload reads the next payload of a length-delimited stream, prefixed by its length as a big-endian uint32.
+func readPayload(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	} else if length > maxPipePayload {
+		return nil, fmt.Errorf("payload length %d exceeds the maximum of %d bytes", length, maxPipePayload)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// writePayload writes a payload to a length-delimited stream, compare readPayload.
+func writePayload(w io.Writer, payload []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(payload))); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// runPipe sends each payload read from in as a Bundle from source to destination, while writing each received
+// Bundle's payload to out. After in's end, Bundles are still received until the bundleConn breaks down.
+func runPipe(conn bundleConn, source, destination bpv7.EndpointID, in io.Reader, out io.Writer) error {
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			b, err := conn.ReadBundle()
+			if err != nil {
+				// The bundleConn breaking down is the regular end of receiving, e.g., after being closed.
+				log.WithError(err).Info("Receiving Bundles stopped")
+				recvErr <- nil
+				return
+			}
+
+			if pb, err := b.PayloadBlock(); err != nil {
+				log.WithError(err).WithField("bundle", b.ID().String()).Warn("Received Bundle without a payload")
+			} else if err := writePayload(out, pb.Value.(*bpv7.PayloadBlock).Data()); err != nil {
+				recvErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		payload, err := readPayload(in)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(source).
+			Destination(destination).
+			CreationTimestampNow().
+			Lifetime("24h").
+			HopCountBlock(64).
+			PayloadBlock(payload).
+			Build()
+		if err != nil {
+			return err
+		} else if err := conn.WriteBundle(b); err != nil {
+			return err
+		}
+	}
+
+	return <-recvErr
+}
+
+// pipe payloads from stdin to a remote endpoint while writing received payloads to stdout.
+func pipe(args []string) {
+	if len(args) != 3 {
+		printUsage()
+	}
+
+	source, err := bpv7.NewEndpointID(args[1])
+	if err != nil {
+		printFatal(err, "Parsing source erred")
+	}
+
+	destination, err := bpv7.NewEndpointID(args[2])
+	if err != nil {
+		printFatal(err, "Parsing destination erred")
+	}
+
+	websocketConn, err := agent.NewWebSocketAgentConnector(args[0], source.String())
+	if err != nil {
+		printFatal(err, "Starting WebSocketAgentConnector erred")
+	}
+
+	closeChan := make(chan os.Signal, 1)
+	signal.Notify(closeChan, os.Interrupt)
+	go func() {
+		<-closeChan
+		websocketConn.Close()
+	}()
+
+	if err := runPipe(websocketConn, source, destination, bufio.NewReader(os.Stdin), os.Stdout); err != nil {
+		printFatal(err, "Piping erred")
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla/websocket"
+	"github.com/dtn7/dtn7-go/pkg/routing"
+)
+
+// pipeTestNode is a Core with a WebSocketAgent at "/ws" and a WebSocket CLA listener at "/bundles".
+type pipeTestNode struct {
+	core   *routing.Core
+	server *httptest.Server
+}
+
+func newPipeTestNode(t *testing.T, nodeId string) *pipeTestNode {
+	eid := bpv7.MustNewEndpointID(nodeId)
+
+	core, err := routing.NewCore(t.TempDir(), eid, false, routing.RoutingConf{Algorithm: "epidemic"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	core.Cron = routing.NewCron()
+	t.Cleanup(core.Close)
+
+	wsAgent := agent.NewWebSocketAgent()
+	core.RegisterApplicationAgent(wsAgent)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", wsAgent.ServeHTTP)
+	core.RegisterConvergable(websocket.Listen(mux, "/bundles", eid))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return &pipeTestNode{core: core, server: server}
+}
+
+func (node *pipeTestNode) url(path string) string {
+	return "ws" + strings.TrimPrefix(node.server.URL, "http") + path
+}
+
+func TestPayloadFraming(t *testing.T) {
+	payloads := [][]byte{[]byte("hello"), {}, bytes.Repeat([]byte{0x23}, 4096)}
+
+	var buff bytes.Buffer
+	for _, payload := range payloads {
+		if err := writePayload(&buff, payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, payload := range payloads {
+		if readPayload, err := readPayload(&buff); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(readPayload, payload) {
+			t.Fatalf("read payload %x, expected %x", readPayload, payload)
+		}
+	}
+
+	if _, err := readPayload(&buff); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	nodeA := newPipeTestNode(t, "dtn://a/")
+	nodeB := newPipeTestNode(t, "dtn://b/")
+	nodeA.core.RegisterConvergable(websocket.Dial(nodeB.url("/bundles"), bpv7.MustNewEndpointID("dtn://a/"), false))
+
+	pipeA, pipeB := bpv7.MustNewEndpointID("dtn://a/pipe"), bpv7.MustNewEndpointID("dtn://b/pipe")
+
+	connA, err := agent.NewWebSocketAgentConnector(nodeA.url("/ws"), pipeA.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	connB, err := agent.NewWebSocketAgentConnector(nodeB.url("/ws"), pipeB.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloads := [][]byte{[]byte("hello"), []byte("world")}
+
+	var inA bytes.Buffer
+	for _, payload := range payloads {
+		if err := writePayload(&inA, payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	outA, outB := new(bytes.Buffer), new(bytes.Buffer)
+	outBReader, outBWriter := io.Pipe()
+
+	pipeErrs := make(chan error, 2)
+	go func() { pipeErrs <- runPipe(connA, pipeA, pipeB, &inA, outA) }()
+	go func() { pipeErrs <- runPipe(connB, pipeB, pipeA, outB, outBWriter) }()
+
+	received := make(chan []byte)
+	go func() {
+		for {
+			payload, err := readPayload(outBReader)
+			if err != nil {
+				return
+			}
+			received <- payload
+		}
+	}()
+
+	for _, payload := range payloads {
+		select {
+		case recvPayload := <-received:
+			if !reflect.DeepEqual(recvPayload, payload) {
+				t.Fatalf("received payload %q, expected %q", recvPayload, payload)
+			}
+
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timeout while waiting for payload %q", payload)
+		}
+	}
+
+	connA.Close()
+	connB.Close()
+	_ = outBWriter.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := <-pipeErrs; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
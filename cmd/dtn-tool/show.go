@@ -5,6 +5,9 @@
 package main
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -12,8 +15,41 @@ import (
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
+// readBundle from r, which is either raw CBOR or encoded as "base64" or "hex", as indicated by the encoding.
+func readBundle(r io.Reader, encoding string) (b bpv7.Bundle, err error) {
+	if encoding == "" {
+		return bpv7.ParseBundle(r)
+	}
+
+	encoded, err := io.ReadAll(r)
+	if err != nil {
+		return
+	}
+	encoded = bytes.TrimSpace(encoded)
+
+	var data []byte
+	switch encoding {
+	case "base64":
+		data, err = base64.StdEncoding.DecodeString(string(encoded))
+	case "hex":
+		data, err = hex.DecodeString(string(encoded))
+	default:
+		err = fmt.Errorf("unknown encoding %q", encoding)
+	}
+	if err != nil {
+		return
+	}
+
+	return bpv7.ParseBundle(bytes.NewReader(data))
+}
+
 // showBundle for the "show" CLI options.
 func showBundle(args []string) {
+	var encoding string
+	if len(args) == 2 && (args[0] == "-base64" || args[0] == "-hex") {
+		encoding, args = args[0][1:], args[1:]
+	}
+
 	if len(args) != 1 {
 		printUsage()
 	}
@@ -33,7 +69,7 @@ func showBundle(args []string) {
 		printFatal(err, "Opening file for reading erred")
 	}
 
-	if err = b.UnmarshalCbor(f); err != nil {
+	if b, err = readBundle(f, encoding); err != nil {
 		printFatal(err, "Unmarshaling Bundle erred")
 	}
 	if err = f.Close(); err != nil {
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestReadBundle(t *testing.T) {
+	b, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buff bytes.Buffer
+	if err := b.WriteBundle(&buff); err != nil {
+		t.Fatal(err)
+	}
+	raw := buff.Bytes()
+
+	tests := []struct {
+		name     string
+		encoding string
+		data     string
+		valid    bool
+	}{
+		{"raw", "", string(raw), true},
+		{"base64", "base64", base64.StdEncoding.EncodeToString(raw), true},
+		{"base64 newline", "base64", base64.StdEncoding.EncodeToString(raw) + "\n", true},
+		{"hex", "hex", hex.EncodeToString(raw), true},
+		{"hex newline", "hex", hex.EncodeToString(raw) + "\n", true},
+		{"invalid base64", "base64", "not base64!", false},
+		{"invalid hex", "hex", "xyz", false},
+		{"unknown encoding", "base32", hex.EncodeToString(raw), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b2, err := readBundle(strings.NewReader(test.data), test.encoding)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid = %t, got error %v", test.valid, err)
+			} else if test.valid && !reflect.DeepEqual(b, b2) {
+				t.Fatalf("Bundles differ:\n%v\n%v", b, b2)
+			}
+		})
+	}
+}
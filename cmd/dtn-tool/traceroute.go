@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// traceroute a path from the sender to a receiver, printing each reporting hop.
+func traceroute(args []string) {
+	if len(args) != 3 && len(args) != 4 {
+		printUsage()
+	}
+
+	sender, err := bpv7.NewEndpointID(args[1])
+	if err != nil {
+		printFatal(err, "Parsing sender erred")
+	}
+
+	receiver, err := bpv7.NewEndpointID(args[2])
+	if err != nil {
+		printFatal(err, "Parsing receiver erred")
+	}
+
+	timeout := 30 * time.Second
+	if len(args) == 4 {
+		if timeout, err = time.ParseDuration(args[3]); err != nil {
+			printFatal(err, "Parsing timeout erred")
+		}
+	}
+
+	websocketConn, err := agent.NewWebSocketAgentConnector(args[0], sender.String())
+	if err != nil {
+		printFatal(err, "Starting WebSocketAgentConnector erred")
+	}
+	defer websocketConn.Close()
+
+	probe, err := agent.NewTracerouteProbe(sender, receiver, timeout)
+	if err != nil {
+		printFatal(err, "Creating probe bundle erred")
+	}
+
+	tr := agent.NewTraceroute(probe)
+	if err := websocketConn.WriteBundle(probe); err != nil {
+		printFatal(err, "Sending probe bundle erred")
+	}
+
+	fmt.Printf("traceroute to %v from %v, %v timeout\n", receiver, sender, timeout)
+
+	bundleReadChan := make(chan bpv7.Bundle)
+	go func() {
+		for {
+			if b, err := websocketConn.ReadBundle(); err != nil {
+				log.WithError(err).Debug("Reading Bundle erred")
+				close(bundleReadChan)
+				return
+			} else {
+				bundleReadChan <- b
+			}
+		}
+	}()
+
+	deadline := time.After(timeout)
+	for hopNo := 1; !tr.Delivered(); {
+		select {
+		case b, ok := <-bundleReadChan:
+			if !ok {
+				printFatal(fmt.Errorf("connection closed"), "Reading status reports erred")
+			}
+
+			if hop, ok := tr.Inspect(b); ok {
+				fmt.Printf("%2d  %v  %v  %v\n", hopNo, hop.Node, hop.Status, hop.Latency.Round(time.Millisecond))
+				hopNo++
+			}
+
+		case <-deadline:
+			fmt.Printf("timeout after %v, destination was not reached\n", timeout)
+			return
+		}
+	}
+}
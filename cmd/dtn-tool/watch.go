@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/signal"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+const (
+	// watchMinBackoff is the initial delay before reconnecting a broken or failed connection.
+	watchMinBackoff = time.Second
+
+	// watchMaxBackoff limits the exponentially growing delay between reconnection attempts.
+	watchMaxBackoff = 30 * time.Second
+)
+
+// bundleReader is a closable source of Bundles, implemented by the agent.WebSocketAgentConnector.
+type bundleReader interface {
+	ReadBundle() (bpv7.Bundle, error)
+	Close()
+}
+
+// watchConnection prints each Bundle read from conn as a JSON line to out, flushing out after each Bundle. It returns
+// after the connection broke down, or with an error if writing to out failed.
+func watchConnection(conn bundleReader, out *bufio.Writer) error {
+	for {
+		b, err := conn.ReadBundle()
+		if err != nil {
+			log.WithError(err).Warn("Reading Bundle erred")
+			return nil
+		}
+
+		line, err := b.MarshalJSON()
+		if err != nil {
+			log.WithError(err).WithField("bundle", b.ID().String()).Warn("Marshaling JSON erred")
+			continue
+		}
+
+		if _, err := out.Write(append(line, '\n')); err != nil {
+			return err
+		} else if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// runWatch prints the Bundles of connections, created by dial, until stop is closed. A failed or broken connection is
+// dialed again after an exponential backoff, starting at minBackoff.
+func runWatch(dial func() (bundleReader, error), out *bufio.Writer, stop <-chan struct{}, minBackoff time.Duration) error {
+	backoff := minBackoff
+
+	for {
+		if conn, err := dial(); err != nil {
+			log.WithError(err).WithField("backoff", backoff).Warn("Connecting erred, retrying")
+		} else {
+			log.Info("Connected, watching Bundles")
+			backoff = minBackoff
+
+			done := make(chan struct{})
+			go func() {
+				select {
+				case <-stop:
+					conn.Close()
+				case <-done:
+				}
+			}()
+
+			watchErr := watchConnection(conn, out)
+
+			close(done)
+			conn.Close()
+
+			if watchErr != nil {
+				return watchErr
+			}
+		}
+
+		select {
+		case <-stop:
+			return nil
+
+		case <-time.After(backoff):
+			if backoff *= 2; backoff > watchMaxBackoff {
+				backoff = watchMaxBackoff
+			}
+		}
+	}
+}
+
+// watch the Bundles delivered to an endpoint of a running node, printing each as a JSON line to stdout.
+func watch(args []string) {
+	if len(args) != 2 {
+		printUsage()
+	}
+
+	var (
+		websocketAddr = args[0]
+		endpointId    = args[1]
+	)
+
+	if _, err := bpv7.NewEndpointID(endpointId); err != nil {
+		printFatal(err, "Parsing endpoint ID erred")
+	}
+
+	dial := func() (bundleReader, error) {
+		return agent.NewWebSocketAgentConnector(websocketAddr, endpointId)
+	}
+
+	closeChan := make(chan os.Signal, 1)
+	signal.Notify(closeChan, os.Interrupt)
+
+	stop := make(chan struct{})
+	go func() {
+		<-closeChan
+		close(stop)
+	}()
+
+	if err := runWatch(dial, bufio.NewWriter(os.Stdout), stop, watchMinBackoff); err != nil {
+		printFatal(err, "Watching erred")
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// mockBundleReader returns its Bundles, followed by an error. If block is set, it blocks until being closed instead.
+type mockBundleReader struct {
+	bundles chan bpv7.Bundle
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newMockBundleReader(block bool, bundles ...bpv7.Bundle) *mockBundleReader {
+	mbr := &mockBundleReader{
+		bundles: make(chan bpv7.Bundle, len(bundles)),
+		closed:  make(chan struct{}),
+	}
+	for _, b := range bundles {
+		mbr.bundles <- b
+	}
+	if !block {
+		close(mbr.bundles)
+	}
+	return mbr
+}
+
+func (mbr *mockBundleReader) ReadBundle() (bpv7.Bundle, error) {
+	select {
+	case b, ok := <-mbr.bundles:
+		if !ok {
+			return bpv7.Bundle{}, fmt.Errorf("connection dropped")
+		}
+		return b, nil
+	case <-mbr.closed:
+		return bpv7.Bundle{}, fmt.Errorf("connection closed")
+	}
+}
+
+func (mbr *mockBundleReader) Close() {
+	mbr.once.Do(func() { close(mbr.closed) })
+}
+
+// syncBuffer is a bytes.Buffer, safe for concurrent use.
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mutex.Lock()
+	defer sb.mutex.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) lines() []string {
+	sb.mutex.Lock()
+	defer sb.mutex.Unlock()
+	return strings.Split(strings.TrimSuffix(sb.buf.String(), "\n"), "\n")
+}
+
+func TestRunWatch(t *testing.T) {
+	var bundles []bpv7.Bundle
+	for i := 0; i < 3; i++ {
+		b, err := bpv7.Builder().
+			Source("dtn://src/").
+			Destination("dtn://dst/watch").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte(fmt.Sprintf("bundle %d", i))).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundles = append(bundles, b)
+	}
+
+	// The first connection drops after two Bundles, followed by a failed reconnection. The third connection stays.
+	var dials int
+	dial := func() (bundleReader, error) {
+		dials++
+		switch dials {
+		case 1:
+			return newMockBundleReader(false, bundles[0], bundles[1]), nil
+		case 2:
+			return nil, fmt.Errorf("connection refused")
+		default:
+			return newMockBundleReader(true, bundles[2]), nil
+		}
+	}
+
+	var out syncBuffer
+	stop := make(chan struct{})
+	errChan := make(chan error)
+	go func() { errChan <- runWatch(dial, bufio.NewWriter(&out), stop, 10*time.Millisecond) }()
+
+	// Each Bundle is flushed on its own, even while the connection stays open.
+	for deadline := time.Now().Add(time.Second); len(out.lines()) < len(bundles); {
+		if time.Now().After(deadline) {
+			t.Fatalf("only got %d lines", len(out.lines()))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	close(stop)
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watch did not stop")
+	}
+
+	lines := out.lines()
+	if len(lines) != len(bundles) {
+		t.Fatalf("expected %d lines, got %d", len(bundles), len(lines))
+	}
+	for i, line := range lines {
+		var record struct {
+			PrimaryBlock struct {
+				Source string `json:"source"`
+			} `json:"primaryBlock"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line %d is no JSON: %v", i, err)
+		} else if record.PrimaryBlock.Source != "dtn://src/" {
+			t.Fatalf("line %d has source %q", i, record.PrimaryBlock.Source)
+		}
+	}
+
+	if dials != 3 {
+		t.Fatalf("expected 3 dials, got %d", dials)
+	}
+}
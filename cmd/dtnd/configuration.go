@@ -7,10 +7,13 @@ package main
 
 import (
 	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
@@ -19,16 +22,17 @@ import (
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/BurntSushi/toml"
-
 	"github.com/dtn7/dtn7-go/pkg/agent"
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/bbc"
+	"github.com/dtn7/dtn7-go/pkg/cla/bibe"
 	"github.com/dtn7/dtn7-go/pkg/cla/mtcp"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4"
+	"github.com/dtn7/dtn7-go/pkg/cla/websocket"
 	"github.com/dtn7/dtn7-go/pkg/discovery"
 	"github.com/dtn7/dtn7-go/pkg/routing"
+	"github.com/dtn7/dtn7-go/pkg/storage"
 )
 
 type ConfigError struct {
@@ -64,6 +68,51 @@ type coreConf struct {
 	InspectAllBundles bool   `toml:"inspect-all-bundles"`
 	NodeId            string `toml:"node-id"`
 	SignPriv          string `toml:"signature-private"`
+	FutureTolerance   string `toml:"future-timestamp-tolerance"`
+
+	// RejectIndefiniteByteStrings rejects bundles whose block data is an indefinite-length CBOR byte string.
+	RejectIndefiniteByteStrings bool `toml:"reject-indefinite-byte-strings"`
+
+	// LenientBlockCRC handles canonical blocks with an invalid CRC by their block control flags.
+	LenientBlockCRC bool `toml:"lenient-block-crc"`
+
+	// PreserveBlockOrder keeps the canonical blocks' received order when modifying bundles, e.g., while forwarding.
+	PreserveBlockOrder bool `toml:"preserve-block-order"`
+
+	// StoreBackend selects the storage.BundleStore by its name, e.g., storage.BackendBBolt; badgerhold by default.
+	StoreBackend string `toml:"store-backend"`
+
+	// ReceiveQueueDepth bounds each CLA's queue of received bundles; disabled for zero.
+	ReceiveQueueDepth int `toml:"receive-queue-depth"`
+	// ReceiveQueuePolicy for a full receive queue: "block" (default), "drop-oldest", or "drop-new".
+	ReceiveQueuePolicy string `toml:"receive-queue-policy"`
+
+	// CLAPreference orders CLA types, e.g., ["quicl", "tcpclv4", "mtcp"], for peers reachable by multiple CLAs.
+	CLAPreference []string `toml:"cla-preference"`
+
+	// BundleRing captures this many recently dispatched bundles in memory for debugging; disabled for zero.
+	BundleRing int `toml:"bundle-ring"`
+
+	// NDJSONSink appends each received, delivered, and forwarded bundle's metadata as newline-delimited JSON to a
+	// rotating file.
+	NDJSONSink ndjsonSinkConf `toml:"ndjson-sink"`
+
+	// DeadLetter is a local endpoint, receiving each bundle before it is dropped; disabled if empty.
+	DeadLetter string `toml:"dead-letter"`
+
+	// IdentityKeys maps node IDs to their hex encoded ed25519 public keys, verifying their identity assertions.
+	IdentityKeys map[string]string `toml:"identity-keys"`
+
+	// IntegrityKeys maps security source node IDs to their hex encoded BIB keys, verifying received bundles' BIBs.
+	IntegrityKeys map[string]string `toml:"integrity-keys"`
+}
+
+// ndjsonSinkConf describes the optional NDJSON sink of the Core-configuration block; disabled for an empty Path.
+type ndjsonSinkConf struct {
+	Path    string
+	MaxSize int64  `toml:"max-size"`
+	MaxAge  string `toml:"max-age"`
+	Payload bool
 }
 
 type cronConf struct {
@@ -84,19 +133,39 @@ type discoveryConf struct {
 	IPv4     bool
 	IPv6     bool
 	Interval uint
+
+	// LocalIds are further identities of this node whose announcements are ignored to prevent self-dialing.
+	LocalIds []string `toml:"local-ids"`
+
+	// Address4, Address6 and Port set the multicast groups, Interface restricts the accepted announcements' origins.
+	Address4  string
+	Address6  string
+	Port      uint
+	Interface string
 }
 
 // agentsConfig describes the ApplicationAgents/Agent-configuration block.
 type agentsConfig struct {
 	Ping      string
+	Status    string
+	Directory agentsDirectoryConfig
 	Webserver agentsWebserverConfig
 }
 
+// agentsDirectoryConfig describes the nested "Directory" configuration for a drop folder agent.
+type agentsDirectoryConfig struct {
+	Endpoint string
+	Path     string
+}
+
 // agentsWebserverConfig describes the nested "Webserver" configuration for agents.
 type agentsWebserverConfig struct {
 	Address   string
 	Websocket bool
 	Rest      bool
+
+	// RestMailboxTTL drops REST clients' mailboxes after being inactive for this duration, e.g., "24h".
+	RestMailboxTTL string `toml:"rest-mailbox-ttl"`
 }
 
 // convergenceConf describes the Convergence-configuration block, used for
@@ -105,15 +174,147 @@ type convergenceConf struct {
 	Node     string
 	Protocol string
 	Endpoint string
+
+	// Compression enables the negotiation of a compressed MTCP stream.
+	Compression bool
+
+	// TLSCert and TLSKey are the PEM encoded certificate and key files of a "listen" block, enabling TLS for MTCP.
+	TLSCert string `toml:"tls-cert"`
+	TLSKey  string `toml:"tls-key"`
+
+	// TLSCA is a PEM encoded file of certificates trusted by a "peer" block, enabling TLS for MTCP.
+	TLSCA string `toml:"tls-ca"`
+
+	// Keepalive in seconds, SegmentMru, and TransferMru in bytes are offered in TCPCLv4 sessions; defaults if unset.
+	// A Keepalive of zero disables keepalives.
+	Keepalive   *uint16 `toml:"keepalive"`
+	SegmentMru  uint64  `toml:"segment-mru"`
+	TransferMru uint64  `toml:"transfer-mru"`
+
+	// IdleTimeout, HandshakeTimeout, KeepAlivePeriod, e.g., "30s", and MaxIncomingStreams configure QUICL connections.
+	IdleTimeout        string `toml:"idle-timeout"`
+	HandshakeTimeout   string `toml:"handshake-timeout"`
+	KeepAlivePeriod    string `toml:"keepalive-period"`
+	MaxIncomingStreams int64  `toml:"max-incoming-streams"`
+
+	// MaxConcurrentStreams and MaxConnectionStreams limit the bundles handled concurrently by a QUICL listener over
+	// all its connections and per connection. Capacity is shared fairly between connections; unlimited if unset.
+	MaxConcurrentStreams int `toml:"max-concurrent-streams"`
+	MaxConnectionStreams int `toml:"max-connection-streams"`
+
+	// RebindInterval and RebindMaxInterval, e.g., "10s", configure the backoff between attempts to bind an occupied
+	// MTCP or TCPCLv4 listen address or to bind it again after its socket died; defaults if unset.
+	RebindInterval    string `toml:"rebind-interval"`
+	RebindMaxInterval string `toml:"rebind-max-interval"`
+}
+
+// sessionConfig returns a TCPCLv4 block's tcpclv4.SessionConfig.
+func (conv convergenceConf) sessionConfig() tcpclv4.SessionConfig {
+	return tcpclv4.SessionConfig{
+		Keepalive:   conv.Keepalive,
+		SegmentMru:  conv.SegmentMru,
+		TransferMru: conv.TransferMru,
+	}
 }
 
-func parseListenPort(endpoint string) (port int, err error) {
-	var portStr string
-	_, portStr, err = net.SplitHostPort(endpoint)
+// quiclConfig parses a QUICL block's quicl.QuiclConfig. Unset durations fall back to the defaults.
+func (conv convergenceConf) quiclConfig() (conf quicl.QuiclConfig, err error) {
+	durations := []struct {
+		name  string
+		value string
+		field *time.Duration
+	}{
+		{"idle-timeout", conv.IdleTimeout, &conf.MaxIdleTimeout},
+		{"handshake-timeout", conv.HandshakeTimeout, &conf.HandshakeTimeout},
+		{"keepalive-period", conv.KeepAlivePeriod, &conf.KeepAlivePeriod},
+	}
+
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		} else if *duration.field, err = time.ParseDuration(duration.value); err != nil {
+			err = fmt.Errorf("invalid QUICL %s: %w", duration.name, err)
+			return
+		}
+	}
+
+	conf.MaxIncomingStreams = conv.MaxIncomingStreams
+	conf.MaxConcurrentStreams = conv.MaxConcurrentStreams
+	conf.MaxConnectionStreams = conv.MaxConnectionStreams
+	return
+}
+
+// rebindBackoff parses a "listen" block's cla.BackoffConfig for rebinding its address. Unset durations fall back to
+// the defaults.
+func (conv convergenceConf) rebindBackoff() (conf cla.BackoffConfig, err error) {
+	durations := []struct {
+		name  string
+		value string
+		field *time.Duration
+	}{
+		{"rebind-interval", conv.RebindInterval, &conf.Base},
+		{"rebind-max-interval", conv.RebindMaxInterval, &conf.Max},
+	}
+
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		} else if *duration.field, err = time.ParseDuration(duration.value); err != nil {
+			err = fmt.Errorf("invalid %s: %w", duration.name, err)
+			return
+		}
+	}
+	return
+}
+
+// tlsServerConfig creates a "listen" block's tls.Config from its certificate and key files. Without both files, nil
+// is returned for a cleartext connection.
+func tlsServerConfig(conv convergenceConf) (*tls.Config, error) {
+	if conv.TLSCert == "" && conv.TLSKey == "" {
+		return nil, nil
+	} else if conv.TLSCert == "" || conv.TLSKey == "" {
+		return nil, fmt.Errorf("TLS requires both a certificate and a key")
+	}
+
+	cert, err := tls.LoadX509KeyPair(conv.TLSCert, conv.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate erred: %v", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
+// tlsClientConfig creates a "peer" block's tls.Config, trusting the certificates of its CA file. Without a CA file,
+// nil is returned for a cleartext connection.
+func tlsClientConfig(conv convergenceConf) (*tls.Config, error) {
+	if conv.TLSCA == "" {
+		return nil, nil
+	}
+
+	pem, err := os.ReadFile(conv.TLSCA)
+	if err != nil {
+		return nil, fmt.Errorf("reading TLS CA file erred: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("TLS CA file %s contains no certificates", conv.TLSCA)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// parseListenAddress returns a listen endpoint's port and its host to be announced. Unspecified hosts, e.g., ":4556"
+// or "0.0.0.0:4556", and loopback hosts result in an empty address, letting peers dial the announcement's origin.
+func parseListenAddress(endpoint string) (address string, port int, err error) {
+	var host, portStr string
+	host, portStr, err = net.SplitHostPort(endpoint)
 	if err != nil {
 		return
 	}
 	port, err = strconv.Atoi(portStr)
+
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !(ip.IsUnspecified() || ip.IsLoopback())) {
+		address = host
+	}
 	return
 }
 
@@ -144,7 +345,7 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 		return conn, nodeId, cla.BBC, discovery.Announcement{}, err
 
 	case "mtcp":
-		portInt, err := parseListenPort(conv.Endpoint)
+		address, portInt, err := parseListenAddress(conv.Endpoint)
 		if err != nil {
 			return nil, nodeId, cla.MTCP, discovery.Announcement{}, err
 		}
@@ -153,28 +354,53 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 			Type:     cla.MTCP,
 			Endpoint: nodeId,
 			Port:     uint(portInt),
+			Address:  address,
 		}
 
-		return mtcp.NewMTCPServer(conv.Endpoint, nodeId, true), nodeId, cla.MTCP, msg, nil
+		server := mtcp.NewMTCPServer(conv.Endpoint, nodeId, true)
+		if backoff, err := conv.rebindBackoff(); err != nil {
+			return nil, nodeId, cla.MTCP, discovery.Announcement{}, err
+		} else {
+			server.SetRebindBackoff(backoff)
+		}
+		if conv.Compression {
+			server.EnableCompression(mtcp.Deflate)
+		}
+		if tlsConf, err := tlsServerConfig(conv); err != nil {
+			return nil, nodeId, cla.MTCP, discovery.Announcement{}, err
+		} else if tlsConf != nil {
+			server.EnableTLS(tlsConf)
+
+			// Discovered peers would connect in cleartext. Thus, TLS servers are not announced.
+			msg = discovery.Announcement{}
+		}
+
+		return server, nodeId, cla.MTCP, msg, nil
 
 	case "tcpclv4":
-		portInt, err := parseListenPort(conv.Endpoint)
+		address, portInt, err := parseListenAddress(conv.Endpoint)
 		if err != nil {
 			return nil, nodeId, cla.TCPCLv4, discovery.Announcement{}, err
 		}
 
-		listener := tcpclv4.ListenTCP(conv.Endpoint, nodeId)
+		listener := tcpclv4.ListenTCPWithConfig(conv.Endpoint, nodeId, conv.sessionConfig())
+		if backoff, err := conv.rebindBackoff(); err != nil {
+			return nil, nodeId, cla.TCPCLv4, discovery.Announcement{}, err
+		} else {
+			listener.SetRebindBackoff(backoff)
+		}
 
 		msg := discovery.Announcement{
 			Type:     cla.TCPCLv4,
 			Endpoint: nodeId,
 			Port:     uint(portInt),
+			Address:  address,
 		}
 
 		return listener, nodeId, cla.TCPCLv4, msg, nil
 
 	case "tcpclv4-ws":
-		listener := tcpclv4.ListenWebSocket(nodeId)
+		listener := tcpclv4.ListenWebSocketWithConfig(nodeId, conv.sessionConfig())
 
 		httpMux := http.NewServeMux()
 		httpMux.Handle("/tcpclv4", listener)
@@ -195,18 +421,44 @@ func parseListen(conv convergenceConf, nodeId bpv7.EndpointID) (cla.Convergable,
 			return listener, nodeId, cla.TCPCLv4WebSocket, discovery.Announcement{}, nil
 		}
 
+	case "websocket":
+		httpMux := http.NewServeMux()
+		listener := websocket.Listen(httpMux, "/bundles", nodeId)
+		httpServer := &http.Server{
+			Addr:              conv.Endpoint,
+			Handler:           httpMux,
+			ReadHeaderTimeout: 60 * time.Second,
+		}
+
+		errChan := make(chan error)
+		go func() { errChan <- httpServer.ListenAndServe() }()
+
+		select {
+		case err := <-errChan:
+			return nil, nodeId, cla.WebSocket, discovery.Announcement{}, err
+
+		case <-time.After(100 * time.Millisecond):
+			return listener, nodeId, cla.WebSocket, discovery.Announcement{}, nil
+		}
+
 	case "quicl":
-		portInt, err := parseListenPort(conv.Endpoint)
+		address, portInt, err := parseListenAddress(conv.Endpoint)
 		if err != nil {
 			return nil, nodeId, cla.QUICL, discovery.Announcement{}, err
 		}
 
-		listener := quicl.NewQUICListener(conv.Endpoint, nodeId)
+		quiclConf, err := conv.quiclConfig()
+		if err != nil {
+			return nil, nodeId, cla.QUICL, discovery.Announcement{}, err
+		}
+
+		listener := quicl.NewQUICListenerWithConfig(conv.Endpoint, nodeId, quiclConf)
 
 		msg := discovery.Announcement{
 			Type:     cla.QUICL,
 			Endpoint: nodeId,
 			Port:     uint(portInt),
+			Address:  address,
 		}
 
 		return listener, nodeId, cla.QUICL, msg, nil
@@ -223,25 +475,52 @@ func parsePeer(conv convergenceConf, nodeId bpv7.EndpointID) (cla.ConvergenceSen
 		if endpointID, err := bpv7.NewEndpointID(conv.Node); err != nil {
 			return nil, err
 		} else {
-			return mtcp.NewMTCPClient(conv.Endpoint, endpointID, true), nil
+			client := mtcp.NewMTCPClient(conv.Endpoint, endpointID, true)
+			if conv.Compression {
+				client.EnableCompression(mtcp.Deflate)
+			}
+			if tlsConf, err := tlsClientConfig(conv); err != nil {
+				return nil, err
+			} else if tlsConf != nil {
+				client.EnableTLS(tlsConf)
+			}
+
+			return client, nil
 		}
 
 	case "tcpclv4":
-		return tcpclv4.DialTCP(conv.Endpoint, nodeId, true), nil
+		return tcpclv4.DialTCPWithConfig(conv.Endpoint, nodeId, true, conv.sessionConfig()), nil
 
 	case "tcpclv4-ws":
-		return tcpclv4.DialWebSocket(conv.Endpoint, nodeId, true), nil
+		return tcpclv4.DialWebSocketWithConfig(conv.Endpoint, nodeId, true, conv.sessionConfig()), nil
+
+	case "websocket":
+		return websocket.Dial(conv.Endpoint, nodeId, true), nil
 
 	case "quicl":
-		return quicl.NewDialerEndpoint(conv.Endpoint, nodeId, true), nil
+		if quiclConf, err := conv.quiclConfig(); err != nil {
+			return nil, err
+		} else {
+			return quicl.NewDialerEndpointWithConfig(conv.Endpoint, nodeId, true, quiclConf), nil
+		}
+
+	case "bibe":
+		if peer, err := bpv7.NewEndpointID(conv.Node); err != nil {
+			return nil, err
+		} else if local, err := bpv7.NewEndpointID(conv.Endpoint); err != nil {
+			return nil, err
+		} else {
+			return bibe.NewEndpoint(local, peer), nil
+		}
 
 	default:
 		return nil, fmt.Errorf("unknown peer.protocol \"%s\"", conv.Protocol)
 	}
 }
 
-// parseAgents for the ApplicationAgents.
-func parseAgents(conf agentsConfig) (agents []agent.ApplicationAgent, err error) {
+// parseAgents for the ApplicationAgents. An optional BundleRing is offered for download and the optional metrics
+// handler is served at /metrics by the webserver. The status function is queried by the status agent.
+func parseAgents(conf agentsConfig, bundleRing *routing.BundleRing, metrics http.Handler, status func() agent.NodeStatus) (agents []agent.ApplicationAgent, err error) {
 	if conf.Ping != "" {
 		if pingEid, pingEidErr := bpv7.NewEndpointID(conf.Ping); pingEidErr != nil {
 			err = pingEidErr
@@ -251,6 +530,30 @@ func parseAgents(conf agentsConfig) (agents []agent.ApplicationAgent, err error)
 		}
 	}
 
+	if conf.Status != "" {
+		if statusEid, statusEidErr := bpv7.NewEndpointID(conf.Status); statusEidErr != nil {
+			err = statusEidErr
+			return
+		} else {
+			agents = append(agents, agent.NewStatusAgent(statusEid, status))
+		}
+	}
+
+	if (conf.Directory != agentsDirectoryConfig{}) {
+		dirEid, dirEidErr := bpv7.NewEndpointID(conf.Directory.Endpoint)
+		if dirEidErr != nil {
+			err = dirEidErr
+			return
+		}
+
+		dirAgent, dirAgentErr := agent.NewDirectoryAgent(dirEid, conf.Directory.Path)
+		if dirAgentErr != nil {
+			err = fmt.Errorf("directory agent failed: %v", dirAgentErr)
+			return
+		}
+		agents = append(agents, dirAgent)
+	}
+
 	if (conf.Webserver != agentsWebserverConfig{}) {
 		if !conf.Webserver.Websocket && !conf.Webserver.Rest {
 			err = fmt.Errorf("webserver agent needs at least one of Websocket or REST")
@@ -268,11 +571,27 @@ func parseAgents(conf agentsConfig) (agents []agent.ApplicationAgent, err error)
 
 		if conf.Webserver.Rest {
 			restRouter := r.PathPrefix("/rest").Subrouter()
-			ra := agent.NewRestAgent(restRouter)
+			var mailboxTTL time.Duration
+			if conf.Webserver.RestMailboxTTL != "" {
+				if mailboxTTL, err = time.ParseDuration(conf.Webserver.RestMailboxTTL); err != nil {
+					err = fmt.Errorf("REST mailbox TTL is invalid: %v", err)
+					return
+				}
+			}
+
+			ra := agent.NewRestAgentWithMailboxTTL(restRouter, mailboxTTL)
 
 			agents = append(agents, ra)
 		}
 
+		if bundleRing != nil {
+			r.Handle("/admin/bundle-ring", bundleRing)
+		}
+
+		if metrics != nil {
+			r.Handle("/metrics", metrics)
+		}
+
 		httpServer := &http.Server{
 			Addr:              conf.Webserver.Address,
 			Handler:           r,
@@ -309,7 +628,7 @@ func parseCron(config cronConf, c *routing.Core) (*routing.Cron, error) {
 	if err != nil {
 		return nil, NewConfigError(fmt.Sprintf("Error parsing duration: %v", config.CleanStore), err)
 	}
-	if err := cron.Register("clean_store", c.Store.DeleteExpired, interval); err != nil {
+	if err := cron.Register("clean_store", c.DeleteExpiredBundles, interval); err != nil {
 		return nil, NewConfigError("Failed to register clean_store at cron", err)
 	}
 
@@ -324,13 +643,50 @@ func parseCron(config cronConf, c *routing.Core) (*routing.Cron, error) {
 	return cron, nil
 }
 
-// parseCore creates the Core based on the given TOML configuration.
-func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err error) {
-	var conf tomlConfig
-	if _, err = toml.DecodeFile(filename, &conf); err != nil {
-		return
+// parseIdentityKeys from the configuration's node IDs and hex encoded ed25519 public keys.
+func parseIdentityKeys(conf map[string]string) (identityKeys map[bpv7.EndpointID]ed25519.PublicKey, err error) {
+	identityKeys = make(map[bpv7.EndpointID]ed25519.PublicKey, len(conf))
+
+	for node, key := range conf {
+		nodeId, nodeErr := bpv7.NewEndpointID(node)
+		if nodeErr != nil {
+			return nil, fmt.Errorf("identity key's node ID \"%s\" is invalid: %v", node, nodeErr)
+		}
+
+		pub, pubErr := hex.DecodeString(key)
+		if pubErr != nil {
+			return nil, fmt.Errorf("identity key for \"%s\" is invalid: %v", node, pubErr)
+		} else if l := len(pub); l != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("identity key for \"%s\" has a length of %d, not %d", node, l, ed25519.PublicKeySize)
+		}
+
+		identityKeys[nodeId] = pub
 	}
+	return
+}
+
+// parseIntegrityKeys creates a routing.StaticSecurityKeyStore from the configured BIB keys.
+func parseIntegrityKeys(conf map[string]string) (routing.StaticSecurityKeyStore, error) {
+	keyStore := make(routing.StaticSecurityKeyStore, len(conf))
 
+	for node, key := range conf {
+		nodeId, nodeErr := bpv7.NewEndpointID(node)
+		if nodeErr != nil {
+			return nil, fmt.Errorf("integrity key's node ID \"%s\" is invalid: %v", node, nodeErr)
+		}
+
+		keyBytes, keyErr := hex.DecodeString(key)
+		if keyErr != nil {
+			return nil, fmt.Errorf("integrity key for \"%s\" is invalid: %v", node, keyErr)
+		}
+
+		keyStore[nodeId] = keyBytes
+	}
+	return keyStore, nil
+}
+
+// parseCore creates the Core based on the given configuration.
+func parseCore(conf tomlConfig) (c *routing.Core, ds *discovery.Manager, err error) {
 	// Logging
 	if conf.Logging.Level != "" {
 		if lvl, err := log.ParseLevel(conf.Logging.Level); err != nil {
@@ -365,7 +721,7 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 	var discoveryMsgs []discovery.Announcement
 
 	// Core
-	if conf.Core.Store == "" {
+	if conf.Core.Store == "" && conf.Core.StoreBackend != storage.BackendMemory {
 		err = fmt.Errorf("routing.store is empty")
 		return
 	}
@@ -374,6 +730,19 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 		"routing": conf.Routing.Algorithm,
 	}).Debug("Selected routing algorithm")
 
+	if conf.Core.FutureTolerance != "" {
+		tolerance, toleranceErr := time.ParseDuration(conf.Core.FutureTolerance)
+		if toleranceErr != nil {
+			err = NewConfigError(fmt.Sprintf("Error parsing duration: %v", conf.Core.FutureTolerance), toleranceErr)
+			return
+		}
+		bpv7.SetFutureTimestampTolerance(tolerance)
+	}
+
+	bpv7.SetIndefiniteByteStrings(!conf.Core.RejectIndefiniteByteStrings)
+	bpv7.SetLenientBlockCRC(conf.Core.LenientBlockCRC)
+	bpv7.SetPreserveBlockOrder(conf.Core.PreserveBlockOrder)
+
 	nodeId, nodeErr := bpv7.NewEndpointID(conf.Core.NodeId)
 	if nodeErr != nil {
 		err = nodeErr
@@ -387,7 +756,18 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 		}
 	}
 
-	if c, err = routing.NewCore(conf.Core.Store, nodeId, conf.Core.InspectAllBundles, conf.Routing, signPriv); err != nil {
+	store, err := storage.NewBundleStore(conf.Core.StoreBackend, conf.Core.Store)
+	if err != nil {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"backend": conf.Core.StoreBackend,
+		"store":   conf.Core.Store,
+	}).Debug("Opened bundle store")
+
+	if c, err = routing.NewCoreWithStore(store, nodeId, conf.Core.InspectAllBundles, conf.Routing, signPriv); err != nil {
+		_ = store.Close()
 		return
 	}
 
@@ -397,9 +777,83 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 	}
 	c.Cron = cron
 
+	if conf.Core.ReceiveQueueDepth > 0 {
+		queueConf := cla.ReceiveQueueConfig{Depth: conf.Core.ReceiveQueueDepth}
+		if conf.Core.ReceiveQueuePolicy != "" {
+			if queueConf.Policy, err = cla.ParseReceiveQueuePolicy(conf.Core.ReceiveQueuePolicy); err != nil {
+				return
+			}
+		}
+
+		if err = c.SetCLAReceiveQueue(queueConf); err != nil {
+			return
+		}
+	}
+
+	if len(conf.Core.CLAPreference) > 0 {
+		preference := make([]cla.CLAType, len(conf.Core.CLAPreference))
+		for i, name := range conf.Core.CLAPreference {
+			if preference[i], err = cla.ParseCLAType(name); err != nil {
+				return
+			}
+		}
+		c.SetCLAPreference(preference)
+	}
+
+	if conf.Core.DeadLetter != "" {
+		deadLetter, deadLetterErr := bpv7.NewEndpointID(conf.Core.DeadLetter)
+		if deadLetterErr != nil {
+			err = fmt.Errorf("dead-letter endpoint \"%s\" is invalid: %v", conf.Core.DeadLetter, deadLetterErr)
+			return
+		}
+		c.SetDeadLetterEndpoint(deadLetter)
+	}
+
+	if len(conf.Core.IdentityKeys) > 0 {
+		identityKeys, identityErr := parseIdentityKeys(conf.Core.IdentityKeys)
+		if identityErr != nil {
+			err = identityErr
+			return
+		}
+		c.SetIdentityKeys(identityKeys)
+	}
+
+	if len(conf.Core.IntegrityKeys) > 0 {
+		keyStore, keyStoreErr := parseIntegrityKeys(conf.Core.IntegrityKeys)
+		if keyStoreErr != nil {
+			err = keyStoreErr
+			return
+		}
+		c.SetSecurityKeyStore(keyStore)
+	}
+
+	var bundleRing *routing.BundleRing
+	if conf.Core.BundleRing > 0 {
+		if bundleRing, err = c.EnableBundleRing(conf.Core.BundleRing); err != nil {
+			return
+		}
+	}
+
+	if conf.Core.NDJSONSink.Path != "" {
+		sinkConf := routing.NDJSONSinkConfig{
+			Path:    conf.Core.NDJSONSink.Path,
+			MaxSize: conf.Core.NDJSONSink.MaxSize,
+			Payload: conf.Core.NDJSONSink.Payload,
+		}
+		if conf.Core.NDJSONSink.MaxAge != "" {
+			if sinkConf.MaxAge, err = time.ParseDuration(conf.Core.NDJSONSink.MaxAge); err != nil {
+				return
+			}
+		}
+
+		if _, err = c.EnableNDJSONSink(sinkConf); err != nil {
+			return
+		}
+	}
+
 	// Agents
 	if conf.Agents != (agentsConfig{}) {
-		if appAgents, appErr := parseAgents(conf.Agents); appErr != nil {
+		if appAgents, appErr := parseAgents(conf.Agents, bundleRing, claMetricsHandler(c.CLAMetrics), c.NodeStatus); appErr != nil {
 			err = appErr
 			return
 		} else {
@@ -433,6 +887,10 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 			continue
 		}
 
+		// A BIBE tunnel also receives its outer bundles as an ApplicationAgent.
+		if appAgent, ok := convRec.(agent.ApplicationAgent); ok {
+			c.RegisterApplicationAgent(appAgent)
+		}
 		c.RegisterConvergable(convRec)
 	}
 
@@ -442,12 +900,29 @@ func parseCore(filename string) (c *routing.Core, ds *discovery.Manager, err err
 			conf.Discovery.Interval = 10
 		}
 
+		var localIds []bpv7.EndpointID
+		for _, localId := range conf.Discovery.LocalIds {
+			eid, eidErr := bpv7.NewEndpointID(localId)
+			if eidErr != nil {
+				err = eidErr
+				return
+			}
+			localIds = append(localIds, eid)
+		}
+
 		ds, err = discovery.NewManager(
-			c.NodeId, c.RegisterConvergable, discoveryMsgs,
-			time.Duration(conf.Discovery.Interval)*time.Second, conf.Discovery.IPv4, conf.Discovery.IPv6)
+			c.NodeId, localIds, c.RegisterConvergable, discoveryMsgs,
+			time.Duration(conf.Discovery.Interval)*time.Second, conf.Discovery.IPv4, conf.Discovery.IPv6,
+			discovery.DiscoveryConfig{
+				Address4:  conf.Discovery.Address4,
+				Address6:  conf.Discovery.Address6,
+				Port:      conf.Discovery.Port,
+				Interface: conf.Discovery.Interface,
+			})
 		if err != nil {
 			return
 		}
+		ds.SetNeighborFunc(c.ReportNeighborDiscovered)
 	}
 
 	return
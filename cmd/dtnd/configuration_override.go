@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// envPrefix is the common prefix of all environment variables to override the configuration.
+const envPrefix = "DTND_"
+
+// configOverride holds single configuration values, which override those of the TOML configuration. Empty values are
+// not overridden.
+//
+// The configuration is layered: the TOML file is the base, which might be overridden by environment variables, which
+// again might be overridden by command line flags.
+type configOverride struct {
+	NodeId   string
+	Store    string
+	Routing  string
+	LogLevel string
+
+	// Listen replaces all "listen" blocks of the TOML configuration, if not empty.
+	Listen []convergenceConf
+}
+
+// parseListenOverride parses a listen configuration in the "protocol=endpoint" format, e.g., "tcpclv4=:4556".
+func parseListenOverride(value string) (conv convergenceConf, err error) {
+	protocol, endpoint, found := strings.Cut(value, "=")
+	if !found || protocol == "" || endpoint == "" {
+		err = fmt.Errorf("listen \"%s\" is not in the \"protocol=endpoint\" format", value)
+		return
+	}
+
+	conv = convergenceConf{Protocol: protocol, Endpoint: endpoint}
+	return
+}
+
+// listenFlag is a repeatable flag.Value for listen configurations.
+type listenFlag []convergenceConf
+
+func (lf *listenFlag) String() string {
+	if lf == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, conv := range *lf {
+		parts = append(parts, conv.Protocol+"="+conv.Endpoint)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (lf *listenFlag) Set(value string) error {
+	if conv, err := parseListenOverride(value); err != nil {
+		return err
+	} else {
+		*lf = append(*lf, conv)
+		return nil
+	}
+}
+
+// overrideFromEnv creates a configOverride based on the DTND_ environment variables, looked up by lookupEnv.
+//
+// DTND_LISTEN might contain multiple comma separated listen configurations.
+func overrideFromEnv(lookupEnv func(string) (string, bool)) (o configOverride, err error) {
+	fields := map[string]*string{
+		"NODE_ID":   &o.NodeId,
+		"STORE":     &o.Store,
+		"ROUTING":   &o.Routing,
+		"LOG_LEVEL": &o.LogLevel,
+	}
+	for name, field := range fields {
+		if value, ok := lookupEnv(envPrefix + name); ok {
+			*field = value
+		}
+	}
+
+	if value, ok := lookupEnv(envPrefix + "LISTEN"); ok && value != "" {
+		for _, listen := range strings.Split(value, ",") {
+			if conv, convErr := parseListenOverride(strings.TrimSpace(listen)); convErr != nil {
+				err = fmt.Errorf("%sLISTEN: %v", envPrefix, convErr)
+				return
+			} else {
+				o.Listen = append(o.Listen, conv)
+			}
+		}
+	}
+
+	return
+}
+
+// parseFlags parses the command line arguments, without the program's name, into the configuration file's name and a
+// configOverride.
+func parseFlags(args []string, output io.Writer) (filename string, o configOverride, err error) {
+	fs := flag.NewFlagSet("dtnd", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: dtnd [flags] configuration.toml\n\n"+
+			"Flags override both the configuration file and the %s environment variables.\n\n", envPrefix)
+		fs.PrintDefaults()
+	}
+
+	var listen listenFlag
+	fs.StringVar(&o.NodeId, "node-id", "", "node's endpoint ID, overrides core.node-id")
+	fs.StringVar(&o.Store, "store", "", "store's directory, overrides core.store")
+	fs.StringVar(&o.Routing, "routing", "", "routing algorithm, overrides routing.algorithm")
+	fs.StringVar(&o.LogLevel, "log-level", "", "log level, overrides logging.level")
+	fs.Var(&listen, "listen", "listen configuration as \"protocol=endpoint\", replaces all listen blocks; repeatable")
+
+	if err = fs.Parse(args); err != nil {
+		return
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		err = fmt.Errorf("expected exactly one configuration file, got %d arguments", fs.NArg())
+		return
+	}
+
+	filename = fs.Arg(0)
+	o.Listen = listen
+	return
+}
+
+// apply this configOverride's values to a tomlConfig.
+func (o configOverride) apply(conf *tomlConfig) {
+	overrides := []struct {
+		value string
+		field *string
+	}{
+		{o.NodeId, &conf.Core.NodeId},
+		{o.Store, &conf.Core.Store},
+		{o.Routing, &conf.Routing.Algorithm},
+		{o.LogLevel, &conf.Logging.Level},
+	}
+	for _, override := range overrides {
+		if override.value != "" {
+			*override.field = override.value
+		}
+	}
+
+	if len(o.Listen) > 0 {
+		conf.Listen = o.Listen
+	}
+}
+
+// loadConfig reads the TOML configuration file and applies the configOverrides in their order.
+func loadConfig(filename string, overrides ...configOverride) (conf tomlConfig, err error) {
+	if _, err = toml.DecodeFile(filename, &conf); err != nil {
+		return
+	}
+
+	for _, override := range overrides {
+		override.apply(&conf)
+	}
+	return
+}
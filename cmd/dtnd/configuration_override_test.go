@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testConfigToml = `
+[core]
+store = "store"
+node-id = "dtn://toml/"
+
+[logging]
+level = "info"
+
+[routing]
+algorithm = "epidemic"
+
+[[listen]]
+protocol = "mtcp"
+endpoint = ":35037"
+`
+
+func writeTestConfig(t *testing.T) string {
+	filename := filepath.Join(t.TempDir(), "configuration.toml")
+	if err := os.WriteFile(filename, []byte(testConfigToml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestConfigOverrideEnv(t *testing.T) {
+	filename := writeTestConfig(t)
+
+	t.Setenv("DTND_NODE_ID", "dtn://env/")
+	t.Setenv("DTND_ROUTING", "prophet")
+	t.Setenv("DTND_LISTEN", "tcpclv4=:4556, quicl=:35039")
+
+	envOverride, err := overrideFromEnv(os.LookupEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := loadConfig(filename, envOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if conf.Core.NodeId != "dtn://env/" {
+		t.Fatalf("node-id was not overridden: %s", conf.Core.NodeId)
+	}
+	if conf.Routing.Algorithm != "prophet" {
+		t.Fatalf("routing algorithm was not overridden: %s", conf.Routing.Algorithm)
+	}
+	if conf.Core.Store != "store" || conf.Logging.Level != "info" {
+		t.Fatalf("unset environment variables altered the configuration: %v", conf)
+	}
+
+	expectedListen := []convergenceConf{{Protocol: "tcpclv4", Endpoint: ":4556"}, {Protocol: "quicl", Endpoint: ":35039"}}
+	if !reflect.DeepEqual(conf.Listen, expectedListen) {
+		t.Fatalf("listen was not overridden: %v", conf.Listen)
+	}
+}
+
+func TestConfigOverrideFlags(t *testing.T) {
+	filename := writeTestConfig(t)
+
+	t.Setenv("DTND_NODE_ID", "dtn://env/")
+	t.Setenv("DTND_STORE", "env-store")
+
+	envOverride, err := overrideFromEnv(os.LookupEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flagFilename, flagOverride, err := parseFlags(
+		[]string{"-node-id", "dtn://flag/", "-listen", "tcpclv4=:4556", filename}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	} else if flagFilename != filename {
+		t.Fatalf("expected configuration file %s, got %s", filename, flagFilename)
+	}
+
+	conf, err := loadConfig(flagFilename, envOverride, flagOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if conf.Core.NodeId != "dtn://flag/" {
+		t.Fatalf("flag did not override the environment: %s", conf.Core.NodeId)
+	}
+	if conf.Core.Store != "env-store" {
+		t.Fatalf("environment did not override the TOML value: %s", conf.Core.Store)
+	}
+	if expected := []convergenceConf{{Protocol: "tcpclv4", Endpoint: ":4556"}}; !reflect.DeepEqual(conf.Listen, expected) {
+		t.Fatalf("listen was not overridden: %v", conf.Listen)
+	}
+}
+
+func TestConfigOverrideInvalid(t *testing.T) {
+	t.Setenv("DTND_LISTEN", "tcpclv4:4556")
+	if _, err := overrideFromEnv(os.LookupEnv); err == nil {
+		t.Fatal("invalid DTND_LISTEN did not fail")
+	}
+
+	if _, _, err := parseFlags([]string{"-listen", "=:4556", "configuration.toml"}, io.Discard); err == nil {
+		t.Fatal("invalid -listen did not fail")
+	}
+
+	if _, _, err := parseFlags([]string{}, io.Discard); err == nil {
+		t.Fatal("missing configuration file did not fail")
+	}
+}
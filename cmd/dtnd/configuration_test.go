@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestParseListenAddress(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		address  string
+		port     int
+		valid    bool
+	}{
+		{":4556", "", 4556, true},
+		{"0.0.0.0:4556", "", 4556, true},
+		{"[::]:4556", "", 4556, true},
+		{"127.0.0.1:4556", "", 4556, true},
+		{"192.0.2.23:4556", "192.0.2.23", 4556, true},
+		{"[2001:db8::23]:4556", "2001:db8::23", 4556, true},
+		{"node.example:4556", "node.example", 4556, true},
+		{"4556", "", 0, false},
+		{":port", "", 0, false},
+	}
+
+	for _, test := range tests {
+		address, port, err := parseListenAddress(test.endpoint)
+		if (err == nil) != test.valid {
+			t.Fatalf("%s: expected valid = %t, got error %v", test.endpoint, test.valid, err)
+		} else if test.valid && (address != test.address || port != test.port) {
+			t.Fatalf("%s: expected %s and %d, got %s and %d", test.endpoint, test.address, test.port, address, port)
+		}
+	}
+}
+
+func TestConvergenceConfKeepalive(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		keepalive *uint16
+	}{
+		{"unset", `protocol = "tcpclv4"`, nil},
+		{"disabled", "protocol = \"tcpclv4\"\nkeepalive = 0", new(uint16)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var conv convergenceConf
+			if _, err := toml.Decode(test.data, &conv); err != nil {
+				t.Fatal(err)
+			}
+
+			keepalive := conv.sessionConfig().Keepalive
+			if (keepalive == nil) != (test.keepalive == nil) {
+				t.Fatalf("expected keepalive %v, got %v", test.keepalive, keepalive)
+			} else if keepalive != nil && *keepalive != *test.keepalive {
+				t.Fatalf("expected keepalive %d, got %d", *test.keepalive, *keepalive)
+			}
+		})
+	}
+}
@@ -20,11 +20,28 @@ func waitSigint() {
 }
 
 func main() {
-	if len(os.Args) != 2 {
-		log.Fatalf("Usage: %s configuration.toml", os.Args[0])
+	filename, flagOverride, err := parseFlags(os.Args[1:], os.Stderr)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+		}).Fatal("Failed to parse flags")
+	}
+
+	envOverride, err := overrideFromEnv(os.LookupEnv)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+		}).Fatal("Failed to parse environment variables")
+	}
+
+	conf, err := loadConfig(filename, envOverride, flagOverride)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+		}).Fatal("Failed to read config")
 	}
 
-	core, discovery, err := parseCore(os.Args[1])
+	core, discovery, err := parseCore(conf)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"error": err,
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// metricsLabelReplacer escapes a label value for Prometheus' text format.
+var metricsLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeCLAMetrics writes the CLA Manager's metrics in Prometheus' text-based exposition format.
+func writeCLAMetrics(w io.Writer, metrics cla.ManagerMetrics) (err error) {
+	gauges := []struct {
+		name  string
+		help  string
+		value int
+	}{
+		{"dtn7_cla_active_senders", "Number of active CLA senders.", metrics.ActiveSenders},
+		{"dtn7_cla_active_receivers", "Number of active CLA receivers.", metrics.ActiveReceivers},
+		{"dtn7_cla_inactive_senders", "Number of inactive CLA senders, waiting for a restart.", metrics.InactiveSenders},
+	}
+	for _, gauge := range gauges {
+		if _, err = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n",
+			gauge.name, gauge.help, gauge.name, gauge.name, gauge.value); err != nil {
+			return
+		}
+	}
+
+	if _, err = fmt.Fprintf(w, "# HELP dtn7_cla_restarts_total Number of restarted CLAs.\n"+
+		"# TYPE dtn7_cla_restarts_total counter\ndtn7_cla_restarts_total %d\n", metrics.Restarts); err != nil {
+		return
+	}
+
+	if _, err = fmt.Fprint(w, "# HELP dtn7_cla_forwarded_bundles_total Number of bundles forwarded per CLA.\n"+
+		"# TYPE dtn7_cla_forwarded_bundles_total counter\n"); err != nil {
+		return
+	}
+
+	addresses := make([]string, 0, len(metrics.ForwardedBundles))
+	for address := range metrics.ForwardedBundles {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		if _, err = fmt.Fprintf(w, "dtn7_cla_forwarded_bundles_total{address=\"%s\"} %d\n",
+			metricsLabelReplacer.Replace(address), metrics.ForwardedBundles[address]); err != nil {
+			return
+		}
+	}
+
+	if _, err = fmt.Fprint(w, "# HELP dtn7_cla_retry_interval_seconds Current interval until an inactive CLA's next "+
+		"activation attempt.\n# TYPE dtn7_cla_retry_interval_seconds gauge\n"); err != nil {
+		return
+	}
+
+	addresses = addresses[:0]
+	for address := range metrics.RetryIntervals {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		if _, err = fmt.Fprintf(w, "dtn7_cla_retry_interval_seconds{address=\"%s\"} %g\n",
+			metricsLabelReplacer.Replace(address), metrics.RetryIntervals[address].Seconds()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// claMetricsHandler serves the CLA Manager's metrics, fetched by the metrics function, for Prometheus.
+func claMetricsHandler(metrics func() cla.ManagerMetrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = writeCLAMetrics(w, metrics())
+	})
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCLAMetricsHandler(t *testing.T) {
+	metrics := cla.ManagerMetrics{
+		ActiveSenders:   2,
+		ActiveReceivers: 3,
+		InactiveSenders: 1,
+		Restarts:        5,
+		ForwardedBundles: map[string]uint64{
+			"10.0.0.2:4556": 42,
+			`odd"address`:   1,
+		},
+		RetryIntervals: map[string]time.Duration{
+			"10.0.0.3:4556": 1500 * time.Millisecond,
+		},
+	}
+
+	handler := claMetricsHandler(func() cla.ManagerMetrics { return metrics })
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"dtn7_cla_active_senders 2",
+		"dtn7_cla_active_receivers 3",
+		"dtn7_cla_inactive_senders 1",
+		"dtn7_cla_restarts_total 5",
+		`dtn7_cla_forwarded_bundles_total{address="10.0.0.2:4556"} 42`,
+		`dtn7_cla_forwarded_bundles_total{address="odd\"address"} 1`,
+		`dtn7_cla_retry_interval_seconds{address="10.0.0.3:4556"} 1.5`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("metrics miss line %q:\n%s", line, body)
+		}
+	}
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2020, 2021 Alvar Penning
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// DirectoryAgent is an ApplicationAgent exchanging Bundles over a drop folder within the filesystem.
+//
+// Each CBOR encoded Bundle file created within the directory is sent. Delivered Bundles are written into the
+// directory, named by their hex encoded bpv7.BundleID, as returned by DirectoryAgentFilename.
+//
+// To avoid reading partially written files, a file must be written under a temporary name first, which is either
+// hidden by a leading dot or ends with ".tmp". Afterwards, it must be renamed to its final name. Temporary files are
+// ignored. Files and Bundles which were already sent or delivered are not sent again.
+type DirectoryAgent struct {
+	endpoint  bpv7.EndpointID
+	directory string
+	watcher   *fsnotify.Watcher
+
+	// knownFiles and knownBundles are only accessed by the handler.
+	knownFiles   map[string]struct{}
+	knownBundles map[bpv7.BundleID]struct{}
+
+	receiver chan Message
+	sender   chan Message
+}
+
+// NewDirectoryAgent creates a new DirectoryAgent for an endpoint, watching an existing directory.
+func NewDirectoryAgent(endpoint bpv7.EndpointID, directory string) (*DirectoryAgent, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(directory); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	d := &DirectoryAgent{
+		endpoint:  endpoint,
+		directory: directory,
+		watcher:   watcher,
+
+		knownFiles:   make(map[string]struct{}),
+		knownBundles: make(map[bpv7.BundleID]struct{}),
+
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+	}
+
+	go d.handler()
+
+	return d, nil
+}
+
+// DirectoryAgentFilename is the name of a Bundle's file, written by a DirectoryAgent.
+func DirectoryAgentFilename(bid bpv7.BundleID) string {
+	return hex.EncodeToString([]byte(bid.String()))
+}
+
+// isTemporaryFile checks if a file's name marks it as being still written.
+func isTemporaryFile(name string) bool {
+	base := filepath.Base(name)
+	return strings.HasPrefix(base, ".") || strings.HasSuffix(base, ".tmp")
+}
+
+func (d *DirectoryAgent) log() *log.Entry {
+	return log.WithFields(log.Fields{
+		"DirectoryAgent": d.endpoint,
+		"directory":      d.directory,
+	})
+}
+
+func (d *DirectoryAgent) handler() {
+	defer close(d.sender)
+	defer func() { _ = d.watcher.Close() }()
+
+	for {
+		select {
+		case m := <-d.receiver:
+			switch m := m.(type) {
+			case BundleMessage:
+				d.writeBundle(m.Bundle)
+
+			case ShutdownMessage:
+				return
+
+			default:
+				d.log().WithField("message", m).Info("Received unsupported Message")
+			}
+
+		case e, ok := <-d.watcher.Events:
+			if !ok {
+				d.log().Error("fsnotify's Event channel was closed")
+				return
+			}
+
+			if e.Op&fsnotify.Create == 0 {
+				continue
+			} else if isTemporaryFile(e.Name) {
+				d.log().WithField("file", e.Name).Debug("Skipping temporary file")
+				continue
+			} else if _, known := d.knownFiles[filepath.Base(e.Name)]; known {
+				d.log().WithField("file", e.Name).Debug("Skipping file; already known")
+				continue
+			}
+
+			d.readFile(e.Name)
+
+		case err, ok := <-d.watcher.Errors:
+			if !ok {
+				d.log().Error("fsnotify's Errors channel was closed")
+				return
+			}
+
+			d.log().WithError(err).Warn("fsnotify erred")
+		}
+	}
+}
+
+// readFile sends the Bundle of a new file, unless this Bundle was already known.
+func (d *DirectoryAgent) readFile(name string) {
+	logger := d.log().WithField("file", name)
+
+	d.knownFiles[filepath.Base(name)] = struct{}{}
+
+	f, err := os.Open(name)
+	if err != nil {
+		logger.WithError(err).Warn("Opening file erred")
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	var b bpv7.Bundle
+	if err := b.UnmarshalCbor(f); err != nil {
+		logger.WithError(err).Warn("Unmarshalling Bundle erred")
+		return
+	}
+
+	logger = logger.WithField("bundle", b.ID().String())
+
+	if _, known := d.knownBundles[b.ID()]; known {
+		logger.Debug("Skipping Bundle; already known")
+		return
+	}
+	d.knownBundles[b.ID()] = struct{}{}
+
+	logger.Info("Sending Bundle from file")
+	d.sender <- BundleMessage{b}
+}
+
+// writeBundle into a new file. The Bundle is written to a temporary file first, which is renamed afterwards.
+func (d *DirectoryAgent) writeBundle(b bpv7.Bundle) {
+	filename := DirectoryAgentFilename(b.ID())
+	filePath := filepath.Join(d.directory, filename)
+	tmpPath := filepath.Join(d.directory, "."+filename+".tmp")
+
+	logger := d.log().WithFields(log.Fields{
+		"bundle": b.ID().String(),
+		"file":   filePath,
+	})
+
+	if _, known := d.knownBundles[b.ID()]; known {
+		logger.Debug("Skipping Bundle; already known")
+		return
+	}
+
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		logger.WithError(err).Error("Creating file erred")
+		return
+	}
+
+	if err := b.MarshalCbor(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		logger.WithError(err).Error("Marshalling Bundle erred")
+		return
+	} else if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		logger.WithError(err).Error("Closing file erred")
+		return
+	}
+
+	d.knownFiles[filename] = struct{}{}
+	d.knownBundles[b.ID()] = struct{}{}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		logger.WithError(err).Error("Renaming file erred")
+		return
+	}
+
+	logger.Info("Saved delivered Bundle")
+}
+
+func (d *DirectoryAgent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{d.endpoint}
+}
+
+func (d *DirectoryAgent) MessageReceiver() chan Message {
+	return d.receiver
+}
+
+func (d *DirectoryAgent) MessageSender() chan Message {
+	return d.sender
+}
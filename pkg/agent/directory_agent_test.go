@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// writeDirectoryAgentFile writes a Bundle to a temporary file and renames it to its name, if given.
+func writeDirectoryAgentFile(t *testing.T, dir, tmpName, name string, b bpv7.Bundle) {
+	tmpPath := filepath.Join(dir, tmpName)
+
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.MarshalCbor(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "" {
+		if err := os.Rename(tmpPath, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDirectoryAgent(t *testing.T) {
+	dir := t.TempDir()
+	eid := bpv7.MustNewEndpointID("dtn://foo/drop")
+
+	da, err := NewDirectoryAgent(eid, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectNoMessage := func(reason string) {
+		select {
+		case m := <-da.MessageSender():
+			t.Fatalf("%s: received unexpected message %v", reason, m)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+
+	// A delivered Bundle is written to a file named by its ID, without being sent back.
+	delivered := createBundle("dtn://bar/", eid.String(), t)
+	da.MessageReceiver() <- BundleMessage{delivered}
+
+	expectNoMessage("delivered bundle")
+
+	f, err := os.Open(filepath.Join(dir, DirectoryAgentFilename(delivered.ID())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bpv7.Bundle
+	if err := b.UnmarshalCbor(f); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	if b.ID() != delivered.ID() {
+		t.Fatalf("file contains %v, not %v", b.ID(), delivered.ID())
+	}
+
+	// A temporary file is ignored until being renamed.
+	outgoing := createBundle(eid.String(), "dtn://bar/", t)
+
+	writeDirectoryAgentFile(t, dir, ".outgoing.tmp", "", outgoing)
+	expectNoMessage("temporary file")
+
+	if err := os.Rename(filepath.Join(dir, ".outgoing.tmp"), filepath.Join(dir, "outgoing")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case m := <-da.MessageSender():
+		if bm, ok := m.(BundleMessage); !ok {
+			t.Fatalf("received %T, not a BundleMessage", m)
+		} else if bm.Bundle.ID() != outgoing.ID() {
+			t.Fatalf("sent %v, not %v", bm.Bundle.ID(), outgoing.ID())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("renamed file was not sent")
+	}
+
+	// The same Bundle is not sent again, even within another file.
+	writeDirectoryAgentFile(t, dir, "duplicate.tmp", "duplicate", outgoing)
+	expectNoMessage("duplicate bundle")
+
+	da.MessageReceiver() <- ShutdownMessage{}
+
+	select {
+	case _, ok := <-da.MessageSender():
+		if ok {
+			t.Fatal("message sender was not closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DirectoryAgent did not shut down")
+	}
+}
+
+func TestDirectoryAgentMissingDirectory(t *testing.T) {
+	if _, err := NewDirectoryAgent(bpv7.MustNewEndpointID("dtn://foo/drop"), filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("DirectoryAgent for a missing directory was created")
+	}
+}
@@ -28,6 +28,21 @@ func (bm BundleMessage) Recipients() []bpv7.EndpointID {
 	return []bpv7.EndpointID{bm.Bundle.PrimaryBlock.Destination}
 }
 
+// PayloadMessage is sent to an ApplicationAgent instead of a BundleMessage if the Bundle's destination was configured
+// for a payload-only delivery. Next to the payload, only the Bundle's source, destination, and creation timestamp are
+// included.
+type PayloadMessage struct {
+	Source            bpv7.EndpointID
+	Destination       bpv7.EndpointID
+	CreationTimestamp bpv7.CreationTimestamp
+	Payload           []byte
+}
+
+// Recipients are the Bundle destination for a PayloadMessage.
+func (pm PayloadMessage) Recipients() []bpv7.EndpointID {
+	return []bpv7.EndpointID{pm.Destination}
+}
+
 // SyscallRequestMessage is sent from an ApplicationAgent to request some "syscall" specific information.
 type SyscallRequestMessage struct {
 	Sender  bpv7.EndpointID
@@ -52,6 +67,17 @@ func (srm SyscallResponseMessage) Recipients() []bpv7.EndpointID {
 	return []bpv7.EndpointID{srm.Recipient}
 }
 
+// DeliveryConfirmationMessage is sent to an ApplicationAgent after a status report confirmed the delivery of a Bundle,
+// previously sent by this ApplicationAgent with a requested delivery report.
+type DeliveryConfirmationMessage struct {
+	BundleID bpv7.BundleID
+}
+
+// Recipients are the delivered Bundle's source.
+func (dcm DeliveryConfirmationMessage) Recipients() []bpv7.EndpointID {
+	return []bpv7.EndpointID{dcm.BundleID.SourceNode}
+}
+
 // ShutdownMessage indicates the closing down of an ApplicationAgent.
 // If the Message is received from an ApplicationAgent, it must close itself down.
 // If the Message is sent from an ApplicationAgent, it is closing down itself.
@@ -5,8 +5,11 @@
 package agent
 
 import (
+	"bytes"
 	"sync"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
@@ -37,9 +40,16 @@ func (mux *MuxAgent) handle() {
 
 	for msg := range mux.receiver {
 		mux.Lock()
+		delivered := false
 		for _, child := range mux.children {
 			if rec := msg.Recipients(); rec == nil || AppAgentContainsEndpoint(child, rec) {
-				child.MessageReceiver() <- msg
+				// Each further child sharing an endpoint receives its own copy, allowing independent modifications.
+				if delivered {
+					child.MessageReceiver() <- copyMessage(msg)
+				} else {
+					child.MessageReceiver() <- msg
+					delivered = true
+				}
 			}
 		}
 		mux.Unlock()
@@ -50,6 +60,32 @@ func (mux *MuxAgent) handle() {
 	}
 }
 
+// copyMessage creates a deep copy of BundleMessages and PayloadMessages. Other Messages are returned as they are.
+func copyMessage(msg Message) Message {
+	switch msg := msg.(type) {
+	case BundleMessage:
+		buff := new(bytes.Buffer)
+		if err := msg.Bundle.MarshalCbor(buff); err != nil {
+			log.WithField("bundle", msg.Bundle.ID().String()).WithError(err).Warn("MuxAgent failed to copy bundle")
+			return msg
+		}
+
+		b, err := bpv7.ParseBundleIgnoringLifetime(buff)
+		if err != nil {
+			log.WithField("bundle", msg.Bundle.ID().String()).WithError(err).Warn("MuxAgent failed to copy bundle")
+			return msg
+		}
+		return BundleMessage{Bundle: b}
+
+	case PayloadMessage:
+		msg.Payload = append([]byte(nil), msg.Payload...)
+		return msg
+
+	default:
+		return msg
+	}
+}
+
 // Register a new ApplicationAgent for this multiplexer.
 // If this ApplicationAgent closes its channel or broadcasts a ShutdownMessage, it will be unregistered.
 func (mux *MuxAgent) Register(agent ApplicationAgent) {
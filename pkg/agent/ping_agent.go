@@ -53,8 +53,10 @@ func (p *PingAgent) handler() {
 
 func (p *PingAgent) ackBundle(b bpv7.Bundle) {
 	hopCount := 64
-	if hc, err := b.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
-		hopCount = int(hc.Value.(*bpv7.HopCountBlock).Limit)
+	if cb, err := b.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
+		if hc, ok := cb.Value.(*bpv7.HopCountBlock); ok {
+			hopCount = int(hc.Limit)
+		}
 	}
 
 	bndl, err := bpv7.Builder().
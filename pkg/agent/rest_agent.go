@@ -6,11 +6,15 @@
 package agent
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -54,6 +58,16 @@ import (
 //	//    ]}
 //	// <- {"error":"","bundles":[]}
 //
+//	// 2a. Instead of polling, a fetch might wait up to a timeout for new bundles, POST to /fetch
+//	// -> {"uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f","timeout":"30s"}
+//	// <- {"error":"","bundles":[...]}, as soon as a bundle arrives, or {"error":"","bundles":[]} after 30s
+//
+//	// 2b. A fetch might also be restricted to bundles matching a filter, leaving all others in the mailbox,
+//	//     POST to /fetch
+//	// -> {"uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f",
+//	//     "filter":{"source":"dtn://sender*/*","min_lifetime":"1h","max_lifetime":"24h","extension_block":10}}
+//	// <- {"error":"","bundles":[...]}
+//
 //	// 3. Create and dispatch a new bundle, POST to /build
 //	// -> {
 //	//      "uuid": "75be76e2-23fc-da0e-eeb8-4773f84a9d2f",
@@ -62,31 +76,91 @@ import (
 //	//        "source": "dtn://foo/bar",
 //	//        "creation_timestamp_now": 1,
 //	//        "lifetime": "24h",
+//	//        "bundle_ctrl_flags": ["MustNotFragmented", "StatusRequestDelivery"],
+//	//        "canonical": [{"block_type_code": 10, "block_control_flags": ["RemoveBlock"], "data": "ghhAAA=="}],
 //	//        "payload_block": "hello world"
 //	//      }
 //	//    }
 //	// <- {"error":""}
+//	// or, for an invalid argument,
+//	// <- {"error":"method lifetime failed: ...","error_method":"lifetime","error_reason":"..."}
+//
+//	// 3a. Optionally, preview a bundle's lifetime before sending it, POST to /lifetime
+//	// -> {"uuid": "75be76e2-23fc-da0e-eeb8-4773f84a9d2f", "arguments": {...}}
+//	// <- {"error":"","expiry":"2020-04-15T14:32:06Z","expired":false}
+//
+//	// 3b. Alternatively, receive bundles as Server-Sent Events without polling, GET /stream
+//	//     The client is registered for the connection's lifetime and might also build bundles with its UUID.
+//	// -> /stream?endpoint_id=dtn://foo/bar
+//	// <- event: register
+//	//    data: {"error":"","uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f"}
+//	//
+//	//    event: bundle
+//	//    data: {"primaryBlock":{...},"canonicalBlocks":[...]}
 //
 //	// 4. Unregister the client, POST to /unregister
 //	// -> {"uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f"}
 //	// <- {"error":""}
+//
+// Optionally, a client might request to acknowledge its bundles by setting "acknowledge" on registration. Then,
+// fetched bundles remain in the client's mailbox until being acknowledged. After reconnecting, e.g., after a crash,
+// unacknowledged bundles will be delivered again.
+//
+//	// 1. Registration with acknowledgements, POST to /register
+//	// -> {"endpoint_id":"dtn://foo/bar","acknowledge":true}
+//	// <- {"error":"","uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f"}
+//
+//	// 2. Fetching bundles, POST to /fetch
+//	// -> {"uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f"}
+//	// <- {"error":"","bundles":[...],"bundle_ids":["dtn://sender/-640103526000-0"]}
+//
+//	// 3a. Acknowledge processed bundles, POST to /ack
+//	// -> {"uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f","bundle_ids":["dtn://sender/-640103526000-0"]}
+//	// <- {"error":""}
+//
+//	// 3b. Or reconnect after a crash to receive unacknowledged bundles again, POST to /register
+//	// -> {"endpoint_id":"dtn://foo/bar","uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f","acknowledge":true}
+//	// <- {"error":"","uuid":"75be76e2-23fc-da0e-eeb8-4773f84a9d2f"}
+//
+// Large payloads might also be downloaded directly, as long as their bundles are within the client's mailbox, e.g.,
+// not yet acknowledged. This is done by a GET request to /payload, supporting HTTP Range requests to resume downloads.
+//
+//	// GET /payload?uuid=75be76e2-23fc-da0e-eeb8-4773f84a9d2f&bundle_id=dtn://sender/-640103526000-0
+//	// Range: bytes=1024-
+//	// <- 206 Partial Content, with the payload starting at its 1024th byte
+//
+// Clients might vanish without unregistering. To reclaim their mailboxes, a mailbox TTL can be configured by
+// NewRestAgentWithMailboxTTL. Clients being inactive, i.e., neither fetching, acknowledging, building nor
+// reconnecting, for longer than this TTL are unregistered and their mailboxes are dropped.
 type RestAgent struct {
 	router *mux.Router
 
 	receiver chan Message
 	sender   chan Message
 
-	// map UUIDs to EIDs and received bundles
+	// map UUIDs to EIDs and received bundles, either stored in mailboxes or pushed to open streams
 	clients      sync.Map // uuid[string] -> bpv7.EndpointID
-	mailboxes    map[string]map[bpv7.BundleID]bpv7.Bundle
+	mailboxes    map[string]*restMailbox
+	streams      map[string]*restStream
 	mailboxMutex sync.Mutex
+
+	// mailboxTTL after which inactive clients are dropped; zero disables this.
+	mailboxTTL time.Duration
 }
 
 // NewRestAgent creates a new RESTful Application Agent.
 func NewRestAgent(router *mux.Router) (ra *RestAgent) {
+	return NewRestAgentWithMailboxTTL(router, 0)
+}
+
+// NewRestAgentWithMailboxTTL creates a new RESTful Application Agent, which drops the mailboxes of clients being
+// inactive for longer than the mailboxTTL. A zero mailboxTTL keeps mailboxes until their clients unregister.
+func NewRestAgentWithMailboxTTL(router *mux.Router, mailboxTTL time.Duration) (ra *RestAgent) {
 	ra = &RestAgent{
-		router:    router,
-		mailboxes: make(map[string]map[bpv7.BundleID]bpv7.Bundle),
+		router:     router,
+		mailboxes:  make(map[string]*restMailbox),
+		streams:    make(map[string]*restStream),
+		mailboxTTL: mailboxTTL,
 
 		receiver: make(chan Message),
 		sender:   make(chan Message),
@@ -96,6 +170,10 @@ func NewRestAgent(router *mux.Router) (ra *RestAgent) {
 	ra.router.HandleFunc("/unregister", ra.handleUnregister).Methods(http.MethodPost)
 	ra.router.HandleFunc("/fetch", ra.handleFetch).Methods(http.MethodPost)
 	ra.router.HandleFunc("/build", ra.handleBuild).Methods(http.MethodPost)
+	ra.router.HandleFunc("/lifetime", ra.handleLifetime).Methods(http.MethodPost)
+	ra.router.HandleFunc("/ack", ra.handleAck).Methods(http.MethodPost)
+	ra.router.HandleFunc("/payload", ra.handlePayload).Methods(http.MethodGet, http.MethodHead)
+	ra.router.HandleFunc("/stream", ra.handleStream).Methods(http.MethodGet)
 
 	go ra.handler()
 
@@ -106,18 +184,66 @@ func NewRestAgent(router *mux.Router) (ra *RestAgent) {
 func (ra *RestAgent) handler() {
 	defer close(ra.sender)
 
-	for msg := range ra.receiver {
-		switch msg := msg.(type) {
-		case BundleMessage:
-			ra.receiveBundleMessage(msg)
+	var sweepChan <-chan time.Time
+	if ra.mailboxTTL > 0 {
+		sweepTicker := time.NewTicker(restMailboxSweepInterval(ra.mailboxTTL))
+		defer sweepTicker.Stop()
 
-		case ShutdownMessage:
-			log.Debug("REST Agent is shutting down")
-			return
+		sweepChan = sweepTicker.C
+	}
+
+	for {
+		select {
+		case msg, ok := <-ra.receiver:
+			if !ok {
+				return
+			}
+
+			switch msg := msg.(type) {
+			case BundleMessage:
+				ra.receiveBundleMessage(msg)
+
+			case ShutdownMessage:
+				log.Debug("REST Agent is shutting down")
+				return
+
+			default:
+				log.WithField("message", msg).Info("REST Agent received unknown / unsupported message")
+			}
+
+		case now := <-sweepChan:
+			ra.sweepMailboxes(now)
+		}
+	}
+}
+
+// restMailboxSweepInterval for a mailbox TTL, checking multiple times per TTL, but at least once a minute.
+func restMailboxSweepInterval(ttl time.Duration) time.Duration {
+	if interval := ttl / 4; interval < time.Minute {
+		return interval
+	}
+	return time.Minute
+}
 
-		default:
-			log.WithField("message", msg).Info("REST Agent received unknown / unsupported message")
+// sweepMailboxes drops the mailboxes and registrations of clients being inactive for longer than the mailbox TTL.
+func (ra *RestAgent) sweepMailboxes(now time.Time) {
+	ra.mailboxMutex.Lock()
+	defer ra.mailboxMutex.Unlock()
+
+	for uuid, mailbox := range ra.mailboxes {
+		if !mailbox.isStale(now, ra.mailboxTTL) {
+			continue
 		}
+
+		log.WithFields(log.Fields{
+			"uuid":          uuid,
+			"last_activity": mailbox.lastActivity,
+			"bundles":       len(mailbox.items),
+		}).Info("REST Agent drops the mailbox of an inactive client")
+
+		delete(ra.mailboxes, uuid)
+		ra.clients.Delete(uuid)
+		mailbox.signal()
 	}
 }
 
@@ -128,33 +254,45 @@ func (ra *RestAgent) receiveBundleMessage(msg BundleMessage) {
 		if bagHasEndpoint(msg.Recipients(), v.(bpv7.EndpointID)) {
 			uuids = append(uuids, k.(string))
 		}
-		return false // multiple clients might be registered for some endpoint
+		return true // multiple clients might be registered for some endpoint
 	})
 
 	ra.mailboxMutex.Lock()
 	for _, uuid := range uuids {
-		mailbox, exists := ra.mailboxes[uuid]
-		if !exists {
-			mailbox = map[bpv7.BundleID]bpv7.Bundle{msg.Bundle.ID(): msg.Bundle}
-			ra.mailboxes[uuid] = mailbox
-			log.WithFields(log.Fields{
-				"bundle": msg.Bundle.ID().String(),
-				"uuid":   uuid,
-			}).Debug("REST Application Agent delivering message to a client's inbox")
-		} else {
-			_, exists = mailbox[msg.Bundle.ID()]
-			if !exists {
-				mailbox[msg.Bundle.ID()] = msg.Bundle
+		if stream, isStream := ra.streams[uuid]; isStream {
+			if stream.push(msg.Bundle) {
 				log.WithFields(log.Fields{
 					"bundle": msg.Bundle.ID().String(),
 					"uuid":   uuid,
-				}).Debug("REST Application Agent delivering message to a client's inbox")
+				}).Debug("REST Application Agent pushing message to a client's stream")
 			} else {
 				log.WithFields(log.Fields{
 					"bundle": msg.Bundle.ID().String(),
 					"uuid":   uuid,
-				}).Debug("REST Application Agent not delivering message to a client's inbox. Message already present.")
+				}).Warn("REST Application Agent moving a client's full stream to its inbox")
+
+				delete(ra.streams, uuid)
+				ra.mailboxes[uuid] = stream.overflow(msg.Bundle)
 			}
+			continue
+		}
+
+		mailbox, exists := ra.mailboxes[uuid]
+		if !exists {
+			mailbox = newRestMailbox(false)
+			ra.mailboxes[uuid] = mailbox
+		}
+
+		if mailbox.deliver(msg.Bundle) {
+			log.WithFields(log.Fields{
+				"bundle": msg.Bundle.ID().String(),
+				"uuid":   uuid,
+			}).Debug("REST Application Agent delivering message to a client's inbox")
+		} else {
+			log.WithFields(log.Fields{
+				"bundle": msg.Bundle.ID().String(),
+				"uuid":   uuid,
+			}).Debug("REST Application Agent not delivering message to a client's inbox. Message already present.")
 		}
 	}
 	ra.mailboxMutex.Unlock()
@@ -181,9 +319,18 @@ func (ra *RestAgent) handleRegister(w http.ResponseWriter, r *http.Request) {
 		registerResponse.Error = jsonErr.Error()
 	} else if eid, eidErr := bpv7.NewEndpointID(registerRequest.EndpointId); eidErr != nil {
 		registerResponse.Error = eidErr.Error()
+	} else if registerRequest.UUID != "" {
+		registerResponse.Error = ra.reconnect(registerRequest, eid)
+		if registerResponse.Error == "" {
+			registerResponse.UUID = registerRequest.UUID
+		}
 	} else if uuid, uuidErr := ra.randomUuid(); uuidErr != nil {
 		registerResponse.Error = uuidErr.Error()
 	} else {
+		ra.mailboxMutex.Lock()
+		ra.mailboxes[uuid] = newRestMailbox(registerRequest.Acknowledge)
+		ra.mailboxMutex.Unlock()
+
 		ra.clients.Store(uuid, eid)
 		registerResponse.UUID = uuid
 	}
@@ -199,6 +346,31 @@ func (ra *RestAgent) handleRegister(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// reconnect a known client, identified by its UUID, and redeliver its unacknowledged bundles. An error message is
+// returned if this client is unknown.
+func (ra *RestAgent) reconnect(registerRequest RestRegisterRequest, eid bpv7.EndpointID) string {
+	if knownEid, ok := ra.clients.Load(registerRequest.UUID); !ok || knownEid != eid {
+		return "Invalid UUID"
+	}
+
+	ra.mailboxMutex.Lock()
+	defer ra.mailboxMutex.Unlock()
+
+	mailbox, exists := ra.mailboxes[registerRequest.UUID]
+	if !exists {
+		mailbox = newRestMailbox(registerRequest.Acknowledge)
+		ra.mailboxes[registerRequest.UUID] = mailbox
+	}
+	mailbox.acknowledge = registerRequest.Acknowledge
+
+	log.WithFields(log.Fields{
+		"uuid":        registerRequest.UUID,
+		"redelivered": mailbox.redeliver(),
+	}).Info("REST client reconnected")
+
+	return ""
+}
+
 // handleUnregister processes /unregister POST requests.
 func (ra *RestAgent) handleUnregister(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -213,7 +385,10 @@ func (ra *RestAgent) handleUnregister(w http.ResponseWriter, r *http.Request) {
 		ra.clients.Delete(unregisterRequest.UUID)
 
 		ra.mailboxMutex.Lock()
-		delete(ra.mailboxes, unregisterRequest.UUID)
+		if mailbox, ok := ra.mailboxes[unregisterRequest.UUID]; ok {
+			delete(ra.mailboxes, unregisterRequest.UUID)
+			mailbox.signal()
+		}
 		ra.mailboxMutex.Unlock()
 	}
 
@@ -233,28 +408,158 @@ func (ra *RestAgent) handleFetch(w http.ResponseWriter, r *http.Request) {
 	if jsonErr := json.NewDecoder(r.Body).Decode(&fetchRequest); jsonErr != nil {
 		log.WithError(jsonErr).Warn("Failed to parse REST fetch request")
 		fetchResponse.Error = jsonErr.Error()
-	} else if mailbox, ok := ra.mailboxes[fetchRequest.UUID]; ok {
-		log.WithField("uuid", fetchRequest.UUID).Info("REST client fetches bundles")
+	} else if timeout, timeoutErr := fetchRequest.timeout(); timeoutErr != nil {
+		log.WithError(timeoutErr).WithField("uuid", fetchRequest.UUID).Debug("REST client's fetch timeout is invalid")
+		fetchResponse.Error = timeoutErr.Error()
+	} else if match, filterErr := fetchRequest.Filter.matcher(); filterErr != nil {
+		log.WithError(filterErr).WithField("uuid", fetchRequest.UUID).Debug("REST client's fetch filter is invalid")
+		fetchResponse.Error = filterErr.Error()
+	} else {
+		fetchResponse.Bundles = ra.fetch(r.Context(), fetchRequest.UUID, timeout, match)
+
+		for _, b := range fetchResponse.Bundles {
+			fetchResponse.BundleIDs = append(fetchResponse.BundleIDs, b.ID().String())
+		}
+	}
 
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(fetchResponse); err != nil {
+		log.WithError(err).Warn("Failed to write REST fetch response")
+	}
+}
+
+// fetch the new bundles from a client's mailbox, optionally restricted by a match function. If there are none, it waits
+// up to the timeout for their arrival, signaled by the mailbox, or until the ctx is canceled, e.g., by a disconnecting
+// client.
+func (ra *RestAgent) fetch(ctx context.Context, uuid string, timeout time.Duration, match func(b bpv7.Bundle) bool) []bpv7.Bundle {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
 		ra.mailboxMutex.Lock()
-		bundles := make([]bpv7.Bundle, 0, len(mailbox))
-		for _, bundle := range mailbox {
-			bundles = append(bundles, bundle)
+		mailbox, ok := ra.mailboxes[uuid]
+		if !ok {
+			ra.mailboxMutex.Unlock()
+
+			log.WithField("uuid", uuid).Debug("REST client has no new bundles to fetch")
+			return make([]bpv7.Bundle, 0)
+		}
+
+		bundles := mailbox.fetch(match)
+		arrival := mailbox.arrival
+		ra.mailboxMutex.Unlock()
+
+		if len(bundles) > 0 || timeout <= 0 {
+			log.WithFields(log.Fields{
+				"uuid":    uuid,
+				"bundles": len(bundles),
+			}).Info("REST client fetches bundles")
+			return bundles
 		}
 
-		fetchResponse.Bundles = bundles
+		select {
+		case <-arrival:
+			continue
 
-		delete(ra.mailboxes, fetchRequest.UUID)
+		case <-deadline.C:
+		case <-ctx.Done():
+		}
+
+		log.WithFields(log.Fields{
+			"uuid":    uuid,
+			"timeout": timeout,
+		}).Debug("REST client's fetch timed out without new bundles")
+		return bundles
+	}
+}
+
+// handleAck acknowledges fetched bundles for a client requiring acknowledgements, called by /ack.
+func (ra *RestAgent) handleAck(w http.ResponseWriter, r *http.Request) {
+	var (
+		ackRequest  RestAckRequest
+		ackResponse RestAckResponse
+	)
+
+	if jsonErr := json.NewDecoder(r.Body).Decode(&ackRequest); jsonErr != nil {
+		log.WithError(jsonErr).Warn("Failed to parse REST ack request")
+		ackResponse.Error = jsonErr.Error()
+	} else {
+		ra.mailboxMutex.Lock()
+		if mailbox, ok := ra.mailboxes[ackRequest.UUID]; !ok {
+			log.WithField("uuid", ackRequest.UUID).Debug("REST client cannot acknowledge for unknown UUID")
+			ackResponse.Error = "Invalid UUID"
+		} else {
+			log.WithFields(log.Fields{
+				"uuid":  ackRequest.UUID,
+				"acked": mailbox.ack(ackRequest.BundleIDs),
+			}).Info("REST client acknowledged bundles")
+		}
 		ra.mailboxMutex.Unlock()
-	} else if !ok {
-		log.WithField("uuid", fetchRequest.UUID).Debug("REST client has no new bundles to fetch")
-		fetchResponse.Bundles = make([]bpv7.Bundle, 0)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(fetchResponse); err != nil {
-		log.WithError(err).Warn("Failed to write REST fetch response")
+	if err := json.NewEncoder(w).Encode(ackResponse); err != nil {
+		log.WithError(err).Warn("Failed to write REST ack response")
+	}
+}
+
+// handlePayload serves the payload of a bundle from some client's mailbox, supporting HTTP Range requests; called by
+// GET /payload with the "uuid" and "bundle_id" query parameters.
+func (ra *RestAgent) handlePayload(w http.ResponseWriter, r *http.Request) {
+	uuid, bid := r.URL.Query().Get("uuid"), r.URL.Query().Get("bundle_id")
+	if uuid == "" || bid == "" {
+		http.Error(w, "Missing uuid or bundle_id", http.StatusBadRequest)
+		return
+	}
+
+	var (
+		payload []byte
+		found   bool
+	)
+
+	ra.mailboxMutex.Lock()
+	mailbox, ok := ra.mailboxes[uuid]
+	if ok {
+		payload, found = mailbox.payload(bid)
 	}
+	ra.mailboxMutex.Unlock()
+
+	if !ok {
+		log.WithField("uuid", uuid).Debug("REST client cannot fetch a payload for unknown UUID")
+		http.Error(w, "Invalid UUID", http.StatusNotFound)
+		return
+	} else if !found {
+		log.WithFields(log.Fields{
+			"uuid":   uuid,
+			"bundle": bid,
+		}).Debug("REST client requested the payload of an unknown bundle")
+		http.Error(w, "Unknown bundle", http.StatusNotFound)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"uuid":   uuid,
+		"bundle": bid,
+		"range":  r.Header.Get("Range"),
+	}).Info("REST client fetches a bundle's payload")
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(payload))
+}
+
+// loadClient returns a registered client's endpoint and marks its mailbox as being active.
+func (ra *RestAgent) loadClient(uuid string) (eid interface{}, ok bool) {
+	if eid, ok = ra.clients.Load(uuid); !ok {
+		return
+	}
+
+	ra.mailboxMutex.Lock()
+	if mailbox, exists := ra.mailboxes[uuid]; exists {
+		mailbox.touch()
+	}
+	ra.mailboxMutex.Unlock()
+
+	return
 }
 
 // handleBuild creates and dispatches a new bundle, called by /build.
@@ -267,12 +572,18 @@ func (ra *RestAgent) handleBuild(w http.ResponseWriter, r *http.Request) {
 	if jsonErr := json.NewDecoder(r.Body).Decode(&buildRequest); jsonErr != nil {
 		log.WithError(jsonErr).Warn("Failed to parse REST build request")
 		buildResponse.Error = jsonErr.Error()
-	} else if eid, ok := ra.clients.Load(buildRequest.UUID); !ok {
+	} else if eid, ok := ra.loadClient(buildRequest.UUID); !ok {
 		log.WithField("uuid", buildRequest.UUID).Debug("REST client cannot build for unknown UUID")
 		buildResponse.Error = "Invalid UUID"
 	} else if b, bErr := bpv7.BuildFromMap(buildRequest.Args); bErr != nil {
 		log.WithError(bErr).WithField("uuid", buildRequest.UUID).Warn("REST client failed to build a bundle")
 		buildResponse.Error = bErr.Error()
+
+		var buildErr *bpv7.BuildFromMapError
+		if errors.As(bErr, &buildErr) {
+			buildResponse.ErrorMethod = buildErr.Method
+			buildResponse.ErrorReason = buildErr.Reason.Error()
+		}
 	} else if pb := b.PrimaryBlock; pb.SourceNode != eid && pb.ReportTo != eid {
 		msg := "REST client's endpoint is neither the source nor the report_to field"
 		log.WithFields(log.Fields{
@@ -295,10 +606,45 @@ func (ra *RestAgent) handleBuild(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleLifetime previews the lifetime of a bundle without dispatching it, called by /lifetime.
+func (ra *RestAgent) handleLifetime(w http.ResponseWriter, r *http.Request) {
+	var (
+		lifetimeRequest  RestBuildRequest
+		lifetimeResponse RestLifetimeResponse
+	)
+
+	if jsonErr := json.NewDecoder(r.Body).Decode(&lifetimeRequest); jsonErr != nil {
+		log.WithError(jsonErr).Warn("Failed to parse REST lifetime request")
+		lifetimeResponse.Error = jsonErr.Error()
+	} else if _, ok := ra.loadClient(lifetimeRequest.UUID); !ok {
+		log.WithField("uuid", lifetimeRequest.UUID).Debug("REST client cannot preview for unknown UUID")
+		lifetimeResponse.Error = "Invalid UUID"
+	} else if expiry, expired, lErr := bpv7.LifetimeFromMap(lifetimeRequest.Args); lErr != nil {
+		log.WithError(lErr).WithField("uuid", lifetimeRequest.UUID).Debug("REST client failed to preview a lifetime")
+		lifetimeResponse.Error = lErr.Error()
+
+		var buildErr *bpv7.BuildFromMapError
+		if errors.As(lErr, &buildErr) {
+			lifetimeResponse.ErrorMethod = buildErr.Method
+			lifetimeResponse.ErrorReason = buildErr.Reason.Error()
+		}
+	} else {
+		lifetimeResponse.Expiry = expiry
+		lifetimeResponse.Expired = expired
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(lifetimeResponse); err != nil {
+		log.WithError(err).Warn("Failed to write REST lifetime response")
+	}
+}
+
 func (ra *RestAgent) Endpoints() (eids []bpv7.EndpointID) {
 	ra.clients.Range(func(_, v interface{}) bool {
-		eids = append(eids, v.(bpv7.EndpointID))
-		return false
+		if eid := v.(bpv7.EndpointID); !bagHasEndpoint(eids, eid) {
+			eids = append(eids, eid)
+		}
+		return true
 	})
 	return
 }
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// restMailboxState describes the delivery state of a bundle within a restMailbox.
+type restMailboxState int
+
+const (
+	// restMailboxNew bundles were not yet fetched by the client.
+	restMailboxNew restMailboxState = iota
+
+	// restMailboxPendingAck bundles were fetched by the client, but not yet acknowledged.
+	restMailboxPendingAck
+)
+
+// restMailboxItem is a bundle together with its delivery state.
+type restMailboxItem struct {
+	bundle bpv7.Bundle
+	state  restMailboxState
+}
+
+// restMailbox stores the bundles for a RestAgent's client.
+//
+// Without acknowledgement, a fetched bundle is removed from the mailbox. Otherwise, the bundle remains in the mailbox
+// as pending until the client acknowledges it. Pending bundles will be redelivered after the client reconnects.
+//
+//	new --fetch--> pending --ack--> removed
+//	 ^                |
+//	 +---reconnect----+
+//
+// A restMailbox is not thread safe and is guarded by the RestAgent's mailboxMutex.
+type restMailbox struct {
+	acknowledge bool
+	items       map[bpv7.BundleID]*restMailboxItem
+
+	// lastActivity is the last time the client used this mailbox, used to reclaim stale mailboxes.
+	lastActivity time.Time
+
+	// arrival is closed and replaced by signal, e.g., when a new bundle is delivered. Thus, it acts as a condition
+	// variable for waiting fetches, which also supports timeouts.
+	arrival chan struct{}
+}
+
+// newRestMailbox creates an empty restMailbox, optionally requiring acknowledgements.
+func newRestMailbox(acknowledge bool) *restMailbox {
+	return &restMailbox{
+		acknowledge:  acknowledge,
+		items:        make(map[bpv7.BundleID]*restMailboxItem),
+		lastActivity: time.Now(),
+		arrival:      make(chan struct{}),
+	}
+}
+
+// signal all fetches waiting for this mailbox's arrival channel.
+func (mb *restMailbox) signal() {
+	close(mb.arrival)
+	mb.arrival = make(chan struct{})
+}
+
+// touch marks this mailbox as being used by its client.
+func (mb *restMailbox) touch() {
+	mb.lastActivity = time.Now()
+}
+
+// isStale checks if the client was inactive for longer than the ttl.
+func (mb *restMailbox) isStale(now time.Time, ttl time.Duration) bool {
+	return now.Sub(mb.lastActivity) > ttl
+}
+
+// deliver a bundle into this mailbox. False is returned if this bundle is already present.
+func (mb *restMailbox) deliver(b bpv7.Bundle) bool {
+	if _, exists := mb.items[b.ID()]; exists {
+		return false
+	}
+
+	mb.items[b.ID()] = &restMailboxItem{bundle: b, state: restMailboxNew}
+	mb.signal()
+	return true
+}
+
+// fetch all new bundles, optionally restricted to those matching a non-nil match function. Fetched bundles are either
+// removed or marked as pending, based on the acknowledgement mode. Non-matching bundles remain untouched.
+func (mb *restMailbox) fetch(match func(b bpv7.Bundle) bool) (bundles []bpv7.Bundle) {
+	mb.touch()
+
+	bundles = make([]bpv7.Bundle, 0, len(mb.items))
+
+	for bid, item := range mb.items {
+		if item.state != restMailboxNew || (match != nil && !match(item.bundle)) {
+			continue
+		}
+
+		bundles = append(bundles, item.bundle)
+
+		if mb.acknowledge {
+			item.state = restMailboxPendingAck
+		} else {
+			delete(mb.items, bid)
+		}
+	}
+	return
+}
+
+// ack removes the pending bundles, identified by their bpv7.BundleID's string representation. The amount of removed
+// bundles is returned; unknown or not yet fetched bundles are ignored.
+func (mb *restMailbox) ack(bids []string) (n int) {
+	mb.touch()
+
+	acks := make(map[string]struct{}, len(bids))
+	for _, bid := range bids {
+		acks[bid] = struct{}{}
+	}
+
+	for bid, item := range mb.items {
+		if _, ok := acks[bid.String()]; ok && item.state == restMailboxPendingAck {
+			delete(mb.items, bid)
+			n++
+		}
+	}
+	return
+}
+
+// payload of a bundle, identified by its bpv7.BundleID's string representation, which is either new or pending.
+func (mb *restMailbox) payload(bid string) (data []byte, ok bool) {
+	mb.touch()
+
+	for itemBid, item := range mb.items {
+		if itemBid.String() != bid {
+			continue
+		}
+
+		if pb, err := item.bundle.PayloadBlock(); err == nil {
+			return pb.Value.(*bpv7.PayloadBlock).Data(), true
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
+// redeliver resets all pending bundles to be fetched again. The amount of those bundles is returned.
+func (mb *restMailbox) redeliver() (n int) {
+	mb.touch()
+
+	for _, item := range mb.items {
+		if item.state == restMailboxPendingAck {
+			item.state = restMailboxNew
+			n++
+		}
+	}
+
+	if n > 0 {
+		mb.signal()
+	}
+	return
+}
@@ -4,11 +4,22 @@
 
 package agent
 
-import "github.com/dtn7/dtn7-go/pkg/bpv7"
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
 
 // RestRegisterRequest describes a JSON to be POSTed to /register.
+//
+// If Acknowledge is set, fetched bundles remain in the mailbox until they are acknowledged by POSTing to /ack.
+// Unacknowledged bundles are redelivered after the client reconnects by registering again with its previous UUID.
 type RestRegisterRequest struct {
-	EndpointId string `json:"endpoint_id"`
+	EndpointId  string `json:"endpoint_id"`
+	UUID        string `json:"uuid,omitempty"`
+	Acknowledge bool   `json:"acknowledge,omitempty"`
 }
 
 // RestRegisterResponse describes a JSON response for /register.
@@ -28,14 +39,122 @@ type RestUnregisterResponse struct {
 }
 
 // RestFetchRequest describes a JSON to be POSTed to /fetch.
+//
+// If the optional Timeout, e.g., "30s", is set and no new bundles are present, the request blocks until at least one
+// bundle arrives or the Timeout, limited to RestFetchMaxTimeout, is exceeded.
+//
+// If the optional Filter is set, only matching bundles are fetched. Others remain in the mailbox for further fetches.
 type RestFetchRequest struct {
-	UUID string `json:"uuid"`
+	UUID    string           `json:"uuid"`
+	Timeout string           `json:"timeout,omitempty"`
+	Filter  *RestFetchFilter `json:"filter,omitempty"`
+}
+
+// RestFetchFilter restricts a RestFetchRequest to bundles matching all of its set fields.
+//
+// Source is a glob pattern for the source endpoint ID, as supported by path.Match, e.g., "dtn://*/app".
+// MinLifetime and MaxLifetime are durations, e.g., "1h", limiting the bundle's lifetime. ExtensionBlock requires an
+// extension block of this block type code to be present.
+type RestFetchFilter struct {
+	Source         string  `json:"source,omitempty"`
+	MinLifetime    string  `json:"min_lifetime,omitempty"`
+	MaxLifetime    string  `json:"max_lifetime,omitempty"`
+	ExtensionBlock *uint64 `json:"extension_block,omitempty"`
+}
+
+// matcher creates a function to check bundles against this RestFetchFilter. A nil filter matches all bundles.
+func (filter *RestFetchFilter) matcher() (func(b bpv7.Bundle) bool, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	if filter.Source != "" {
+		if _, err := path.Match(filter.Source, ""); err != nil {
+			return nil, fmt.Errorf("source pattern %s is invalid: %v", filter.Source, err)
+		}
+	}
+
+	var minLifetime, maxLifetime time.Duration
+	for _, lt := range []struct {
+		field string
+		value *time.Duration
+	}{
+		{filter.MinLifetime, &minLifetime},
+		{filter.MaxLifetime, &maxLifetime},
+	} {
+		if lt.field == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(lt.field)
+		if err != nil {
+			return nil, err
+		} else if d < 0 {
+			return nil, fmt.Errorf("lifetime %s is negative", lt.field)
+		}
+		*lt.value = d
+	}
+
+	if filter.MaxLifetime != "" && minLifetime > maxLifetime {
+		return nil, fmt.Errorf("min lifetime %s exceeds max lifetime %s", filter.MinLifetime, filter.MaxLifetime)
+	}
+
+	return func(b bpv7.Bundle) bool {
+		if filter.Source != "" {
+			if ok, _ := path.Match(filter.Source, b.PrimaryBlock.SourceNode.String()); !ok {
+				return false
+			}
+		}
+
+		lifetime := time.Duration(b.PrimaryBlock.Lifetime) * time.Millisecond
+		if lifetime < minLifetime || (filter.MaxLifetime != "" && lifetime > maxLifetime) {
+			return false
+		}
+
+		if filter.ExtensionBlock != nil && !b.HasExtensionBlock(*filter.ExtensionBlock) {
+			return false
+		}
+
+		return true
+	}, nil
+}
+
+// RestFetchMaxTimeout limits a RestFetchRequest's Timeout.
+const RestFetchMaxTimeout = 5 * time.Minute
+
+// timeout of this RestFetchRequest, limited to RestFetchMaxTimeout. Without a Timeout, zero is returned.
+func (fetchRequest RestFetchRequest) timeout() (time.Duration, error) {
+	if fetchRequest.Timeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(fetchRequest.Timeout)
+	if err != nil {
+		return 0, err
+	} else if timeout < 0 {
+		return 0, fmt.Errorf("timeout %s is negative", fetchRequest.Timeout)
+	} else if timeout > RestFetchMaxTimeout {
+		timeout = RestFetchMaxTimeout
+	}
+	return timeout, nil
 }
 
-// RestFetchResponse describes a JSON response for /fetch.
+// RestFetchResponse describes a JSON response for /fetch. The BundleIDs are ordered as the Bundles.
 type RestFetchResponse struct {
-	Error   string        `json:"error"`
-	Bundles []bpv7.Bundle `json:"bundles"`
+	Error     string        `json:"error"`
+	Bundles   []bpv7.Bundle `json:"bundles"`
+	BundleIDs []string      `json:"bundle_ids,omitempty"`
+}
+
+// RestAckRequest describes a JSON to be POSTed to /ack, acknowledging fetched bundles by their IDs.
+type RestAckRequest struct {
+	UUID      string   `json:"uuid"`
+	BundleIDs []string `json:"bundle_ids"`
+}
+
+// RestAckResponse describes a JSON response for /ack.
+type RestAckResponse struct {
+	Error string `json:"error"`
 }
 
 // RestBuildRequest describes a JSON to be POSTed to /build.
@@ -45,6 +164,22 @@ type RestBuildRequest struct {
 }
 
 // RestBuildResponse describes a JSON response for /build.
+//
+// If building the bundle failed due to an argument, its key is named in ErrorMethod and the reason in ErrorReason.
 type RestBuildResponse struct {
-	Error string `json:"error"`
+	Error       string `json:"error"`
+	ErrorMethod string `json:"error_method,omitempty"`
+	ErrorReason string `json:"error_reason,omitempty"`
+}
+
+// RestLifetimeResponse describes a JSON response for /lifetime, which expects a RestBuildRequest.
+//
+// Expiry is the point in time when the bundle's lifetime will be exceeded. If this has already happened, Expired is set.
+// Errors are reported as for RestBuildResponse.
+type RestLifetimeResponse struct {
+	Error       string    `json:"error"`
+	ErrorMethod string    `json:"error_method,omitempty"`
+	ErrorReason string    `json:"error_reason,omitempty"`
+	Expiry      time.Time `json:"expiry"`
+	Expired     bool      `json:"expired"`
 }
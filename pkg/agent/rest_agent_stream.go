@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// restStreamBuffer is the amount of bundles being buffered for a restStream. If its client is too slow, the stream is
+// closed and its bundles are moved to a restMailbox, compare restStream.overflow.
+const restStreamBuffer = 64
+
+// restStream pushes bundles to a RestAgent's client over an open Server-Sent Events connection. In contrast to a
+// restMailbox, bundles are not stored, but only passed to the connection's handler.
+type restStream struct {
+	bundles chan bpv7.Bundle
+
+	// overflowed is closed after the buffer ran full, signaling the connection's handler to disconnect.
+	overflowed chan struct{}
+}
+
+// newRestStream creates a restStream with a buffered bundle channel.
+func newRestStream() *restStream {
+	return &restStream{
+		bundles:    make(chan bpv7.Bundle, restStreamBuffer),
+		overflowed: make(chan struct{}),
+	}
+}
+
+// push a bundle to this stream without blocking. False is returned if the stream's buffer is full.
+func (rs *restStream) push(b bpv7.Bundle) bool {
+	select {
+	case rs.bundles <- b:
+		return true
+	default:
+		return false
+	}
+}
+
+// overflow moves all buffered bundles and the rejected one to a new restMailbox and signals the connection's handler
+// to disconnect. Thus, a slow client loses no bundles, but might fetch them by its UUID. The RestAgent's mailboxMutex
+// must be held and the stream must already be removed from the RestAgent's streams.
+func (rs *restStream) overflow(rejected bpv7.Bundle) *restMailbox {
+	mailbox := newRestMailbox(false)
+
+drain:
+	for {
+		select {
+		case b := <-rs.bundles:
+			mailbox.deliver(b)
+		default:
+			break drain
+		}
+	}
+	mailbox.deliver(rejected)
+
+	close(rs.overflowed)
+	return mailbox
+}
+
+// writeRestStreamEvent writes a Server-Sent Event, whose data is JSON encoded.
+func writeRestStreamEvent(w io.Writer, event string, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, jsonData)
+	return err
+}
+
+// handleStream registers a client and pushes its bundles as Server-Sent Events, called by GET /stream with the
+// "endpoint_id" query parameter. The first "register" event carries a RestRegisterResponse, followed by a "bundle"
+// event for each incoming bundle. The client is unregistered as soon as its connection is closed.
+//
+// If the client cannot keep up with its bundles, the connection is closed by the RestAgent. However, the client stays
+// registered and its pending bundles are kept in a mailbox, to be fetched by the UUID of the "register" event.
+func (ra *RestAgent) handleStream(w http.ResponseWriter, r *http.Request) {
+	eid, eidErr := bpv7.NewEndpointID(r.URL.Query().Get("endpoint_id"))
+	if eidErr != nil {
+		http.Error(w, eidErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	uuid, uuidErr := ra.randomUuid()
+	if uuidErr != nil {
+		http.Error(w, uuidErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	stream := newRestStream()
+
+	ra.mailboxMutex.Lock()
+	ra.streams[uuid] = stream
+	ra.mailboxMutex.Unlock()
+	ra.clients.Store(uuid, eid)
+
+	logger := log.WithFields(log.Fields{
+		"uuid":     uuid,
+		"endpoint": eid,
+	})
+	logger.Info("REST client opened a stream")
+
+	defer func() {
+		ra.mailboxMutex.Lock()
+		overflowed := ra.streams[uuid] != stream
+		if !overflowed {
+			delete(ra.streams, uuid)
+			ra.clients.Delete(uuid)
+		}
+		ra.mailboxMutex.Unlock()
+
+		if overflowed {
+			logger.Warn("REST client's stream was closed as being too slow, keeping its bundles in a mailbox")
+		} else {
+			logger.Info("REST client closed its stream")
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeRestStreamEvent(w, "register", RestRegisterResponse{UUID: uuid}); err != nil {
+		logger.WithError(err).Warn("Failed to write REST stream's registration")
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-stream.overflowed:
+			return
+
+		case b := <-stream.bundles:
+			if err := writeRestStreamEvent(w, "bundle", b); err != nil {
+				logger.WithError(err).Warn("Failed to write bundle to REST stream")
+				return
+			}
+			flusher.Flush()
+
+			logger.WithField("bundle", b.ID().String()).Debug("REST Application Agent streamed bundle to a client")
+		}
+	}
+}
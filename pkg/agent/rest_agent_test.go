@@ -5,10 +5,13 @@
 package agent
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"sync"
@@ -209,3 +212,662 @@ func TestRestAgentCycle(t *testing.T) {
 		t.Fatal("endpoint is still registered")
 	}
 }
+
+// startRestAgent serves a new RestAgent at a random port for testing purpose and returns its base URL.
+func startRestAgent(t *testing.T) (string, *RestAgent) {
+	return startRestAgentWithMailboxTTL(t, 0)
+}
+
+// startRestAgentWithMailboxTTL is startRestAgent, dropping inactive clients' mailboxes after the mailboxTTL.
+func startRestAgentWithMailboxTTL(t *testing.T, mailboxTTL time.Duration) (string, *RestAgent) {
+	addr := fmt.Sprintf("localhost:%d", randomPort(t))
+
+	r := mux.NewRouter()
+	restRouter := r.PathPrefix("/rest").Subrouter()
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+	go func() { _ = httpServer.ListenAndServe() }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	restAgent := NewRestAgentWithMailboxTTL(restRouter, mailboxTTL)
+	t.Cleanup(func() { restAgent.MessageReceiver() <- ShutdownMessage{} })
+
+	for i := 1; i <= 3; i++ {
+		if isAddrReachable(addr) {
+			break
+		} else if i == 3 {
+			t.Fatal("RestAgent seems to be unreachable")
+		}
+	}
+
+	return fmt.Sprintf("http://%s/rest", addr), restAgent
+}
+
+// restPost a JSON request to some RestAgent's URL and decode its JSON response.
+func restPost(t *testing.T, url string, request, response interface{}) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(request); err != nil {
+		t.Fatal(err)
+	}
+
+	if resp, err := http.Post(url, "application/json", buf); err != nil {
+		t.Fatal(err)
+	} else if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		t.Fatal(err)
+	} else {
+		_ = resp.Body.Close()
+	}
+}
+
+// restFetchIDs fetches the bundles for some client and returns their IDs.
+func restFetchIDs(t *testing.T, baseUrl, uuid string) []string {
+	var fetchResponse struct {
+		Error     string            `json:"error"`
+		Bundles   []json.RawMessage `json:"bundles"`
+		BundleIDs []string          `json:"bundle_ids"`
+	}
+
+	restPost(t, baseUrl+"/fetch", RestFetchRequest{UUID: uuid}, &fetchResponse)
+	if fetchResponse.Error != "" {
+		t.Fatal(fetchResponse.Error)
+	} else if len(fetchResponse.Bundles) != len(fetchResponse.BundleIDs) {
+		t.Fatalf("fetched %d bundles, but %d IDs", len(fetchResponse.Bundles), len(fetchResponse.BundleIDs))
+	}
+
+	return fetchResponse.BundleIDs
+}
+
+func TestRestAgentAcknowledge(t *testing.T) {
+	baseUrl, restAgent := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	var registerResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String(), Acknowledge: true}, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+	uuid := registerResponse.UUID
+
+	b1 := createBundle("dtn://sender-a/", registerEid.String(), t)
+	b2 := createBundle("dtn://sender-b/", registerEid.String(), t)
+	restAgent.MessageReceiver() <- BundleMessage{Bundle: b1}
+	restAgent.MessageReceiver() <- BundleMessage{Bundle: b2}
+
+	time.Sleep(250 * time.Millisecond)
+
+	// Fetch both bundles; a second fetch without reconnecting must not deliver them again.
+	if bids := restFetchIDs(t, baseUrl, uuid); len(bids) != 2 {
+		t.Fatalf("fetched %d bundles, not 2", len(bids))
+	}
+	if bids := restFetchIDs(t, baseUrl, uuid); len(bids) != 0 {
+		t.Fatalf("fetched %d bundles without reconnecting", len(bids))
+	}
+
+	// Acknowledge only the first bundle.
+	var ackResponse RestAckResponse
+	restPost(t, baseUrl+"/ack", RestAckRequest{UUID: uuid, BundleIDs: []string{b1.ID().String()}}, &ackResponse)
+	if ackResponse.Error != "" {
+		t.Fatal(ackResponse.Error)
+	}
+
+	// Reconnect, e.g., after a crash; the unacknowledged bundle must be redelivered.
+	reconnectRequest := RestRegisterRequest{EndpointId: registerEid.String(), UUID: uuid, Acknowledge: true}
+	restPost(t, baseUrl+"/register", reconnectRequest, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	} else if registerResponse.UUID != uuid {
+		t.Fatalf("reconnect changed UUID from %s to %s", uuid, registerResponse.UUID)
+	}
+
+	if bids := restFetchIDs(t, baseUrl, uuid); len(bids) != 1 || bids[0] != b2.ID().String() {
+		t.Fatalf("redelivery resulted in %v, not %s", bids, b2.ID())
+	}
+
+	// After acknowledging, nothing must be redelivered.
+	restPost(t, baseUrl+"/ack", RestAckRequest{UUID: uuid, BundleIDs: []string{b2.ID().String()}}, &ackResponse)
+	if ackResponse.Error != "" {
+		t.Fatal(ackResponse.Error)
+	}
+
+	restPost(t, baseUrl+"/register", reconnectRequest, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+
+	if bids := restFetchIDs(t, baseUrl, uuid); len(bids) != 0 {
+		t.Fatalf("fetched %d acknowledged bundles after reconnecting", len(bids))
+	}
+
+	// Reconnecting with an unknown UUID must fail.
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String(), UUID: "nope"}, &registerResponse)
+	if registerResponse.Error == "" {
+		t.Fatal("reconnecting with an unknown UUID did not fail")
+	}
+}
+
+func TestRestAgentMailboxTTL(t *testing.T) {
+	baseUrl, restAgent := startRestAgentWithMailboxTTL(t, 200*time.Millisecond)
+
+	var activeResponse, inactiveResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: "dtn://foo/active"}, &activeResponse)
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: "dtn://foo/inactive"}, &inactiveResponse)
+	if activeResponse.Error != "" || inactiveResponse.Error != "" {
+		t.Fatalf("registration failed: %s, %s", activeResponse.Error, inactiveResponse.Error)
+	}
+
+	b, err := bpv7.Builder().
+		Source("dtn://bar/").
+		Destination("dtn://foo/inactive").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restAgent.MessageReceiver() <- BundleMessage{Bundle: b}
+
+	for i := 0; i < 10; i++ {
+		time.Sleep(100 * time.Millisecond)
+		_ = restFetchIDs(t, baseUrl, activeResponse.UUID)
+	}
+
+	restAgent.mailboxMutex.Lock()
+	_, activeExists := restAgent.mailboxes[activeResponse.UUID]
+	_, inactiveExists := restAgent.mailboxes[inactiveResponse.UUID]
+	restAgent.mailboxMutex.Unlock()
+
+	if !activeExists {
+		t.Fatal("active client's mailbox was dropped")
+	} else if inactiveExists {
+		t.Fatal("inactive client's mailbox was not dropped")
+	}
+
+	if _, ok := restAgent.clients.Load(inactiveResponse.UUID); ok {
+		t.Fatal("inactive client is still registered")
+	}
+
+	var buildResponse RestBuildResponse
+	restPost(t, baseUrl+"/build", RestBuildRequest{
+		UUID: inactiveResponse.UUID,
+		Args: map[string]interface{}{
+			"destination":        "dtn://bar/",
+			"source":             "dtn://foo/inactive",
+			"creation_timestamp": "now",
+			"lifetime":           "10m",
+			"payload_block":      "hello world",
+		},
+	}, &buildResponse)
+	if buildResponse.Error != "Invalid UUID" {
+		t.Fatalf("inactive client was able to build a bundle: %q", buildResponse.Error)
+	}
+}
+
+func TestRestAgentBuildError(t *testing.T) {
+	baseUrl, _ := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	var registerResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String()}, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"lifetime", "forever"},
+		{"destination", "nope"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			args := map[string]interface{}{
+				"destination":              "dtn://dst/",
+				"source":                   registerEid.String(),
+				"creation_timestamp_epoch": true,
+				"lifetime":                 "10m",
+				"payload_block":            "hello world",
+			}
+			args[test.key] = test.value
+
+			var buildResponse RestBuildResponse
+			restPost(t, baseUrl+"/build", RestBuildRequest{UUID: registerResponse.UUID, Args: args}, &buildResponse)
+
+			if buildResponse.Error == "" {
+				t.Fatal("building bundle succeeded")
+			} else if buildResponse.ErrorMethod != test.key {
+				t.Fatalf("error method is %q, expected %q", buildResponse.ErrorMethod, test.key)
+			} else if buildResponse.ErrorReason == "" {
+				t.Fatal("error reason is missing")
+			}
+		})
+	}
+}
+
+func TestRestAgentLifetime(t *testing.T) {
+	baseUrl, _ := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	var registerResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String()}, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		lifetime time.Duration
+		expired  bool
+	}{
+		{"valid", map[string]interface{}{"creation_timestamp_now": true, "lifetime": "24h"}, 24 * time.Hour, false},
+		{"too short", map[string]interface{}{
+			"creation_timestamp_epoch": true,
+			"bundle_age_block":         "1s",
+			"lifetime":                 "1ms",
+		}, -999 * time.Millisecond, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := map[string]interface{}{
+				"destination":   "dtn://dst/",
+				"source":        registerEid.String(),
+				"payload_block": "hello world",
+			}
+			for k, v := range test.args {
+				args[k] = v
+			}
+
+			var lifetimeResponse RestLifetimeResponse
+			restPost(t, baseUrl+"/lifetime", RestBuildRequest{UUID: registerResponse.UUID, Args: args}, &lifetimeResponse)
+
+			expectedExpiry := time.Now().Add(test.lifetime)
+			if lifetimeResponse.Error != "" {
+				t.Fatal(lifetimeResponse.Error)
+			} else if lifetimeResponse.Expired != test.expired {
+				t.Fatalf("expired is %t, expected %t", lifetimeResponse.Expired, test.expired)
+			} else if diff := lifetimeResponse.Expiry.Sub(expectedExpiry); diff < -2*time.Second || diff > 2*time.Second {
+				t.Fatalf("expiry is %v, expected about %v", lifetimeResponse.Expiry, expectedExpiry)
+			}
+		})
+	}
+
+	var lifetimeResponse RestLifetimeResponse
+	restPost(t, baseUrl+"/lifetime", RestBuildRequest{
+		UUID: registerResponse.UUID,
+		Args: map[string]interface{}{"lifetime": "forever"},
+	}, &lifetimeResponse)
+	if lifetimeResponse.Error == "" || lifetimeResponse.ErrorMethod != "lifetime" {
+		t.Fatalf("invalid lifetime was not reported: %+v", lifetimeResponse)
+	}
+}
+
+func TestRestAgentPayloadRange(t *testing.T) {
+	baseUrl, restAgent := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	var registerResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String(), Acknowledge: true}, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+	uuid := registerResponse.UUID
+
+	payload := []byte("0123456789abcdef")
+	b, err := bpv7.Builder().
+		Source("dtn://sender/").
+		Destination(registerEid).
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restAgent.MessageReceiver() <- BundleMessage{Bundle: b}
+
+	time.Sleep(250 * time.Millisecond)
+
+	if bids := restFetchIDs(t, baseUrl, uuid); len(bids) != 1 {
+		t.Fatalf("fetched %d bundles, not 1", len(bids))
+	}
+
+	tests := []struct {
+		name         string
+		uuid         string
+		bundleId     string
+		rangeHeader  string
+		status       int
+		body         []byte
+		contentRange string
+	}{
+		{"full", uuid, b.ID().String(), "", http.StatusOK, payload, ""},
+		{"partial", uuid, b.ID().String(), "bytes=2-5", http.StatusPartialContent, payload[2:6], "bytes 2-5/16"},
+		{"resume", uuid, b.ID().String(), "bytes=10-", http.StatusPartialContent, payload[10:], "bytes 10-15/16"},
+		{"suffix", uuid, b.ID().String(), "bytes=-4", http.StatusPartialContent, payload[12:], "bytes 12-15/16"},
+		{"unsatisfiable", uuid, b.ID().String(), "bytes=100-200", http.StatusRequestedRangeNotSatisfiable, nil, "bytes */16"},
+		{"malformed", uuid, b.ID().String(), "bytes=five-six", http.StatusRequestedRangeNotSatisfiable, nil, ""},
+		{"unknown bundle", uuid, "dtn://sender/-0-0", "", http.StatusNotFound, nil, ""},
+		{"unknown uuid", "invalid", b.ID().String(), "", http.StatusNotFound, nil, ""},
+		{"missing bundle", uuid, "", "", http.StatusBadRequest, nil, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := url.Values{}
+			query.Set("uuid", test.uuid)
+			if test.bundleId != "" {
+				query.Set("bundle_id", test.bundleId)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, baseUrl+"/payload?"+query.Encode(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.rangeHeader != "" {
+				req.Header.Set("Range", test.rangeHeader)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != test.status {
+				t.Fatalf("expected status %d, got %d: %s", test.status, resp.StatusCode, body)
+			}
+			if test.body != nil && !bytes.Equal(body, test.body) {
+				t.Fatalf("expected body %q, got %q", test.body, body)
+			}
+			if contentRange := resp.Header.Get("Content-Range"); contentRange != test.contentRange {
+				t.Fatalf("expected Content-Range %q, got %q", test.contentRange, contentRange)
+			}
+		})
+	}
+}
+
+func TestRestAgentFetchTimeout(t *testing.T) {
+	baseUrl, restAgent := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	var registerResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String()}, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+	uuid := registerResponse.UUID
+
+	fetch := func(timeout string) (fetchResponse RestFetchResponse, duration time.Duration) {
+		start := time.Now()
+		restPost(t, baseUrl+"/fetch", RestFetchRequest{UUID: uuid, Timeout: timeout}, &fetchResponse)
+		return fetchResponse, time.Since(start)
+	}
+
+	// Without any bundle, the fetch blocks until its timeout.
+	if resp, duration := fetch("200ms"); resp.Error != "" {
+		t.Fatal(resp.Error)
+	} else if len(resp.Bundles) != 0 {
+		t.Fatalf("fetched %d bundles from an empty mailbox", len(resp.Bundles))
+	} else if duration < 200*time.Millisecond {
+		t.Fatalf("fetch returned after %v, before its timeout", duration)
+	}
+
+	// A bundle arriving while waiting is returned immediately.
+	b := createBundle("dtn://sender/", registerEid.String(), t)
+	time.AfterFunc(200*time.Millisecond, func() { restAgent.MessageReceiver() <- BundleMessage{Bundle: b} })
+
+	if resp, duration := fetch("10s"); resp.Error != "" {
+		t.Fatal(resp.Error)
+	} else if len(resp.BundleIDs) != 1 || resp.BundleIDs[0] != b.ID().String() {
+		t.Fatalf("fetched %v, not %v", resp.BundleIDs, b.ID())
+	} else if duration > 5*time.Second {
+		t.Fatalf("fetch returned after %v, not on the bundle's arrival", duration)
+	}
+
+	// Without a timeout, the fetch returns immediately.
+	if resp, duration := fetch(""); resp.Error != "" {
+		t.Fatal(resp.Error)
+	} else if len(resp.Bundles) != 0 {
+		t.Fatalf("fetched %d bundles again", len(resp.Bundles))
+	} else if duration > time.Second {
+		t.Fatalf("fetch without a timeout returned after %v", duration)
+	}
+
+	for _, timeout := range []string{"soon", "-1s"} {
+		if resp, _ := fetch(timeout); resp.Error == "" {
+			t.Fatalf("invalid timeout %s was accepted", timeout)
+		}
+	}
+}
+
+func TestRestAgentFetchFilter(t *testing.T) {
+	baseUrl, restAgent := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	var registerResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String()}, &registerResponse)
+	if registerResponse.Error != "" {
+		t.Fatal(registerResponse.Error)
+	}
+	uuid := registerResponse.UUID
+
+	build := func(src, lifetime string, hopCount bool) bpv7.Bundle {
+		builder := bpv7.Builder().
+			Source(src).
+			Destination(registerEid).
+			CreationTimestampNow().
+			Lifetime(lifetime)
+		if hopCount {
+			builder = builder.HopCountBlock(64)
+		}
+
+		b, err := builder.PayloadBlock([]byte("hello world")).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	sensorShort := build("dtn://sensor-a/temp", "10m", false)
+	sensorLong := build("dtn://sensor-b/temp", "48h", true)
+	other := build("dtn://other/chat", "1h", true)
+	for _, b := range []bpv7.Bundle{sensorShort, sensorLong, other} {
+		restAgent.MessageReceiver() <- BundleMessage{Bundle: b}
+	}
+
+	time.Sleep(250 * time.Millisecond)
+
+	hopCountBlock := uint64(bpv7.ExtBlockTypeHopCountBlock)
+
+	tests := []struct {
+		name     string
+		filter   *RestFetchFilter
+		expected []bpv7.Bundle
+	}{
+		{"source and max lifetime", &RestFetchFilter{Source: "dtn://sensor-*/temp", MaxLifetime: "1h"}, []bpv7.Bundle{sensorShort}},
+		{"no match", &RestFetchFilter{Source: "dtn://nobody/*"}, nil},
+		{"min lifetime", &RestFetchFilter{MinLifetime: "24h"}, []bpv7.Bundle{sensorLong}},
+		{"extension block", &RestFetchFilter{ExtensionBlock: &hopCountBlock}, []bpv7.Bundle{other}},
+		{"none left", &RestFetchFilter{}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var fetchResponse RestFetchResponse
+			restPost(t, baseUrl+"/fetch", RestFetchRequest{UUID: uuid, Filter: test.filter}, &fetchResponse)
+			if fetchResponse.Error != "" {
+				t.Fatal(fetchResponse.Error)
+			}
+
+			if len(fetchResponse.BundleIDs) != len(test.expected) {
+				t.Fatalf("fetched %v, expected %d bundles", fetchResponse.BundleIDs, len(test.expected))
+			}
+			for i, b := range test.expected {
+				if fetchResponse.BundleIDs[i] != b.ID().String() {
+					t.Fatalf("fetched %v, expected %v", fetchResponse.BundleIDs[i], b.ID())
+				}
+			}
+		})
+	}
+
+	for _, filter := range []RestFetchFilter{
+		{Source: "dtn://[/"},
+		{MinLifetime: "soon"},
+		{MaxLifetime: "-1h"},
+		{MinLifetime: "2h", MaxLifetime: "1h"},
+	} {
+		var fetchResponse RestFetchResponse
+		restPost(t, baseUrl+"/fetch", RestFetchRequest{UUID: uuid, Filter: &filter}, &fetchResponse)
+		if fetchResponse.Error == "" {
+			t.Fatalf("invalid filter %v was accepted", filter)
+		}
+	}
+}
+
+// readRestStreamEvent reads the next Server-Sent Event's name and data.
+func readRestStreamEvent(t *testing.T, r *bufio.Reader) (event, data string) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		switch line = strings.TrimSuffix(line, "\n"); {
+		case line == "":
+			return
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestRestAgentStream(t *testing.T) {
+	baseUrl, restAgent := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	resp, err := http.Get(baseUrl + "/stream?endpoint_id=" + url.QueryEscape(registerEid.String()))
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	} else if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %s", contentType)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	var registerResponse RestRegisterResponse
+	if event, data := readRestStreamEvent(t, reader); event != "register" {
+		t.Fatalf("expected a register event, got %s", event)
+	} else if err := json.Unmarshal([]byte(data), &registerResponse); err != nil {
+		t.Fatal(err)
+	} else if registerResponse.Error != "" || registerResponse.UUID == "" {
+		t.Fatalf("registration failed: %v", registerResponse)
+	}
+
+	if !AppAgentHasEndpoint(restAgent, registerEid) {
+		t.Fatal("stream's endpoint was not registered")
+	}
+
+	// A polling client for the same endpoint receives the bundles as well.
+	var pollResponse RestRegisterResponse
+	restPost(t, baseUrl+"/register", RestRegisterRequest{EndpointId: registerEid.String()}, &pollResponse)
+	if pollResponse.Error != "" {
+		t.Fatal(pollResponse.Error)
+	}
+
+	var bundles []bpv7.Bundle
+	for _, sender := range []string{"dtn://sender-a/", "dtn://sender-b/"} {
+		b := createBundle(sender, registerEid.String(), t)
+		bundles = append(bundles, b)
+		restAgent.MessageReceiver() <- BundleMessage{Bundle: b}
+	}
+
+	for _, b := range bundles {
+		var streamed bpv7.Bundle
+		if event, data := readRestStreamEvent(t, reader); event != "bundle" {
+			t.Fatalf("expected a bundle event, got %s", event)
+		} else if err := json.Unmarshal([]byte(data), &streamed); err != nil {
+			t.Fatal(err)
+		} else if streamed.ID() != b.ID() {
+			t.Fatalf("streamed bundle %v, expected %v", streamed.ID(), b.ID())
+		}
+	}
+
+	if bids := restFetchIDs(t, baseUrl, pollResponse.UUID); len(bids) != len(bundles) {
+		t.Fatalf("polling client fetched %d bundles, not %d", len(bids), len(bundles))
+	}
+
+	// Closing the connection unregisters the stream's client.
+	_ = resp.Body.Close()
+
+	for i := 0; ; i++ {
+		restAgent.mailboxMutex.Lock()
+		streams := len(restAgent.streams)
+		restAgent.mailboxMutex.Unlock()
+		_, registered := restAgent.clients.Load(registerResponse.UUID)
+
+		if streams == 0 && !registered {
+			break
+		} else if i == 20 {
+			t.Fatalf("closed stream was not cleaned up: %d streams, registered %t", streams, registered)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if resp, err := http.Get(baseUrl + "/stream?endpoint_id=invalid"); err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid endpoint resulted in status %d", resp.StatusCode)
+	}
+}
+
+func TestRestAgentStreamOverflow(t *testing.T) {
+	baseUrl, restAgent := startRestAgent(t)
+	registerEid := bpv7.MustNewEndpointID("dtn://foo/bar")
+
+	// Register a stream without a connection handler, acting as a client being too slow.
+	const uuid = "slow-client"
+	stream := newRestStream()
+	restAgent.clients.Store(uuid, registerEid)
+	restAgent.mailboxMutex.Lock()
+	restAgent.streams[uuid] = stream
+	restAgent.mailboxMutex.Unlock()
+
+	for i := 0; i <= restStreamBuffer; i++ {
+		b := createBundle(fmt.Sprintf("dtn://sender-%d/", i), registerEid.String(), t)
+		restAgent.receiveBundleMessage(BundleMessage{Bundle: b})
+	}
+
+	select {
+	case <-stream.overflowed:
+	default:
+		t.Fatal("overflowed stream was not closed")
+	}
+
+	restAgent.mailboxMutex.Lock()
+	_, isStream := restAgent.streams[uuid]
+	restAgent.mailboxMutex.Unlock()
+	if isStream {
+		t.Fatal("overflowed stream is still registered")
+	} else if _, registered := restAgent.clients.Load(uuid); !registered {
+		t.Fatal("client of the overflowed stream was unregistered")
+	}
+
+	if bids := restFetchIDs(t, baseUrl, uuid); len(bids) != restStreamBuffer+1 {
+		t.Fatalf("fetched %d bundles, not %d", len(bids), restStreamBuffer+1)
+	}
+}
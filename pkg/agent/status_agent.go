@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// NodeStatus is a node's status, sent by a StatusAgent as a JSON encoded payload.
+type NodeStatus struct {
+	NodeId  string    `json:"node_id"`
+	Version string    `json:"version"`
+	Time    time.Time `json:"time"`
+
+	// ActiveSenders and ActiveReceivers count the started CLAs, as in the cla.ManagerMetrics.
+	ActiveSenders   int `json:"active_senders"`
+	ActiveReceivers int `json:"active_receivers"`
+
+	// StoreSize is the amount of stored Bundles.
+	StoreSize int `json:"store_size"`
+
+	// Neighbors is the amount of distinct peers reachable by an active ConvergenceSender.
+	Neighbors int `json:"neighbors"`
+}
+
+// statusCacheTime is the duration a StatusAgent replies with the same NodeStatus. As anyone might query a StatusAgent,
+// this bounds the effort of gathering a NodeStatus, e.g., counting the stored Bundles.
+const statusCacheTime = 10 * time.Second
+
+// StatusAgent is an ApplicationAgent to reply to each incoming Bundle with the node's current NodeStatus. This allows
+// remote health queries purely over DTN.
+type StatusAgent struct {
+	endpoint bpv7.EndpointID
+	status   func() NodeStatus
+	receiver chan Message
+	sender   chan Message
+
+	cached   NodeStatus
+	cachedAt time.Time
+}
+
+// NewStatusAgent creates a new StatusAgent ApplicationAgent, calling the status function for queries. Its result is
+// cached for statusCacheTime.
+func NewStatusAgent(endpoint bpv7.EndpointID, status func() NodeStatus) *StatusAgent {
+	s := &StatusAgent{
+		endpoint: endpoint,
+		status:   status,
+		receiver: make(chan Message),
+		sender:   make(chan Message),
+	}
+
+	go s.handler()
+
+	return s
+}
+
+func (s *StatusAgent) log() *log.Entry {
+	return log.WithField("StatusAgent", s.endpoint)
+}
+
+func (s *StatusAgent) handler() {
+	defer close(s.sender)
+
+	for m := range s.receiver {
+		switch m := m.(type) {
+		case BundleMessage:
+			s.replyStatus(m.Bundle)
+
+		case ShutdownMessage:
+			return
+
+		default:
+			s.log().WithField("message", m).Info("Received unsupported Message")
+		}
+	}
+}
+
+// currentStatus returns the cached NodeStatus or fetches a new one, if the cache has expired.
+func (s *StatusAgent) currentStatus() NodeStatus {
+	if s.cachedAt.IsZero() || time.Since(s.cachedAt) >= statusCacheTime {
+		s.cached = s.status()
+		s.cachedAt = time.Now()
+	}
+	return s.cached
+}
+
+func (s *StatusAgent) replyStatus(b bpv7.Bundle) {
+	payload, err := json.Marshal(s.currentStatus())
+	if err != nil {
+		s.log().WithError(err).Warn("Encoding node status erred")
+		return
+	}
+
+	hopCount := 64
+	if cb, err := b.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
+		if hc, ok := cb.Value.(*bpv7.HopCountBlock); ok {
+			hopCount = int(hc.Limit)
+		}
+	}
+
+	bndl, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source(s.endpoint).
+		Destination(b.PrimaryBlock.ReportTo).
+		CreationTimestampNow().
+		Lifetime(b.PrimaryBlock.Lifetime).
+		HopCountBlock(hopCount).
+		PayloadBlock(payload).
+		Build()
+
+	if err != nil {
+		s.log().WithError(err).Warn("Building status Bundle erred")
+	} else {
+		s.log().WithFields(log.Fields{
+			"query":  b.ID().String(),
+			"bundle": bndl.ID().String(),
+		}).Info("Sending status Bundle")
+		s.sender <- BundleMessage{bndl}
+	}
+}
+
+func (s *StatusAgent) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{s.endpoint}
+}
+
+func (s *StatusAgent) MessageReceiver() chan Message {
+	return s.receiver
+}
+
+func (s *StatusAgent) MessageSender() chan Message {
+	return s.sender
+}
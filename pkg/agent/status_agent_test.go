@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestStatusAgent(t *testing.T) {
+	expected := NodeStatus{
+		NodeId:          "dtn://foo/",
+		Version:         "v0.0.0-test",
+		Time:            time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		ActiveSenders:   2,
+		ActiveReceivers: 1,
+		StoreSize:       23,
+		Neighbors:       2,
+	}
+
+	status := NewStatusAgent(bpv7.MustNewEndpointID("dtn://foo/status"), func() NodeStatus { return expected })
+
+	query, err := bpv7.Builder().
+		Source("dtn://bar/").
+		Destination("dtn://foo/status").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock([]byte("")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status.receiver <- BundleMessage{query}
+
+	select {
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("StatusAgent did not answer after 500ms")
+
+	case m := <-status.sender:
+		bm, ok := m.(BundleMessage)
+		if !ok {
+			t.Fatalf("Incoming message is not a BundleMessage, it's a %T", m)
+		}
+
+		reply := bm.Bundle
+		if reply.PrimaryBlock.Destination != query.PrimaryBlock.SourceNode {
+			t.Fatalf("Reply's Destination %v is not query's Source %v",
+				reply.PrimaryBlock.Destination, query.PrimaryBlock.SourceNode)
+		}
+
+		payloadBlock, err := reply.PayloadBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ns NodeStatus
+		if err := json.Unmarshal(payloadBlock.Value.(*bpv7.PayloadBlock).Data(), &ns); err != nil {
+			t.Fatal(err)
+		} else if ns != expected {
+			t.Fatalf("Reply's status %v differs from %v", ns, expected)
+		}
+	}
+
+	status.receiver <- ShutdownMessage{}
+}
+
+func TestStatusAgentCache(t *testing.T) {
+	calls := 0
+	status := NewStatusAgent(bpv7.MustNewEndpointID("dtn://foo/status"), func() NodeStatus {
+		calls++
+		return NodeStatus{NodeId: "dtn://foo/", StoreSize: calls}
+	})
+
+	for i := 0; i < 3; i++ {
+		query, err := bpv7.Builder().
+			Source("dtn://bar/").
+			Destination("dtn://foo/status").
+			CreationTimestampNow().
+			Lifetime("5m").
+			PayloadBlock([]byte("")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		status.receiver <- BundleMessage{query}
+
+		select {
+		case <-time.After(500 * time.Millisecond):
+			t.Fatal("StatusAgent did not answer after 500ms")
+
+		case m := <-status.sender:
+			reply := m.(BundleMessage).Bundle
+			payloadBlock, err := reply.PayloadBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ns NodeStatus
+			if err := json.Unmarshal(payloadBlock.Value.(*bpv7.PayloadBlock).Data(), &ns); err != nil {
+				t.Fatal(err)
+			} else if ns.StoreSize != 1 {
+				t.Fatalf("Reply %d has not the cached status, but %v", i, ns)
+			}
+		}
+	}
+
+	status.receiver <- ShutdownMessage{}
+	if calls != 1 {
+		t.Fatalf("status function was called %d times, not once", calls)
+	}
+}
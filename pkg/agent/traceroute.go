@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// tracerouteProbeFlags request both forwarding and delivery status reports, including their time.
+const tracerouteProbeFlags = bpv7.MustNotFragmented | bpv7.StatusRequestForward | bpv7.StatusRequestDelivery |
+	bpv7.RequestStatusTime
+
+// NewTracerouteProbe creates a probe Bundle to trace its path from the source to the destination.
+//
+// Each node forwarding this probe, and finally its destination, sends a status report back to the source. Thus, the
+// source needs to be an endpoint able to receive administrative records, e.g., an ApplicationAgent's endpoint. Those
+// status reports should be passed to a Traceroute for correlation.
+func NewTracerouteProbe(source, destination bpv7.EndpointID, lifetime time.Duration) (bpv7.Bundle, error) {
+	return bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source(source).
+		Destination(destination).
+		BundleCtrlFlags(tracerouteProbeFlags).
+		CreationTimestampNow().
+		Lifetime(lifetime).
+		HopCountBlock(64).
+		PayloadBlock([]byte("traceroute")).
+		Build()
+}
+
+// TracerouteHop is a node on a traced path, identified by a status report for the probe.
+type TracerouteHop struct {
+	// Node is the status report's source, the reporting node.
+	Node bpv7.EndpointID
+
+	// Status is either bpv7.ForwardedBundle or bpv7.DeliveredBundle for the probe's destination.
+	Status bpv7.StatusInformationPos
+
+	// Time as reported by the node; bpv7.DtnTimeEpoch if unknown.
+	Time bpv7.DtnTime
+
+	// Latency between sending the probe and receiving this hop's status report.
+	Latency time.Duration
+}
+
+func (hop TracerouteHop) String() string {
+	return fmt.Sprintf("%v %v %v", hop.Node, hop.Status, hop.Latency)
+}
+
+// Traceroute correlates the status reports of a probe Bundle, created by NewTracerouteProbe, to its path.
+type Traceroute struct {
+	probe bpv7.BundleID
+	sent  time.Time
+
+	mutex sync.Mutex
+	hops  []TracerouteHop
+}
+
+// NewTraceroute for a probe Bundle, which is just about to be sent.
+func NewTraceroute(probe bpv7.Bundle) *Traceroute {
+	return &Traceroute{
+		probe: probe.ID(),
+		sent:  time.Now(),
+	}
+}
+
+// Inspect a received Bundle. If it is a forwarding or delivery status report for the probe Bundle, the resulting
+// TracerouteHop is recorded and returned. Otherwise, false is returned.
+func (tr *Traceroute) Inspect(b bpv7.Bundle) (hop TracerouteHop, ok bool) {
+	if !b.IsAdministrativeRecord() {
+		return
+	}
+
+	ar, arErr := b.AdministrativeRecord()
+	if arErr != nil {
+		return
+	}
+
+	sr, isSr := ar.(*bpv7.StatusReport)
+	if !isSr || sr.RefBundle != tr.probe {
+		return
+	}
+
+	for _, sip := range []bpv7.StatusInformationPos{bpv7.DeliveredBundle, bpv7.ForwardedBundle} {
+		if int(sip) >= len(sr.StatusInformation) || !sr.StatusInformation[sip].Asserted {
+			continue
+		}
+
+		hop = TracerouteHop{
+			Node:    b.PrimaryBlock.SourceNode,
+			Status:  sip,
+			Time:    sr.StatusInformation[sip].Time,
+			Latency: time.Since(tr.sent),
+		}
+		ok = true
+		break
+	}
+
+	if ok {
+		tr.mutex.Lock()
+		tr.hops = append(tr.hops, hop)
+		tr.mutex.Unlock()
+	}
+	return
+}
+
+// Hops of the traced path, ordered by their latency, while the destination is always last.
+func (tr *Traceroute) Hops() []TracerouteHop {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	hops := make([]TracerouteHop, len(tr.hops))
+	copy(hops, tr.hops)
+
+	sort.SliceStable(hops, func(i, j int) bool {
+		iDelivered, jDelivered := hops[i].Status == bpv7.DeliveredBundle, hops[j].Status == bpv7.DeliveredBundle
+		if iDelivered != jDelivered {
+			return jDelivered
+		}
+		return hops[i].Latency < hops[j].Latency
+	})
+	return hops
+}
+
+// Delivered checks if the probe's destination has reported its delivery.
+func (tr *Traceroute) Delivered() bool {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	for _, hop := range tr.hops {
+		if hop.Status == bpv7.DeliveredBundle {
+			return true
+		}
+	}
+	return false
+}
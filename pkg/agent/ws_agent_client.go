@@ -24,6 +24,10 @@ type webAgentClient struct {
 	receiver chan Message
 	sender   chan Message
 
+	// deliveryRequests are the IDs of sent Bundles with a requested delivery report, awaiting their confirmation.
+	deliveryRequests      map[string]struct{}
+	deliveryRequestsMutex sync.Mutex
+
 	shutdownOnce sync.Once
 }
 
@@ -33,6 +37,8 @@ func newWebAgentClient(conn *websocket.Conn) *webAgentClient {
 		endpoint: bpv7.EndpointID{},
 		receiver: make(chan Message),
 		sender:   make(chan Message),
+
+		deliveryRequests: make(map[string]struct{}),
 	}
 }
 
@@ -77,12 +83,47 @@ func (client *webAgentClient) handleReceiver() {
 				logger.WithField("syscall", msg.Request).Info("Sent syscall response to client")
 			}
 
+		case DeliveryConfirmationMessage:
+			if !client.confirmDeliveryRequest(msg.BundleID) {
+				logger.WithField("bundle", msg.BundleID).Debug("Ignoring delivery confirmation for an unknown bundle")
+			} else if err := client.writeMessage(newDeliveryConfirmationMessage(msg.BundleID)); err != nil {
+				logger.WithError(err).Warn("Sending delivery confirmation erred")
+				return
+			} else {
+				logger.WithField("bundle", msg.BundleID).Info("Sent delivery confirmation to client")
+			}
+
 		default:
 			logger.WithField("message", msg).Info("Received unknown / unsupported message")
 		}
 	}
 }
 
+// addDeliveryRequest remembers an outgoing Bundle if it requests a delivery report.
+func (client *webAgentClient) addDeliveryRequest(b bpv7.Bundle) {
+	if !b.PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestDelivery) {
+		return
+	}
+
+	client.deliveryRequestsMutex.Lock()
+	defer client.deliveryRequestsMutex.Unlock()
+
+	client.deliveryRequests[b.ID().String()] = struct{}{}
+}
+
+// confirmDeliveryRequest removes a remembered Bundle ID and returns true if it was known.
+func (client *webAgentClient) confirmDeliveryRequest(bid bpv7.BundleID) bool {
+	client.deliveryRequestsMutex.Lock()
+	defer client.deliveryRequestsMutex.Unlock()
+
+	if _, ok := client.deliveryRequests[bid.String()]; !ok {
+		return false
+	}
+
+	delete(client.deliveryRequests, bid.String())
+	return true
+}
+
 func (client *webAgentClient) handleConn() {
 	defer client.shutdown()
 
@@ -115,6 +156,7 @@ func (client *webAgentClient) handleConn() {
 
 			case *wamBundle:
 				logger.WithField("bundle", msg.b).Info("Received Bundle")
+				client.addDeliveryRequest(msg.b)
 				client.sender <- BundleMessage{msg.b}
 
 			case *wamSyscallRequest:
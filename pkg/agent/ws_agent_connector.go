@@ -13,6 +13,10 @@ import (
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
+// deliveryConfirmationBuffer is the amount of buffered delivery confirmations. Further confirmations are dropped until
+// they are read by WebSocketAgentConnector.ReadDeliveryConfirmation.
+const deliveryConfirmationBuffer = 64
+
 // WebSocketAgentConnector is the client side version of the WebSocketAgent.
 type WebSocketAgentConnector struct {
 	conn *websocket.Conn
@@ -20,8 +24,9 @@ type WebSocketAgentConnector struct {
 	msgOutChan chan webAgentMessage
 	msgOutErr  chan error
 
-	msgInBundleChan  chan bpv7.Bundle
-	msgInSyscallChan chan []byte
+	msgInBundleChan   chan bpv7.Bundle
+	msgInSyscallChan  chan []byte
+	msgInDeliveryChan chan bpv7.BundleID
 
 	closeSyn chan struct{}
 	closeAck chan struct{}
@@ -40,8 +45,9 @@ func NewWebSocketAgentConnector(apiUrl, endpointId string) (wac *WebSocketAgentC
 		msgOutChan: make(chan webAgentMessage),
 		msgOutErr:  make(chan error),
 
-		msgInBundleChan:  make(chan bpv7.Bundle),
-		msgInSyscallChan: make(chan []byte),
+		msgInBundleChan:   make(chan bpv7.Bundle),
+		msgInSyscallChan:  make(chan []byte),
+		msgInDeliveryChan: make(chan bpv7.BundleID, deliveryConfirmationBuffer),
 
 		closeSyn: make(chan struct{}),
 		closeAck: make(chan struct{}),
@@ -103,6 +109,7 @@ func (wac *WebSocketAgentConnector) registerEndpoint(endpointId string) error {
 func (wac *WebSocketAgentConnector) handleReader() {
 	defer close(wac.msgInBundleChan)
 	defer close(wac.msgInSyscallChan)
+	defer close(wac.msgInDeliveryChan)
 
 	for {
 		if msg, err := wac.readMessage(); err != nil {
@@ -115,6 +122,13 @@ func (wac *WebSocketAgentConnector) handleReader() {
 			case *wamSyscallResponse:
 				wac.msgInSyscallChan <- msg.response
 
+			case *wamDeliveryConfirmation:
+				// Do not block incoming Bundles for clients which are not interested in their confirmations.
+				select {
+				case wac.msgInDeliveryChan <- msg.bid:
+				default:
+				}
+
 			default:
 				// oof
 			}
@@ -171,6 +185,19 @@ func (wac *WebSocketAgentConnector) ReadBundle() (b bpv7.Bundle, err error) {
 	return
 }
 
+// ReadDeliveryConfirmation returns the next incoming delivery confirmation's Bundle ID. This method blocks.
+//
+// A delivery confirmation is sent for each written Bundle with the StatusRequestDelivery Bundle Control Flag, after
+// its delivery was reported back by a status report.
+func (wac *WebSocketAgentConnector) ReadDeliveryConfirmation() (bid bpv7.BundleID, err error) {
+	if bidIn, ok := <-wac.msgInDeliveryChan; ok {
+		bid = bidIn
+	} else {
+		err = fmt.Errorf("channel was closed")
+	}
+	return
+}
+
 // Syscall will be send to the server. An answer or an error after a timeout will be returned.
 func (wac *WebSocketAgentConnector) Syscall(request string, timeout time.Duration) (response []byte, err error) {
 	defer func() {
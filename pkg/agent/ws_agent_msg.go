@@ -30,6 +30,8 @@ const (
 	wamBundleCode          uint64 = 2
 	wamSyscallRequestCode  uint64 = 3
 	wamSyscallResponseCode uint64 = 4
+
+	wamDeliveryConfirmationCode uint64 = 5
 )
 
 var wamMapping = map[interface{}]reflect.Type{
@@ -38,6 +40,8 @@ var wamMapping = map[interface{}]reflect.Type{
 	wamBundleCode:          reflect.TypeOf(wamBundle{}),
 	wamSyscallRequestCode:  reflect.TypeOf(wamSyscallRequest{}),
 	wamSyscallResponseCode: reflect.TypeOf(wamSyscallResponse{}),
+
+	wamDeliveryConfirmationCode: reflect.TypeOf(wamDeliveryConfirmation{}),
 }
 
 // marshalCbor writes a webAgentMessage wrapped with its type code as CBOR.
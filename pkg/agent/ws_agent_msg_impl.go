@@ -163,3 +163,44 @@ func (wsr *wamSyscallResponse) UnmarshalCbor(r io.Reader) error {
 
 	return nil
 }
+
+// wamDeliveryConfirmation is a webAgentMessage sent from the server to a client, confirming the delivery of a Bundle
+// which was previously sent by this client with a requested delivery report.
+type wamDeliveryConfirmation struct {
+	bid bpv7.BundleID
+}
+
+// newDeliveryConfirmationMessage creates a new wamDeliveryConfirmation webAgentMessage.
+func newDeliveryConfirmationMessage(bid bpv7.BundleID) *wamDeliveryConfirmation {
+	return &wamDeliveryConfirmation{bid}
+}
+
+func (_ *wamDeliveryConfirmation) typeCode() uint64 {
+	return wamDeliveryConfirmationCode
+}
+
+func (wdc *wamDeliveryConfirmation) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(wdc.bid.Len(), w); err != nil {
+		return err
+	}
+
+	return cboring.Marshal(&wdc.bid, w)
+}
+
+func (wdc *wamDeliveryConfirmation) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	}
+
+	switch n {
+	case 2:
+		wdc.bid.IsFragment = false
+	case 4:
+		wdc.bid.IsFragment = true
+	default:
+		return fmt.Errorf("expected CBOR array of 2 or 4 elements, not %d", n)
+	}
+
+	return cboring.Unmarshal(&wdc.bid, r)
+}
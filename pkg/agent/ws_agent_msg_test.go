@@ -34,6 +34,14 @@ func TestWebsocketAgentMessageEnDecode(t *testing.T) {
 		newBundleMessage(b),
 		newSyscallRequestMessage("test"),
 		newSyscallResponseMessage("foobar", []byte{0x23, 0x42, 0xAC, 0xAB}),
+		newDeliveryConfirmationMessage(b.ID()),
+		newDeliveryConfirmationMessage(bpv7.BundleID{
+			SourceNode:      bpv7.MustNewEndpointID("dtn://src/"),
+			Timestamp:       bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, 23),
+			IsFragment:      true,
+			FragmentOffset:  42,
+			TotalDataLength: 1024,
+		}),
 	}
 
 	for _, msg := range msgs {
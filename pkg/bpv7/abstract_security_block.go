@@ -6,7 +6,6 @@
 package bpv7
 
 import (
-	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -56,7 +55,7 @@ func (idvtbs *IDValueTupleByteString) UnmarshalCbor(r io.Reader) error {
 		idvtbs.id = id
 	}
 
-	if result, err := cboring.ReadByteString(r); err != nil {
+	if result, err := readSecurityValue(r); err != nil {
 		return err
 	} else {
 		idvtbs.value = result
@@ -170,6 +169,8 @@ func (tsr *TargetSecurityResults) UnmarshalCbor(r io.Reader) error {
 
 	if resultCount, err := cboring.ReadArrayLength(r); err != nil {
 		return fmt.Errorf("SecurityBlock failed to unmarshal TargetSecurityResult : %v", err)
+	} else if err := checkLimit("security result count", resultCount, GetSecurityParsingLimits().MaxResultsPerTarget); err != nil {
+		return fmt.Errorf("SecurityBlock: TargetSecurityResults %v", err)
 	} else {
 
 		for i := uint64(0); i < resultCount; i++ {
@@ -190,7 +191,22 @@ const (
 	SecurityContextParametersPresentFlag = 0b01
 )
 
+// maxSecurityContextParameters is the highest amount of Security Context Parameters defined by a supported security
+// context, being the four parameters of BCB-IOP-AES-GCM: IV, AES variant, wrapped key, and AAD scope flags.
+const maxSecurityContextParameters = 4
+
+// SecurityBlock is implemented by Extension Blocks based on an AbstractSecurityBlock, e.g., BIBs and BCBs.
+type SecurityBlock interface {
+	ExtensionBlock
+
+	// SecurityTargets returns the block numbers of this security block's targets.
+	SecurityTargets() []uint64
+}
+
 // AbstractSecurityBlock implements the Abstract Security Block (ASB) data structure described in BPSEC 3.6.
+//
+// The SecuritySource might be dtn:none, or be left unset, which is treated as dtn:none. In this case, the Bundle's
+// source node is the security source, as resolved by BundleSecuritySource.
 type AbstractSecurityBlock struct {
 	SecurityTargets                      []uint64
 	SecurityContextID                    uint64
@@ -200,6 +216,23 @@ type AbstractSecurityBlock struct {
 	SecurityResults                      []TargetSecurityResults
 }
 
+// securitySource returns the SecuritySource, replacing an unset EndpointID by dtn:none.
+func (asb *AbstractSecurityBlock) securitySource() EndpointID {
+	if asb.SecuritySource.EndpointType == nil {
+		return DtnNone()
+	}
+	return asb.SecuritySource
+}
+
+// BundleSecuritySource returns the security source for this block within a Bundle. For a SecuritySource of dtn:none,
+// the Bundle's source node is returned.
+func (asb *AbstractSecurityBlock) BundleSecuritySource(b *Bundle) EndpointID {
+	if ss := asb.securitySource(); ss != DtnNone() {
+		return ss
+	}
+	return b.PrimaryBlock.SourceNode
+}
+
 // HasSecurityContextParametersPresentContextFlag interpreters the securityContextParametersPresentFlag for the presence of the
 // SecurityContextParametersPresentField as required by BPSec 3.6.
 func (asb *AbstractSecurityBlock) HasSecurityContextParametersPresentContextFlag() bool {
@@ -244,7 +277,8 @@ func (asb *AbstractSecurityBlock) MarshalCbor(w io.Writer) error {
 	}
 
 	// SecuritySource
-	if err := asb.SecuritySource.MarshalCbor(w); err != nil {
+	securitySource := asb.securitySource()
+	if err := securitySource.MarshalCbor(w); err != nil {
 		return err
 	}
 
@@ -292,6 +326,8 @@ func (asb *AbstractSecurityBlock) UnmarshalCbor(r io.Reader) error {
 	// SecurityTargets
 	if targetCount, err := cboring.ReadArrayLength(r); err != nil {
 		return err
+	} else if err := checkLimit("security target count", targetCount, GetSecurityParsingLimits().MaxTargets); err != nil {
+		return fmt.Errorf("SecurityBlock: %v", err)
 	} else {
 		for i := uint64(0); i < targetCount; i++ {
 			if st, err := cboring.ReadUInt(r); err != nil {
@@ -339,6 +375,8 @@ func (asb *AbstractSecurityBlock) UnmarshalCbor(r io.Reader) error {
 	arrayLength, err := cboring.ReadArrayLength(r)
 	if err != nil {
 		return fmt.Errorf("SecurityBlock failed to unmarshal SecurityResults : %v", err)
+	} else if err := checkLimit("security results count", arrayLength, GetSecurityParsingLimits().MaxTargets); err != nil {
+		return fmt.Errorf("SecurityBlock: %v", err)
 	}
 	for i := uint64(0); i < arrayLength; i++ {
 		tsr := TargetSecurityResults{}
@@ -417,71 +455,65 @@ func (asb *AbstractSecurityBlock) CheckValid() (errs error) {
 		}
 	}
 
-	// CheckValid EndpointID
-	if err := asb.SecuritySource.CheckValid(); err != nil {
+	// CheckValid EndpointID, an unset SecuritySource is treated as dtn:none
+	if err := asb.securitySource().CheckValid(); err != nil {
 		errs = multierror.Append(errs, err)
 	}
 
 	return errs
 }
 
-// UnmarshalCborSecurityParameters marshals the SecurityParameters to CBOR.
+// UnmarshalCborSecurityParameters unmarshals the SecurityParameters from CBOR.
 // This has to be done like this because the IDValueTuples are generic by standard, they can have Uint64, or bytes as the value
 // so the corresponding IDValueType has to determined by reading the majortype.
-// Since the original reader must not be read before actual unmarshalling, the reader is copied and the copy is used to read the Majortype.
+// Therefore, each IDValueTuple's header up to its value's major type is read, and replayed for the actual unmarshalling.
+// Values of any other major type result in an error. The returned Reader continues after the SecurityParameters.
 func (asb *AbstractSecurityBlock) UnmarshalCborSecurityParameters(r io.Reader) (rr io.Reader, err error) {
 	arrayLengthParameters, err := cboring.ReadArrayLength(r)
 	if err != nil {
 		return nil, err
-	} else if arrayLengthParameters > 3 {
-		return nil, fmt.Errorf("wrong array length: %d instead of max 3", arrayLengthParameters)
+	} else if arrayLengthParameters > maxSecurityContextParameters {
+		return nil, fmt.Errorf("wrong array length: %d instead of max %d", arrayLengthParameters, maxSecurityContextParameters)
 	}
 
-	bufferedReader := bufio.NewReader(r)
-
 	for i := uint64(0); i < arrayLengthParameters; i++ {
-		peekForID, _ := bufferedReader.Peek(bufferedReader.Size())
-		peekReader := bytes.NewReader(peekForID)
+		// The header consists of the array length, the ID, and the value's major type with its additional information,
+		// being at most 27 bytes. Only those bytes are read and replayed afterwards.
+		var header bytes.Buffer
+		headerReader := io.TeeReader(r, &header)
 
 		// Read the Array Length to advance the reader
-		_, err := cboring.ReadArrayLength(peekReader)
-		if err != nil {
-			return nil, fmt.Errorf("TargetSecurityResults UnmarshalCbor failed reading ArrayLength: %v", err)
+		if _, err := cboring.ReadArrayLength(headerReader); err != nil {
+			return nil, fmt.Errorf("SecurityContextParameters UnmarshalCbor failed reading ArrayLength: %v", err)
 		}
 		// Read ID to advance the reader
-		_, err = cboring.ReadUInt(peekReader)
-		if err != nil {
-			return nil, fmt.Errorf("TargetSecurityResults UnmarshalCbor failed reading ID: %v", err)
+		if _, err := cboring.ReadUInt(headerReader); err != nil {
+			return nil, fmt.Errorf("SecurityContextParameters UnmarshalCbor failed reading ID: %v", err)
 		}
 
 		// Read the MajorType
-		securityParameterValueMajorType, _, err := cboring.ReadMajors(peekReader)
+		securityParameterValueMajorType, _, err := cboring.ReadMajors(headerReader)
 		if err != nil {
-			return nil, fmt.Errorf("TargetSecurityResults UnmarshalCbor failed reading MajorType: %v", err)
+			return nil, fmt.Errorf("SecurityContextParameters UnmarshalCbor failed reading MajorType: %v", err)
 		}
 
 		// Set the IDValueType based on the MajorType
-		securityParameter := func() IDValueTuple {
-			var idValueTuple IDValueTuple
-			if securityParameterValueMajorType == cboring.ByteString {
-				idValueTuple = &IDValueTupleByteString{}
-			} else if securityParameterValueMajorType == cboring.UInt {
-				idValueTuple = &IDValueTupleUInt64{}
-			}
-			return idValueTuple
-		}()
+		var securityParameter IDValueTuple
+		switch securityParameterValueMajorType {
+		case cboring.ByteString:
+			securityParameter = &IDValueTupleByteString{}
+		case cboring.UInt:
+			securityParameter = &IDValueTupleUInt64{}
+		default:
+			return nil, fmt.Errorf("SecurityContextParameters value has unsupported major type 0x%x", securityParameterValueMajorType)
+		}
 
-		if err := cboring.Unmarshal(securityParameter, bufferedReader); err != nil {
-			return nil, fmt.Errorf("TargetSecurityResults UnmarshalCbor failed: %v", err)
+		if err := cboring.Unmarshal(securityParameter, io.MultiReader(&header, r)); err != nil {
+			return nil, fmt.Errorf("SecurityContextParameters UnmarshalCbor failed: %v", err)
 		} else {
 			asb.SecurityContextParameters = append(asb.SecurityContextParameters, securityParameter)
 		}
 	}
 
-	restOfBufferedReader, _ := io.ReadAll(bufferedReader)
-
-	rr = bytes.NewReader(restOfBufferedReader)
-
-	return rr, nil
-
+	return r, nil
 }
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dtn7/cboring"
+)
+
+// SecurityParsingLimits bound the security targets, results and parameters accepted while parsing a security block.
+// Otherwise, a malicious BIB or BCB might declare enormous arrays or byte strings, resulting in huge allocations.
+//
+// A zero value disables the respective limit.
+type SecurityParsingLimits struct {
+	// MaxTargets is the maximum amount of security targets and thus of target security result sets.
+	MaxTargets uint64
+
+	// MaxResultsPerTarget is the maximum amount of security results for each security target.
+	MaxResultsPerTarget uint64
+
+	// MaxValueSize is the maximum length in bytes of a security result's or security parameter's value.
+	MaxValueSize uint64
+}
+
+// DefaultSecurityParsingLimits are generous enough for all supported security contexts.
+var DefaultSecurityParsingLimits = SecurityParsingLimits{
+	MaxTargets:          256,
+	MaxResultsPerTarget: 16,
+	MaxValueSize:        64 * 1024,
+}
+
+var (
+	securityParsingLimits      = DefaultSecurityParsingLimits
+	securityParsingLimitsMutex sync.RWMutex
+)
+
+// SetSecurityParsingLimits configures the limits applied while parsing security blocks.
+func SetSecurityParsingLimits(limits SecurityParsingLimits) {
+	securityParsingLimitsMutex.Lock()
+	defer securityParsingLimitsMutex.Unlock()
+
+	securityParsingLimits = limits
+}
+
+// GetSecurityParsingLimits returns the limits currently applied while parsing security blocks.
+func GetSecurityParsingLimits() SecurityParsingLimits {
+	securityParsingLimitsMutex.RLock()
+	defer securityParsingLimitsMutex.RUnlock()
+
+	return securityParsingLimits
+}
+
+// checkLimit errors if a declared amount exceeds a non-zero limit.
+func checkLimit(name string, n, limit uint64) error {
+	if limit > 0 && n > limit {
+		return fmt.Errorf("%s of %d exceeds the limit of %d", name, n, limit)
+	}
+	return nil
+}
+
+// readSecurityValue reads a CBOR byte string, whose length is checked against the MaxValueSize before allocating it.
+func readSecurityValue(r io.Reader) ([]byte, error) {
+	n, err := cboring.ReadByteStringLen(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkLimit("security value size", n, GetSecurityParsingLimits().MaxValueSize); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -7,7 +7,9 @@ package bpv7
 
 import (
 	"bytes"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dtn7/cboring"
@@ -508,3 +510,207 @@ func TestAbstractSecurityBlockCbor(t *testing.T) {
 		}
 	}
 }
+
+func TestSecurityParsingLimits(t *testing.T) {
+	const huge = uint64(1) << 40
+
+	// declare writes CBOR headers, followed by no actual data, as a malicious block would do.
+	declare := func(writes ...func(w io.Writer) error) []byte {
+		buff := new(bytes.Buffer)
+		for _, write := range writes {
+			if err := write(buff); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return buff.Bytes()
+	}
+	arrayLen := func(n uint64) func(w io.Writer) error {
+		return func(w io.Writer) error { return cboring.WriteArrayLength(n, w) }
+	}
+	uintVal := func(n uint64) func(w io.Writer) error {
+		return func(w io.Writer) error { return cboring.WriteUInt(n, w) }
+	}
+	byteStringLen := func(n uint64) func(w io.Writer) error {
+		return func(w io.Writer) error { return cboring.WriteByteStringLen(n, w) }
+	}
+	endpoint := func(w io.Writer) error { return cboring.Marshal(&EndpointID{DtnEndpoint{IsDtnNone: true}}, w) }
+
+	tests := []struct {
+		name  string
+		value cboring.CborMarshaler
+		data  []byte
+	}{
+		{"result count", &TargetSecurityResults{}, declare(arrayLen(2), uintVal(1), arrayLen(huge))},
+		{"result value size", &IDValueTupleByteString{}, declare(arrayLen(2), uintVal(1), byteStringLen(huge))},
+		{"target count", &AbstractSecurityBlock{}, declare(arrayLen(5), arrayLen(huge))},
+		{"target results count", &AbstractSecurityBlock{},
+			declare(arrayLen(5), arrayLen(1), uintVal(1), uintVal(SecConIdentBIBIOPHMACSHA), uintVal(0), endpoint, arrayLen(huge))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := cboring.Unmarshal(test.value, bytes.NewBuffer(test.data))
+			if err == nil {
+				t.Fatal("excessive declaration was accepted")
+			} else if !strings.Contains(err.Error(), "exceeds the limit") {
+				t.Fatalf("expected a limit error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestSecurityParsingLimitsConfigurable(t *testing.T) {
+	defer SetSecurityParsingLimits(GetSecurityParsingLimits())
+
+	tsr1 := TargetSecurityResults{
+		securityTarget: 1,
+		results: []IDValueTuple{
+			&IDValueTupleByteString{id: 1, value: []byte{0x23}},
+			&IDValueTupleByteString{id: 2, value: []byte{0x42}},
+		},
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&tsr1, buff); err != nil {
+		t.Fatal(err)
+	}
+	data := buff.Bytes()
+
+	if err := cboring.Unmarshal(&TargetSecurityResults{}, bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("default limits rejected results: %v", err)
+	}
+
+	SetSecurityParsingLimits(SecurityParsingLimits{MaxResultsPerTarget: 1})
+	if err := cboring.Unmarshal(&TargetSecurityResults{}, bytes.NewBuffer(data)); err == nil {
+		t.Fatal("lowered limit accepted two results")
+	}
+
+	SetSecurityParsingLimits(SecurityParsingLimits{})
+	if err := cboring.Unmarshal(&TargetSecurityResults{}, bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("disabled limits rejected results: %v", err)
+	}
+}
+
+func TestUnmarshalCborSecurityParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		valid  bool
+		params []IDValueTuple
+	}{
+		{"empty", []byte{0x80}, true, nil},
+		{"byte string", []byte{0x81, 0x82, 0x01, 0x42, 0x23, 0x42}, true,
+			[]IDValueTuple{&IDValueTupleByteString{id: 1, value: []byte{0x23, 0x42}}}},
+		{"uint", []byte{0x81, 0x82, 0x02, 0x18, 0x2a}, true,
+			[]IDValueTuple{&IDValueTupleUInt64{id: 2, value: 42}}},
+		{"mixed", []byte{0x82, 0x82, 0x01, 0x41, 0x23, 0x82, 0x02, 0x00}, true,
+			[]IDValueTuple{&IDValueTupleByteString{id: 1, value: []byte{0x23}}, &IDValueTupleUInt64{id: 2, value: 0}}},
+
+		{"empty stream", []byte{}, false, nil},
+		{"too many parameters", []byte{0x85}, false, nil},
+		{"missing parameter", []byte{0x81}, false, nil},
+		{"no tuple", []byte{0x81, 0x01}, false, nil},
+		{"wrong tuple length", []byte{0x81, 0x83, 0x01, 0x00, 0x00}, false, nil},
+		{"missing id", []byte{0x81, 0x82}, false, nil},
+		{"non-uint id", []byte{0x81, 0x82, 0x41, 0x00, 0x00}, false, nil},
+		{"truncated id", []byte{0x81, 0x82, 0x1b, 0x00, 0x00}, false, nil},
+		{"missing value", []byte{0x81, 0x82, 0x01}, false, nil},
+		{"truncated uint value", []byte{0x81, 0x82, 0x01, 0x19, 0x01}, false, nil},
+		{"truncated byte string value", []byte{0x81, 0x82, 0x01, 0x45, 0x23}, false, nil},
+		{"array value", []byte{0x81, 0x82, 0x01, 0x81, 0x00}, false, nil},
+		{"map value", []byte{0x81, 0x82, 0x01, 0xa1, 0x00, 0x00}, false, nil},
+		{"text string value", []byte{0x81, 0x82, 0x01, 0x61, 0x41}, false, nil},
+		{"negative integer value", []byte{0x81, 0x82, 0x01, 0x20}, false, nil},
+		{"tagged value", []byte{0x81, 0x82, 0x01, 0xc2, 0x41, 0x00}, false, nil},
+		{"simple value", []byte{0x81, 0x82, 0x01, 0xf5}, false, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Trailing data of valid parameters must remain in the returned Reader.
+			trailer := []byte{0xff, 0x00}
+			data := test.data
+			if test.valid {
+				data = append(append([]byte{}, test.data...), trailer...)
+			}
+
+			var asb AbstractSecurityBlock
+			r, err := asb.UnmarshalCborSecurityParameters(bytes.NewBuffer(data))
+
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("malformed parameters were accepted: %v", asb.SecurityContextParameters)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(asb.SecurityContextParameters, test.params) {
+				t.Fatalf("parameters differ:\n%v\n%v", asb.SecurityContextParameters, test.params)
+			}
+
+			if rest, err := io.ReadAll(r); err != nil {
+				t.Fatal(err)
+			} else if !bytes.Equal(rest, trailer) {
+				t.Fatalf("remaining data %x differs from %x", rest, trailer)
+			}
+		})
+	}
+}
+
+func TestAbstractSecurityBlockDtnNoneSource(t *testing.T) {
+	b := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		mustBuild()
+
+	tests := []struct {
+		name           string
+		securitySource EndpointID
+		bundleSource   EndpointID
+	}{
+		{"dtn:none", DtnNone(), b.PrimaryBlock.SourceNode},
+		{"unset", EndpointID{}, b.PrimaryBlock.SourceNode},
+		{"explicit", MustNewEndpointID("dtn://security-source/"), MustNewEndpointID("dtn://security-source/")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asb1 := AbstractSecurityBlock{
+				SecurityTargets:   []uint64{0},
+				SecurityContextID: SecConIdentBIBIOPHMACSHA,
+				SecuritySource:    test.securitySource,
+				SecurityResults: []TargetSecurityResults{{
+					securityTarget: 0,
+					results:        []IDValueTuple{&IDValueTupleByteString{id: 1, value: []byte{0x23, 0x42}}},
+				}},
+			}
+			if err := asb1.CheckValid(); err != nil {
+				t.Fatal(err)
+			}
+
+			buff := new(bytes.Buffer)
+			if err := cboring.Marshal(&asb1, buff); err != nil {
+				t.Fatal(err)
+			}
+
+			asb2 := AbstractSecurityBlock{}
+			if err := cboring.Unmarshal(&asb2, buff); err != nil {
+				t.Fatal(err)
+			} else if err := asb2.CheckValid(); err != nil {
+				t.Fatal(err)
+			}
+
+			if ss1, ss2 := asb1.securitySource(), asb2.SecuritySource; ss1 != ss2 {
+				t.Fatalf("security source differs: %v %v", ss1, ss2)
+			}
+			if bss := asb2.BundleSecuritySource(&b); bss != test.bundleSource {
+				t.Fatalf("expected bundle security source %v, got %v", test.bundleSource, bss)
+			}
+		})
+	}
+}
@@ -18,6 +18,17 @@ import (
 const (
 	// AdminRecordTypeStatusReport is the administrative record type code for a status report.
 	AdminRecordTypeStatusReport uint64 = 1
+
+	// AdminRecordTypeBIBEProtocolDataUnit is the administrative record type code for an encapsulated bundle.
+	AdminRecordTypeBIBEProtocolDataUnit uint64 = 3
+
+	// AdminRecordTypeDeliveryAcknowledgement is the administrative record type code for a delivery acknowledgement.
+	// This type is not registered by IANA and only understood by dtn7 nodes.
+	AdminRecordTypeDeliveryAcknowledgement uint64 = 192
+
+	// AdminRecordTypeDetachedSignature is the administrative record type code for a detached signature.
+	// This type is not registered by IANA and only understood by dtn7 nodes.
+	AdminRecordTypeDetachedSignature uint64 = 193
 )
 
 // AdministrativeRecord describes an administrative record, e.g., a status report.
@@ -125,6 +136,9 @@ func GetAdministrativeRecordManager() *AdministrativeRecordManager {
 		administrativeRecordManager = NewAdministrativeRecordManager()
 
 		_ = administrativeRecordManager.Register(&StatusReport{})
+		_ = administrativeRecordManager.Register(&BIBEProtocolDataUnit{})
+		_ = administrativeRecordManager.Register(&DeliveryAcknowledgement{})
+		_ = administrativeRecordManager.Register(&DetachedSignature{})
 	}
 
 	return administrativeRecordManager
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// BIBEProtocolDataUnit is the administrative record of the Bundle-in-Bundle Encapsulation (BIBE), carrying an
+// encapsulated Bundle as the payload of an outer Bundle, as described in draft-ietf-dtn-bibect.
+//
+// Custody transfer is not supported. Thus, both the TransmissionID and the RetransmissionTime are usually zero.
+type BIBEProtocolDataUnit struct {
+	TransmissionID     uint64
+	RetransmissionTime DtnTime
+	EncapsulatedBundle []byte
+}
+
+// NewBIBEProtocolDataUnit encapsulates a Bundle without custody transfer.
+func NewBIBEProtocolDataUnit(b Bundle) (*BIBEProtocolDataUnit, error) {
+	var buff bytes.Buffer
+	if err := b.MarshalCbor(&buff); err != nil {
+		return nil, fmt.Errorf("encapsulating bundle failed: %v", err)
+	}
+
+	return &BIBEProtocolDataUnit{EncapsulatedBundle: buff.Bytes()}, nil
+}
+
+// Bundle decapsulates the inner Bundle.
+func (bpdu *BIBEProtocolDataUnit) Bundle() (Bundle, error) {
+	return ParseBundle(bytes.NewBuffer(bpdu.EncapsulatedBundle))
+}
+
+// RecordTypeCode returns this AdministrativeRecord's type code.
+func (bpdu *BIBEProtocolDataUnit) RecordTypeCode() uint64 {
+	return AdminRecordTypeBIBEProtocolDataUnit
+}
+
+// MarshalCbor writes the CBOR representation of a BIBEProtocolDataUnit.
+func (bpdu *BIBEProtocolDataUnit) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(3, w); err != nil {
+		return err
+	}
+
+	for _, n := range []uint64{bpdu.TransmissionID, uint64(bpdu.RetransmissionTime)} {
+		if err := cboring.WriteUInt(n, w); err != nil {
+			return err
+		}
+	}
+
+	return cboring.WriteByteString(bpdu.EncapsulatedBundle, w)
+}
+
+// UnmarshalCbor reads a CBOR representation of a BIBEProtocolDataUnit.
+func (bpdu *BIBEProtocolDataUnit) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 3 {
+		return fmt.Errorf("BIBEProtocolDataUnit: expected array of length 3, got %d", n)
+	}
+
+	if n, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		bpdu.TransmissionID = n
+	}
+
+	if n, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		bpdu.RetransmissionTime = DtnTime(n)
+	}
+
+	if data, err := cboring.ReadByteString(r); err != nil {
+		return err
+	} else {
+		bpdu.EncapsulatedBundle = data
+	}
+
+	return nil
+}
+
+func (bpdu BIBEProtocolDataUnit) String() string {
+	return fmt.Sprintf("BIBEProtocolDataUnit(%d, %v, %d bytes)",
+		bpdu.TransmissionID, bpdu.RetransmissionTime, len(bpdu.EncapsulatedBundle))
+}
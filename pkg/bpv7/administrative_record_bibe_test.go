@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBIBEProtocolDataUnitEncapsulation(t *testing.T) {
+	inner := Builder().
+		CRC(CRC32).
+		Source("dtn://alice/app").
+		Destination("dtn://bob/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello through the tunnel")).
+		mustBuild()
+
+	bpdu, err := NewBIBEProtocolDataUnit(inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outer, err := Builder().
+		CRC(CRC32).
+		Source("dtn://entry/bibe").
+		Destination("dtn://exit/bibe").
+		CreationTimestampNow().
+		Lifetime("10m").
+		AdministrativeRecord(bpdu).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outerBuff bytes.Buffer
+	if err := outer.MarshalCbor(&outerBuff); err != nil {
+		t.Fatal(err)
+	}
+	received, err := ParseBundle(&outerBuff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ar, err := received.AdministrativeRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	receivedBpdu, ok := ar.(*BIBEProtocolDataUnit)
+	if !ok {
+		t.Fatalf("administrative record is %T, not a BIBEProtocolDataUnit", ar)
+	}
+
+	decapsulated, err := receivedBpdu.Bundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var innerBuff, decapsulatedBuff bytes.Buffer
+	if err := inner.MarshalCbor(&innerBuff); err != nil {
+		t.Fatal(err)
+	} else if err := decapsulated.MarshalCbor(&decapsulatedBuff); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(innerBuff.Bytes(), decapsulatedBuff.Bytes()) {
+		t.Fatalf("decapsulated bundle differs:\n%x\n%x", innerBuff.Bytes(), decapsulatedBuff.Bytes())
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// DeliveryAcknowledgement is an administrative record announcing that the referenced Bundle was delivered to its
+// destination. Routing algorithms, e.g., Spray and Wait, might use it to drop remaining copies of this Bundle.
+type DeliveryAcknowledgement struct {
+	RefBundle BundleID
+}
+
+// NewDeliveryAcknowledgement for the given Bundle's ID.
+func NewDeliveryAcknowledgement(bid BundleID) *DeliveryAcknowledgement {
+	return &DeliveryAcknowledgement{RefBundle: bid}
+}
+
+// RecordTypeCode returns this AdministrativeRecord's type code.
+func (da *DeliveryAcknowledgement) RecordTypeCode() uint64 {
+	return AdminRecordTypeDeliveryAcknowledgement
+}
+
+// MarshalCbor writes the CBOR representation of a DeliveryAcknowledgement.
+func (da *DeliveryAcknowledgement) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(da.RefBundle.Len(), w); err != nil {
+		return err
+	}
+
+	return da.RefBundle.MarshalCbor(w)
+}
+
+// UnmarshalCbor reads a CBOR representation of a DeliveryAcknowledgement.
+func (da *DeliveryAcknowledgement) UnmarshalCbor(r io.Reader) error {
+	var bid BundleID
+
+	if fields, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if fields == 4 {
+		bid.IsFragment = true
+	} else if fields != 2 {
+		return fmt.Errorf("DeliveryAcknowledgement: expected array of length 2 or 4, got %d", fields)
+	}
+
+	if err := bid.UnmarshalCbor(r); err != nil {
+		return fmt.Errorf("DeliveryAcknowledgement: %v", err)
+	}
+
+	da.RefBundle = bid
+	return nil
+}
+
+func (da DeliveryAcknowledgement) String() string {
+	return fmt.Sprintf("DeliveryAcknowledgement(%v)", da.RefBundle)
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDeliveryAcknowledgementCbor(t *testing.T) {
+	tests := []struct {
+		name string
+		bid  BundleID
+	}{
+		{"plain", BundleID{
+			SourceNode: MustNewEndpointID("dtn://alice/"),
+			Timestamp:  NewCreationTimestamp(DtnTimeEpoch, 23),
+		}},
+		{"fragment", BundleID{
+			SourceNode:      MustNewEndpointID("ipn:23.42"),
+			Timestamp:       NewCreationTimestamp(DtnTimeNow(), 0),
+			IsFragment:      true,
+			FragmentOffset:  100,
+			TotalDataLength: 1000,
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			da := NewDeliveryAcknowledgement(test.bid)
+
+			var buff bytes.Buffer
+			if err := GetAdministrativeRecordManager().WriteAdministrativeRecord(da, &buff); err != nil {
+				t.Fatal(err)
+			}
+
+			ar, err := GetAdministrativeRecordManager().ReadAdministrativeRecord(&buff)
+			if err != nil {
+				t.Fatal(err)
+			}
+			received, ok := ar.(*DeliveryAcknowledgement)
+			if !ok {
+				t.Fatalf("administrative record is %T, not a DeliveryAcknowledgement", ar)
+			}
+			if !reflect.DeepEqual(received.RefBundle, test.bid) {
+				t.Fatalf("BundleID differs: %v != %v", received.RefBundle, test.bid)
+			}
+		})
+	}
+}
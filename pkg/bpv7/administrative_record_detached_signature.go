@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+	"github.com/hashicorp/go-multierror"
+)
+
+// DetachedSignature is an administrative record, in which a node asserts the integrity of a previously sent Bundle
+// out-of-band. It carries an ed25519 signature over the referenced Bundle's ID and the SHA-256 hash of its payload.
+//
+// Thus, networks which cannot carry inline integrity blocks might send the signature in a separate Bundle:
+//
+//	ds, err := bpv7.NewDetachedSignature(data, priv)
+//	sig, err := bpv7.Builder()./* ... */.AdministrativeRecord(ds).Build()
+//
+// The recipient correlates both Bundles by the referenced BundleID, see References, and verifies the data Bundle
+// against the public key known for its source node by Bundle.VerifyDetachedSignature.
+//
+// A DetachedSignature MUST be represented as a CBOR array comprising four elements: the referenced BundleID as an
+// array, the payload's hash, the public key, and the signature. The last three elements are CBOR byte strings. The
+// signature covers the CBOR array of the first two elements.
+//
+// This administrative record is NOT specified in RFC 9171.
+type DetachedSignature struct {
+	RefBundle   BundleID
+	PayloadHash []byte
+	PublicKey   []byte
+	Signature   []byte
+}
+
+// detachedSignatureData creates the CBOR representation of a BundleID and a payload hash, used as the message to be
+// signed.
+func detachedSignatureData(bid BundleID, payloadHash []byte) ([]byte, error) {
+	var buff bytes.Buffer
+	if err := cboring.WriteArrayLength(2, &buff); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteArrayLength(bid.Len(), &buff); err != nil {
+		return nil, err
+	}
+	if err := bid.MarshalCbor(&buff); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteByteString(payloadHash, &buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// detachedSignaturePayloadHash is the SHA-256 hash of a Bundle's payload.
+func detachedSignaturePayloadHash(b Bundle) ([]byte, error) {
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(pb.Value.(*PayloadBlock).Data())
+	return hash[:], nil
+}
+
+// NewDetachedSignature for a Bundle from its source node's private key. Fragments cannot be signed, as their payload
+// is only a part of the original Bundle's payload.
+func NewDetachedSignature(b Bundle, priv ed25519.PrivateKey) (*DetachedSignature, error) {
+	if l := len(priv); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("ed25519 private key's length is %d, not %d", l, ed25519.PrivateKeySize)
+	}
+
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		return nil, fmt.Errorf("fragmented Bundles cannot be signed")
+	}
+
+	payloadHash, err := detachedSignaturePayloadHash(b)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := detachedSignatureData(b.ID(), payloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DetachedSignature{
+		RefBundle:   b.ID(),
+		PayloadHash: payloadHash,
+		PublicKey:   priv.Public().(ed25519.PublicKey),
+		Signature:   ed25519.Sign(priv, data),
+	}, nil
+}
+
+// RecordTypeCode returns this AdministrativeRecord's type code.
+func (ds *DetachedSignature) RecordTypeCode() uint64 {
+	return AdminRecordTypeDetachedSignature
+}
+
+// References checks if this DetachedSignature refers to a Bundle, based on its BundleID.
+func (ds *DetachedSignature) References(b Bundle) bool {
+	return b.ID() == ds.RefBundle
+}
+
+// CheckValid checks the field lengths for errors.
+//
+// This DOES NOT verify the signature. Therefore please use the Verify method.
+func (ds *DetachedSignature) CheckValid() (err error) {
+	if l := len(ds.PayloadHash); l != sha256.Size {
+		err = multierror.Append(err,
+			fmt.Errorf("DetachedSignature: payload hash's length is %d, not required %d", l, sha256.Size))
+	}
+
+	if l := len(ds.PublicKey); l != ed25519.PublicKeySize {
+		err = multierror.Append(err,
+			fmt.Errorf("DetachedSignature: public key's length is %d, not required %d", l, ed25519.PublicKeySize))
+	}
+
+	if l := len(ds.Signature); l != ed25519.SignatureSize {
+		err = multierror.Append(err,
+			fmt.Errorf("DetachedSignature: signature's length is %d, not required %d", l, ed25519.SignatureSize))
+	}
+
+	return
+}
+
+// Verify the signature against the referenced Bundle and the public key known for its source node.
+func (ds *DetachedSignature) Verify(b Bundle, sourceKey ed25519.PublicKey) error {
+	if err := ds.CheckValid(); err != nil {
+		return err
+	}
+
+	if !ds.References(b) {
+		return fmt.Errorf("DetachedSignature: refers to %v, not to %v", ds.RefBundle, b.ID())
+	}
+
+	if !bytes.Equal(ds.PublicKey, sourceKey) {
+		return fmt.Errorf("DetachedSignature: public key is not the one of %v", b.PrimaryBlock.SourceNode)
+	}
+
+	if payloadHash, err := detachedSignaturePayloadHash(b); err != nil {
+		return fmt.Errorf("DetachedSignature: %v", err)
+	} else if !bytes.Equal(ds.PayloadHash, payloadHash) {
+		return fmt.Errorf("DetachedSignature: payload hash mismatches the Bundle's payload")
+	}
+
+	data, err := detachedSignatureData(ds.RefBundle, ds.PayloadHash)
+	if err != nil {
+		return fmt.Errorf("DetachedSignature: %v", err)
+	}
+
+	if !ed25519.Verify(ds.PublicKey, data, ds.Signature) {
+		return fmt.Errorf("DetachedSignature: signature mismatches the referenced Bundle")
+	}
+	return nil
+}
+
+// MarshalCbor writes the CBOR representation of a DetachedSignature.
+func (ds *DetachedSignature) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(4, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteArrayLength(ds.RefBundle.Len(), w); err != nil {
+		return err
+	}
+	if err := ds.RefBundle.MarshalCbor(w); err != nil {
+		return err
+	}
+
+	for _, field := range []*[]byte{&ds.PayloadHash, &ds.PublicKey, &ds.Signature} {
+		if err := cboring.WriteByteString(*field, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalCbor reads a CBOR representation of a DetachedSignature.
+func (ds *DetachedSignature) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 4 {
+		return fmt.Errorf("DetachedSignature: array has %d instead of 4 elements", n)
+	}
+
+	var bid BundleID
+	if fields, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if fields == 4 {
+		bid.IsFragment = true
+	} else if fields != 2 {
+		return fmt.Errorf("DetachedSignature: expected BundleID array of length 2 or 4, got %d", fields)
+	}
+
+	if err := bid.UnmarshalCbor(r); err != nil {
+		return fmt.Errorf("DetachedSignature: %v", err)
+	}
+	ds.RefBundle = bid
+
+	for _, field := range []*[]byte{&ds.PayloadHash, &ds.PublicKey, &ds.Signature} {
+		if data, err := cboring.ReadByteString(r); err != nil {
+			return err
+		} else {
+			*field = data
+		}
+	}
+
+	return nil
+}
+
+func (ds DetachedSignature) String() string {
+	return fmt.Sprintf("DetachedSignature(%v)", ds.RefBundle)
+}
+
+// VerifyDetachedSignature correlates a signature Bundle, carrying a DetachedSignature, with this data Bundle and
+// verifies the signature against the public key known for this Bundle's source node. An error is returned both for a
+// failed verification and a signature Bundle without a DetachedSignature referring to this Bundle.
+func (b Bundle) VerifyDetachedSignature(signature Bundle, sourceKey ed25519.PublicKey) error {
+	ar, err := signature.AdministrativeRecord()
+	if err != nil {
+		return err
+	}
+
+	ds, ok := ar.(*DetachedSignature)
+	if !ok {
+		return fmt.Errorf("administrative record is %T, not a DetachedSignature", ar)
+	}
+
+	return ds.Verify(b, sourceKey)
+}
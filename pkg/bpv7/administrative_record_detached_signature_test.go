@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+)
+
+// mustBuildBundle from a BundleBuilder or fails the test.
+func mustBuildBundle(t *testing.T, bldr *BundleBuilder) Bundle {
+	b, err := bldr.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDetachedSignatureCbor(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := mustBuildBundle(t, Builder().
+		Source("dtn://alice/").
+		Destination("dtn://bob/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello bob")))
+
+	ds, err := NewDetachedSignature(data, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buff bytes.Buffer
+	if err := GetAdministrativeRecordManager().WriteAdministrativeRecord(ds, &buff); err != nil {
+		t.Fatal(err)
+	}
+
+	ar, err := GetAdministrativeRecordManager().ReadAdministrativeRecord(&buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ar, ds) {
+		t.Fatalf("DetachedSignature differs: %v != %v", ar, ds)
+	}
+}
+
+func TestDetachedSignatureVerify(t *testing.T) {
+	alicePub, alicePriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, malloryPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := DtnTimeNow()
+
+	newData := func(payload string, seq uint64) Bundle {
+		b := mustBuildBundle(t, Builder().
+			Source("dtn://alice/").
+			Destination("dtn://bob/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte(payload)))
+		b.PrimaryBlock.CreationTimestamp = NewCreationTimestamp(now, seq)
+		return b
+	}
+
+	newSignature := func(ar AdministrativeRecord) Bundle {
+		return mustBuildBundle(t, Builder().
+			Source("dtn://alice/").
+			Destination("dtn://bob/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			AdministrativeRecord(ar))
+	}
+
+	data := newData("hello bob", 1)
+
+	genuine, err := NewDetachedSignature(data, alicePriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, err := NewDetachedSignature(data, malloryPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := *genuine
+	tampered.PayloadHash = make([]byte, len(genuine.PayloadHash))
+
+	tests := []struct {
+		name      string
+		data      Bundle
+		signature Bundle
+		valid     bool
+	}{
+		{"genuine", data, newSignature(genuine), true},
+		{"forged", data, newSignature(forged), false},
+		{"altered payload", newData("hello mallory", 1), newSignature(genuine), false},
+		{"other bundle", newData("hello bob", 2), newSignature(genuine), false},
+		{"tampered hash", data, newSignature(&tampered), false},
+		{"no detached signature", data, newSignature(NewDeliveryAcknowledgement(data.ID())), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The signature bundle is transmitted separately.
+			var buff bytes.Buffer
+			if err := test.signature.WriteBundle(&buff); err != nil {
+				t.Fatal(err)
+			}
+			signature, err := ParseBundle(&buff)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.data.VerifyDetachedSignature(signature, alicePub); (err == nil) != test.valid {
+				t.Fatalf("expected valid = %t, got %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestDetachedSignatureFragment(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := mustBuildBundle(t, Builder().
+		Source("dtn://alice/").
+		Destination("dtn://bob/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(make([]byte, 1024)))
+
+	frags, err := b.Fragment(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewDetachedSignature(frags[0], priv); err == nil {
+		t.Fatal("fragment was signed")
+	}
+}
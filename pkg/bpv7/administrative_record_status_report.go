@@ -152,6 +152,26 @@ const (
 	// BlockUnsupported is the "Block unsupported" bundle status report reason
 	// code.
 	BlockUnsupported StatusReportReason = 11
+
+	// MissingSecurityOperation is the "Missing security operation" bundle
+	// status report reason code, RFC 9172 9.1.
+	MissingSecurityOperation StatusReportReason = 12
+
+	// UnknownSecurityOperation is the "Unknown security operation" bundle
+	// status report reason code, RFC 9172 9.1.
+	UnknownSecurityOperation StatusReportReason = 13
+
+	// UnexpectedSecurityOperation is the "Unexpected security operation" bundle
+	// status report reason code, RFC 9172 9.1.
+	UnexpectedSecurityOperation StatusReportReason = 14
+
+	// FailedSecurityOperation is the "Failed security operation" bundle status
+	// report reason code, RFC 9172 9.1.
+	FailedSecurityOperation StatusReportReason = 15
+
+	// ConflictingSecurityOperation is the "Conflicting security operation"
+	// bundle status report reason code, RFC 9172 9.1.
+	ConflictingSecurityOperation StatusReportReason = 16
 )
 
 func (srr StatusReportReason) String() string {
@@ -192,6 +212,21 @@ func (srr StatusReportReason) String() string {
 	case BlockUnsupported:
 		return "Block unsupported"
 
+	case MissingSecurityOperation:
+		return "Missing security operation"
+
+	case UnknownSecurityOperation:
+		return "Unknown security operation"
+
+	case UnexpectedSecurityOperation:
+		return "Unexpected security operation"
+
+	case FailedSecurityOperation:
+		return "Failed security operation"
+
+	case ConflictingSecurityOperation:
+		return "Conflicting security operation"
+
 	default:
 		return "unknown"
 	}
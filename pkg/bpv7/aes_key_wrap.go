@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+)
+
+// aesKeyWrapIV is the default initial value of the AES Key Wrap algorithm, RFC 3394 2.2.3.1.
+var aesKeyWrapIV = []byte{0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6}
+
+// aesKeyWrap wraps a key by the AES Key Wrap algorithm for a key-encryption-key, as specified in RFC 3394 2.2.1.
+// The key's length must be a multiple of 64 bits, at least 128 bits.
+func aesKeyWrap(kek, key []byte) ([]byte, error) {
+	if len(key) < 16 || len(key)%8 != 0 {
+		return nil, fmt.Errorf("AES Key Wrap: key length %d is not a multiple of 8 and at least 16 bytes", len(key))
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(key) / 8
+	wrapped := make([]byte, 8+len(key))
+	copy(wrapped[:8], aesKeyWrapIV)
+	copy(wrapped[8:], key)
+
+	buf := make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 1; i <= n; i++ {
+			copy(buf[:8], wrapped[:8])
+			copy(buf[8:], wrapped[8*i:8*i+8])
+			block.Encrypt(buf, buf)
+
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(wrapped[:8], binary.BigEndian.Uint64(buf[:8])^t)
+			copy(wrapped[8*i:8*i+8], buf[8:])
+		}
+	}
+
+	return wrapped, nil
+}
+
+// aesKeyUnwrap unwraps a key, wrapped by aesKeyWrap for the same key-encryption-key, as specified in RFC 3394 2.2.2.
+// An error is returned if the integrity check fails, e.g., for a wrong key-encryption-key.
+func aesKeyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 24 || len(wrapped)%8 != 0 {
+		return nil, fmt.Errorf("AES Key Wrap: wrapped key length %d is not a multiple of 8 and at least 24 bytes", len(wrapped))
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(wrapped)/8 - 1
+	unwrapped := make([]byte, len(wrapped))
+	copy(unwrapped, wrapped)
+
+	buf := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(buf[:8], binary.BigEndian.Uint64(unwrapped[:8])^t)
+			copy(buf[8:], unwrapped[8*i:8*i+8])
+			block.Decrypt(buf, buf)
+
+			copy(unwrapped[:8], buf[:8])
+			copy(unwrapped[8*i:8*i+8], buf[8:])
+		}
+	}
+
+	if subtle.ConstantTimeCompare(unwrapped[:8], aesKeyWrapIV) != 1 {
+		return nil, fmt.Errorf("AES Key Wrap: integrity check failed")
+	}
+
+	return unwrapped[8:], nil
+}
@@ -6,6 +6,7 @@ package bpv7
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -39,19 +40,32 @@ func (bcf BlockControlFlags) CheckValid() error {
 	return nil
 }
 
-// Strings returns an array of all flags as a string representation.
-func (bcf BlockControlFlags) Strings() (fields []string) {
-	checks := []struct {
-		field BlockControlFlags
-		text  string
-	}{
-		{DeleteBundle, "DELETE_BUNDLE"},
-		{StatusReportBlock, "REQUEST_STATUS_REPORT"},
-		{RemoveBlock, "REMOVE_BLOCK"},
-		{ReplicateBlock, "REPLICATE_BLOCK"},
+// blockControlFlagNames maps each flag to its string representation and its Go constant's name.
+var blockControlFlagNames = []struct {
+	field BlockControlFlags
+	text  string
+	name  string
+}{
+	{DeleteBundle, "DELETE_BUNDLE", "DeleteBundle"},
+	{StatusReportBlock, "REQUEST_STATUS_REPORT", "StatusReportBlock"},
+	{RemoveBlock, "REMOVE_BLOCK", "RemoveBlock"},
+	{ReplicateBlock, "REPLICATE_BLOCK", "ReplicateBlock"},
+}
+
+// ParseBlockControlFlag returns the flag for either its string representation, e.g., "REMOVE_BLOCK", or its Go
+// constant's name, e.g., "RemoveBlock". An unknown name results in an error.
+func ParseBlockControlFlag(name string) (BlockControlFlags, error) {
+	for _, check := range blockControlFlagNames {
+		if check.text == name || check.name == name {
+			return check.field, nil
+		}
 	}
+	return 0, fmt.Errorf("unknown control flag %q", name)
+}
 
-	for _, check := range checks {
+// Strings returns an array of all flags as a string representation.
+func (bcf BlockControlFlags) Strings() (fields []string) {
+	for _, check := range blockControlFlagNames {
 		if bcf.Has(check.field) {
 			fields = append(fields, check.text)
 		}
@@ -65,6 +79,24 @@ func (bcf BlockControlFlags) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bcf.Strings())
 }
 
+// UnmarshalJSON reads a JSON array of control flags, as created by MarshalJSON.
+func (bcf *BlockControlFlags) UnmarshalJSON(data []byte) error {
+	var fields []string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*bcf = 0
+	for _, field := range fields {
+		flag, err := ParseBlockControlFlag(field)
+		if err != nil {
+			return err
+		}
+		*bcf |= flag
+	}
+	return nil
+}
+
 func (bcf BlockControlFlags) String() string {
 	return strings.Join(bcf.Strings(), ",")
 }
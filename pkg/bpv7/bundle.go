@@ -8,9 +8,11 @@ package bpv7
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/dtn7/cboring"
@@ -22,6 +24,9 @@ import (
 type Bundle struct {
 	PrimaryBlock    PrimaryBlock
 	CanonicalBlocks []CanonicalBlock
+
+	// corruptBlocks were removed due to an invalid CRC while unmarshalling, compare SetLenientBlockCRC.
+	corruptBlocks []CanonicalBlock
 }
 
 // NewBundle creates a new Bundle. The values and flags of the blocks will be
@@ -51,6 +56,21 @@ func ParseBundle(r io.Reader) (b Bundle, err error) {
 	return
 }
 
+// ParseBundleIgnoringLifetime reads a new CBOR encoded Bundle from a Reader, like ParseBundle, but also accepts a
+// Bundle whose lifetime is exceeded. Thus, a stored Bundle might still be loaded, e.g., to report its deletion.
+func ParseBundleIgnoringLifetime(r io.Reader) (b Bundle, err error) {
+	err = b.unmarshalCbor(r, checkIgnoringLifetime)
+	return
+}
+
+// ParseValidatedBundle reads a CBOR encoded Bundle from a Reader, which was already validated, e.g., on its reception,
+// and was not altered since. Thus, the expensive CheckValid is skipped, including the lifetime. Malformed CBOR or
+// mismatching CRC values still result in an error.
+func ParseValidatedBundle(r io.Reader) (b Bundle, err error) {
+	err = b.unmarshalCbor(r, checkNone)
+	return
+}
+
 // WriteBundle writes this Bundle CBOR encoded into a Writer.
 func (b *Bundle) WriteBundle(w io.Writer) error {
 	return cboring.Marshal(b, w)
@@ -104,53 +124,75 @@ func (b *Bundle) HasExtensionBlock(blockType uint64) bool {
 }
 
 // PayloadBlock returns this Bundle's payload block or an error, if it does
-// not exists.
+// not exists or if there are multiple payload blocks.
 func (b *Bundle) PayloadBlock() (*CanonicalBlock, error) {
-	return b.ExtensionBlock(ExtBlockTypePayloadBlock)
+	cbs, _ := b.ExtensionBlocks(ExtBlockTypePayloadBlock)
+	switch l := len(cbs); l {
+	case 0:
+		return nil, fmt.Errorf("Bundle contains no Payload Block")
+	case 1:
+		return cbs[0], nil
+	default:
+		return nil, fmt.Errorf("Bundle contains %d Payload Blocks, but exactly one is required", l)
+	}
 }
 
-// sortBlocks sorts the canonical blocks.
+// sortBlocks sorts the canonical blocks by their block numbers, while placing security blocks before their targets.
+// If PreserveBlockOrder is enabled, the current order is kept instead, only placing the payload block last.
 //
 // This method is called internally after block modification, e.g., in MustNewBundle or Bundle.AddExtensionBlock.
 func (b *Bundle) sortBlocks() {
-	sort.Sort(canonicalBlockNumberSort(b.CanonicalBlocks))
+	if PreserveBlockOrder() {
+		sortPayloadBlockLast(b.CanonicalBlocks)
+	} else {
+		sort.Sort(canonicalBlockNumberSort(b.CanonicalBlocks))
+	}
+	sortSecurityBlocks(b.CanonicalBlocks)
 }
 
 // AddExtensionBlock adds a new ExtensionBlock to this Bundle.
 //
-// The block number will be calculated and overwritten within this method.
+// The block number will be calculated and overwritten within this method, compare AddExtensionBlockNumbered.
 func (b *Bundle) AddExtensionBlock(block CanonicalBlock) error {
-	var blockNumbers []uint64
-	for i := 0; i < len(b.CanonicalBlocks); i++ {
-		blockNumbers = append(blockNumbers, b.CanonicalBlocks[i].BlockNumber)
+	_, err := b.AddExtensionBlockNumbered(block)
+	return err
+}
+
+// AddExtensionBlockNumbered adds a new ExtensionBlock to this Bundle, like AddExtensionBlock, and returns the assigned
+// block number, e.g., to be referenced by a new security block.
+//
+// A payload block is numbered 1, if available. Any other block gets the lowest block number, starting at 2, which is
+// neither used by another block nor referenced by a security block's SecurityTargets. Thus, the number of a removed
+// block is not reused while a security block still targets it.
+func (b *Bundle) AddExtensionBlockNumbered(block CanonicalBlock) (uint64, error) {
+	isPayload := block.Value.BlockTypeCode() == ExtBlockTypePayloadBlock
+
+	blockNumbers := make(map[uint64]bool, len(b.CanonicalBlocks))
+	for _, cb := range b.CanonicalBlocks {
+		blockNumbers[cb.BlockNumber] = true
+
+		// Security targets are not reserved for a payload block, as its security blocks might be added first.
+		if sb, ok := cb.Value.(SecurityBlock); ok && !isPayload {
+			for _, target := range sb.SecurityTargets() {
+				blockNumbers[target] = true
+			}
+		}
 	}
 
 	var blockNumber uint64 = 1
-	if block.Value.BlockTypeCode() != ExtBlockTypePayloadBlock {
+	if !isPayload {
 		blockNumber = 2
 	}
 
-	for {
-		flag := true
-		for _, no := range blockNumbers {
-			if blockNumber == no {
-				flag = false
-				break
-			}
-		}
-
-		if flag {
-			break
-		} else {
-			blockNumber += 1
-		}
+	for blockNumbers[blockNumber] {
+		blockNumber += 1
 	}
 
 	block.BlockNumber = blockNumber
 
 	b.CanonicalBlocks = append(b.CanonicalBlocks, block)
 	b.sortBlocks()
-	return nil
+	return blockNumber, nil
 }
 
 // GetExtensionBlockByBlockNumber  searches and returns a CanonicalBlock / ExtensionBlock with the given block number.
@@ -202,23 +244,96 @@ func (b Bundle) String() string {
 	return b.ID().String()
 }
 
+// futureTimestampTolerance is the configured tolerance for future creation timestamps in nanoseconds. A negative value
+// accepts all future timestamps.
+var futureTimestampTolerance int64 = -1
+
+// SetFutureTimestampTolerance configures how far a Bundle's creation timestamp might lie in the future, e.g., due to
+// clock skew between nodes. Bundles exceeding this tolerance are invalid and their lifetime is considered exceeded.
+//
+// A negative tolerance, which is the default, accepts all future creation timestamps.
+func SetFutureTimestampTolerance(tolerance time.Duration) {
+	atomic.StoreInt64(&futureTimestampTolerance, int64(tolerance))
+}
+
+// FutureTimestampTolerance returns the configured tolerance for future creation timestamps.
+func FutureTimestampTolerance() time.Duration {
+	return time.Duration(atomic.LoadInt64(&futureTimestampTolerance))
+}
+
+// futureTimestampExcess returns how far this Bundle's creation timestamp exceeds the tolerated future. False is
+// returned if the creation timestamp is within the tolerance.
+func (b Bundle) futureTimestampExcess(now time.Time) (time.Duration, bool) {
+	tolerance := FutureTimestampTolerance()
+	if tolerance < 0 || b.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		return 0, false
+	}
+
+	excess := b.PrimaryBlock.CreationTimestamp.DtnTime().Time().Sub(now.Add(tolerance))
+	return excess, excess > 0
+}
+
 // IsLifetimeExceeded of this Bundle by checking an optional Bundle Age Block and the PrimaryBlock's Lifetime.
+//
+// A creation timestamp lying further in the future than the FutureTimestampTolerance also exceeds the lifetime.
 func (b Bundle) IsLifetimeExceeded() bool {
 	if b.PrimaryBlock.CreationTimestamp.IsZeroTime() {
-		if bab, err := b.ExtensionBlock(ExtBlockTypeBundleAgeBlock); err != nil {
+		if bab, ok := b.bundleAgeBlock(); !ok {
 			return true
 		} else {
-			return bab.Value.(*BundleAgeBlock).Age() > b.PrimaryBlock.Lifetime
+			return bab.Age() > b.PrimaryBlock.Lifetime
 		}
 	}
 
-	maxTimestamp := b.PrimaryBlock.CreationTimestamp.DtnTime().Time().Add(
-		time.Duration(b.PrimaryBlock.Lifetime) * time.Millisecond)
-	return time.Now().After(maxTimestamp)
+	now := time.Now()
+	if _, exceeded := b.futureTimestampExcess(now); exceeded {
+		return true
+	}
+
+	return now.After(b.expiryTime(now))
+}
+
+// ExpiryTime returns the point in time when this Bundle's lifetime will be exceeded.
+//
+// For a zero creation timestamp, the expiry is derived from the Bundle Age Block relative to now. Without such a block,
+// the zero time.Time is returned, as this Bundle's lifetime is already exceeded, compare IsLifetimeExceeded.
+func (b Bundle) ExpiryTime() time.Time {
+	return b.expiryTime(time.Now())
+}
+
+// expiryTime is ExpiryTime, relative to the given time.
+func (b Bundle) expiryTime(now time.Time) time.Time {
+	lifetime := time.Duration(b.PrimaryBlock.Lifetime) * time.Millisecond
+
+	if b.PrimaryBlock.CreationTimestamp.IsZeroTime() {
+		if bab, ok := b.bundleAgeBlock(); !ok {
+			return time.Time{}
+		} else {
+			age := time.Duration(bab.Age()) * time.Millisecond
+			return now.Add(lifetime - age)
+		}
+	}
+
+	return b.PrimaryBlock.CreationTimestamp.DtnTime().Time().Add(lifetime)
+}
+
+// bundleAgeBlock returns this Bundle's BundleAgeBlock. An encrypted BundleAgeBlock is treated as a missing one, as its
+// age cannot be read.
+func (b Bundle) bundleAgeBlock() (*BundleAgeBlock, bool) {
+	if cb, err := b.ExtensionBlock(ExtBlockTypeBundleAgeBlock); err == nil {
+		bab, ok := cb.Value.(*BundleAgeBlock)
+		return bab, ok
+	}
+	return nil, false
 }
 
 // CheckValid returns an array of errors for incorrect data.
 func (b Bundle) CheckValid() (errs error) {
+	return b.checkValid(true)
+}
+
+// checkValid is CheckValid, optionally without checking the lifetime.
+func (b Bundle) checkValid(checkLifetime bool) (errs error) {
 	// Check blocks for errors
 	b.forEachBlock(func(blck block) {
 		if blckErr := blck.CheckValid(); blckErr != nil {
@@ -264,6 +379,11 @@ func (b Bundle) CheckValid() (errs error) {
 		}
 	}
 
+	// Check if there is exactly one PayloadBlock.
+	if _, pbErr := b.PayloadBlock(); pbErr != nil {
+		errs = multierror.Append(errs, fmt.Errorf("Bundle: %v", pbErr))
+	}
+
 	// Check if the PayloadBlock is the last block.
 	if last := b.CanonicalBlocks[len(b.CanonicalBlocks)-1].Value.BlockTypeCode(); last != ExtBlockTypePayloadBlock {
 		errs = multierror.Append(errs,
@@ -278,8 +398,12 @@ func (b Bundle) CheckValid() (errs error) {
 		}
 	}
 
-	// Check if the Bundle's lifetime is exceeded
-	if b.IsLifetimeExceeded() {
+	// Check if the Bundle's creation timestamp lies too far in the future or if its lifetime is exceeded
+	if excess, exceeded := b.futureTimestampExcess(time.Now()); exceeded {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"Bundle: Creation Timestamp exceeds the future timestamp tolerance of %v by %v",
+			FutureTimestampTolerance(), excess))
+	} else if checkLifetime && b.IsLifetimeExceeded() {
 		errs = multierror.Append(errs, fmt.Errorf("Bundle: Lifetime is exceeded"))
 	}
 
@@ -332,8 +456,27 @@ func (b *Bundle) MarshalCbor(w io.Writer) error {
 	return nil
 }
 
+// validityCheck determines how thoroughly unmarshalCbor checks a Bundle.
+type validityCheck int
+
+const (
+	// checkAll performs a whole CheckValid.
+	checkAll validityCheck = iota
+
+	// checkIgnoringLifetime performs CheckValid, but accepts an exceeded lifetime.
+	checkIgnoringLifetime
+
+	// checkNone skips CheckValid for an already validated Bundle.
+	checkNone
+)
+
 // UnmarshalCbor creates this Bundle based on a CBOR representation.
 func (b *Bundle) UnmarshalCbor(r io.Reader) error {
+	return b.unmarshalCbor(r, checkAll)
+}
+
+// unmarshalCbor is UnmarshalCbor, whose final validation is determined by the validityCheck.
+func (b *Bundle) unmarshalCbor(r io.Reader, check validityCheck) error {
 	if err := cboring.ReadExpect(cboring.IndefiniteArray, r); err != nil {
 		return err
 	}
@@ -342,18 +485,77 @@ func (b *Bundle) UnmarshalCbor(r io.Reader) error {
 		return fmt.Errorf("PrimaryBlock failed: %v", err)
 	}
 
+	blocksData := make(map[uint64][]byte)
 	for {
 		cb := CanonicalBlock{}
-		if err := cboring.Unmarshal(&cb, r); err == cboring.FlagBreakCode {
+		var crcErr *BlockCRCError
+		if blockData, err := cb.unmarshalCbor(r); err == cboring.FlagBreakCode {
 			break
+		} else if errors.As(err, &crcErr) {
+			if corruptErr := b.handleCorruptBlock(cb, crcErr); corruptErr != nil {
+				return fmt.Errorf("CanonicalBlock failed: %v", corruptErr)
+			}
 		} else if err != nil {
 			return fmt.Errorf("CanonicalBlock failed: %v", err)
 		} else {
 			b.CanonicalBlocks = append(b.CanonicalBlocks, cb)
+			blocksData[cb.BlockNumber] = blockData
 		}
 	}
 
-	return b.CheckValid()
+	if err := b.checkConfidentialityTargets(blocksData); err != nil {
+		return fmt.Errorf("CanonicalBlock failed: %v", err)
+	}
+
+	// Other implementations might place security blocks after their targets, which RFC 9172 does not prohibit.
+	sortSecurityBlocks(b.CanonicalBlocks)
+
+	if check == checkNone {
+		return nil
+	}
+	return b.checkValid(check == checkAll)
+}
+
+// checkConfidentialityTargets of a received Bundle, whose blocks' data is passed by their block numbers.
+//
+// Blocks whose data could not be unmarshalled are only accepted if encrypted by a BCB. Furthermore, an encrypted
+// block's data might be unmarshalled as its block type by chance. Thus, each encrypted block but the payload block
+// is reverted to a GenericExtensionBlock of its data.
+func (b *Bundle) checkConfidentialityTargets(blocksData map[uint64][]byte) error {
+	for i := range b.CanonicalBlocks {
+		cb := &b.CanonicalBlocks[i]
+		isTarget := b.isConfidentialityTarget(cb.BlockNumber)
+
+		if geb, ok := cb.Value.(*GenericExtensionBlock); ok {
+			if geb.unmarshalErr != nil && !isTarget {
+				return geb.unmarshalErr
+			}
+		} else if isTarget && cb.TypeCode() != ExtBlockTypePayloadBlock {
+			cb.Value = NewGenericExtensionBlock(blocksData[cb.BlockNumber], cb.TypeCode())
+		}
+	}
+	return nil
+}
+
+// isConfidentialityTarget checks if a block number is the security target of a BCB, being encrypted.
+func (b *Bundle) isConfidentialityTarget(blockNumber uint64) bool {
+	cbs, err := b.ExtensionBlocks(ExtBlockTypeBlockConfidentialityBlock)
+	if err != nil {
+		return false
+	}
+
+	for _, cb := range cbs {
+		bcb, ok := cb.Value.(*BCBIOPAESGCM)
+		if !ok {
+			continue
+		}
+		for _, target := range bcb.Asb.SecurityTargets {
+			if target == blockNumber {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // MarshalJSON creates a JSON object for this Bundle.
@@ -371,3 +573,22 @@ func (b Bundle) MarshalJSON() ([]byte, error) {
 		CanonicalBlocks: canonicals,
 	})
 }
+
+// UnmarshalJSON reads a JSON object of a Bundle, as created by MarshalJSON, and checks its validity. As the JSON
+// representation lacks CRC types, the CRCs are set as for a BundleBuilder without a CRC, i.e., only for the primary
+// block.
+func (b *Bundle) UnmarshalJSON(data []byte) error {
+	var bJson struct {
+		PrimaryBlock    PrimaryBlock     `json:"primaryBlock"`
+		CanonicalBlocks []CanonicalBlock `json:"canonicalBlocks"`
+	}
+	if err := json.Unmarshal(data, &bJson); err != nil {
+		return err
+	}
+
+	b.PrimaryBlock = bJson.PrimaryBlock
+	b.CanonicalBlocks = bJson.CanonicalBlocks
+	b.SetCRCType(CRCNo)
+
+	return b.CheckValid()
+}
@@ -6,8 +6,11 @@ package bpv7
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -29,6 +32,11 @@ type BundleBuilder struct {
 	canonicals       []CanonicalBlock
 	canonicalCounter uint64
 	crcType          CRCType
+
+	// Security blocks for the payload block, created after the bundle was assembled.
+	integrityKey        []byte
+	integrityShaVariant uint64
+	confidentialityKey  []byte
 }
 
 // Builder creates a new BundleBuilder.
@@ -48,10 +56,17 @@ func (bldr *BundleBuilder) Error() error {
 	return bldr.err
 }
 
-// CRC sets the bundle's CRC value.
+// CRC sets the bundle's CRC value. Only CRCNo, CRC16 and CRC32 are valid.
 func (bldr *BundleBuilder) CRC(crcType CRCType) *BundleBuilder {
-	if bldr.err == nil {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	switch crcType {
+	case CRCNo, CRC16, CRC32:
 		bldr.crcType = crcType
+	default:
+		bldr.err = fmt.Errorf("CRC received an unknown CRCType %d", crcType)
 	}
 
 	return bldr
@@ -75,9 +90,21 @@ func (bldr *BundleBuilder) Build() (bndl Bundle, err error) {
 		return
 	}
 
+	if err = bldr.fillPayloadDigest(); err != nil {
+		return
+	}
+
+	if err = bldr.fillIdentityAssertion(); err != nil {
+		return
+	}
+
 	bndl, err = NewBundle(bldr.primary, bldr.canonicals)
 	if err == nil {
 		bndl.SetCRCType(bldr.crcType)
+		err = bldr.securePayload(&bndl)
+	}
+	if err == nil {
+		err = checkSecurityBlockOrder(bndl.CanonicalBlocks)
 	}
 
 	return
@@ -296,6 +323,10 @@ func (bldr *BundleBuilder) Canonical(args ...interface{}) *BundleBuilder {
 		}
 
 		if data.BlockTypeCode() == ExtBlockTypePayloadBlock {
+			if bldr.hasPayloadBlock() {
+				bldr.err = fmt.Errorf("Canonical received a second Payload Block")
+				return bldr
+			}
 			blockNumber = 1
 		} else {
 			blockNumber = bldr.canonicalCounter
@@ -308,6 +339,10 @@ func (bldr *BundleBuilder) Canonical(args ...interface{}) *BundleBuilder {
 	case CanonicalBlock:
 		cb := args[0].(CanonicalBlock)
 		if cb.TypeCode() == ExtBlockTypePayloadBlock {
+			if bldr.hasPayloadBlock() {
+				bldr.err = fmt.Errorf("Canonical received a second Payload Block")
+				return bldr
+			}
 			blockNumber = 1
 		} else {
 			blockNumber = bldr.canonicalCounter
@@ -324,6 +359,16 @@ func (bldr *BundleBuilder) Canonical(args ...interface{}) *BundleBuilder {
 	return bldr
 }
 
+// hasPayloadBlock checks if a Payload Block was already added, as a Bundle must have exactly one.
+func (bldr *BundleBuilder) hasPayloadBlock() bool {
+	for _, cb := range bldr.canonicals {
+		if cb.TypeCode() == ExtBlockTypePayloadBlock {
+			return true
+		}
+	}
+	return false
+}
+
 // canonicalParseFlags is a helper function for the following specific Canonical / Extension Blocks to get the flags.
 func (bldr *BundleBuilder) canonicalParseFlags(args ...interface{}) (flags BlockControlFlags) {
 	if len(args) == 2 {
@@ -383,9 +428,20 @@ func (bldr *BundleBuilder) HopCountBlock(args ...interface{}) *BundleBuilder {
 //
 //	Data[, BlockControlFlags]
 //
-//	where Data is the payload's data and
+//	where Data is the payload's data, which might be empty or nil, and
 //	BlockControlFlags are _optional_ block processing control flags
 func (bldr *BundleBuilder) PayloadBlock(args ...interface{}) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	if len(args) == 0 {
+		bldr.err = fmt.Errorf("PayloadBlock requires the payload's data")
+		return bldr
+	} else if args[0] == nil {
+		args[0] = []byte{}
+	}
+
 	var buf bytes.Buffer
 	if err := binary.Write(&buf, binary.LittleEndian, args[0]); err != nil {
 		bldr.err = err
@@ -419,6 +475,220 @@ func (bldr *BundleBuilder) PreviousNodeBlock(args ...interface{}) *BundleBuilder
 	return bldr.Canonical(NewPreviousNodeBlock(eid), flags)
 }
 
+// PayloadDigestBlock adds a payload digest block to this bundle, whose digest will be calculated while building. The
+// parameters are:
+//
+//	Algorithm[, BlockControlFlags]
+//
+//	where Algorithm is the PayloadDigestAlgorithm and
+//	BlockControlFlags are _optional_ block processing control flags
+func (bldr *BundleBuilder) PayloadDigestBlock(args ...interface{}) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	if len(args) == 0 {
+		bldr.err = fmt.Errorf("PayloadDigestBlock requires the digest's algorithm")
+		return bldr
+	}
+
+	alg, chk := args[0].(PayloadDigestAlgorithm)
+	if !chk {
+		bldr.err = fmt.Errorf("PayloadDigestBlock received wrong parameter type")
+		return bldr
+	}
+
+	flags := bldr.canonicalParseFlags(args) | ReplicateBlock
+
+	return bldr.Canonical(&PayloadDigestBlock{Algorithm: alg}, flags)
+}
+
+// fillPayloadDigest calculates the digest of a PayloadDigestBlock, added by the PayloadDigestBlock method.
+func (bldr *BundleBuilder) fillPayloadDigest() error {
+	for _, cb := range bldr.canonicals {
+		pdb, ok := cb.Value.(*PayloadDigestBlock)
+		if !ok || pdb.Digest != nil {
+			continue
+		}
+
+		canonicals := make([]CanonicalBlock, len(bldr.canonicals))
+		copy(canonicals, bldr.canonicals)
+
+		digest, err := NewPayloadDigestBlock(MustNewBundle(bldr.primary, canonicals), pdb.Algorithm)
+		if err != nil {
+			return err
+		}
+		pdb.Digest = digest.Digest
+	}
+	return nil
+}
+
+// CostMetricBlock adds a cost metric block to this bundle, accumulating the costs of forwarding nodes. The parameters
+// are:
+//
+//	Metric[, BlockControlFlags]
+//
+//	where Metric is the CostMetricType and
+//	BlockControlFlags are _optional_ block processing control flags
+func (bldr *BundleBuilder) CostMetricBlock(args ...interface{}) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	if len(args) == 0 {
+		bldr.err = fmt.Errorf("CostMetricBlock requires the metric")
+		return bldr
+	}
+
+	metric, chk := args[0].(CostMetricType)
+	if !chk {
+		bldr.err = fmt.Errorf("CostMetricBlock received wrong parameter type")
+		return bldr
+	}
+
+	flags := bldr.canonicalParseFlags(args) | ReplicateBlock
+
+	return bldr.Canonical(NewCostMetricBlock(metric), flags)
+}
+
+// IdentityAssertionBlock adds an identity assertion block to this bundle, whose signature will be created while
+// building. The parameters are:
+//
+//	PrivateKey[, BlockControlFlags]
+//
+//	where PrivateKey is the source node's ed25519.PrivateKey and
+//	BlockControlFlags are _optional_ block processing control flags
+func (bldr *BundleBuilder) IdentityAssertionBlock(args ...interface{}) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	if len(args) == 0 {
+		bldr.err = fmt.Errorf("IdentityAssertionBlock requires the source node's private key")
+		return bldr
+	}
+
+	priv, chk := args[0].(ed25519.PrivateKey)
+	if !chk {
+		bldr.err = fmt.Errorf("IdentityAssertionBlock received wrong parameter type")
+		return bldr
+	} else if l := len(priv); l != ed25519.PrivateKeySize {
+		bldr.err = fmt.Errorf("IdentityAssertionBlock's private key length is %d, not %d", l, ed25519.PrivateKeySize)
+		return bldr
+	}
+
+	flags := bldr.canonicalParseFlags(args) | ReplicateBlock
+
+	return bldr.Canonical(&IdentityAssertionBlock{privateKey: priv}, flags)
+}
+
+// fillIdentityAssertion creates the signature of an IdentityAssertionBlock, added by the IdentityAssertionBlock method.
+func (bldr *BundleBuilder) fillIdentityAssertion() error {
+	for _, cb := range bldr.canonicals {
+		iab, ok := cb.Value.(*IdentityAssertionBlock)
+		if !ok || iab.privateKey == nil {
+			continue
+		}
+
+		signed, err := NewIdentityAssertionBlock(Bundle{PrimaryBlock: bldr.primary}, iab.privateKey)
+		if err != nil {
+			return err
+		}
+		iab.PublicKey, iab.Signature, iab.privateKey = signed.PublicKey, signed.Signature, nil
+	}
+	return nil
+}
+
+// IntegrityBlockForPayload adds a BIB-IOP-HMAC-SHA2 block, targeting the payload block. The HMAC is created while
+// building for the given key and SHA variant, e.g., HMAC256SHA256. The bundle's source node is the security source.
+//
+// The recipient verifies the payload by the BIBIOPHMACSHA2's VerifyTargets method for the same key.
+func (bldr *BundleBuilder) IntegrityBlockForPayload(key []byte, shaVariant uint64) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	switch {
+	case len(key) == 0:
+		bldr.err = fmt.Errorf("IntegrityBlockForPayload requires a key")
+	case shaVariant != HMAC256SHA256 && shaVariant != HMAC384SHA384 && shaVariant != HMAC512SHA512:
+		bldr.err = fmt.Errorf("IntegrityBlockForPayload received unknown SHA variant %d", shaVariant)
+	case bldr.confidentialityKey != nil:
+		bldr.err = fmt.Errorf("IntegrityBlockForPayload cannot be combined with ConfidentialityBlockForPayload")
+	default:
+		bldr.integrityKey, bldr.integrityShaVariant = key, shaVariant
+	}
+	return bldr
+}
+
+// ConfidentialityBlockForPayload adds a BCB-IOP-AES-GCM block, targeting the payload block. The payload is encrypted
+// while building for the given key, whose length of 16 or 32 bytes selects AES-128 or AES-256. The bundle's source
+// node is the security source.
+//
+// The recipient decrypts the payload by the BCBIOPAESGCM's DecryptTarget method for the same key. As a BIB and a BCB
+// would share the payload as their target, this cannot be combined with IntegrityBlockForPayload.
+func (bldr *BundleBuilder) ConfidentialityBlockForPayload(key []byte) *BundleBuilder {
+	if bldr.err != nil {
+		return bldr
+	}
+
+	switch {
+	case len(key) != 16 && len(key) != 32:
+		bldr.err = fmt.Errorf("ConfidentialityBlockForPayload's key length is %d, not 16 or 32", len(key))
+	case bldr.integrityKey != nil:
+		bldr.err = fmt.Errorf("ConfidentialityBlockForPayload cannot be combined with IntegrityBlockForPayload")
+	default:
+		bldr.confidentialityKey = key
+	}
+	return bldr
+}
+
+// securePayload adds and applies the security blocks, requested by IntegrityBlockForPayload or
+// ConfidentialityBlockForPayload, to the assembled bundle.
+func (bldr *BundleBuilder) securePayload(bndl *Bundle) error {
+	if bldr.integrityKey == nil && bldr.confidentialityKey == nil {
+		return nil
+	}
+
+	payload, err := bndl.PayloadBlock()
+	if err != nil {
+		return err
+	}
+
+	if bldr.integrityKey != nil {
+		bib := NewBIBIOPHMACSHA2(&bldr.integrityShaVariant, nil, nil, []uint64{payload.BlockNumber}, bndl.PrimaryBlock.SourceNode)
+		cb, err := bldr.addSecurityBlock(bndl, bib)
+		if err != nil {
+			return err
+		}
+		return bib.SignTargets(*bndl, cb.BlockNumber, bldr.integrityKey)
+	}
+
+	aesVariant := A256GCM
+	if len(bldr.confidentialityKey) == 16 {
+		aesVariant = A128GCM
+	}
+
+	bcb := NewBCBIOPAESGCM(&aesVariant, nil, nil, payload.BlockNumber, bndl.PrimaryBlock.SourceNode)
+	cb, err := bldr.addSecurityBlock(bndl, bcb)
+	if err != nil {
+		return err
+	}
+	return bcb.EncryptTarget(*bndl, cb.BlockNumber, bldr.confidentialityKey)
+}
+
+// addSecurityBlock to the assembled bundle and return its CanonicalBlock, including the assigned block number.
+func (bldr *BundleBuilder) addSecurityBlock(bndl *Bundle, value ExtensionBlock) (*CanonicalBlock, error) {
+	cb := NewCanonicalBlock(0, 0, value)
+	cb.SetCRCType(bldr.crcType)
+
+	blockNumber, err := bndl.AddExtensionBlockNumbered(cb)
+	if err != nil {
+		return nil, err
+	}
+	return bndl.GetExtensionBlockByBlockNumber(blockNumber)
+}
+
 // AdministrativeRecord configures an AdministrativeRecord as the Payload. Furthermore, the AdministrativeRecordPayload
 // BundleControlFlags is set.
 func (bldr *BundleBuilder) AdministrativeRecord(ar AdministrativeRecord) *BundleBuilder {
@@ -485,9 +755,29 @@ func (bldr *BundleBuilder) StatusReport(args ...interface{}) *BundleBuilder {
 	return bldr.AdministrativeRecord(NewStatusReport(bundle, statusItem, reason, t))
 }
 
+// BuildFromMapError is returned by BuildFromMap, identifying the map's offending method, i.e., its key.
+type BuildFromMapError struct {
+	// Method is the map's key whose method failed. It is empty if the final Build failed, e.g., due to a missing field.
+	Method string
+	// Reason for this method's failure.
+	Reason error
+}
+
+func (err *BuildFromMapError) Error() string {
+	if err.Method == "" {
+		return fmt.Sprintf("building bundle failed: %v", err.Reason)
+	}
+	return fmt.Sprintf("method %s failed: %v", err.Method, err.Reason)
+}
+
+func (err *BuildFromMapError) Unwrap() error {
+	return err.Reason
+}
+
 // BuildFromMap creates a Bundle from a map which "calls" the BundleBuilder's methods.
 //
 // This function does not use reflection or other dark magic. So it is safe to be called by unchecked data.
+// Each error is a *BuildFromMapError, identifying the offending method.
 //
 //	args := map[string]interface{}{
 //	  "destination":            "dtn://dst/",
@@ -495,10 +785,218 @@ func (bldr *BundleBuilder) StatusReport(args ...interface{}) *BundleBuilder {
 //	  "creation_timestamp_now": true,
 //	  "lifetime":               "24h",
 //	  "payload_block":          "hello world",
+//	  "bundle_ctrl_flags":      []string{"MustNotFragmented", "StatusRequestDelivery"},
+//	  "canonical": []map[string]interface{}{{
+//	    "block_type_code":     ExtBlockTypeHopCountBlock,
+//	    "block_control_flags": []string{"RemoveBlock"},
+//	    "data":                []byte{0x82, 0x18, 0x40, 0x00}, // CBOR encoded [64, 0]
+//	  }},
 //	}
 //	b, err := BuildFromMap(args)
 func BuildFromMap(m map[string]interface{}) (bndl Bundle, err error) {
-	bldr := Builder()
+	bldr, err := builderFromMap(m)
+	if err != nil {
+		return
+	}
+
+	if bndl, err = bldr.Build(); err != nil {
+		err = &BuildFromMapError{Reason: err}
+	}
+	return
+}
+
+// LifetimeFromMap previews the lifetime of a Bundle which BuildFromMap would create from the same map.
+//
+// The expiry is the point in time when the Bundle's lifetime will be exceeded, compare Bundle.ExpiryTime. In contrast
+// to BuildFromMap, an already expired Bundle is not an error but reported as expired. Thus, lifetimes can be checked
+// before building and sending a Bundle. Each error is a *BuildFromMapError, identifying the offending method.
+func LifetimeFromMap(m map[string]interface{}) (expiry time.Time, expired bool, err error) {
+	bldr, err := builderFromMap(m)
+	if err != nil {
+		return
+	}
+
+	b := MustNewBundle(bldr.primary, bldr.canonicals)
+	expiry = b.ExpiryTime()
+	expired = b.IsLifetimeExceeded()
+	return
+}
+
+// uintFromMap parses a non-negative integer of a BuildFromMap argument, e.g., a float64 as unmarshalled from JSON.
+func uintFromMap(arg interface{}) (uint64, error) {
+	switch arg := arg.(type) {
+	case int:
+		if arg < 0 {
+			return 0, fmt.Errorf("%d is negative", arg)
+		}
+		return uint64(arg), nil
+	case uint64:
+		return arg, nil
+	case float64:
+		// JSON numbers are unmarshalled as float64
+		if arg < 0 || arg != math.Trunc(arg) || arg >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v is no valid unsigned integer", arg)
+		}
+		return uint64(arg), nil
+	default:
+		return 0, fmt.Errorf("needs a number, not %T", arg)
+	}
+}
+
+// namesFromMap parses a list of names of a BuildFromMap argument. If the argument is no list, ok is false.
+func namesFromMap(arg interface{}) (names []string, ok bool, err error) {
+	switch arg := arg.(type) {
+	case []string:
+		return arg, true, nil
+	case []interface{}:
+		for _, name := range arg {
+			nameStr, isStr := name.(string)
+			if !isStr {
+				return nil, true, fmt.Errorf("flag name needs to be a string, not %T", name)
+			}
+			names = append(names, nameStr)
+		}
+		return names, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// bundleControlFlagsFromMap parses BuildFromMap's "bundle_ctrl_flags" argument. This is either a numeric value, e.g.,
+// 0x020000, or a list of flag names, as accepted by ParseBundleControlFlag. Unknown flags result in an error.
+func bundleControlFlagsFromMap(args interface{}) (bcf BundleControlFlags, err error) {
+	if bcfArgs, ok := args.(BundleControlFlags); ok {
+		bcf = bcfArgs
+	} else if names, ok, namesErr := namesFromMap(args); namesErr != nil {
+		return 0, namesErr
+	} else if ok {
+		for _, name := range names {
+			flag, flagErr := ParseBundleControlFlag(name)
+			if flagErr != nil {
+				return 0, flagErr
+			}
+			bcf |= flag
+		}
+		return bcf, nil
+	} else if value, valueErr := uintFromMap(args); valueErr != nil {
+		return 0, fmt.Errorf("control flags need to be a number or a list of names: %v", valueErr)
+	} else {
+		bcf = BundleControlFlags(value)
+	}
+
+	if unknown := bcf &^ bundleControlFlagsMask; unknown != 0 {
+		return 0, fmt.Errorf("control flags 0x%x contain unknown flags 0x%x", uint64(bcf), uint64(unknown))
+	}
+	return bcf, nil
+}
+
+// blockControlFlagsFromMap parses a block's "block_control_flags" for BuildFromMap's "canonical" argument. Like
+// bundleControlFlagsFromMap, this is either a numeric value or a list of flag names, as accepted by
+// ParseBlockControlFlag. In contrast, unknown bits of a numeric value are allowed.
+func blockControlFlagsFromMap(args interface{}) (bcf BlockControlFlags, err error) {
+	if bcfArgs, ok := args.(BlockControlFlags); ok {
+		return bcfArgs, nil
+	} else if names, ok, namesErr := namesFromMap(args); namesErr != nil {
+		return 0, namesErr
+	} else if ok {
+		for _, name := range names {
+			flag, flagErr := ParseBlockControlFlag(name)
+			if flagErr != nil {
+				return 0, flagErr
+			}
+			bcf |= flag
+		}
+		return bcf, nil
+	} else if value, valueErr := uintFromMap(args); valueErr != nil {
+		return 0, fmt.Errorf("block control flags need to be a number or a list of names: %v", valueErr)
+	} else {
+		return BlockControlFlags(value), nil
+	}
+}
+
+// canonicalFromMap parses a single block of BuildFromMap's "canonical" argument, being a map of its
+// "block_type_code", its optional "block_control_flags", and its "data". The data is the block-type-specific data in
+// its CBOR encoding, either as a byte slice or, e.g., from JSON, as a base64 encoded string. The data is unmarshalled
+// into the ExtensionBlock registered for this block type code; unknown block type codes result in an error.
+func canonicalFromMap(args map[string]interface{}) (eb ExtensionBlock, bcf BlockControlFlags, err error) {
+	for key := range args {
+		if key != "block_type_code" && key != "block_control_flags" && key != "data" {
+			return nil, 0, fmt.Errorf("unknown field %q", key)
+		}
+	}
+
+	typeCodeArg, ok := args["block_type_code"]
+	if !ok {
+		return nil, 0, fmt.Errorf("block_type_code is missing")
+	}
+	typeCode, err := uintFromMap(typeCodeArg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("block_type_code: %v", err)
+	} else if !GetExtensionBlockManager().IsKnown(typeCode) {
+		return nil, 0, fmt.Errorf("block type code %d is not registered", typeCode)
+	}
+
+	if bcfArg, ok := args["block_control_flags"]; ok {
+		if bcf, err = blockControlFlagsFromMap(bcfArg); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var data []byte
+	switch dataArg := args["data"].(type) {
+	case []byte:
+		data = dataArg
+	case string:
+		if data, err = base64.StdEncoding.DecodeString(dataArg); err != nil {
+			return nil, 0, fmt.Errorf("data is no valid base64: %v", err)
+		}
+	case nil:
+		return nil, 0, fmt.Errorf("data is missing")
+	default:
+		return nil, 0, fmt.Errorf("data needs to be bytes or a base64 string, not %T", dataArg)
+	}
+
+	if eb, err = readBlockData(typeCode, data); err != nil {
+		return nil, 0, fmt.Errorf("data of block type code %d: %v", typeCode, err)
+	}
+	return eb, bcf, nil
+}
+
+// canonicalsFromMap calls the BundleBuilder's Canonical method for each block of BuildFromMap's "canonical" argument,
+// being either a list of blocks or a single block, as described for canonicalFromMap.
+func canonicalsFromMap(bldr *BundleBuilder, args interface{}) error {
+	var blocks []interface{}
+	switch args := args.(type) {
+	case []interface{}:
+		blocks = args
+	case []map[string]interface{}:
+		for _, block := range args {
+			blocks = append(blocks, block)
+		}
+	case map[string]interface{}:
+		blocks = []interface{}{args}
+	default:
+		return fmt.Errorf("needs a list of blocks, not %T", args)
+	}
+
+	for i, block := range blocks {
+		blockMap, ok := block.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("block %d needs to be a map, not %T", i, block)
+		}
+
+		eb, bcf, err := canonicalFromMap(blockMap)
+		if err != nil {
+			return fmt.Errorf("block %d: %v", i, err)
+		}
+		bldr.Canonical(eb, bcf)
+	}
+	return nil
+}
+
+// builderFromMap "calls" the BundleBuilder's methods for each map entry, as described for BuildFromMap.
+func builderFromMap(m map[string]interface{}) (bldr *BundleBuilder, err error) {
+	bldr = Builder()
 
 	for method, args := range m {
 		switch method {
@@ -527,7 +1025,7 @@ func BuildFromMap(m map[string]interface{}) (bndl Bundle, err error) {
 			if argsT, ok := args.(time.Time); ok {
 				bldr.CreationTimestampTime(argsT)
 			} else {
-				err = fmt.Errorf("creation_timestamp_time needs a time.Time, not %T", args)
+				err = fmt.Errorf("needs a time.Time, not %T", args)
 			}
 
 		// func (bldr *BundleBuilder) Lifetime(duration interface{}) *BundleBuilder
@@ -536,12 +1034,14 @@ func BuildFromMap(m map[string]interface{}) (bndl Bundle, err error) {
 
 		// func (bldr *BundleBuilder) BundleCtrlFlags(bcf BundleControlFlags) *BundleBuilder
 		case "bundle_ctrl_flags":
-			// TODO: implement
-			err = fmt.Errorf("bundle_ctrl_flags is not yet implemented")
+			var bcf BundleControlFlags
+			if bcf, err = bundleControlFlagsFromMap(args); err == nil {
+				bldr.BundleCtrlFlags(bcf)
+			}
 
 		// func (bldr *BundleBuilder) Canonical(args ...interface{}) *BundleBuilder
 		case "canonical":
-			err = fmt.Errorf("canonical is not impelemtend")
+			err = canonicalsFromMap(bldr, args)
 
 		// func (bldr *BundleBuilder) BundleAgeBlock(args ...interface{}) *BundleBuilder
 		case "bundle_age_block":
@@ -564,16 +1064,17 @@ func BuildFromMap(m map[string]interface{}) (bndl Bundle, err error) {
 			bldr.PreviousNodeBlock(args)
 
 		default:
-			err = fmt.Errorf("method %s is either not implemented or not existing", method)
+			err = fmt.Errorf("either not implemented or not existing")
 		}
 
 		if err == nil {
 			err = bldr.Error()
 		}
 		if err != nil {
+			err = &BuildFromMapError{Method: method, Reason: err}
 			return
 		}
 	}
 
-	return bldr.Build()
+	return
 }
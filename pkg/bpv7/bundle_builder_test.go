@@ -7,6 +7,7 @@ package bpv7
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -76,6 +77,65 @@ func TestBundleBuilderSimple(t *testing.T) {
 	}
 }
 
+func TestBundleBuilderSecondPayloadBlock(t *testing.T) {
+	_, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello")).
+		PayloadBlock([]byte("world")).
+		Build()
+	if err == nil {
+		t.Fatal("BundleBuilder accepted a second payload block")
+	}
+
+	_, err = Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello")).
+		Canonical(NewCanonicalBlock(1, 0, NewPayloadBlock([]byte("world")))).
+		Build()
+	if err == nil {
+		t.Fatal("BundleBuilder accepted a second payload block as a CanonicalBlock")
+	}
+}
+
+func TestBundleBuilderCRC(t *testing.T) {
+	tests := []struct {
+		crcType CRCType
+		valid   bool
+	}{
+		{CRCNo, true},
+		{CRC16, true},
+		{CRC32, true},
+		{CRC32 + 1, false},
+		{CRCType(0xff), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.crcType.String(), func(t *testing.T) {
+			bldr := Builder().CRC(test.crcType)
+			if err := bldr.Error(); (err == nil) != test.valid {
+				t.Fatalf("CRC(%d) error: %v, expected valid: %t", test.crcType, err, test.valid)
+			}
+
+			_, err := bldr.
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if (err == nil) != test.valid {
+				t.Fatalf("Build error: %v, expected valid: %t", err, test.valid)
+			}
+		})
+	}
+}
+
 func TestBldrParseEndpoint(t *testing.T) {
 	eidIn, _ := NewEndpointID("dtn://foo/bar/")
 	if eidTmp, _ := bldrParseEndpoint(eidIn); eidTmp != eidIn {
@@ -174,6 +234,8 @@ func TestBundleBuilderAdministrativeRecord(t *testing.T) {
 }
 
 func TestBuildFromMap(t *testing.T) {
+	now := time.Now()
+
 	tests := []struct {
 		name     string
 		args     map[string]interface{}
@@ -220,6 +282,82 @@ func TestBuildFromMap(t *testing.T) {
 				mustBuild(),
 			wantErr: false,
 		},
+		{
+			name: "control flags as number",
+			args: map[string]interface{}{
+				"destination":              "dtn://dst/",
+				"source":                   "dtn://src/",
+				"creation_timestamp_epoch": true,
+				"lifetime":                 "24h",
+				"bundle_ctrl_flags":        float64(MustNotFragmented | StatusRequestDelivery),
+				"bundle_age_block":         23,
+				"payload_block":            "hello world",
+			},
+			wantBndl: Builder().
+				Destination("dtn://dst/").
+				Source("dtn://src/").
+				CreationTimestampEpoch().
+				Lifetime("24h").
+				BundleCtrlFlags(MustNotFragmented | StatusRequestDelivery).
+				BundleAgeBlock(23).
+				PayloadBlock([]byte("hello world")).
+				mustBuild(),
+			wantErr: false,
+		},
+		{
+			name: "control flags as names",
+			args: map[string]interface{}{
+				"destination":              "dtn://dst/",
+				"source":                   "dtn://src/",
+				"creation_timestamp_epoch": true,
+				"lifetime":                 "24h",
+				"bundle_ctrl_flags":        []interface{}{"MustNotFragmented", "REQUESTED_DELIVERY_STATUS_REPORT"},
+				"bundle_age_block":         23,
+				"payload_block":            "hello world",
+			},
+			wantBndl: Builder().
+				Destination("dtn://dst/").
+				Source("dtn://src/").
+				CreationTimestampEpoch().
+				Lifetime("24h").
+				BundleCtrlFlags(MustNotFragmented | StatusRequestDelivery).
+				BundleAgeBlock(23).
+				PayloadBlock([]byte("hello world")).
+				mustBuild(),
+			wantErr: false,
+		},
+		{
+			name: "canonical blocks",
+			args: map[string]interface{}{
+				"destination":             "dtn://dst/",
+				"source":                  "dtn://src/",
+				"creation_timestamp_time": now,
+				"lifetime":                "24h",
+				"canonical": []interface{}{
+					// HopCountBlock of CBOR [64, 0], base64 encoded as from JSON
+					map[string]interface{}{
+						"block_type_code":     float64(ExtBlockTypeHopCountBlock),
+						"block_control_flags": []interface{}{"RemoveBlock", "REQUEST_STATUS_REPORT"},
+						"data":                "ghhAAA==",
+					},
+					map[string]interface{}{
+						"block_type_code": ExtBlockTypePreviousNodeBlock,
+						"data":            []byte{0x82, 0x01, 0x00}, // dtn:none
+					},
+				},
+				"payload_block": "hello world",
+			},
+			wantBndl: Builder().
+				Destination("dtn://dst/").
+				Source("dtn://src/").
+				CreationTimestampTime(now).
+				Lifetime("24h").
+				Canonical(NewHopCountBlock(64), RemoveBlock|StatusReportBlock).
+				Canonical(NewPreviousNodeBlock(DtnNone()), BlockControlFlags(0)).
+				PayloadBlock([]byte("hello world")).
+				mustBuild(),
+			wantErr: false,
+		},
 		{
 			name: "illegal method",
 			args: map[string]interface{}{
@@ -255,6 +393,120 @@ func TestBuildFromMap(t *testing.T) {
 	}
 }
 
+func TestBuildFromMapError(t *testing.T) {
+	validArgs := func() map[string]interface{} {
+		return map[string]interface{}{
+			"destination":              "dtn://dst/",
+			"source":                   "dtn://src/",
+			"creation_timestamp_epoch": true,
+			"lifetime":                 "24h",
+			"payload_block":            "hello world",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		value  interface{}
+		method string
+	}{
+		{"bad lifetime", "lifetime", "forever", "lifetime"},
+		{"bad destination", "destination", "nope", "destination"},
+		{"unknown method", "nope", "nope", "nope"},
+		{"unknown control flag name", "bundle_ctrl_flags", []interface{}{"MustNotFragmentd"}, "bundle_ctrl_flags"},
+		{"control flag name no string", "bundle_ctrl_flags", []interface{}{4}, "bundle_ctrl_flags"},
+		{"unknown control flag bits", "bundle_ctrl_flags", float64(0x08), "bundle_ctrl_flags"},
+		{"fractional control flags", "bundle_ctrl_flags", 4.5, "bundle_ctrl_flags"},
+		{"negative control flags", "bundle_ctrl_flags", -4, "bundle_ctrl_flags"},
+		{"control flags as string", "bundle_ctrl_flags", "MustNotFragmented", "bundle_ctrl_flags"},
+		{"canonical no list", "canonical", "nope", "canonical"},
+		{"canonical unknown type code", "canonical", []interface{}{
+			map[string]interface{}{"block_type_code": 192, "data": []byte{0x40}}}, "canonical"},
+		{"canonical missing type code", "canonical", []interface{}{
+			map[string]interface{}{"data": []byte{0x40}}}, "canonical"},
+		{"canonical missing data", "canonical", []interface{}{
+			map[string]interface{}{"block_type_code": ExtBlockTypeHopCountBlock}}, "canonical"},
+		{"canonical invalid base64", "canonical", []interface{}{
+			map[string]interface{}{"block_type_code": ExtBlockTypeHopCountBlock, "data": "!!!"}}, "canonical"},
+		{"canonical invalid data", "canonical", []interface{}{
+			map[string]interface{}{"block_type_code": ExtBlockTypeHopCountBlock, "data": []byte{0x40}}}, "canonical"},
+		{"canonical unknown block flag", "canonical", []interface{}{
+			map[string]interface{}{"block_type_code": ExtBlockTypeHopCountBlock, "data": "ghhAAA==",
+				"block_control_flags": []interface{}{"RemoveBlok"}}}, "canonical"},
+		{"canonical unknown field", "canonical", []interface{}{
+			map[string]interface{}{"block_type_code": ExtBlockTypeHopCountBlock, "data": "ghhAAA==", "nope": 1}},
+			"canonical"},
+		{"missing source", "source", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := validArgs()
+			if tt.value == nil {
+				delete(args, tt.key)
+			} else {
+				args[tt.key] = tt.value
+			}
+
+			var buildErr *BuildFromMapError
+			if _, err := BuildFromMap(args); err == nil {
+				t.Fatal("BuildFromMap succeeded")
+			} else if !errors.As(err, &buildErr) {
+				t.Fatalf("error is a %T, not a BuildFromMapError", err)
+			} else if buildErr.Method != tt.method {
+				t.Fatalf("error's method is %q, expected %q", buildErr.Method, tt.method)
+			} else if buildErr.Reason == nil {
+				t.Fatal("error has no reason")
+			}
+		})
+	}
+}
+
+func TestLifetimeFromMap(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		creation interface{}
+		lifetime string
+		expiry   time.Time
+		expired  bool
+	}{
+		{"valid", now, "24h", now.Add(24 * time.Hour), false},
+		{"too short", now.Add(-time.Hour), "1m", now.Add(-59 * time.Minute), true},
+		{"epoch without age", nil, "24h", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]interface{}{
+				"destination":   "dtn://dst/",
+				"source":        "dtn://src/",
+				"lifetime":      tt.lifetime,
+				"payload_block": "hello world",
+			}
+			if tt.creation == nil {
+				args["creation_timestamp_epoch"] = true
+			} else {
+				args["creation_timestamp_time"] = tt.creation
+			}
+
+			expiry, expired, err := LifetimeFromMap(args)
+			if err != nil {
+				t.Fatal(err)
+			} else if expired != tt.expired {
+				t.Fatalf("expired is %t, expected %t", expired, tt.expired)
+			} else if diff := expiry.Sub(tt.expiry); diff < -time.Second || diff > time.Second {
+				t.Fatalf("expiry is %v, expected %v", expiry, tt.expiry)
+			}
+		})
+	}
+
+	if _, _, err := LifetimeFromMap(map[string]interface{}{"lifetime": "forever"}); err == nil {
+		t.Fatal("LifetimeFromMap accepted an invalid lifetime")
+	}
+}
+
 func TestBuildFromMapJSON(t *testing.T) {
 	var args map[string]interface{}
 	data := []byte(`{
@@ -285,3 +537,122 @@ func TestBuildFromMapJSON(t *testing.T) {
 		t.Fatalf("%v != %v", expectedBndl, bndl)
 	}
 }
+
+func TestBundleBuilderSecurityBlocksForPayload(t *testing.T) {
+	payload := []byte("hello secured world")
+	integrityKey := []byte("dtnislove")
+	confidentialityKey := []byte("dtnislovedtnislovedtnislovedtnis")
+
+	// transmit the Bundle as it would be received by the destination.
+	transmit := func(b Bundle) Bundle {
+		buff := new(bytes.Buffer)
+		if err := b.MarshalCbor(buff); err != nil {
+			t.Fatal(err)
+		}
+		received, err := ParseBundle(buff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return received
+	}
+
+	build := func(secure func(*BundleBuilder) *BundleBuilder) Bundle {
+		b, err := secure(Builder().
+			CRC(CRC32).
+			Source("dtn://src/").
+			Destination("dtn://dst/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			HopCountBlock(64).
+			PayloadBlock(payload)).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return transmit(b)
+	}
+
+	t.Run("integrity", func(t *testing.T) {
+		b := build(func(bldr *BundleBuilder) *BundleBuilder {
+			return bldr.IntegrityBlockForPayload(integrityKey, HMAC384SHA384)
+		})
+
+		cb, err := b.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bib := cb.Value.(*BIBIOPHMACSHA2)
+
+		pb, _ := b.PayloadBlock()
+		if targets := bib.SecurityTargets(); len(targets) != 1 || targets[0] != pb.BlockNumber {
+			t.Fatalf("BIB targets %v instead of the payload block", targets)
+		} else if err := bib.VerifyTargets(b, cb.BlockNumber, integrityKey); err != nil {
+			t.Fatal(err)
+		} else if err := bib.VerifyTargets(b, cb.BlockNumber, []byte("wrong key")); err == nil {
+			t.Fatal("BIB was verified for a wrong key")
+		}
+
+		pb.Value = NewPayloadBlock([]byte("hello altered world"))
+		if err := bib.VerifyTargets(b, cb.BlockNumber, integrityKey); err == nil {
+			t.Fatal("BIB was verified for an altered payload")
+		}
+	})
+
+	t.Run("confidentiality", func(t *testing.T) {
+		b := build(func(bldr *BundleBuilder) *BundleBuilder {
+			return bldr.ConfidentialityBlockForPayload(confidentialityKey)
+		})
+
+		pb, _ := b.PayloadBlock()
+		if bytes.Contains(pb.Value.(*PayloadBlock).Data(), payload) {
+			t.Fatal("payload was not encrypted")
+		}
+
+		cb, err := b.ExtensionBlock(ExtBlockTypeBlockConfidentialityBlock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bcb := cb.Value.(*BCBIOPAESGCM)
+
+		if targets := bcb.SecurityTargets(); len(targets) != 1 || targets[0] != pb.BlockNumber {
+			t.Fatalf("BCB targets %v instead of the payload block", targets)
+		} else if err := bcb.DecryptTarget(b, cb.BlockNumber, bytes.Repeat([]byte{0x23}, 32)); err == nil {
+			t.Fatal("BCB was decrypted for a wrong key")
+		} else if err := bcb.DecryptTarget(b, cb.BlockNumber, confidentialityKey); err != nil {
+			t.Fatal(err)
+		}
+
+		pb, _ = b.PayloadBlock()
+		if data := pb.Value.(*PayloadBlock).Data(); !bytes.Equal(data, payload) {
+			t.Fatalf("decrypted payload %q differs from %q", data, payload)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			secure func(*BundleBuilder) *BundleBuilder
+		}{
+			{"empty integrity key", func(bldr *BundleBuilder) *BundleBuilder {
+				return bldr.IntegrityBlockForPayload(nil, HMAC256SHA256)
+			}},
+			{"unknown SHA variant", func(bldr *BundleBuilder) *BundleBuilder {
+				return bldr.IntegrityBlockForPayload(integrityKey, 23)
+			}},
+			{"wrong AES key length", func(bldr *BundleBuilder) *BundleBuilder {
+				return bldr.ConfidentialityBlockForPayload([]byte("short"))
+			}},
+			{"combined", func(bldr *BundleBuilder) *BundleBuilder {
+				return bldr.IntegrityBlockForPayload(integrityKey, HMAC256SHA256).ConfidentialityBlockForPayload(confidentialityKey)
+			}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				if err := test.secure(Builder()).Error(); err == nil {
+					t.Fatal("builder accepted invalid arguments")
+				}
+			})
+		}
+	})
+}
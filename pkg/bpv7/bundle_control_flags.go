@@ -73,24 +73,42 @@ func (bcf BundleControlFlags) CheckValid() (errs error) {
 	return
 }
 
-// Strings returns an array of all flags as a string representation.
-func (bcf BundleControlFlags) Strings() (fields []string) {
-	checks := []struct {
-		field BundleControlFlags
-		text  string
-	}{
-		{StatusRequestDeletion, "REQUESTED_DELETION_STATUS_REPORT"},
-		{StatusRequestDelivery, "REQUESTED_DELIVERY_STATUS_REPORT"},
-		{StatusRequestForward, "REQUESTED_FORWARD_STATUS_REPORT"},
-		{StatusRequestReception, "REQUESTED_RECEPTION_STATUS_REPORT"},
-		{RequestStatusTime, "REQUESTED_TIME_IN_STATUS_REPORT"},
-		{RequestUserApplicationAck, "REQUESTED_APPLICATION_ACK"},
-		{MustNotFragmented, "MUST_NOT_BE_FRAGMENTED"},
-		{AdministrativeRecordPayload, "ADMINISTRATIVE_PAYLOAD"},
-		{IsFragment, "IS_FRAGMENT"},
+// bundleControlFlagNames maps each flag to its string representation and its Go constant's name.
+var bundleControlFlagNames = []struct {
+	field BundleControlFlags
+	text  string
+	name  string
+}{
+	{StatusRequestDeletion, "REQUESTED_DELETION_STATUS_REPORT", "StatusRequestDeletion"},
+	{StatusRequestDelivery, "REQUESTED_DELIVERY_STATUS_REPORT", "StatusRequestDelivery"},
+	{StatusRequestForward, "REQUESTED_FORWARD_STATUS_REPORT", "StatusRequestForward"},
+	{StatusRequestReception, "REQUESTED_RECEPTION_STATUS_REPORT", "StatusRequestReception"},
+	{RequestStatusTime, "REQUESTED_TIME_IN_STATUS_REPORT", "RequestStatusTime"},
+	{RequestUserApplicationAck, "REQUESTED_APPLICATION_ACK", "RequestUserApplicationAck"},
+	{MustNotFragmented, "MUST_NOT_BE_FRAGMENTED", "MustNotFragmented"},
+	{AdministrativeRecordPayload, "ADMINISTRATIVE_PAYLOAD", "AdministrativeRecordPayload"},
+	{IsFragment, "IS_FRAGMENT", "IsFragment"},
+}
+
+// bundleControlFlagsMask contains all known flags.
+const bundleControlFlagsMask = StatusRequestDeletion | StatusRequestDelivery | StatusRequestForward |
+	StatusRequestReception | RequestStatusTime | RequestUserApplicationAck | MustNotFragmented |
+	AdministrativeRecordPayload | IsFragment
+
+// ParseBundleControlFlag returns the flag for either its string representation, e.g., "MUST_NOT_BE_FRAGMENTED", or its
+// Go constant's name, e.g., "MustNotFragmented". An unknown name results in an error.
+func ParseBundleControlFlag(name string) (BundleControlFlags, error) {
+	for _, check := range bundleControlFlagNames {
+		if check.text == name || check.name == name {
+			return check.field, nil
+		}
 	}
+	return 0, fmt.Errorf("unknown control flag %q", name)
+}
 
-	for _, check := range checks {
+// Strings returns an array of all flags as a string representation.
+func (bcf BundleControlFlags) Strings() (fields []string) {
+	for _, check := range bundleControlFlagNames {
 		if bcf.Has(check.field) {
 			fields = append(fields, check.text)
 		}
@@ -104,6 +122,24 @@ func (bcf BundleControlFlags) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bcf.Strings())
 }
 
+// UnmarshalJSON reads a JSON array of control flags, as created by MarshalJSON.
+func (bcf *BundleControlFlags) UnmarshalJSON(data []byte) error {
+	var fields []string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*bcf = 0
+	for _, field := range fields {
+		flag, err := ParseBundleControlFlag(field)
+		if err != nil {
+			return err
+		}
+		*bcf |= flag
+	}
+	return nil
+}
+
 func (bcf BundleControlFlags) String() string {
 	return strings.Join(bcf.Strings(), ",")
 }
@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+
+	"github.com/dtn7/cboring"
+)
+
+// BundleReader reads a CBOR encoded Bundle incrementally, without holding its payload in memory. The primary block
+// and all canonical blocks preceding the payload block are read eagerly by NewBundleReader. The payload block's
+// data is read on demand from the underlying Reader by Payload, e.g., to spool a huge payload to disk. Afterwards,
+// the payload block's CRC and the end of the Bundle are checked.
+//
+// A BundleReader is safe for concurrent use, but its payload can only be read once. ParseBundle remains the
+// convenience function to read a whole Bundle into memory.
+type BundleReader struct {
+	// PrimaryBlock of this Bundle.
+	PrimaryBlock PrimaryBlock
+
+	// CanonicalBlocks are the Bundle's blocks preceding its payload block.
+	CanonicalBlocks []CanonicalBlock
+
+	// PayloadHeader is the payload block without its data; its Value is nil.
+	PayloadHeader CanonicalBlock
+
+	corruptBlocks []CanonicalBlock
+
+	mutex sync.Mutex
+
+	r   io.Reader // underlying Reader
+	pr  io.Reader // underlying Reader for the payload, teed into crc
+	crc hash.Hash // CRC hash of the payload block, if it has a CRC
+
+	indefinite bool   // payload is an indefinite-length byte string
+	remaining  uint64 // remaining bytes of the payload or its current chunk
+	read       uint64 // bytes of the payload read so far
+	err        error  // terminal error or io.EOF
+}
+
+// NewBundleReader reads a Bundle's primary block and all canonical blocks up to its payload block's data from a
+// Reader. Those blocks are checked individually, while the Bundle as a whole is only checked by Bundle.
+func NewBundleReader(r io.Reader) (*BundleReader, error) {
+	br := &BundleReader{r: r}
+
+	if err := cboring.ReadExpect(cboring.IndefiniteArray, r); err != nil {
+		return nil, err
+	}
+
+	if err := cboring.Unmarshal(&br.PrimaryBlock, r); err != nil {
+		return nil, fmt.Errorf("PrimaryBlock failed: %v", err)
+	} else if err := br.PrimaryBlock.CheckValid(); err != nil {
+		return nil, err
+	}
+
+	// Collect preceding blocks within a Bundle to treat blocks with an invalid CRC as Bundle.UnmarshalCbor does.
+	var b Bundle
+	blocksData := make(map[uint64][]byte)
+	for {
+		var header bytes.Buffer
+		blockLen, err := cboring.ReadArrayLength(r)
+		if err == cboring.FlagBreakCode {
+			return nil, fmt.Errorf("Bundle has no payload block")
+		} else if err != nil {
+			return nil, fmt.Errorf("CanonicalBlock failed: %v", err)
+		} else if blockLen != 5 && blockLen != 6 {
+			return nil, fmt.Errorf("CanonicalBlock failed: expected array with length 5 or 6, got %d", blockLen)
+		}
+		_ = cboring.WriteArrayLength(blockLen, &header)
+
+		blockType, err := cboring.ReadUInt(r)
+		if err != nil {
+			return nil, fmt.Errorf("CanonicalBlock failed: %v", err)
+		}
+		_ = cboring.WriteUInt(blockType, &header)
+
+		if blockType == ExtBlockTypePayloadBlock {
+			if err := br.readPayloadHeader(blockLen, header.Bytes()); err != nil {
+				return nil, fmt.Errorf("payload block failed: %v", err)
+			}
+			break
+		}
+
+		cb := CanonicalBlock{}
+		var crcErr *BlockCRCError
+		if blockData, err := cb.unmarshalCbor(io.MultiReader(&header, r)); errors.As(err, &crcErr) {
+			if corruptErr := b.handleCorruptBlock(cb, crcErr); corruptErr != nil {
+				return nil, fmt.Errorf("CanonicalBlock failed: %v", corruptErr)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("CanonicalBlock failed: %v", err)
+		} else if err := cb.CheckValid(); err != nil {
+			return nil, err
+		} else {
+			b.CanonicalBlocks = append(b.CanonicalBlocks, cb)
+			blocksData[cb.BlockNumber] = blockData
+		}
+	}
+
+	if err := b.checkConfidentialityTargets(blocksData); err != nil {
+		return nil, fmt.Errorf("CanonicalBlock failed: %v", err)
+	}
+	sortSecurityBlocks(b.CanonicalBlocks)
+
+	br.CanonicalBlocks = b.CanonicalBlocks
+	br.corruptBlocks = b.corruptBlocks
+	return br, nil
+}
+
+// readPayloadHeader reads the payload block's fields up to its data, whose array length and type code are given.
+func (br *BundleReader) readPayloadHeader(blockLen uint64, header []byte) error {
+	fields := make([]uint64, 3)
+	for i := range fields {
+		if f, err := cboring.ReadUInt(br.r); err != nil {
+			return err
+		} else {
+			fields[i] = f
+		}
+	}
+
+	br.PayloadHeader = CanonicalBlock{
+		BlockNumber:       fields[0],
+		BlockControlFlags: BlockControlFlags(fields[1]),
+		CRCType:           CRCType(fields[2]),
+	}
+
+	if hasCRC := blockLen == 6; hasCRC != br.PayloadHeader.HasCRC() {
+		return fmt.Errorf("array length %d does not match CRCType %v", blockLen, br.PayloadHeader.CRCType)
+	} else if br.PayloadHeader.BlockNumber != 1 {
+		return fmt.Errorf("block number is %d, not 1", br.PayloadHeader.BlockNumber)
+	} else if err := br.PayloadHeader.BlockControlFlags.CheckValid(); err != nil {
+		return err
+	}
+
+	br.pr = br.r
+	if br.PayloadHeader.HasCRC() {
+		crc, err := newCRCHash(br.PayloadHeader.CRCType)
+		if err != nil {
+			return err
+		}
+
+		var replay bytes.Buffer
+		replay.Write(header)
+		for _, f := range fields {
+			_ = cboring.WriteUInt(f, &replay)
+		}
+		_, _ = crc.Write(replay.Bytes())
+
+		br.crc = crc
+		br.pr = io.TeeReader(br.r, crc)
+	}
+
+	var initial [1]byte
+	if _, err := io.ReadFull(br.pr, initial[:]); err != nil {
+		return err
+	}
+
+	if initial[0] == indefiniteByteString {
+		if !IndefiniteByteStrings() {
+			return fmt.Errorf("indefinite-length byte strings are not accepted")
+		}
+		br.indefinite = true
+		return nil
+	}
+
+	n, err := cboring.ReadExpectMajors(cboring.ByteString, io.MultiReader(bytes.NewReader(initial[:]), br.pr))
+	br.remaining = n
+	return err
+}
+
+// nextChunk of an indefinite-length payload. False is returned after its break code.
+func (br *BundleReader) nextChunk() (bool, error) {
+	m, n, err := cboring.ReadMajors(br.pr)
+	if err == cboring.FlagBreakCode {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else if m != cboring.ByteString {
+		return false, fmt.Errorf("indefinite-length byte string contains a chunk of major type 0x%x", m)
+	}
+
+	br.remaining = n
+	return true, nil
+}
+
+// finish reads the payload block's CRC and the Bundle's final break code.
+func (br *BundleReader) finish() error {
+	if br.crc != nil {
+		empty, _ := emptyCRC(br.PayloadHeader.CRCType)
+		_ = cboring.WriteByteString(empty, br.crc)
+		crcCalc := br.crc.Sum(nil)
+
+		if crcVal, err := cboring.ReadByteString(br.r); err != nil {
+			return err
+		} else if !bytes.Equal(crcCalc, crcVal) {
+			return &BlockCRCError{Expected: crcCalc, Got: crcVal}
+		} else {
+			br.PayloadHeader.CRC = crcVal
+		}
+	}
+
+	if err := cboring.ReadExpect(cboring.BreakCode, br.r); err != nil {
+		return fmt.Errorf("payload block is not the last block: %v", err)
+	}
+	return nil
+}
+
+// readPayload reads the next payload bytes. The mutex must be held.
+func (br *BundleReader) readPayload(p []byte) (int, error) {
+	if br.err != nil {
+		return 0, br.err
+	}
+
+	for br.remaining == 0 {
+		more := false
+		if br.indefinite {
+			if more, br.err = br.nextChunk(); br.err != nil {
+				return 0, br.err
+			}
+		}
+
+		if !more {
+			if br.err = br.finish(); br.err == nil {
+				br.err = io.EOF
+			}
+			return 0, br.err
+		}
+	}
+
+	if uint64(len(p)) > br.remaining {
+		p = p[:br.remaining]
+	}
+
+	n, err := br.pr.Read(p)
+	br.remaining -= uint64(n)
+	br.read += uint64(n)
+
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		br.err = err
+	}
+	return n, err
+}
+
+// payloadReader is the io.Reader returned by BundleReader.Payload.
+type payloadReader struct {
+	br *BundleReader
+}
+
+func (pr payloadReader) Read(p []byte) (int, error) {
+	pr.br.mutex.Lock()
+	defer pr.br.mutex.Unlock()
+
+	return pr.br.readPayload(p)
+}
+
+// Payload returns the payload block's data, streamed from the underlying Reader. After the data, its CRC and the
+// Bundle's end are checked, resulting in either io.EOF or an error.
+func (br *BundleReader) Payload() io.Reader {
+	return payloadReader{br}
+}
+
+// Bundle reads the remaining payload into memory and returns the whole, checked Bundle. This fails if the payload was
+// already partially read.
+func (br *BundleReader) Bundle() (b Bundle, err error) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	if br.read > 0 {
+		return b, fmt.Errorf("payload was already read partially")
+	}
+
+	var data bytes.Buffer
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := br.readPayload(buf)
+		data.Write(buf[:n])
+
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			return b, readErr
+		}
+	}
+
+	payload := br.PayloadHeader
+	payload.Value = NewPayloadBlock(data.Bytes())
+
+	b = Bundle{
+		PrimaryBlock:    br.PrimaryBlock,
+		CanonicalBlocks: append(append([]CanonicalBlock{}, br.CanonicalBlocks...), payload),
+		corruptBlocks:   br.corruptBlocks,
+	}
+	err = b.CheckValid()
+	return
+}
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+// readerTestBundle builds a Bundle with some extension blocks and a random payload of the given size.
+func readerTestBundle(t *testing.T, crcType CRCType, size int) (Bundle, []byte) {
+	payload := make([]byte, size)
+	rand.New(rand.NewSource(int64(size))).Read(payload)
+
+	b := mustBuildBundle(t, Builder().
+		CRC(crcType).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PreviousNodeBlock("dtn://prev/").
+		PayloadBlock(payload))
+
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return b, buf.Bytes()
+}
+
+func TestBundleReader(t *testing.T) {
+	for _, crcType := range []CRCType{CRCNo, CRC16, CRC32} {
+		for _, size := range []int{0, 23, 1024 * 1024} {
+			b, data := readerTestBundle(t, crcType, size)
+
+			br, err := NewBundleReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("%v, %d: %v", crcType, size, err)
+			}
+
+			if br.PrimaryBlock.SourceNode != b.PrimaryBlock.SourceNode {
+				t.Fatalf("%v, %d: primary block differs", crcType, size)
+			} else if len(br.CanonicalBlocks) != len(b.CanonicalBlocks)-1 {
+				t.Fatalf("%v, %d: %d preceding blocks", crcType, size, len(br.CanonicalBlocks))
+			} else if br.PayloadHeader.BlockNumber != 1 || br.PayloadHeader.CRCType != crcType {
+				t.Fatalf("%v, %d: payload header is %v", crcType, size, br.PayloadHeader)
+			}
+
+			payload, err := io.ReadAll(br.Payload())
+			if err != nil {
+				t.Fatalf("%v, %d: %v", crcType, size, err)
+			}
+
+			pb, _ := b.PayloadBlock()
+			if !bytes.Equal(payload, pb.Value.(*PayloadBlock).Data()) {
+				t.Fatalf("%v, %d: payload differs", crcType, size)
+			}
+		}
+	}
+}
+
+func TestBundleReaderBundle(t *testing.T) {
+	_, data := readerTestBundle(t, CRC32, 4096)
+
+	br, err := NewBundleReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := br.Bundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("Bundle differs from its original")
+	}
+
+	// The payload cannot be read again after being partially read.
+	br, err = NewBundleReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := br.Payload().Read(make([]byte, 16)); err != nil {
+		t.Fatal(err)
+	} else if _, err := br.Bundle(); err == nil {
+		t.Fatal("partially read Bundle was returned")
+	}
+}
+
+func TestBundleReaderIndefinite(t *testing.T) {
+	defer SetIndefiniteByteStrings(true)
+
+	data := indefinitePayloadBundle(t, CRC32, "hello", "", " ", "world")
+
+	br, err := NewBundleReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload, err := io.ReadAll(br.Payload()); err != nil {
+		t.Fatal(err)
+	} else if string(payload) != "hello world" {
+		t.Fatalf("payload is %q", payload)
+	}
+
+	SetIndefiniteByteStrings(false)
+	if _, err := NewBundleReader(bytes.NewReader(data)); err == nil {
+		t.Fatal("indefinite-length payload was accepted")
+	}
+}
+
+func TestBundleReaderInvalid(t *testing.T) {
+	_, data := readerTestBundle(t, CRC32, 4096)
+
+	// Flip a payload byte, resulting in an invalid CRC after the payload was read.
+	corrupt := append([]byte{}, data...)
+	corrupt[len(corrupt)-100] ^= 0xFF
+
+	br, err := NewBundleReader(bytes.NewReader(corrupt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var crcErr *BlockCRCError
+	if _, err := io.ReadAll(br.Payload()); !errors.As(err, &crcErr) {
+		t.Fatalf("corrupt payload resulted in %v", err)
+	}
+
+	// Truncated Bundle, missing its payload's end.
+	br, err = NewBundleReader(bytes.NewReader(data[:len(data)-1000]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(br.Payload()); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("truncated payload resulted in %v", err)
+	}
+
+	// Bundle without a payload block.
+	var buf bytes.Buffer
+	buf.WriteByte(cboring.IndefiniteArray)
+	b, _ := readerTestBundle(t, CRCNo, 0)
+	if err := b.PrimaryBlock.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteByte(cboring.BreakCode)
+	if _, err := NewBundleReader(&buf); err == nil {
+		t.Fatal("Bundle without a payload block was accepted")
+	}
+}
+
+func TestBundleReaderConcurrent(t *testing.T) {
+	b, data := readerTestBundle(t, CRC32, 1024*1024)
+
+	br, err := NewBundleReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		total int
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			n, _ := io.Copy(io.Discard, br.Payload())
+			mutex.Lock()
+			total += int(n)
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	pb, _ := b.PayloadBlock()
+	if l := len(pb.Value.(*PayloadBlock).Data()); total != l {
+		t.Fatalf("read %d bytes concurrently, expected %d", total, l)
+	}
+}
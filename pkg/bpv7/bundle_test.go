@@ -7,10 +7,13 @@ package bpv7
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dtn7/cboring"
 )
@@ -212,6 +215,56 @@ func TestBundleCheckValid(t *testing.T) {
 	}
 }
 
+func TestBundlePayloadBlockCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		canonicals []CanonicalBlock
+		errMsg     string
+	}{
+		{"zero", []CanonicalBlock{
+			NewCanonicalBlock(2, 0, NewHopCountBlock(23))},
+			"no Payload Block"},
+		{"one", []CanonicalBlock{
+			NewCanonicalBlock(2, 0, NewHopCountBlock(23)),
+			NewCanonicalBlock(1, 0, NewPayloadBlock([]byte("hello world")))},
+			""},
+		{"two", []CanonicalBlock{
+			NewCanonicalBlock(1, 0, NewPayloadBlock([]byte("hello"))),
+			NewCanonicalBlock(2, 0, NewPayloadBlock([]byte("world")))},
+			"2 Payload Blocks"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := Bundle{
+				PrimaryBlock: NewPrimaryBlock(MustNotFragmented,
+					MustNewEndpointID("dtn://dst/"), MustNewEndpointID("dtn://src/"), NewCreationTimestamp(DtnTimeNow(), 0), 3600),
+				CanonicalBlocks: test.canonicals,
+			}
+
+			_, pbErr := b.PayloadBlock()
+			checkErr := b.CheckValid()
+
+			if test.errMsg == "" {
+				if pbErr != nil {
+					t.Fatalf("PayloadBlock erred: %v", pbErr)
+				}
+				if checkErr != nil {
+					t.Fatalf("CheckValid erred: %v", checkErr)
+				}
+				return
+			}
+
+			if pbErr == nil || !strings.Contains(pbErr.Error(), test.errMsg) {
+				t.Fatalf("PayloadBlock error %v does not contain %q", pbErr, test.errMsg)
+			}
+			if checkErr == nil || !strings.Contains(checkErr.Error(), test.errMsg) {
+				t.Fatalf("CheckValid error %v does not contain %q", checkErr, test.errMsg)
+			}
+		})
+	}
+}
+
 func TestBundleAddRemoveExtensionBlocks(t *testing.T) {
 	primary := NewPrimaryBlock(0,
 		MustNewEndpointID("dtn://dst/"),
@@ -249,6 +302,117 @@ func TestBundleAddRemoveExtensionBlocks(t *testing.T) {
 	}
 }
 
+func TestBundleAddExtensionBlockNumbered(t *testing.T) {
+	shaVariant := HMAC256SHA256
+	bib := func(targets ...uint64) ExtensionBlock {
+		return NewBIBIOPHMACSHA2(&shaVariant, nil, nil, targets, MustNewEndpointID("dtn://src/"))
+	}
+
+	tests := []struct {
+		name        string
+		canonicals  []CanonicalBlock
+		removed     []uint64
+		value       ExtensionBlock
+		blockNumber uint64
+	}{
+		{"first extension block",
+			[]CanonicalBlock{NewCanonicalBlock(1, 0, NewPayloadBlock(nil))},
+			nil, NewHopCountBlock(64), 2},
+		{"next free block number",
+			[]CanonicalBlock{NewCanonicalBlock(2, 0, NewBundleAgeBlock(0)), NewCanonicalBlock(1, 0, NewPayloadBlock(nil))},
+			nil, NewHopCountBlock(64), 3},
+		{"reuse removed block number",
+			[]CanonicalBlock{NewCanonicalBlock(2, 0, NewBundleAgeBlock(0)), NewCanonicalBlock(3, 0, NewHopCountBlock(64)),
+				NewCanonicalBlock(1, 0, NewPayloadBlock(nil))},
+			[]uint64{2}, NewPreviousNodeBlock(MustNewEndpointID("dtn://prev/")), 2},
+		{"removed security target",
+			[]CanonicalBlock{NewCanonicalBlock(2, 0, NewBundleAgeBlock(0)), NewCanonicalBlock(3, 0, bib(2)),
+				NewCanonicalBlock(1, 0, NewPayloadBlock(nil))},
+			[]uint64{2}, NewPreviousNodeBlock(MustNewEndpointID("dtn://prev/")), 4},
+		{"dangling security targets",
+			[]CanonicalBlock{NewCanonicalBlock(2, 0, bib(0, 1, 3, 4)), NewCanonicalBlock(1, 0, NewPayloadBlock(nil))},
+			nil, NewHopCountBlock(64), 5},
+		{"payload block targeted by security block",
+			[]CanonicalBlock{NewCanonicalBlock(2, 0, bib(1))},
+			nil, NewPayloadBlock(nil), 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := MustNewBundle(NewPrimaryBlock(0,
+				MustNewEndpointID("dtn://dst/"),
+				MustNewEndpointID("dtn://src/"),
+				NewCreationTimestamp(DtnTimeEpoch, 0),
+				60*60*1000000), test.canonicals)
+
+			for _, removed := range test.removed {
+				b.RemoveExtensionBlockByBlockNumber(removed)
+			}
+
+			blockNumber, err := b.AddExtensionBlockNumbered(NewCanonicalBlock(0, 0, test.value))
+			if err != nil {
+				t.Fatal(err)
+			} else if blockNumber != test.blockNumber {
+				t.Fatalf("expected block number %d, got %d", test.blockNumber, blockNumber)
+			}
+
+			if cb, err := b.GetExtensionBlockByBlockNumber(blockNumber); err != nil {
+				t.Fatal(err)
+			} else if cb.Value != test.value {
+				t.Fatalf("block number %d belongs to %v instead of %v", blockNumber, cb.Value, test.value)
+			}
+		})
+	}
+}
+
+func TestBundleEmptyPayload(t *testing.T) {
+	for _, payload := range [][]byte{nil, {}} {
+		for _, crc := range []CRCType{CRCNo, CRC16, CRC32} {
+			t.Run(fmt.Sprintf("%t-%v", payload == nil, crc), func(t *testing.T) {
+				bndl1, err := Builder().
+					CRC(crc).
+					Source("dtn://src/").
+					Destination("dtn://dst/").
+					CreationTimestampNow().
+					Lifetime("10m").
+					PayloadBlock(payload).
+					Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				buff := new(bytes.Buffer)
+				if err := cboring.Marshal(&bndl1, buff); err != nil {
+					t.Fatal(err)
+				}
+
+				bndl2 := Bundle{}
+				if err := cboring.Unmarshal(&bndl2, buff); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := bndl2.CheckValid(); err != nil {
+					t.Fatal(err)
+				}
+
+				pb, err := bndl2.PayloadBlock()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if data := pb.Value.(*PayloadBlock).Data(); len(data) != 0 {
+					t.Fatalf("Payload is not empty: %x", data)
+				}
+
+				if data, err := json.Marshal(pb.Value); err != nil {
+					t.Fatal(err)
+				} else if string(data) != `""` {
+					t.Fatalf("Empty payload's JSON is %s", data)
+				}
+			})
+		}
+	}
+}
+
 func BenchmarkBundleSerializationCboring(b *testing.B) {
 	var sizes = []int{0, 1024, 1048576, 10485760, 104857600}
 	var crcs = []CRCType{CRCNo, CRC16, CRC32}
@@ -333,3 +497,152 @@ func BenchmarkBundleDeserializationCboring(b *testing.B) {
 		}
 	}
 }
+
+func TestBundleFutureTimestampTolerance(t *testing.T) {
+	t.Cleanup(func() { SetFutureTimestampTolerance(-1) })
+
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		offset    time.Duration
+		valid     bool
+	}{
+		{"disabled far future", -1, 24 * time.Hour, true},
+		{"past", time.Minute, -10 * time.Second, true},
+		{"now", 0, 0, true},
+		{"within tolerance", time.Minute, 30 * time.Second, true},
+		{"below boundary", time.Minute, time.Minute - 5*time.Second, true},
+		{"above boundary", time.Minute, time.Minute + 5*time.Second, false},
+		{"far future", time.Minute, 24 * time.Hour, false},
+		{"zero tolerance", 0, 5 * time.Second, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetFutureTimestampTolerance(test.tolerance)
+
+			b := MustNewBundle(
+				NewPrimaryBlock(
+					MustNotFragmented,
+					MustNewEndpointID("dtn://dst/"), MustNewEndpointID("dtn://src/"),
+					NewCreationTimestamp(DtnTimeFromTime(time.Now().Add(test.offset)), 0), 60*60*1000),
+				[]CanonicalBlock{NewCanonicalBlock(1, 0, NewPayloadBlock([]byte("hello world")))})
+
+			if exceeded := b.IsLifetimeExceeded(); exceeded == test.valid {
+				t.Fatalf("lifetime exceeded is %t, expected %t", exceeded, !test.valid)
+			}
+
+			if err := b.CheckValid(); (err == nil) != test.valid {
+				t.Fatalf("expected validity %t, got error %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestParseBundleIgnoringLifetime(t *testing.T) {
+	b := MustNewBundle(
+		NewPrimaryBlock(0, MustNewEndpointID("dtn://dest/"), MustNewEndpointID("dtn://src/"),
+			NewCreationTimestamp(DtnTimeFromTime(time.Now().Add(-time.Hour)), 0), 60*1000),
+		[]CanonicalBlock{NewCanonicalBlock(1, 0, NewPayloadBlock([]byte("hello world")))})
+
+	var buff bytes.Buffer
+	if err := b.WriteBundle(&buff); err != nil {
+		t.Fatal(err)
+	}
+	data := buff.Bytes()
+
+	if _, err := ParseBundle(bytes.NewReader(data)); err == nil {
+		t.Fatal("expired bundle was parsed")
+	}
+
+	if b2, err := ParseBundleIgnoringLifetime(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(b, b2) {
+		t.Fatalf("parsed bundle differs: %v != %v", b, b2)
+	}
+}
+
+func TestBundleJsonRoundTrip(t *testing.T) {
+	bndl, err := Builder().
+		BundleCtrlFlags(StatusRequestDelivery|MustNotFragmented).
+		Source("dtn://src/").
+		Destination("ipn:23.42").
+		ReportTo("dtn://rprt/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		BundleAgeBlock(23).
+		HopCountBlock(16).
+		PreviousNodeBlock("dtn://prev/").
+		CostMetricBlock(CostMetricType(42)).
+		PayloadDigestBlock(PayloadDigestSHA256).
+		Canonical(NewGenericExtensionBlock([]byte{0x23, 0x42}, 192), DeleteBundle|RemoveBlock).
+		IntegrityBlockForPayload(bytes.Repeat([]byte{0x23}, 32), HMAC256SHA256).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frags, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(bytes.Repeat([]byte("hello world"), 16)).
+		mustBuild().
+		Fragment(128)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(frags) < 2 {
+		t.Fatalf("expected multiple fragments, got %d", len(frags))
+	}
+
+	tests := []struct {
+		name string
+		b    Bundle
+	}{
+		{"extension blocks", bndl},
+		{"first fragment", frags[0]},
+		{"last fragment", frags[len(frags)-1]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var b Bundle
+			if err := json.Unmarshal(data, &b); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.b, b) {
+				t.Fatalf("Bundles differ:\n%v\n%v", test.b, b)
+			}
+		})
+	}
+}
+
+func TestBundleUnmarshalJsonInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unknown bundle control flag", `{"primaryBlock":{"bundleControlFlags":["NOPE"],"destination":"dtn://dst/","source":"dtn://src/","reportTo":"dtn://src/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":0},"lifetime":10},"canonicalBlocks":[{"blockNumber":1,"blockTypeCode":1,"blockControlFlags":null,"data":""}]}`},
+		{"invalid endpoint", `{"primaryBlock":{"bundleControlFlags":null,"destination":"nope","source":"dtn://src/","reportTo":"dtn://src/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":0},"lifetime":10},"canonicalBlocks":[{"blockNumber":1,"blockTypeCode":1,"blockControlFlags":null,"data":""}]}`},
+		{"invalid date", `{"primaryBlock":{"bundleControlFlags":null,"destination":"dtn://dst/","source":"dtn://src/","reportTo":"dtn://src/","creationTimestamp":{"date":"yesterday","sequenceNo":0},"lifetime":10},"canonicalBlocks":[{"blockNumber":1,"blockTypeCode":1,"blockControlFlags":null,"data":""}]}`},
+		{"invalid block data", `{"primaryBlock":{"bundleControlFlags":null,"destination":"dtn://dst/","source":"dtn://src/","reportTo":"dtn://src/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":0},"lifetime":10},"canonicalBlocks":[{"blockNumber":2,"blockTypeCode":7,"blockControlFlags":null,"data":"old"},{"blockNumber":1,"blockTypeCode":1,"blockControlFlags":null,"data":""}]}`},
+		{"missing payload", `{"primaryBlock":{"bundleControlFlags":null,"destination":"dtn://dst/","source":"dtn://src/","reportTo":"dtn://src/","creationTimestamp":{"date":"2000-01-01 00:00:00.000","sequenceNo":0},"lifetime":10},"canonicalBlocks":[]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b Bundle
+			if err := json.Unmarshal([]byte(test.data), &b); err == nil {
+				t.Fatalf("unmarshalling succeeded: %v", b)
+			}
+		})
+	}
+}
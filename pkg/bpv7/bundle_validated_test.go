@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// validatedTestBundle creates a Bundle serialized to CBOR. An expensive Bundle's validation is costly due to an
+// IdentityAssertionBlock and a PayloadDigestBlock.
+func validatedTestBundle(t testing.TB, payloadSize int, lifetime string, expensive bool) []byte {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := make([]byte, payloadSize)
+	rand.Read(payload)
+
+	bldr := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(lifetime)
+	if expensive {
+		bldr = bldr.IdentityAssertionBlock(priv).PayloadDigestBlock(PayloadDigestSHA256)
+	}
+
+	b, err := bldr.PayloadBlock(payload).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseValidatedBundle(t *testing.T) {
+	data := validatedTestBundle(t, 1024, "10m", true)
+
+	if _, err := ParseValidatedBundle(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replacing the payload breaks both the identity assertion's signature and the payload digest, which is only
+	// detected by CheckValid.
+	b, err := ParseBundle(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb.Value = NewPayloadBlock([]byte("modified payload"))
+
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseBundle(bytes.NewReader(buf.Bytes())); err == nil {
+		t.Fatal("parsing modified bundle succeeded")
+	}
+	if _, err := ParseValidatedBundle(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("parsing modified, but validated bundle failed: %v", err)
+	}
+
+	// A corrupted representation is still detected by its CRC value.
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-16] ^= 0xFF
+	if _, err := ParseValidatedBundle(bytes.NewReader(corrupted)); err == nil {
+		t.Fatal("parsing corrupted bundle succeeded")
+	}
+}
+
+func TestParseValidatedBundleLifetime(t *testing.T) {
+	data := validatedTestBundle(t, 16, "50ms", false)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := ParseBundle(bytes.NewReader(data)); err == nil {
+		t.Fatal("parsing expired bundle succeeded")
+	}
+	if _, err := ParseValidatedBundle(bytes.NewReader(data)); err != nil {
+		t.Fatalf("parsing expired, but validated bundle failed: %v", err)
+	}
+}
+
+func BenchmarkBundleDeserializationValidated(b *testing.B) {
+	parsers := []struct {
+		name  string
+		parse func(io.Reader) (Bundle, error)
+	}{
+		{"checked", ParseBundle},
+		{"validated", ParseValidatedBundle},
+	}
+
+	for _, expensive := range []bool{false, true} {
+		for _, size := range []int{1024, 1048576, 4194304} {
+			data := validatedTestBundle(b, size, "1h", expensive)
+
+			for _, parser := range parsers {
+				b.Run(fmt.Sprintf("expensive-%t-%d-%s", expensive, size, parser.name), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						if _, err := parser.parse(bytes.NewReader(data)); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
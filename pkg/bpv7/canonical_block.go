@@ -96,13 +96,36 @@ func (cb *CanonicalBlock) MarshalCbor(w io.Writer) error {
 	return nil
 }
 
-// UnmarshalCbor creates this Canonical Block based on a CBOR representation.
+// readBlockData unmarshals an ExtensionBlock from its block-type-specific data through the ExtensionBlockManager.
+func readBlockData(blockType uint64, data []byte) (ExtensionBlock, error) {
+	buff := new(bytes.Buffer)
+	if err := cboring.WriteByteString(data, buff); err != nil {
+		return nil, err
+	}
+	return GetExtensionBlockManager().ReadBlock(blockType, buff)
+}
+
+// UnmarshalCbor creates this Canonical Block based on a CBOR representation. Data not matching a known block type
+// results in an error.
 func (cb *CanonicalBlock) UnmarshalCbor(r io.Reader) error {
+	if _, err := cb.unmarshalCbor(r); err != nil {
+		return err
+	} else if geb, ok := cb.Value.(*GenericExtensionBlock); ok && geb.unmarshalErr != nil {
+		return geb.unmarshalErr
+	}
+	return nil
+}
+
+// unmarshalCbor is UnmarshalCbor, additionally returning the block-type-specific data. In contrast to UnmarshalCbor,
+// data not matching a known block type is kept as a GenericExtensionBlock with its unmarshalErr, as it might be
+// encrypted. Furthermore, an encrypted BCB target's data might be unmarshalled as its block type by chance. Both are
+// resolved by Bundle.checkConfidentialityTargets.
+func (cb *CanonicalBlock) unmarshalCbor(r io.Reader) (blockData []byte, err error) {
 	var blockLen uint64
 	if bl, err := cboring.ReadArrayLength(r); err != nil {
-		return err
+		return nil, err
 	} else if bl != 5 && bl != 6 {
-		return fmt.Errorf("expected array with length 5 or 6, got %d", bl)
+		return nil, fmt.Errorf("expected array with length 5 or 6, got %d", bl)
 	} else {
 		blockLen = bl
 	}
@@ -112,55 +135,63 @@ func (cb *CanonicalBlock) UnmarshalCbor(r io.Reader) error {
 	if blockLen == 6 {
 		// Replay array's start
 		if err := cboring.WriteArrayLength(blockLen, crcBuff); err != nil {
-			return err
+			return nil, err
 		}
 		r = io.TeeReader(r, crcBuff)
 	}
 
 	var blockType uint64
 	if bt, err := cboring.ReadUInt(r); err != nil {
-		return err
+		return nil, err
 	} else {
 		blockType = bt
 	}
 
 	if bn, err := cboring.ReadUInt(r); err != nil {
-		return err
+		return nil, err
 	} else {
 		cb.BlockNumber = bn
 	}
 
 	if bcf, err := cboring.ReadUInt(r); err != nil {
-		return err
+		return nil, err
 	} else {
 		cb.BlockControlFlags = BlockControlFlags(bcf)
 	}
 
 	if crcT, err := cboring.ReadUInt(r); err != nil {
-		return err
+		return nil, err
 	} else {
 		cb.CRCType = CRCType(crcT)
 	}
 
-	if b, err := GetExtensionBlockManager().ReadBlock(blockType, r); err != nil {
-		return fmt.Errorf("unmarshalling block type %d failed: %v", blockType, err)
+	if blockData, err = readByteString(r); err != nil {
+		return nil, err
+	} else if b, err := readBlockData(blockType, blockData); err != nil {
+		// The data might be encrypted by a BCB, which is checked by Bundle.UnmarshalCbor.
+		cb.Value = &GenericExtensionBlock{
+			data:         blockData,
+			typeCode:     blockType,
+			unmarshalErr: fmt.Errorf("unmarshalling block type %d failed: %v", blockType, err),
+		}
 	} else {
 		cb.Value = b
 	}
 
 	if blockLen == 6 {
 		if crcCalc, crcErr := calculateCRCBuff(crcBuff, cb.CRCType); crcErr != nil {
-			return crcErr
+			return nil, crcErr
 		} else if crcVal, err := cboring.ReadByteString(r); err != nil {
-			return err
+			return nil, err
 		} else if !bytes.Equal(crcCalc, crcVal) {
-			return fmt.Errorf("invalid CRC value: %x instead of expected %x", crcVal, crcCalc)
+			cb.CRC = crcVal
+			return nil, &BlockCRCError{Expected: crcCalc, Got: crcVal}
 		} else {
 			cb.CRC = crcVal
 		}
 	}
 
-	return nil
+	return blockData, nil
 }
 
 // MarshalJSON writes a JSON object for this Canonical Block.
@@ -192,6 +223,52 @@ func (cb CanonicalBlock) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON reads a JSON object of a Canonical Block, as created by MarshalJSON. The block's data is dispatched
+// through the ExtensionBlockManager: known blocks with their own JSON representation read it, while all other blocks,
+// including unknown ones as a GenericExtensionBlock, are read from their base64 encoded CBOR representation. As the JSON
+// representation lacks the CRC type, no CRC will be used.
+func (cb *CanonicalBlock) UnmarshalJSON(data []byte) error {
+	var cbJson struct {
+		BlockNumber   uint64            `json:"blockNumber"`
+		BlockTypeCode uint64            `json:"blockTypeCode"`
+		ControlFlags  BlockControlFlags `json:"blockControlFlags"`
+		Data          json.RawMessage   `json:"data"`
+	}
+	if err := json.Unmarshal(data, &cbJson); err != nil {
+		return err
+	}
+
+	value := GetExtensionBlockManager().createBlock(cbJson.BlockTypeCode)
+	if _, ok := value.(json.Marshaler); ok {
+		unmarshaler, ok := value.(json.Unmarshaler)
+		if !ok {
+			return fmt.Errorf("block type %d does not support JSON unmarshalling", cbJson.BlockTypeCode)
+		}
+
+		if err := unmarshaler.UnmarshalJSON(cbJson.Data); err != nil {
+			return fmt.Errorf("unmarshalling block type %d failed: %v", cbJson.BlockTypeCode, err)
+		}
+	} else {
+		var blockData []byte
+		if err := json.Unmarshal(cbJson.Data, &blockData); err != nil {
+			return fmt.Errorf("unmarshalling block type %d failed: %v", cbJson.BlockTypeCode, err)
+		}
+
+		var err error
+		if value, err = GetExtensionBlockManager().ReadBlock(cbJson.BlockTypeCode, bytes.NewBuffer(blockData)); err != nil {
+			return fmt.Errorf("unmarshalling block type %d failed: %v", cbJson.BlockTypeCode, err)
+		}
+	}
+
+	*cb = CanonicalBlock{
+		BlockNumber:       cbJson.BlockNumber,
+		BlockControlFlags: cbJson.ControlFlags,
+		CRCType:           CRCNo,
+		Value:             value,
+	}
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (cb CanonicalBlock) CheckValid() (errs error) {
 	if bcfErr := cb.BlockControlFlags.CheckValid(); bcfErr != nil {
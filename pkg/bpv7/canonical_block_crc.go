@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// lenientBlockCRC is non-zero if canonical blocks with an invalid CRC are handled by their block control flags.
+var lenientBlockCRC int32
+
+// SetLenientBlockCRC configures how a received canonical block with an invalid CRC is handled.
+//
+// By default, the strict mode rejects the whole Bundle. In the lenient mode, such a block is handled by its
+// BlockControlFlags, like a block which cannot be processed. A block requesting DeleteBundle still rejects the Bundle,
+// while a block requesting RemoveBlock is removed and listed by Bundle.CorruptBlocks, e.g., to send a status report
+// for StatusReportBlock. As a corrupt block must not be forwarded with a recalculated CRC, it is also rejected without
+// either flag. The primary block's and the payload block's CRC are always checked strictly.
+func SetLenientBlockCRC(lenient bool) {
+	var v int32
+	if lenient {
+		v = 1
+	}
+	atomic.StoreInt32(&lenientBlockCRC, v)
+}
+
+// LenientBlockCRC returns whether the lenient mode for canonical blocks with an invalid CRC is enabled.
+func LenientBlockCRC() bool {
+	return atomic.LoadInt32(&lenientBlockCRC) != 0
+}
+
+// BlockCRCError is returned when unmarshalling a CanonicalBlock with an invalid CRC. The CanonicalBlock was read
+// completely, allowing to continue with the next block.
+type BlockCRCError struct {
+	// Expected is the calculated CRC value; Got is the received one.
+	Expected []byte
+	Got      []byte
+}
+
+func (err *BlockCRCError) Error() string {
+	return fmt.Sprintf("invalid CRC value: %x instead of expected %x", err.Got, err.Expected)
+}
+
+// CorruptBlocks returns the canonical blocks with an invalid CRC which were removed while unmarshalling this Bundle in
+// the lenient mode, compare SetLenientBlockCRC.
+func (b Bundle) CorruptBlocks() []CanonicalBlock {
+	return b.corruptBlocks
+}
+
+// handleCorruptBlock decides if a canonical block with an invalid CRC might be removed. Otherwise, an error is
+// returned to reject the whole Bundle.
+func (b *Bundle) handleCorruptBlock(cb CanonicalBlock, crcErr *BlockCRCError) error {
+	switch {
+	case !LenientBlockCRC():
+		return crcErr
+	case cb.TypeCode() == ExtBlockTypePayloadBlock:
+		return fmt.Errorf("payload block: %v", crcErr)
+	case cb.BlockControlFlags.Has(DeleteBundle):
+		return fmt.Errorf("block %d requests bundle deletion: %v", cb.BlockNumber, crcErr)
+	case !cb.BlockControlFlags.Has(RemoveBlock):
+		return fmt.Errorf("block %d cannot be removed: %v", cb.BlockNumber, crcErr)
+	}
+
+	b.corruptBlocks = append(b.corruptBlocks, cb)
+	return nil
+}
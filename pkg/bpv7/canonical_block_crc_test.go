@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+// corruptBlockBundle encodes a Bundle with a hop count block, configured by its flags, and flips a bit of the CRC of
+// either this block or the payload block.
+func corruptBlockBundle(t *testing.T, flags BlockControlFlags, corruptPayload bool) []byte {
+	b := mustBuildBundle(t, Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello world")))
+
+	hcb, err := b.ExtensionBlock(ExtBlockTypeHopCountBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hcb.BlockControlFlags = flags
+
+	target := hcb
+	if corruptPayload {
+		if target, err = b.PayloadBlock(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var blockBuf bytes.Buffer
+	if err := cboring.Marshal(target, &blockBuf); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	i := bytes.Index(data, blockBuf.Bytes())
+	if i < 0 {
+		t.Fatal("block was not found within the bundle")
+	}
+	// The CRC value is the block's last field.
+	data[i+blockBuf.Len()-1] ^= 0x01
+	return data
+}
+
+func TestBundleCorruptBlockCRC(t *testing.T) {
+	tests := []struct {
+		name           string
+		lenient        bool
+		flags          BlockControlFlags
+		corruptPayload bool
+		valid          bool
+	}{
+		{"strict", false, RemoveBlock | StatusReportBlock, false, false},
+		{"lenient remove block", true, RemoveBlock | StatusReportBlock, false, true},
+		{"lenient delete bundle", true, RemoveBlock | DeleteBundle, false, false},
+		{"lenient without flags", true, 0, false, false},
+		{"lenient payload block", true, RemoveBlock, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetLenientBlockCRC(test.lenient)
+			defer SetLenientBlockCRC(false)
+
+			var b Bundle
+			err := b.UnmarshalCbor(bytes.NewReader(corruptBlockBundle(t, test.flags, test.corruptPayload)))
+			if (err == nil) != test.valid {
+				t.Fatalf("expected validity %t, got error %v", test.valid, err)
+			} else if !test.valid {
+				return
+			}
+
+			if _, err := b.ExtensionBlock(ExtBlockTypeHopCountBlock); err == nil {
+				t.Fatal("corrupt hop count block was not removed")
+			} else if _, err := b.PayloadBlock(); err != nil {
+				t.Fatal(err)
+			}
+
+			if corrupt := b.CorruptBlocks(); len(corrupt) != 1 {
+				t.Fatalf("expected one corrupt block, got %d", len(corrupt))
+			} else if corrupt[0].TypeCode() != ExtBlockTypeHopCountBlock || !corrupt[0].BlockControlFlags.Has(StatusReportBlock) {
+				t.Fatalf("corrupt block %v is not the hop count block", corrupt[0])
+			}
+		})
+	}
+}
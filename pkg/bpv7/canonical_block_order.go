@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// preserveBlockOrder is non-zero if the CanonicalBlocks' order is kept instead of sorting them by their block numbers.
+var preserveBlockOrder int32
+
+// SetPreserveBlockOrder configures how a Bundle's CanonicalBlocks are ordered after a modification, e.g., when an
+// ExtensionBlock is added while forwarding.
+//
+// By default, the CanonicalBlocks are sorted by their block numbers. When preserving the order, e.g., as received,
+// a new block is inserted before the payload block, which is always the last one. In both cases, security blocks are
+// placed before their targets.
+func SetPreserveBlockOrder(preserve bool) {
+	var v int32
+	if preserve {
+		v = 1
+	}
+	atomic.StoreInt32(&preserveBlockOrder, v)
+}
+
+// PreserveBlockOrder returns whether the CanonicalBlocks' order is preserved, compare SetPreserveBlockOrder.
+func PreserveBlockOrder() bool {
+	return atomic.LoadInt32(&preserveBlockOrder) != 0
+}
+
+// sortPayloadBlockLast moves the payload block to the last position, while keeping the order of all other blocks.
+func sortPayloadBlockLast(cbs []CanonicalBlock) {
+	sort.SliceStable(cbs, func(i, j int) bool {
+		return cbs[i].TypeCode() != ExtBlockTypePayloadBlock && cbs[j].TypeCode() == ExtBlockTypePayloadBlock
+	})
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// blockNumbers of a Bundle's CanonicalBlocks in their order.
+func blockNumbers(b Bundle) (numbers []uint64) {
+	for _, cb := range b.CanonicalBlocks {
+		numbers = append(numbers, cb.BlockNumber)
+	}
+	return
+}
+
+func TestPreserveBlockOrder(t *testing.T) {
+	defer SetPreserveBlockOrder(PreserveBlockOrder())
+
+	tests := []struct {
+		name     string
+		preserve bool
+		order    []uint64
+	}{
+		{"sorted", false, []uint64{2, 3, 4, 1}},
+		{"preserved", true, []uint64{3, 2, 4, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetPreserveBlockOrder(test.preserve)
+
+			b := mustBuildBundle(t, Builder().
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				HopCountBlock(64).
+				BundleAgeBlock(0).
+				PayloadBlock([]byte("hello world")))
+
+			// Send the extension blocks in reverse order.
+			cbs := b.CanonicalBlocks
+			cbs[0], cbs[1] = cbs[1], cbs[0]
+
+			buff := new(bytes.Buffer)
+			if err := b.MarshalCbor(buff); err != nil {
+				t.Fatal(err)
+			}
+
+			received, err := ParseBundle(buff)
+			if err != nil {
+				t.Fatal(err)
+			} else if numbers := blockNumbers(received); !reflect.DeepEqual(numbers, []uint64{3, 2, 1}) {
+				t.Fatalf("received block order %v differs from the sent one", numbers)
+			}
+
+			if err := received.AddExtensionBlock(NewCanonicalBlock(0, 0, NewPreviousNodeBlock(MustNewEndpointID("dtn://node/")))); err != nil {
+				t.Fatal(err)
+			} else if numbers := blockNumbers(received); !reflect.DeepEqual(numbers, test.order) {
+				t.Fatalf("expected block order %v, got %v", test.order, numbers)
+			}
+
+			buff.Reset()
+			if err := received.MarshalCbor(buff); err != nil {
+				t.Fatal(err)
+			} else if forwarded, err := ParseBundle(buff); err != nil {
+				t.Fatal(err)
+			} else if numbers := blockNumbers(forwarded); !reflect.DeepEqual(numbers, test.order) {
+				t.Fatalf("expected forwarded block order %v, got %v", test.order, numbers)
+			}
+		})
+	}
+}
@@ -4,6 +4,12 @@
 
 package bpv7
 
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
 // canonicalBlockNumberSort implements sort.Interface to sort []CanonicalBlock based on their block number.
 // The sorting is in ascending order. An exception is the payload block, which occurs in the last position despite
 // having the lowest block number of 1.
@@ -34,3 +40,92 @@ func (cbns canonicalBlockNumberSort) Less(i, j int) bool {
 func (cbns canonicalBlockNumberSort) Swap(i, j int) {
 	cbns[i], cbns[j] = cbns[j], cbns[i]
 }
+
+// sortSecurityBlocks reorders CanonicalBlocks, e.g., already sorted by canonicalBlockNumberSort or as received, such
+// that each security block precedes its security targets. Apart from that, the present order is kept.
+//
+// If the security targets form a cycle, e.g., two BCBs targeting each other, the order remains unchanged.
+func sortSecurityBlocks(cbs []CanonicalBlock) {
+	indices := make(map[uint64]int, len(cbs))
+	for i, cb := range cbs {
+		indices[cb.BlockNumber] = i
+	}
+
+	// successors[i] are the indices of all blocks which must be placed after block i.
+	successors := make([][]int, len(cbs))
+	predecessors := make([]int, len(cbs))
+	constrained := false
+
+	for i, cb := range cbs {
+		sb, ok := cb.Value.(SecurityBlock)
+		if !ok {
+			continue
+		}
+
+		for _, target := range sb.SecurityTargets() {
+			if j, ok := indices[target]; ok && j != i {
+				successors[i] = append(successors[i], j)
+				predecessors[j]++
+				constrained = true
+			}
+		}
+	}
+
+	if !constrained {
+		return
+	}
+
+	// Topological sort, always picking the foremost block without unplaced predecessors.
+	order := make([]int, 0, len(cbs))
+	placed := make([]bool, len(cbs))
+
+	for len(order) < len(cbs) {
+		next := -1
+		for i := range cbs {
+			if !placed[i] && predecessors[i] == 0 {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			return
+		}
+
+		placed[next] = true
+		order = append(order, next)
+
+		for _, j := range successors[next] {
+			predecessors[j]--
+		}
+	}
+
+	sorted := make([]CanonicalBlock, len(cbs))
+	for i, j := range order {
+		sorted[i] = cbs[j]
+	}
+	copy(cbs, sorted)
+}
+
+// checkSecurityBlockOrder returns an error if a security block succeeds one of its security targets.
+//
+// RFC 9172 does not require this order. Thus, it is only enforced for Bundles built by this node, while received
+// Bundles are reordered by sortSecurityBlocks.
+func checkSecurityBlockOrder(cbs []CanonicalBlock) (errs error) {
+	for i, cb := range cbs {
+		sb, ok := cb.Value.(SecurityBlock)
+		if !ok {
+			continue
+		}
+
+		for _, target := range sb.SecurityTargets() {
+			for _, prev := range cbs[:i] {
+				if prev.BlockNumber == target {
+					errs = multierror.Append(errs, fmt.Errorf(
+						"Bundle: security block %d succeeds its security target %d", cb.BlockNumber, target))
+				}
+			}
+		}
+	}
+	return
+}
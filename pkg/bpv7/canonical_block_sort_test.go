@@ -6,6 +6,7 @@ package bpv7
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -67,3 +68,73 @@ func TestCanonicalBlockNumberSort(t *testing.T) {
 		t.Fatalf("last block's block number is %d", blockNumber)
 	}
 }
+
+func TestSortSecurityBlocks(t *testing.T) {
+	bib := func(targets ...uint64) ExtensionBlock {
+		return NewBIBIOPHMACSHA2(nil, nil, nil, targets, MustNewEndpointID("dtn://src/"))
+	}
+	bcb := func(target uint64) ExtensionBlock {
+		return NewBCBIOPAESGCM(nil, nil, nil, target, MustNewEndpointID("dtn://src/"))
+	}
+
+	tests := []struct {
+		name       string
+		canonicals []CanonicalBlock
+		want       []uint64
+	}{
+		{
+			name: "no security blocks",
+			canonicals: []CanonicalBlock{
+				NewCanonicalBlock(3, 0, nil),
+				NewCanonicalBlock(1, 0, nil),
+				NewCanonicalBlock(2, 0, nil),
+			},
+			want: []uint64{2, 3, 1},
+		},
+		{
+			name: "bib before its targets",
+			canonicals: []CanonicalBlock{
+				NewCanonicalBlock(1, 0, nil),
+				NewCanonicalBlock(2, 0, nil),
+				NewCanonicalBlock(3, 0, bib(2, 1)),
+				NewCanonicalBlock(4, 0, nil),
+			},
+			want: []uint64{3, 2, 4, 1},
+		},
+		{
+			name: "bcb targeting a bib",
+			canonicals: []CanonicalBlock{
+				NewCanonicalBlock(1, 0, nil),
+				NewCanonicalBlock(2, 0, nil),
+				NewCanonicalBlock(3, 0, bib(1)),
+				NewCanonicalBlock(4, 0, bcb(3)),
+			},
+			want: []uint64{2, 4, 3, 1},
+		},
+		{
+			name: "cyclic security targets",
+			canonicals: []CanonicalBlock{
+				NewCanonicalBlock(1, 0, nil),
+				NewCanonicalBlock(2, 0, bcb(3)),
+				NewCanonicalBlock(3, 0, bcb(2)),
+			},
+			want: []uint64{2, 3, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sort.Sort(canonicalBlockNumberSort(test.canonicals))
+			sortSecurityBlocks(test.canonicals)
+
+			var got []uint64
+			for _, cb := range test.canonicals {
+				got = append(got, cb.BlockNumber)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("block order is %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
@@ -109,3 +109,25 @@ func TestCanonicalBlockJson(t *testing.T) {
 		}
 	}
 }
+
+func TestCanonicalBlockUnmarshalInvalidData(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		valid bool
+	}{
+		// Hop Count Block, block number 2, without flags and CRC, whose data is no CBOR array
+		{"known type", []byte{0x85, 0x0A, 0x02, 0x00, 0x00, 0x42, 0xFF, 0x00}, false},
+		// The same data for an unknown block type 192
+		{"unknown type", []byte{0x85, 0x18, 0xC0, 0x02, 0x00, 0x00, 0x42, 0xFF, 0x00}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cb CanonicalBlock
+			if err := cb.UnmarshalCbor(bytes.NewReader(test.data)); (err == nil) != test.valid {
+				t.Fatalf("expected valid = %t, got error %v", test.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/dtn7/cboring"
+)
+
+// indefiniteByteString is the initial byte of an indefinite-length CBOR byte string, RFC 8949, section 3.2.3.
+const indefiniteByteString byte = 0x5F
+
+// indefiniteByteStrings is non-zero if indefinite-length byte strings are accepted.
+var indefiniteByteStrings int32 = 1
+
+// SetIndefiniteByteStrings configures whether a block's data might be an indefinite-length CBOR byte string, as
+// produced by some encoders. Its chunks are concatenated, resulting in a definite-length byte string when the block
+// is marshalled again.
+//
+// Accepting indefinite-length byte strings is the default. Otherwise, such Bundles are rejected.
+func SetIndefiniteByteStrings(accept bool) {
+	var v int32
+	if accept {
+		v = 1
+	}
+	atomic.StoreInt32(&indefiniteByteStrings, v)
+}
+
+// IndefiniteByteStrings returns whether indefinite-length byte strings are accepted.
+func IndefiniteByteStrings() bool {
+	return atomic.LoadInt32(&indefiniteByteStrings) != 0
+}
+
+// readByteString reads a definite-length CBOR byte string or, if accepted, an indefinite-length one.
+func readByteString(r io.Reader) ([]byte, error) {
+	var initial [1]byte
+	if _, err := io.ReadFull(r, initial[:]); err != nil {
+		return nil, err
+	}
+
+	if initial[0] != indefiniteByteString {
+		return cboring.ReadByteString(io.MultiReader(bytes.NewReader(initial[:]), r))
+	} else if !IndefiniteByteStrings() {
+		return nil, fmt.Errorf("indefinite-length byte strings are not accepted")
+	}
+
+	var data bytes.Buffer
+	for {
+		m, n, err := cboring.ReadMajors(r)
+		if err == cboring.FlagBreakCode {
+			return data.Bytes(), nil
+		} else if err != nil {
+			return nil, err
+		} else if m != cboring.ByteString {
+			return nil, fmt.Errorf("indefinite-length byte string contains a chunk of major type 0x%x", m)
+		}
+
+		chunk, err := cboring.ReadRawBytes(n, r)
+		if err != nil {
+			return nil, err
+		}
+		data.Write(chunk)
+	}
+}
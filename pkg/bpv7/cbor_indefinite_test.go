@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+// indefinitePayloadBundle encodes a Bundle whose payload block's data is an indefinite-length byte string of chunks.
+func indefinitePayloadBundle(t *testing.T, crcType CRCType, chunks ...string) []byte {
+	b := mustBuildBundle(t, Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("")))
+
+	var block bytes.Buffer
+	blockLen := uint64(5)
+	if crcType != CRCNo {
+		blockLen = 6
+	}
+	if err := cboring.WriteArrayLength(blockLen, &block); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []uint64{ExtBlockTypePayloadBlock, 1, 0, uint64(crcType)} {
+		if err := cboring.WriteUInt(field, &block); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	block.WriteByte(indefiniteByteString)
+	for _, chunk := range chunks {
+		if err := cboring.WriteByteString([]byte(chunk), &block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	block.WriteByte(cboring.BreakCode)
+
+	if crcType != CRCNo {
+		crcVal, err := calculateCRCBuff(bytes.NewBuffer(append([]byte{}, block.Bytes()...)), crcType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cboring.WriteByteString(crcVal, &block); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(cboring.IndefiniteArray)
+	if err := b.PrimaryBlock.MarshalCbor(&buf); err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(block.Bytes())
+	buf.WriteByte(cboring.BreakCode)
+
+	return buf.Bytes()
+}
+
+func TestIndefiniteByteStringPayload(t *testing.T) {
+	defer SetIndefiniteByteStrings(true)
+
+	tests := []struct {
+		name    string
+		crcType CRCType
+		chunks  []string
+		payload string
+	}{
+		{"chunks", CRCNo, []string{"hello", " ", "world"}, "hello world"},
+		{"chunks with CRC32", CRC32, []string{"hello", " world"}, "hello world"},
+		{"chunks with CRC16", CRC16, []string{"hello world"}, "hello world"},
+		{"empty chunk", CRCNo, []string{"", "hello world", ""}, "hello world"},
+		{"no chunks", CRC32, nil, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := indefinitePayloadBundle(t, test.crcType, test.chunks...)
+
+			SetIndefiniteByteStrings(true)
+			b, err := ParseBundle(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pb, err := b.PayloadBlock()
+			if err != nil {
+				t.Fatal(err)
+			} else if payload := pb.Value.(*PayloadBlock).Data(); string(payload) != test.payload {
+				t.Fatalf("payload is %q, not %q", payload, test.payload)
+			}
+
+			// Re-emitting the Bundle normalizes its payload to a definite-length byte string.
+			var buf bytes.Buffer
+			if err := b.MarshalCbor(&buf); err != nil {
+				t.Fatal(err)
+			}
+
+			SetIndefiniteByteStrings(false)
+			if _, err := ParseBundle(bytes.NewReader(data)); err == nil {
+				t.Fatal("indefinite-length byte string was accepted while being disabled")
+			}
+
+			b2, err := ParseBundle(&buf)
+			if err != nil {
+				t.Fatalf("re-emitted bundle is not definite-length: %v", err)
+			} else if pb2, _ := b2.PayloadBlock(); string(pb2.Value.(*PayloadBlock).Data()) != test.payload {
+				t.Fatalf("re-emitted payload is %q, not %q", pb2.Value.(*PayloadBlock).Data(), test.payload)
+			}
+		})
+	}
+}
+
+func TestIndefiniteByteStringInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		// Chunk of a text string instead of a byte string
+		{indefiniteByteString, 0x61, 'a', cboring.BreakCode},
+		// Nested indefinite-length byte string
+		{indefiniteByteString, indefiniteByteString, cboring.BreakCode, cboring.BreakCode},
+		// Missing break code
+		{indefiniteByteString, 0x41, 'a'},
+		// Truncated chunk
+		{indefiniteByteString, 0x42, 'a'},
+	} {
+		if _, err := readByteString(bytes.NewReader(data)); err == nil {
+			t.Fatalf("invalid byte string %x was accepted", data)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"hash/crc32"
 
 	"github.com/dtn7/cboring"
@@ -76,6 +77,21 @@ func calculateCRCBuff(buff *bytes.Buffer, crcType CRCType) ([]byte, error) {
 	return data, nil
 }
 
+// newCRCHash creates a hash.Hash to calculate a block's CRC value incrementally, as calculateCRCBuff does for a
+// whole buffer. Its sum must be taken after writing the CRC type's encoded emptyCRC value.
+func newCRCHash(crcType CRCType) (hash.Hash, error) {
+	switch crcType {
+	case CRC16:
+		return crc16.New(crc16table), nil
+
+	case CRC32:
+		return crc32.New(crc32table), nil
+
+	default:
+		return nil, fmt.Errorf("no CRC hash for CRCType %d", crcType)
+	}
+}
+
 // emptyCRC returns the "default" CRC value for the given CRC Type.
 func emptyCRC(crcType CRCType) (arr []byte, err error) {
 	switch crcType {
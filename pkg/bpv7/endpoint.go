@@ -178,6 +178,22 @@ func (eid EndpointID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(eid.String())
 }
 
+// UnmarshalJSON reads an EndpointID from its JSON string representation.
+func (eid *EndpointID) UnmarshalJSON(data []byte) error {
+	var uri string
+	if err := json.Unmarshal(data, &uri); err != nil {
+		return err
+	}
+
+	tmpEid, err := NewEndpointID(uri)
+	if err != nil {
+		return err
+	}
+
+	*eid = tmpEid
+	return nil
+}
+
 // Authority is the authority part of the Endpoint URI, e.g., "foo" for "dtn://foo/bar".
 func (eid EndpointID) Authority() string {
 	return eid.EndpointType.Authority()
@@ -193,6 +209,19 @@ func (eid EndpointID) IsSingleton() bool {
 	return eid.EndpointType.IsSingleton()
 }
 
+// IsAdministrativeEndpoint checks if this Endpoint addresses a node's administrative endpoint, e.g., "ipn:23.0".
+// Bundles for such an endpoint must be handled by the node itself and not by an application agent.
+func (eid EndpointID) IsAdministrativeEndpoint() bool {
+	switch et := eid.EndpointType.(type) {
+	case IpnEndpoint:
+		return et.IsAdministrative()
+	case *IpnEndpoint:
+		return et.IsAdministrative()
+	default:
+		return false
+	}
+}
+
 // SameNode checks if two Endpoints contain to the same Node, based on the scheme and authority part.
 func (eid EndpointID) SameNode(other EndpointID) bool {
 	switch {
@@ -16,34 +16,60 @@ import (
 const (
 	ipnEndpointSchemeName string = "ipn"
 	ipnEndpointSchemeNo   uint64 = 2
+
+	// ipnAdministrativeService is the service number of a node's administrative endpoint, RFC 9171 section 4.2.5.1.2.
+	ipnAdministrativeService uint64 = 0
 )
 
-// IpnEndpoint describes the ipn URI for EndpointIDs, as defined in RFC 6260.
+// IpnEndpoint describes the ipn URI for EndpointIDs, as defined in RFC 6260 and updated by RFC 9758.
+//
+// The Node is the Fully-Qualified Node Number, whose upper 32 bits are the allocator identifier and whose lower 32 bits
+// are the node number. Thus, both the legacy two-component form "ipn:N.S" and the three-component form "ipn:A.N.S"
+// result in the same IpnEndpoint for the same node.
 type IpnEndpoint struct {
 	Node    uint64
 	Service uint64
 }
 
-// NewIpnEndpoint from an URI with the ipn scheme.
+// NewIpnEndpoint from an URI with the ipn scheme, either in the two- or three-component form.
 func NewIpnEndpoint(uri string) (e EndpointType, err error) {
 	// As defined in RFC 6260, section 2.1:
 	// - node number: ASCII numeric digits between 1 and (2^64-1)
 	// - an ASCII dot
 	// - service number: ASCII numeric digits between 1 and (2^64-1)
+	//
+	// Additionally, RFC 9171 reserves the service number 0 for the node's administrative endpoint.
+	//
+	// RFC 9758 prefixes the node number by an allocator identifier and an ASCII dot. Both the allocator identifier
+	// and the node number are then limited to (2^32-1).
 
-	re := regexp.MustCompile("^" + ipnEndpointSchemeName + ":(\\d+)\\.(\\d+)$")
+	re := regexp.MustCompile("^" + ipnEndpointSchemeName + ":(\\d+)\\.(\\d+)(?:\\.(\\d+))?$")
 	matches := re.FindStringSubmatch(uri)
-	if len(matches) != 3 {
+	if len(matches) != 4 {
 		err = fmt.Errorf("uri does not match an ipn endpoint")
 		return
 	}
 
 	var node, service uint64
-	if node, err = strconv.ParseUint(matches[1], 10, 64); err != nil {
-		return
-	}
-	if service, err = strconv.ParseUint(matches[2], 10, 64); err != nil {
-		return
+	if matches[3] == "" {
+		if node, err = strconv.ParseUint(matches[1], 10, 64); err != nil {
+			return
+		}
+		if service, err = strconv.ParseUint(matches[2], 10, 64); err != nil {
+			return
+		}
+	} else {
+		var allocator, nodeNo uint64
+		if allocator, err = strconv.ParseUint(matches[1], 10, 32); err != nil {
+			return
+		}
+		if nodeNo, err = strconv.ParseUint(matches[2], 10, 32); err != nil {
+			return
+		}
+		if service, err = strconv.ParseUint(matches[3], 10, 64); err != nil {
+			return
+		}
+		node = allocator<<32 | nodeNo
 	}
 
 	e = IpnEndpoint{node, service}
@@ -52,6 +78,18 @@ func NewIpnEndpoint(uri string) (e EndpointType, err error) {
 	return
 }
 
+// Allocator is the allocator identifier, the upper 32 bits of the Fully-Qualified Node Number. It is zero for node
+// numbers of the legacy two-component form.
+func (e IpnEndpoint) Allocator() uint64 {
+	return e.Node >> 32
+}
+
+// NodeNumber is the node number within the Allocator's namespace, the lower 32 bits of the Fully-Qualified Node
+// Number.
+func (e IpnEndpoint) NodeNumber() uint64 {
+	return e.Node & 0xFFFFFFFF
+}
+
 // SchemeName is "ipn" for IpnEndpoints.
 func (e IpnEndpoint) SchemeName() string {
 	return ipnEndpointSchemeName
@@ -62,9 +100,13 @@ func (e IpnEndpoint) SchemeNo() uint64 {
 	return ipnEndpointSchemeNo
 }
 
-// Authority is the authority part of the Endpoint URI, e.g., "23" for "ipn:23.42".
+// Authority is the authority part of the Endpoint URI, e.g., "23" for "ipn:23.42" or "977000.23" for
+// "ipn:977000.23.42". It is the same for both the two- and three-component form of the same node.
 func (e IpnEndpoint) Authority() string {
-	return fmt.Sprintf("%d", e.Node)
+	if e.Allocator() == 0 {
+		return fmt.Sprintf("%d", e.Node)
+	}
+	return fmt.Sprintf("%d.%d", e.Allocator(), e.NodeNumber())
 }
 
 // Path is the path part of the Endpoint URI, e.g., "42" for "ipn:23.42".
@@ -79,26 +121,38 @@ func (_ IpnEndpoint) IsSingleton() bool {
 	return true
 }
 
+// IsAdministrative checks if this Endpoint is a node's administrative endpoint, e.g., "ipn:23.0".
+func (e IpnEndpoint) IsAdministrative() bool {
+	return e.Service == ipnAdministrativeService
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (e IpnEndpoint) CheckValid() error {
-	if e.Node < 1 || e.Service < 1 {
-		return fmt.Errorf("ipn's node and Service number must be >= 1")
+	if e.Node < 1 {
+		return fmt.Errorf("ipn's node number must be >= 1")
 	}
 
 	return nil
 }
 
+// String representation of this IpnEndpoint, using the three-component form for a non-zero Allocator.
 func (e IpnEndpoint) String() string {
-	return fmt.Sprintf("%s:%d.%d", ipnEndpointSchemeName, e.Node, e.Service)
+	return fmt.Sprintf("%s:%s.%d", ipnEndpointSchemeName, e.Authority(), e.Service)
 }
 
-// MarshalCbor writes this IpnEndpoint's CBOR representation.
+// MarshalCbor writes this IpnEndpoint's CBOR representation. For a non-zero Allocator, this is the three-element form
+// of RFC 9758. Otherwise, the legacy two-element form is used, which is understood by all implementations.
 func (e IpnEndpoint) MarshalCbor(w io.Writer) error {
-	if err := cboring.WriteArrayLength(2, w); err != nil {
+	elements := []uint64{e.Node, e.Service}
+	if e.Allocator() != 0 {
+		elements = []uint64{e.Allocator(), e.NodeNumber(), e.Service}
+	}
+
+	if err := cboring.WriteArrayLength(uint64(len(elements)), w); err != nil {
 		return err
 	}
 
-	for _, n := range []uint64{e.Node, e.Service} {
+	for _, n := range elements {
 		if err := cboring.WriteUInt(n, w); err != nil {
 			return err
 		}
@@ -107,21 +161,30 @@ func (e IpnEndpoint) MarshalCbor(w io.Writer) error {
 	return nil
 }
 
-// UnmarshalCbor reads a CBOR representation for an IpnEndpoint.
+// UnmarshalCbor reads a CBOR representation for an IpnEndpoint, either in the two- or three-element form.
 func (e *IpnEndpoint) UnmarshalCbor(r io.Reader) error {
-	if n, err := cboring.ReadArrayLength(r); err != nil {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
 		return err
-	} else if n != 2 {
-		return fmt.Errorf("ipn uri expected array of 2 elements, not %d", n)
+	} else if n != 2 && n != 3 {
+		return fmt.Errorf("ipn uri expected array of 2 or 3 elements, not %d", n)
 	}
 
-	for _, n := range []*uint64{&e.Node, &e.Service} {
-		if i, err := cboring.ReadUInt(r); err != nil {
+	elements := make([]uint64, n)
+	for i := range elements {
+		if elements[i], err = cboring.ReadUInt(r); err != nil {
 			return err
-		} else {
-			*n = i
 		}
 	}
 
+	if n == 2 {
+		e.Node, e.Service = elements[0], elements[1]
+		return nil
+	}
+
+	if elements[0] > 0xFFFFFFFF || elements[1] > 0xFFFFFFFF {
+		return fmt.Errorf("ipn uri's allocator %d or node number %d exceeds 32 bits", elements[0], elements[1])
+	}
+	e.Node, e.Service = elements[0]<<32|elements[1], elements[2]
 	return nil
 }
@@ -20,9 +20,16 @@ func TestNewIpnEndpoint(t *testing.T) {
 		{"ipn:1.1", 1, 1, true},
 		{"ipn:23.42", 23, 42, true},
 		{"ipn:0.1", 0, 0, false},
-		{"ipn:1.0", 0, 0, false},
+		{"ipn:1.0", 1, 0, true},
 		{"ipn:99999999999999999999.1", 0, 0, false},
 		{"ipn:11", 0, 0, false},
+		{"ipn:977000.23.42", 977000<<32 | 23, 42, true},
+		{"ipn:0.23.42", 23, 42, true},
+		{"ipn:1.0.42", 1 << 32, 42, true},
+		{"ipn:0.0.42", 0, 0, false},
+		{"ipn:4294967296.1.1", 0, 0, false},
+		{"ipn:1.4294967296.1", 0, 0, false},
+		{"ipn:1.2.3.4", 0, 0, false},
 		{"ipn1.1", 0, 0, false},
 		{"uff:1.1", 0, 0, false},
 		{"", 0, 0, false},
@@ -49,6 +56,8 @@ func TestIpnEndpointCbor(t *testing.T) {
 	}{
 		{IpnEndpoint{1, 1}, []byte{0x82, 0x01, 0x01}},
 		{IpnEndpoint{23, 42}, []byte{0x82, 0x17, 0x18, 0x2A}},
+		{IpnEndpoint{23, 0}, []byte{0x82, 0x17, 0x00}},
+		{IpnEndpoint{2<<32 | 23, 42}, []byte{0x83, 0x02, 0x17, 0x18, 0x2A}},
 	}
 
 	for _, test := range tests {
@@ -74,3 +83,70 @@ func TestIpnEndpointCbor(t *testing.T) {
 		}
 	}
 }
+
+func TestIpnEndpointAdministrative(t *testing.T) {
+	tests := []struct {
+		uri   string
+		admin bool
+	}{
+		{"ipn:23.0", true},
+		{"ipn:23.42", false},
+		{"dtn://foo/", false},
+		{"dtn:none", false},
+	}
+
+	for _, test := range tests {
+		if admin := MustNewEndpointID(test.uri).IsAdministrativeEndpoint(); admin != test.admin {
+			t.Fatalf("%s: expected administrative %t, got %t", test.uri, test.admin, admin)
+		}
+	}
+}
+
+func TestIpnEndpointLegacyCbor(t *testing.T) {
+	tests := []struct {
+		data  []byte
+		ep    IpnEndpoint
+		valid bool
+	}{
+		// Two-element form with a Fully-Qualified Node Number of allocator 2 and node number 23
+		{[]byte{0x82, 0x1B, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x17, 0x18, 0x2A}, IpnEndpoint{2<<32 | 23, 42}, true},
+		// Three-element form of allocator 0
+		{[]byte{0x83, 0x00, 0x17, 0x18, 0x2A}, IpnEndpoint{23, 42}, true},
+		// Three-element form with a node number exceeding 32 bits
+		{[]byte{0x83, 0x02, 0x1B, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x18, 0x2A}, IpnEndpoint{}, false},
+		{[]byte{0x84, 0x00, 0x02, 0x17, 0x18, 0x2A}, IpnEndpoint{}, false},
+	}
+
+	for _, test := range tests {
+		var ep IpnEndpoint
+		if err := ep.UnmarshalCbor(bytes.NewReader(test.data)); (err == nil) != test.valid {
+			t.Fatalf("%x: expected valid = %t, got err: %v", test.data, test.valid, err)
+		} else if test.valid && ep != test.ep {
+			t.Fatalf("%x: expected %v, got %v", test.data, test.ep, ep)
+		}
+	}
+}
+
+func TestIpnEndpointAuthority(t *testing.T) {
+	tests := []struct {
+		uris      []string
+		authority string
+		str       string
+	}{
+		{[]string{"ipn:23.42", "ipn:0.23.42"}, "23", "ipn:23.42"},
+		{[]string{"ipn:977000.23.42", "ipn:4196183048192023.42"}, "977000.23", "ipn:977000.23.42"},
+	}
+
+	for _, test := range tests {
+		for _, uri := range test.uris {
+			eid := MustNewEndpointID(uri)
+			if authority := eid.Authority(); authority != test.authority {
+				t.Fatalf("%s: expected authority %s, got %s", uri, test.authority, authority)
+			} else if str := eid.String(); str != test.str {
+				t.Fatalf("%s: expected string %s, got %s", uri, test.str, str)
+			} else if !eid.SameNode(MustNewEndpointID(test.uris[0])) {
+				t.Fatalf("%s is not the same node as %s", uri, test.uris[0])
+			}
+		}
+	}
+}
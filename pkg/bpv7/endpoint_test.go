@@ -38,7 +38,7 @@ func TestEndpointCheckValid(t *testing.T) {
 		{EndpointID{&DtnEndpoint{IsDtnNone: true}}, true},
 		{EndpointID{&IpnEndpoint{0, 0}}, false},
 		{EndpointID{&IpnEndpoint{0, 1}}, false},
-		{EndpointID{&IpnEndpoint{1, 0}}, false},
+		{EndpointID{&IpnEndpoint{1, 0}}, true},
 		{EndpointID{&IpnEndpoint{1, 1}}, true},
 	}
 
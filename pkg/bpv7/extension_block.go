@@ -48,6 +48,27 @@ const (
 
 	// ExtBlockTypeSignatureBlock is the custom block type code for a SignatureBlock, bpv7/extension_block_signature.go
 	ExtBlockTypeSignatureBlock uint64 = 195
+
+	// ExtBlockTypePayloadDigestBlock is the custom block type code for a PayloadDigestBlock, bpv7/extension_block_payload_digest.go
+	ExtBlockTypePayloadDigestBlock uint64 = 196
+
+	// ExtBlockTypeIdentityAssertionBlock is the custom block type code for an IdentityAssertionBlock,
+	// bpv7/extension_block_identity_assertion.go
+	ExtBlockTypeIdentityAssertionBlock uint64 = 197
+
+	// ExtBlockTypeCostMetricBlock is the custom block type code for a CostMetricBlock, bpv7/extension_block_cost_metric.go
+	ExtBlockTypeCostMetricBlock uint64 = 198
+
+	// ExtBlockTypeSummaryVectorBlock is the custom block type code for a SummaryVectorBlock,
+	// bpv7/extension_block_summary_vector.go
+	ExtBlockTypeSummaryVectorBlock uint64 = 199
+
+	// ExtBlockTypeCompressionBlock is the custom block type code for a CompressionBlock,
+	// bpv7/extension_block_compression.go
+	ExtBlockTypeCompressionBlock uint64 = 200
+
+	// ExtBlockTypeTraceBlock is the custom block type code for a TraceBlock, bpv7/extension_block_trace.go
+	ExtBlockTypeTraceBlock uint64 = 201
 )
 
 // ExtensionBlock describes the block-type specific data of any Canonical Block.
@@ -194,7 +215,8 @@ var (
 
 // GetExtensionBlockManager returns the singleton ExtensionBlockManager. If none
 // exists, a new ExtensionBlockManager will be generated with a knowledge of the
-// PayloadBlock, PreviousNodeBlock, BundleAgeBlock and HopCountBlock.
+// PayloadBlock, PreviousNodeBlock, BundleAgeBlock, HopCountBlock, the BPSec blocks, the PayloadDigestBlock and the
+// IdentityAssertionBlock.
 func GetExtensionBlockManager() *ExtensionBlockManager {
 	extensionBlockManagerMutex.Lock()
 	defer extensionBlockManagerMutex.Unlock()
@@ -208,6 +230,12 @@ func GetExtensionBlockManager() *ExtensionBlockManager {
 		_ = extensionBlockManager.Register(NewHopCountBlock(0))
 		_ = extensionBlockManager.Register(new(BIBIOPHMACSHA2))
 		_ = extensionBlockManager.Register(new(BCBIOPAESGCM))
+		_ = extensionBlockManager.Register(new(PayloadDigestBlock))
+		_ = extensionBlockManager.Register(new(IdentityAssertionBlock))
+		_ = extensionBlockManager.Register(new(CostMetricBlock))
+		_ = extensionBlockManager.Register(new(SummaryVectorBlock))
+		_ = extensionBlockManager.Register(new(CompressionBlock))
+		_ = extensionBlockManager.Register(new(TraceBlock))
 	}
 
 	return extensionBlockManager
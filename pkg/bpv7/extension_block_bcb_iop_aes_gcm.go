@@ -77,6 +77,11 @@ func (bcb *BCBIOPAESGCM) UnmarshalCbor(r io.Reader) error {
 	return bcb.Asb.UnmarshalCbor(r)
 }
 
+// SecurityTargets returns the block numbers of this BCB's targets.
+func (bcb *BCBIOPAESGCM) SecurityTargets() []uint64 {
+	return bcb.Asb.SecurityTargets
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (bcb *BCBIOPAESGCM) CheckValid() error {
 	if err := bcb.Asb.CheckValid(); err != nil {
@@ -142,18 +147,49 @@ func NewBCBIOPAESGCM(aesVariant *uint64, wrappedKey *[]byte, AADScopeFlags *uint
 
 }
 
-// extractPlainText extracts the plaintext used during encryption according to RFC9173 4.7.1
+// extractPlainText extracts the plaintext used during encryption according to RFC9173 4.7.1, the security target's
+// block-type-specific data without its enclosing CBOR byte string. After encryption, this yields the ciphertext.
 func (bcb *BCBIOPAESGCM) extractPlainText(securityTargetBlock *CanonicalBlock) (plainText *bytes.Buffer, err error) {
-	// 1. Create a buffer for the plaintext
-	plainText = new(bytes.Buffer)
+	// 1. Serialize the block-type-specific data, as for the BIB's IPPT
+	blockData := new(bytes.Buffer)
+	if err = GetExtensionBlockManager().WriteBlock(securityTargetBlock.Value, blockData); err != nil {
+		return nil, err
+	}
 
-	// 2. Get Plaintext from Payloadblock
-	payLoadBlock := securityTargetBlock.Value.(*PayloadBlock)
-	_, err = plainText.Write(payLoadBlock.Data())
+	// 2. Strip the enclosing byte string
+	data, err := cboring.ReadByteString(blockData)
 	if err != nil {
 		return nil, err
 	}
-	return
+	return bytes.NewBuffer(data), nil
+}
+
+// checkSecurityTarget checks if a block might be a BCB security target, RFC 9172 3.9.
+func checkSecurityTarget(securityTargetBlock *CanonicalBlock) error {
+	switch code := securityTargetBlock.TypeCode(); code {
+	case ExtBlockTypeBlockIntegrityBlock, ExtBlockTypeBlockConfidentialityBlock:
+		return fmt.Errorf("unsupported security target block type code %d, %s", code, securityTargetBlock.Value.BlockTypeName())
+	default:
+		return nil
+	}
+}
+
+// cipherTextBlock holds an encrypted security target's block-type-specific data. The payload block stays a
+// PayloadBlock, while any other block becomes a GenericExtensionBlock of its block type code until decrypted.
+func cipherTextBlock(typeCode uint64, cipherText []byte) ExtensionBlock {
+	if typeCode == ExtBlockTypePayloadBlock {
+		return NewPayloadBlock(cipherText)
+	}
+	return NewGenericExtensionBlock(cipherText, typeCode)
+}
+
+// plainTextBlock restores a decrypted security target's ExtensionBlock through the ExtensionBlockManager.
+func plainTextBlock(typeCode uint64, plainText []byte) (ExtensionBlock, error) {
+	blockData := new(bytes.Buffer)
+	if err := cboring.WriteByteString(plainText, blockData); err != nil {
+		return nil, err
+	}
+	return GetExtensionBlockManager().ReadBlock(typeCode, blockData)
 }
 
 // prepareAAD constructs the "Additional Authenticated Data" using the process defined in RFC9173 4.7.2
@@ -240,20 +276,6 @@ func (bcb *BCBIOPAESGCM) prepareAAD(b Bundle, securityTargetBlock *CanonicalBloc
 // computeAuthenticationTagAndCipherText computes the results of the BCB-IOP-AES-GCM security operation for the BCP Security Target, depending on the AESVariant SecurityContextParameter
 func (bcb *BCBIOPAESGCM) computeAuthenticationTagAndCipherText(plainText *bytes.Buffer, aad *bytes.Buffer, privateKey []byte) (cipherText []byte, authenticationTag []byte, err error) {
 
-	// Check if WrappedKey is present and throw unimplemented error if not.
-	wrappedKey := func() *[]byte {
-		for _, scp := range bcb.Asb.SecurityContextParameters {
-			if scp.ID() == SecParIdBCBIOPAESGCMWrappedKey {
-				scpValue := scp.Value().([]byte)
-				return &scpValue
-			}
-		}
-		return nil
-	}()
-	if wrappedKey != nil {
-		return nil, nil, fmt.Errorf("wrapped key not implemented")
-	}
-
 	err = checkKeyLengthAgainstAESVariantParameter(bcb, privateKey)
 	if err != nil {
 		return nil, nil, err
@@ -291,6 +313,7 @@ func (bcb *BCBIOPAESGCM) computeAuthenticationTagAndCipherText(plainText *bytes.
 
 		// Add the IV to the Security Context Parameters
 		bcb.Asb.SecurityContextParameters = append(bcb.Asb.SecurityContextParameters, &IDValueTupleByteString{SecParIdBCBIOPAESGCMIV, iv})
+		bcb.Asb.SecurityContextParametersPresentFlag = 1
 
 	}
 
@@ -304,6 +327,71 @@ func (bcb *BCBIOPAESGCM) computeAuthenticationTagAndCipherText(plainText *bytes.
 	return
 }
 
+// wrappedKeyParameter returns the WrappedKey SecurityContextParameter's value, or nil if not present.
+func (bcb *BCBIOPAESGCM) wrappedKeyParameter() []byte {
+	for _, scp := range bcb.Asb.SecurityContextParameters {
+		if scp.ID() == SecParIdBCBIOPAESGCMWrappedKey {
+			return scp.Value().([]byte)
+		}
+	}
+	return nil
+}
+
+// contentEncryptionKey returns the key used for AES-GCM according to RFC 9173 4.3.3. If a WrappedKey
+// SecurityContextParameter is present, it is unwrapped by AES Key Wrap (RFC 3394) for the key-encryption-key.
+// Otherwise, the privateKey itself is used.
+func (bcb *BCBIOPAESGCM) contentEncryptionKey(privateKey []byte, keyEncryptionKey []byte) ([]byte, error) {
+	wrappedKey := bcb.wrappedKeyParameter()
+	if wrappedKey == nil {
+		return privateKey, nil
+	}
+
+	if keyEncryptionKey == nil {
+		return nil, fmt.Errorf("wrapped key is present, but no key-encryption-key was supplied")
+	}
+	return aesKeyUnwrap(keyEncryptionKey, wrappedKey)
+}
+
+// wrapContentEncryptionKey wraps the privateKey for the key-encryption-key and adds the result as a WrappedKey
+// SecurityContextParameter. If privateKey is nil, a random key fitting the AESVariant SecurityContextParameter is
+// generated. The key to be used for AES-GCM is returned.
+func (bcb *BCBIOPAESGCM) wrapContentEncryptionKey(privateKey []byte, keyEncryptionKey []byte) ([]byte, error) {
+	if privateKey == nil {
+		privateKey = make([]byte, 32)
+		for _, scp := range bcb.Asb.SecurityContextParameters {
+			if scp.ID() == SecParIdBCBIOPAESGCMAESVariant && scp.Value().(uint64) == A128GCM {
+				privateKey = make([]byte, 16)
+			}
+		}
+
+		if _, err := io.ReadFull(rand.Reader, privateKey); err != nil {
+			return nil, err
+		}
+	}
+
+	wrappedKey, err := aesKeyWrap(keyEncryptionKey, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	bcb.Asb.SecurityContextParameters = append(bcb.Asb.SecurityContextParameters, &IDValueTupleByteString{SecParIdBCBIOPAESGCMWrappedKey, wrappedKey})
+	bcb.Asb.SecurityContextParametersPresentFlag = 1
+
+	return privateKey, nil
+}
+
+// optionalKeyEncryptionKey returns the single optional key-encryption-key or nil.
+func optionalKeyEncryptionKey(keyEncryptionKey [][]byte) ([]byte, error) {
+	switch len(keyEncryptionKey) {
+	case 0:
+		return nil, nil
+	case 1:
+		return keyEncryptionKey[0], nil
+	default:
+		return nil, fmt.Errorf("expected at most one key-encryption-key, got %d", len(keyEncryptionKey))
+	}
+}
+
 // checkKeyLengthAgainstAESVariantParameter checks if the key length is valid for the AESVariant SecurityContextParameter
 func checkKeyLengthAgainstAESVariantParameter(bcb *BCBIOPAESGCM, privateKey []byte) (err error) {
 	// Get AES variant
@@ -333,16 +421,35 @@ func checkKeyLengthAgainstAESVariantParameter(bcb *BCBIOPAESGCM, privateKey []by
 	return nil
 }
 
-// EncryptTarget encrypts the target block using the BCB-IOP-AES-GCM security operation.
-func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateKey []byte) (err error) {
+// EncryptTarget encrypts the target block using the BCB-IOP-AES-GCM security operation. Any block but another BPSec
+// block might be the target. Except for the payload block, the encrypted target becomes a GenericExtensionBlock of the
+// same block type code until decrypted by DecryptTarget.
+//
+// An optional key-encryption-key enables AES Key Wrap. If the block already has a WrappedKey SecurityContextParameter,
+// it is unwrapped and used for encryption. Otherwise, the privateKey - or a random key, if privateKey is nil - is
+// wrapped and added as a WrappedKey SecurityContextParameter. Thus, the recipient only needs the key-encryption-key.
+func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateKey []byte, keyEncryptionKey ...[]byte) (err error) {
+	kek, err := optionalKeyEncryptionKey(keyEncryptionKey)
+	if err != nil {
+		return err
+	}
 
-	// Check if the target is a supported payload block
+	// Check if the target is a supported block
 	securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(bcb.Asb.SecurityTargets[0])
 	if err != nil {
 		return err
 	}
-	if securityTargetBlock.Value.BlockTypeCode() != ExtBlockTypePayloadBlock {
-		return fmt.Errorf("unsupported security target block type code %d, %s", securityTargetBlock.Value.BlockTypeCode(), securityTargetBlock.Value.BlockTypeName())
+	if err = checkSecurityTarget(securityTargetBlock); err != nil {
+		return err
+	}
+
+	if kek != nil && bcb.wrappedKeyParameter() == nil {
+		privateKey, err = bcb.wrapContentEncryptionKey(privateKey, kek)
+	} else {
+		privateKey, err = bcb.contentEncryptionKey(privateKey, kek)
+	}
+	if err != nil {
+		return err
 	}
 
 	// Remove CRC if present
@@ -369,8 +476,8 @@ func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateK
 		return err
 	}
 
-	// Set the cipherText as the payload
-	securityTargetBlock.Value = NewPayloadBlock(cipherText)
+	// Set the cipherText as the block-type-specific data
+	securityTargetBlock.Value = cipherTextBlock(securityTargetBlock.TypeCode(), cipherText)
 
 	// Set the authenticationTag as security result
 	bcb.Asb.SecurityResults[0].results = append(bcb.Asb.SecurityResults[0].results, &IDValueTupleByteString{
@@ -382,17 +489,29 @@ func (bcb *BCBIOPAESGCM) EncryptTarget(b Bundle, bcbBlockNumber uint64, privateK
 
 }
 
-// DecryptTarget decrypts the payload of the security target block and verifies the authentication tag
-func (bcb *BCBIOPAESGCM) DecryptTarget(b Bundle, bcbBlockNumber uint64, privateKey []byte) (err error) {
+// DecryptTarget decrypts the security target block and verifies the authentication tag. The decrypted
+// block-type-specific data is restored as its ExtensionBlock through the ExtensionBlockManager.
+//
+// If the block has a WrappedKey SecurityContextParameter, the key-encryption-key must be supplied to unwrap the
+// content-encryption-key. In this case, the privateKey is ignored and might be nil.
+func (bcb *BCBIOPAESGCM) DecryptTarget(b Bundle, bcbBlockNumber uint64, privateKey []byte, keyEncryptionKey ...[]byte) (err error) {
+	kek, err := optionalKeyEncryptionKey(keyEncryptionKey)
+	if err != nil {
+		return err
+	}
 
-	// Check if the target is a supported payload block
-	securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(bcb.Asb.SecurityTargets[0])
+	privateKey, err = bcb.contentEncryptionKey(privateKey, kek)
 	if err != nil {
 		return err
 	}
 
-	if securityTargetBlock.Value.BlockTypeCode() != ExtBlockTypePayloadBlock {
-		return fmt.Errorf("unsupported security target block type code %d, %s", securityTargetBlock.Value.BlockTypeCode(), securityTargetBlock.Value.BlockTypeName())
+	// Check if the target is a supported block
+	securityTargetBlock, err := b.GetExtensionBlockByBlockNumber(bcb.Asb.SecurityTargets[0])
+	if err != nil {
+		return err
+	}
+	if err = checkSecurityTarget(securityTargetBlock); err != nil {
+		return err
 	}
 
 	// Decrypt and Authenticate
@@ -401,8 +520,12 @@ func (bcb *BCBIOPAESGCM) DecryptTarget(b Bundle, bcbBlockNumber uint64, privateK
 		return err
 	}
 
-	// Set the plainText as the payload
-	securityTargetBlock.Value = NewPayloadBlock(plainText)
+	// Restore the plainText as the block's value
+	value, err := plainTextBlock(securityTargetBlock.TypeCode(), plainText)
+	if err != nil {
+		return err
+	}
+	securityTargetBlock.Value = value
 
 	// Set CRC
 	securityTargetBlock.CRCType = CRC32
@@ -446,7 +569,11 @@ func (bcb *BCBIOPAESGCM) decryptAndAuthenticate(b Bundle, targetBlock *Canonical
 	}
 
 	// Get the cipherText
-	cipherText := targetBlock.Value.(*PayloadBlock).Data()
+	cipherTextBuff, err := bcb.extractPlainText(targetBlock)
+	if err != nil {
+		return nil, err
+	}
+	cipherText := cipherTextBuff.Bytes()
 
 	// Prepare the AAD
 	aad, err := bcb.prepareAAD(b, targetBlock, number)
@@ -99,6 +99,42 @@ func TestBCBBlockCbor(t *testing.T) {
 	tests := []struct {
 		bcb1 BCBIOPAESGCM
 	}{
+		{
+			// All defined Security Context Parameters are set.
+			bcb1: BCBIOPAESGCM{
+				Asb: AbstractSecurityBlock{
+					SecurityTargets:                      []uint64{1},
+					SecurityContextID:                    SecConIdentBCBIOPAESGCM,
+					SecurityContextParametersPresentFlag: 0x1,
+					SecuritySource:                       ep,
+					SecurityContextParameters: []IDValueTuple{
+						&IDValueTupleByteString{
+							id:    SecParIdBCBIOPAESGCMIV,
+							value: []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+						},
+						&IDValueTupleUInt64{
+							id:    SecParIdBCBIOPAESGCMAESVariant,
+							value: aesVariant,
+						},
+						&IDValueTupleByteString{
+							id:    SecParIdBCBIOPAESGCMWrappedKey,
+							value: []byte{37, 35, 92, 90, 54},
+						},
+						&IDValueTupleUInt64{
+							id:    SecParIdBCBIOPAESGCMAADScopeFlags,
+							value: uint64(DefaultAADScopeFlags),
+						},
+					},
+					SecurityResults: []TargetSecurityResults{{
+						securityTarget: 1,
+						results: []IDValueTuple{&IDValueTupleByteString{
+							id:    SecConResultIDBCBIOPAESGCMAuthenticationTag,
+							value: []byte{37, 35, 92, 90, 54, 37, 35, 92, 90, 54},
+						}},
+					}},
+				},
+			},
+		},
 		{
 			bcb1: BCBIOPAESGCM{
 				Asb: AbstractSecurityBlock{
@@ -147,3 +183,291 @@ func TestBCBBlockCbor(t *testing.T) {
 		}
 	}
 }
+
+func TestBCBIOPAESGCMEmptyPayload(t *testing.T) {
+	b, bErr := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(30 * time.Minute).
+		PayloadBlock([]byte{}).
+		Build()
+	if bErr != nil {
+		t.Fatal(bErr)
+	}
+
+	privateKey := []byte("dtnislovedtnislovedtnislovedtnis")
+	payloadSecurityTarget, _ := b.ExtensionBlock(ExtBlockTypePayloadBlock)
+	aesVariant := A256GCM
+
+	bcb := NewBCBIOPAESGCM(&aesVariant, nil, nil, payloadSecurityTarget.BlockNumber, b.PrimaryBlock.SourceNode)
+	if err := b.AddExtensionBlock(CanonicalBlock{Value: bcb}); err != nil {
+		t.Fatal(err)
+	}
+
+	bcbBlock, _ := b.ExtensionBlock(bcb.BlockTypeCode())
+	if err := bcbBlock.Value.(*BCBIOPAESGCM).EncryptTarget(b, bcbBlock.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// The authentication tag is a security result; thus, an empty plaintext results in an empty ciphertext.
+	if pb, _ := b.PayloadBlock(); len(pb.Value.(*PayloadBlock).Data()) != 0 {
+		t.Fatalf("Encrypted empty payload is not empty: %x", pb.Value.(*PayloadBlock).Data())
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&b, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	b2 := Bundle{}
+	if err := cboring.Unmarshal(&b2, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	bcbBlock2, _ := b2.ExtensionBlock(bcb.BlockTypeCode())
+	if err := bcbBlock2.Value.(*BCBIOPAESGCM).DecryptTarget(b2, bcbBlock2.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if pb, _ := b2.PayloadBlock(); len(pb.Value.(*PayloadBlock).Data()) != 0 {
+		t.Fatalf("Decrypted payload is not empty: %x", pb.Value.(*PayloadBlock).Data())
+	}
+}
+
+func TestBCBIOPAESGCMWrappedKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		aesVariant uint64
+		privateKey []byte
+	}{
+		{"A128GCM random key", A128GCM, nil},
+		{"A256GCM random key", A256GCM, nil},
+		{"A256GCM given key", A256GCM, []byte("dtnislovedtnislovedtnislovedtnis")},
+	}
+
+	kek := bytes.Repeat([]byte{0x42}, 16)
+	payload := []byte("hello world!")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := Builder().
+				CRC(CRC32).
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime(30 * time.Minute).
+				PayloadBlock(payload).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			payloadSecurityTarget, _ := b.ExtensionBlock(ExtBlockTypePayloadBlock)
+			bcb := NewBCBIOPAESGCM(&test.aesVariant, nil, nil, payloadSecurityTarget.BlockNumber, b.PrimaryBlock.SourceNode)
+			if err := b.AddExtensionBlock(CanonicalBlock{Value: bcb}); err != nil {
+				t.Fatal(err)
+			}
+
+			bcbBlock, _ := b.ExtensionBlock(bcb.BlockTypeCode())
+			if err := bcbBlock.Value.(*BCBIOPAESGCM).EncryptTarget(b, bcbBlock.BlockNumber, test.privateKey, kek); err != nil {
+				t.Fatal(err)
+			}
+
+			if bcbBlock.Value.(*BCBIOPAESGCM).wrappedKeyParameter() == nil {
+				t.Fatal("WrappedKey Security Context Parameter is missing")
+			}
+
+			buff := new(bytes.Buffer)
+			if err := cboring.Marshal(&b, buff); err != nil {
+				t.Fatal(err)
+			}
+
+			b2 := Bundle{}
+			if err := cboring.Unmarshal(&b2, buff); err != nil {
+				t.Fatal(err)
+			}
+
+			bcbBlock2, _ := b2.ExtensionBlock(bcb.BlockTypeCode())
+			bcb2 := bcbBlock2.Value.(*BCBIOPAESGCM)
+
+			if err := bcb2.DecryptTarget(b2, bcbBlock2.BlockNumber, nil); err == nil {
+				t.Fatal("decrypting without a KEK succeeded")
+			}
+			if err := bcb2.DecryptTarget(b2, bcbBlock2.BlockNumber, nil, bytes.Repeat([]byte{0x23}, 16)); err == nil {
+				t.Fatal("decrypting with a wrong KEK succeeded")
+			}
+			if err := bcb2.DecryptTarget(b2, bcbBlock2.BlockNumber, nil, kek); err != nil {
+				t.Fatal(err)
+			}
+
+			if pb, _ := b2.PayloadBlock(); !bytes.Equal(pb.Value.(*PayloadBlock).Data(), payload) {
+				t.Fatalf("decrypted payload %q differs from %q", pb.Value.(*PayloadBlock).Data(), payload)
+			}
+		})
+	}
+}
+
+func TestBCBIOPAESGCMExtensionBlockTarget(t *testing.T) {
+	tests := []struct {
+		name  string
+		value ExtensionBlock
+	}{
+		{"previous node block", NewPreviousNodeBlock(MustNewEndpointID("dtn://prev/"))},
+		{"hop count block", NewHopCountBlock(23)},
+		{"cost metric block", &CostMetricBlock{CostMetricBytes, 42}},
+	}
+
+	privateKey := []byte("dtnislovedtnislovedtnislovedtnis")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := Builder().
+				CRC(CRC32).
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime(30 * time.Minute).
+				Canonical(test.value).
+				PayloadBlock([]byte("hello world!")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			target, err := b.ExtensionBlock(test.value.BlockTypeCode())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bcb := NewBCBIOPAESGCM(nil, nil, nil, target.BlockNumber, b.PrimaryBlock.SourceNode)
+			if err := b.AddExtensionBlock(CanonicalBlock{Value: bcb}); err != nil {
+				t.Fatal(err)
+			}
+
+			bcbBlock, _ := b.ExtensionBlock(bcb.BlockTypeCode())
+			if err := bcbBlock.Value.(*BCBIOPAESGCM).EncryptTarget(b, bcbBlock.BlockNumber, privateKey); err != nil {
+				t.Fatal(err)
+			}
+
+			buff := new(bytes.Buffer)
+			if err := cboring.Marshal(&b, buff); err != nil {
+				t.Fatal(err)
+			}
+
+			b2 := Bundle{}
+			if err := cboring.Unmarshal(&b2, buff); err != nil {
+				t.Fatal(err)
+			}
+
+			target2, err := b2.GetExtensionBlockByBlockNumber(target.BlockNumber)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := target2.Value.(*GenericExtensionBlock); !ok {
+				t.Fatalf("encrypted target is a %T", target2.Value)
+			}
+
+			bcbBlock2, _ := b2.ExtensionBlock(bcb.BlockTypeCode())
+			if err := bcbBlock2.Value.(*BCBIOPAESGCM).DecryptTarget(b2, bcbBlock2.BlockNumber, privateKey); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(target2.Value, test.value) {
+				t.Fatalf("decrypted target %v differs from %v", target2.Value, test.value)
+			}
+		})
+	}
+}
+
+func TestBCBIOPAESGCMUnmarshalUntargetedGarbage(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(30 * time.Minute).
+		PayloadBlock([]byte("hello world!")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A Hop Count Block with garbage data, not being the target of a BCB, must be rejected.
+	if err := b.AddExtensionBlock(NewCanonicalBlock(0, 0, NewGenericExtensionBlock([]byte{0xFF, 0x00}, ExtBlockTypeHopCountBlock))); err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&b, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cboring.Unmarshal(&Bundle{}, buff); err == nil {
+		t.Fatal("unmarshalling an invalid Hop Count Block succeeded")
+	}
+}
+
+func TestBCBIOPAESGCMUnmarshalTargetValidData(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(30 * time.Minute).
+		BundleAgeBlock(0).
+		PayloadBlock([]byte("hello world!")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A cipher text, being valid data of its block type by chance, must not be treated as a Bundle Age Block.
+	cipherText := []byte{0x17}
+	target, err := b.ExtensionBlock(ExtBlockTypeBundleAgeBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target.Value = NewGenericExtensionBlock(cipherText, ExtBlockTypeBundleAgeBlock)
+
+	bcb := NewBCBIOPAESGCM(nil, nil, nil, target.BlockNumber, b.PrimaryBlock.SourceNode)
+	if err := b.AddExtensionBlock(NewCanonicalBlock(0, 0, bcb)); err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := b.WriteBundle(buff); err != nil {
+		t.Fatal(err)
+	}
+	data := buff.Bytes()
+
+	parsers := []struct {
+		name  string
+		parse func() (Bundle, error)
+	}{
+		{"ParseBundle", func() (Bundle, error) { return ParseBundle(bytes.NewReader(data)) }},
+		{"BundleReader", func() (Bundle, error) {
+			br, err := NewBundleReader(bytes.NewReader(data))
+			if err != nil {
+				return Bundle{}, err
+			}
+			return br.Bundle()
+		}},
+	}
+
+	for _, parser := range parsers {
+		t.Run(parser.name, func(t *testing.T) {
+			b2, err := parser.parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cb, err := b2.ExtensionBlock(ExtBlockTypeBundleAgeBlock); err != nil {
+				t.Fatal(err)
+			} else if geb, ok := cb.Value.(*GenericExtensionBlock); !ok {
+				t.Fatalf("encrypted target is a %T", cb.Value)
+			} else if !bytes.Equal(geb.data, cipherText) {
+				t.Fatalf("encrypted target's data %x differs from %x", geb.data, cipherText)
+			}
+		})
+	}
+}
@@ -74,6 +74,11 @@ func (bib *BIBIOPHMACSHA2) UnmarshalCbor(r io.Reader) error {
 	return bib.Asb.UnmarshalCbor(r)
 }
 
+// SecurityTargets returns the block numbers of this BIB's targets.
+func (bib *BIBIOPHMACSHA2) SecurityTargets() []uint64 {
+	return bib.Asb.SecurityTargets
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (bib *BIBIOPHMACSHA2) CheckValid() error {
 	if err := bib.Asb.CheckValid(); err != nil {
@@ -80,3 +80,128 @@ func TestBIBIOPHMACSHA2_VerifyTargets(t *testing.T) {
 	}
 
 }
+
+func TestBIBIOPHMACSHA2EmptyPayload(t *testing.T) {
+	b, bErr := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(30 * time.Minute).
+		PayloadBlock([]byte{}).
+		Build()
+	if bErr != nil {
+		t.Fatal(bErr)
+	}
+
+	privateKey := []byte("dtnislove")
+	payloadSecurityTarget, _ := b.ExtensionBlock(ExtBlockTypePayloadBlock)
+	shaVariant := HMAC256SHA256
+
+	bib := NewBIBIOPHMACSHA2(&shaVariant, nil, nil, []uint64{payloadSecurityTarget.BlockNumber}, b.PrimaryBlock.SourceNode)
+	if err := b.AddExtensionBlock(CanonicalBlock{Value: bib}); err != nil {
+		t.Fatal(err)
+	}
+
+	bibBlock, _ := b.ExtensionBlock(bib.BlockTypeCode())
+	if err := bibBlock.Value.(*BIBIOPHMACSHA2).SignTargets(b, bibBlock.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&b, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	b2 := Bundle{}
+	if err := cboring.Unmarshal(&b2, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	bibBlock2, _ := b2.ExtensionBlock(bib.BlockTypeCode())
+	if err := bibBlock2.Value.(*BIBIOPHMACSHA2).VerifyTargets(b2, bibBlock2.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBIBIOPHMACSHA2BlockOrder(t *testing.T) {
+	b, bErr := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime(30 * time.Minute).
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if bErr != nil {
+		t.Fatal(bErr)
+	}
+
+	hopCountBlock, _ := b.ExtensionBlock(ExtBlockTypeHopCountBlock)
+	payloadBlock, _ := b.PayloadBlock()
+	securityTargets := []uint64{hopCountBlock.BlockNumber, payloadBlock.BlockNumber}
+
+	shaVariant := HMAC256SHA256
+	bib := NewBIBIOPHMACSHA2(&shaVariant, nil, nil, securityTargets, b.PrimaryBlock.SourceNode)
+	if err := b.AddExtensionBlock(CanonicalBlock{Value: bib}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The BIB's block number is higher than the Hop Count Block's, but it must precede both of its targets.
+	if typeCode := b.CanonicalBlocks[0].TypeCode(); typeCode != ExtBlockTypeBlockIntegrityBlock {
+		t.Fatalf("first block has type code %d, not the BIB", typeCode)
+	}
+	if typeCode := b.CanonicalBlocks[len(b.CanonicalBlocks)-1].TypeCode(); typeCode != ExtBlockTypePayloadBlock {
+		t.Fatalf("last block has type code %d, not the Payload Block", typeCode)
+	}
+	if err := b.CheckValid(); err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey := []byte("dtnislove")
+	bibBlock, _ := b.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock)
+	if err := bibBlock.Value.(*BIBIOPHMACSHA2).SignTargets(b, bibBlock.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&b, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	b2 := Bundle{}
+	if err := cboring.Unmarshal(&b2, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	bibBlock2, _ := b2.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock)
+	if err := bibBlock2.Value.(*BIBIOPHMACSHA2).VerifyTargets(b2, bibBlock2.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Manually misplace the BIB after its target, as another implementation might do.
+	b2.CanonicalBlocks[0], b2.CanonicalBlocks[1] = b2.CanonicalBlocks[1], b2.CanonicalBlocks[0]
+	if err := checkSecurityBlockOrder(b2.CanonicalBlocks); err == nil {
+		t.Fatal("BIB succeeding its target was not detected")
+	}
+
+	// A received Bundle with a misplaced BIB must be accepted and reordered.
+	buff.Reset()
+	if err := cboring.Marshal(&b2, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	b3 := Bundle{}
+	if err := cboring.Unmarshal(&b3, buff); err != nil {
+		t.Fatal(err)
+	}
+
+	if typeCode := b3.CanonicalBlocks[0].TypeCode(); typeCode != ExtBlockTypeBlockIntegrityBlock {
+		t.Fatalf("first block has type code %d, not the BIB", typeCode)
+	}
+	bibBlock3, _ := b3.ExtensionBlock(ExtBlockTypeBlockIntegrityBlock)
+	if err := bibBlock3.Value.(*BIBIOPHMACSHA2).VerifyTargets(b3, bibBlock3.BlockNumber, privateKey); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,290 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+	"github.com/ulikunitz/xz"
+)
+
+// CompressionAlgorithm identifies the algorithm of a CompressionBlock.
+type CompressionAlgorithm uint64
+
+const (
+	// CompressionGzip compresses the payload by gzip, RFC 1952.
+	CompressionGzip CompressionAlgorithm = 0
+
+	// CompressionXZ compresses the payload by xz, achieving a higher compression ratio at the cost of speed.
+	CompressionXZ CompressionAlgorithm = 1
+)
+
+// compressionAlgorithms lists all known CompressionAlgorithms.
+var compressionAlgorithms = []CompressionAlgorithm{CompressionGzip, CompressionXZ}
+
+// DefaultMaxDecompressedLength is DecompressPayload's default limit of a decompressed payload's length, 64 MiB.
+const DefaultMaxDecompressedLength uint64 = 64 * 1024 * 1024
+
+// compress data by this CompressionAlgorithm.
+func (alg CompressionAlgorithm) compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+
+	switch alg {
+	case CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case CompressionXZ:
+		xzw, err := xz.NewWriter(&buf)
+		if err != nil {
+			return nil, err
+		}
+		w = xzw
+	default:
+		return nil, fmt.Errorf("unknown compression algorithm %d", alg)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	} else if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress data by this CompressionAlgorithm, expecting a result of length bytes. Decompressed data exceeding this
+// length results in an error without being read further.
+func (alg CompressionAlgorithm) decompress(data []byte, length uint64) ([]byte, error) {
+	var r io.Reader
+
+	switch alg {
+	case CompressionGzip:
+		gzr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+		r = gzr
+	case CompressionXZ:
+		xzr, err := xz.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		r = xzr
+	default:
+		return nil, fmt.Errorf("unknown compression algorithm %d", alg)
+	}
+
+	decompressed, err := io.ReadAll(io.LimitReader(r, int64(length)+1))
+	if err != nil {
+		return nil, err
+	} else if l := uint64(len(decompressed)); l != length {
+		return nil, fmt.Errorf("decompressed payload's length mismatches, expected %d bytes", length)
+	}
+	return decompressed, nil
+}
+
+func (alg CompressionAlgorithm) String() string {
+	switch alg {
+	case CompressionGzip:
+		return "gzip"
+	case CompressionXZ:
+		return "xz"
+	default:
+		return "unknown"
+	}
+}
+
+// CompressionBlock is a custom block indicating that the Bundle's payload is compressed, e.g., for low-bandwidth links.
+//
+// A payload is compressed by CompressPayload at the Bundle's source and decompressed by DecompressPayload before being
+// delivered to its destination's application agent. Intermediate nodes forward the compressed payload untouched.
+//
+// The block-type-specific data in a CompressionBlock MUST be represented as a CBOR array comprising two elements, the
+// Algorithm and the payload's OriginalLength, both as unsigned integers.
+//
+// This block is NOT specified in RFC 9171.
+type CompressionBlock struct {
+	Algorithm      CompressionAlgorithm
+	OriginalLength uint64
+}
+
+// NewCompressionBlock for a payload of the given original length, compressed by the algorithm.
+func NewCompressionBlock(alg CompressionAlgorithm, originalLength uint64) *CompressionBlock {
+	return &CompressionBlock{Algorithm: alg, OriginalLength: originalLength}
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (cb *CompressionBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeCompressionBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (cb *CompressionBlock) BlockTypeName() string {
+	return "Compression Block"
+}
+
+// MarshalCbor writes the CBOR representation of a CompressionBlock.
+func (cb *CompressionBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+
+	for _, n := range []uint64{uint64(cb.Algorithm), cb.OriginalLength} {
+		if err := cboring.WriteUInt(n, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads a CBOR representation of a CompressionBlock.
+func (cb *CompressionBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("CompressionBlock: array has %d instead of 2 elements", n)
+	}
+
+	if alg, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		cb.Algorithm = CompressionAlgorithm(alg)
+	}
+
+	if length, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		cb.OriginalLength = length
+	}
+
+	return nil
+}
+
+// MarshalJSON writes a JSON representation of this CompressionBlock.
+func (cb *CompressionBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Algorithm      string `json:"algorithm"`
+		OriginalLength uint64 `json:"original_length"`
+	}{cb.Algorithm.String(), cb.OriginalLength})
+}
+
+// UnmarshalJSON reads a JSON representation of a CompressionBlock, as created by MarshalJSON.
+func (cb *CompressionBlock) UnmarshalJSON(data []byte) error {
+	var tmpCb struct {
+		Algorithm      string `json:"algorithm"`
+		OriginalLength uint64 `json:"original_length"`
+	}
+	if err := json.Unmarshal(data, &tmpCb); err != nil {
+		return err
+	}
+
+	for _, alg := range compressionAlgorithms {
+		if alg.String() == tmpCb.Algorithm {
+			cb.Algorithm = alg
+			cb.OriginalLength = tmpCb.OriginalLength
+			return nil
+		}
+	}
+	return fmt.Errorf("CompressionBlock: unknown algorithm %q", tmpCb.Algorithm)
+}
+
+// CheckValid checks the algorithm.
+func (cb *CompressionBlock) CheckValid() error {
+	for _, alg := range compressionAlgorithms {
+		if alg == cb.Algorithm {
+			return nil
+		}
+	}
+	return fmt.Errorf("CompressionBlock: unknown algorithm %d", cb.Algorithm)
+}
+
+// CheckContextValid that there is at most one CompressionBlock.
+func (cb *CompressionBlock) CheckContextValid(b *Bundle) error {
+	if cbb, err := b.ExtensionBlock(ExtBlockTypeCompressionBlock); err != nil {
+		return err
+	} else if cbb.Value != cb {
+		return fmt.Errorf("CompressionBlock's pointer differs, %p != %p", cbb.Value, cb)
+	}
+	return nil
+}
+
+// CompressPayload compresses a Bundle's payload by the given algorithm and adds a CompressionBlock.
+//
+// Fragments, Bundles with an already compressed payload, and Bundles with a PayloadDigestBlock, whose digest would
+// mismatch afterwards, cannot be compressed. Thus, a payload should be compressed before adding a digest or signature.
+func CompressPayload(b *Bundle, alg CompressionAlgorithm) error {
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		return fmt.Errorf("fragmented Bundles cannot be compressed")
+	} else if b.HasExtensionBlock(ExtBlockTypeCompressionBlock) {
+		return fmt.Errorf("Bundle's payload is already compressed")
+	} else if b.HasExtensionBlock(ExtBlockTypePayloadDigestBlock) {
+		return fmt.Errorf("Bundle's PayloadDigestBlock would mismatch the compressed payload")
+	}
+
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return err
+	}
+
+	data := pb.Value.(*PayloadBlock).Data()
+	compressed, err := alg.compress(data)
+	if err != nil {
+		return err
+	}
+
+	cb := NewCanonicalBlock(0, 0, NewCompressionBlock(alg, uint64(len(data))))
+	cb.SetCRCType(pb.GetCRCType())
+
+	// AddExtensionBlock might move the payload block, so it is altered before.
+	pb.Value = NewPayloadBlock(compressed)
+	if err := b.AddExtensionBlock(cb); err != nil {
+		pb.Value = NewPayloadBlock(data)
+		return err
+	}
+	return nil
+}
+
+// DecompressPayload decompresses a Bundle's payload, compressed by CompressPayload, and removes its CompressionBlock.
+// A Bundle without a CompressionBlock is left untouched.
+//
+// As the CompressionBlock's OriginalLength is chosen by the sender, payloads exceeding maxLength are rejected before
+// being decompressed. A zero maxLength falls back to DefaultMaxDecompressedLength.
+func DecompressPayload(b *Bundle, maxLength uint64) error {
+	cbb, err := b.ExtensionBlock(ExtBlockTypeCompressionBlock)
+	if err != nil {
+		return nil
+	}
+	cb, ok := cbb.Value.(*CompressionBlock)
+	if !ok {
+		return fmt.Errorf("CompressionBlock is encrypted")
+	}
+
+	if maxLength == 0 {
+		maxLength = DefaultMaxDecompressedLength
+	}
+	if cb.OriginalLength > maxLength {
+		return fmt.Errorf("decompressed payload of %d bytes would exceed the limit of %d bytes",
+			cb.OriginalLength, maxLength)
+	}
+
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return err
+	}
+
+	decompressed, err := cb.Algorithm.decompress(pb.Value.(*PayloadBlock).Data(), cb.OriginalLength)
+	if err != nil {
+		return fmt.Errorf("decompressing %v payload failed: %v", cb.Algorithm, err)
+	}
+
+	pb.Value = NewPayloadBlock(decompressed)
+	b.RemoveExtensionBlockByBlockNumber(cbb.BlockNumber)
+	return nil
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestCompressionBlockCbor(t *testing.T) {
+	for _, alg := range compressionAlgorithms {
+		t.Run(alg.String(), func(t *testing.T) {
+			cb1 := NewCompressionBlock(alg, 1<<20)
+			if err := cb1.CheckValid(); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := cboring.Marshal(cb1, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			cb2 := new(CompressionBlock)
+			if err := cboring.Unmarshal(cb2, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cb1, cb2) {
+				t.Fatalf("CompressionBlock differs: %v %v", cb1, cb2)
+			}
+		})
+	}
+
+	if err := NewCompressionBlock(CompressionAlgorithm(23), 0).CheckValid(); err == nil {
+		t.Fatal("unknown algorithm is valid")
+	}
+}
+
+func TestCompressPayload(t *testing.T) {
+	payload := bytes.Repeat([]byte("low bandwidth links like compressed payloads "), 64)
+
+	for _, alg := range compressionAlgorithms {
+		t.Run(alg.String(), func(t *testing.T) {
+			b := mustBuildBundle(t, Builder().
+				CRC(CRC32).
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				HopCountBlock(64).
+				PayloadBlock(payload))
+
+			if err := CompressPayload(&b, alg); err != nil {
+				t.Fatal(err)
+			} else if err := CompressPayload(&b, alg); err == nil {
+				t.Fatal("payload was compressed twice")
+			}
+
+			// Round trip through the CBOR representation, as a forwarding node would do.
+			var buf bytes.Buffer
+			if err := b.MarshalCbor(&buf); err != nil {
+				t.Fatal(err)
+			}
+			var received Bundle
+			if err := received.UnmarshalCbor(&buf); err != nil {
+				t.Fatal(err)
+			}
+
+			pb, err := received.PayloadBlock()
+			if err != nil {
+				t.Fatal(err)
+			} else if l := len(pb.Value.(*PayloadBlock).Data()); l >= len(payload) {
+				t.Fatalf("compressed payload has %d bytes, original %d bytes", l, len(payload))
+			}
+
+			if cbb, err := received.ExtensionBlock(ExtBlockTypeCompressionBlock); err != nil {
+				t.Fatal(err)
+			} else if cb := cbb.Value.(*CompressionBlock); cb.Algorithm != alg || cb.OriginalLength != uint64(len(payload)) {
+				t.Fatalf("CompressionBlock %v mismatches", cb)
+			}
+
+			if err := DecompressPayload(&received, 0); err != nil {
+				t.Fatal(err)
+			} else if received.HasExtensionBlock(ExtBlockTypeCompressionBlock) {
+				t.Fatal("CompressionBlock was not removed")
+			} else if err := received.CheckValid(); err != nil {
+				t.Fatal(err)
+			}
+
+			if pb, err := received.PayloadBlock(); err != nil {
+				t.Fatal(err)
+			} else if !bytes.Equal(pb.Value.(*PayloadBlock).Data(), payload) {
+				t.Fatal("decompressed payload differs")
+			}
+		})
+	}
+}
+
+func TestDecompressPayloadErrors(t *testing.T) {
+	newBundle := func() Bundle {
+		return mustBuildBundle(t, Builder().
+			Source("dtn://src/").
+			Destination("dtn://dst/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello world")))
+	}
+
+	// A Bundle without a CompressionBlock is left untouched.
+	b := newBundle()
+	if err := DecompressPayload(&b, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		alter func(b *Bundle)
+	}{
+		{"length mismatch", func(b *Bundle) {
+			cbb, _ := b.ExtensionBlock(ExtBlockTypeCompressionBlock)
+			cbb.Value.(*CompressionBlock).OriginalLength = 5
+		}},
+		{"algorithm mismatch", func(b *Bundle) {
+			cbb, _ := b.ExtensionBlock(ExtBlockTypeCompressionBlock)
+			cbb.Value.(*CompressionBlock).Algorithm = CompressionXZ
+		}},
+		{"corrupt payload", func(b *Bundle) {
+			pb, _ := b.PayloadBlock()
+			pb.Value = NewPayloadBlock([]byte("not gzipped"))
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newBundle()
+			if err := CompressPayload(&b, CompressionGzip); err != nil {
+				t.Fatal(err)
+			}
+
+			test.alter(&b)
+			if err := DecompressPayload(&b, 0); err == nil {
+				t.Fatal("decompression succeeded")
+			}
+		})
+	}
+
+	// The announced OriginalLength is checked against the limit before decompressing.
+	b = newBundle()
+	if err := CompressPayload(&b, CompressionGzip); err != nil {
+		t.Fatal(err)
+	} else if err := DecompressPayload(&b, 5); err == nil {
+		t.Fatal("decompression exceeding the limit succeeded")
+	} else if !b.HasExtensionBlock(ExtBlockTypeCompressionBlock) {
+		t.Fatal("CompressionBlock was removed")
+	} else if err := DecompressPayload(&b, 11); err != nil {
+		t.Fatal(err)
+	}
+}
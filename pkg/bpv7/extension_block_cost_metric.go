@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/dtn7/cboring"
+)
+
+// CostMetricType identifies the metric accumulated within a CostMetricBlock. Custom metrics might use further values.
+type CostMetricType uint64
+
+const (
+	// CostMetricBytes accumulates the serialized Bundle's length in bytes for each forwarding node.
+	CostMetricBytes CostMetricType = 0
+
+	// CostMetricDwellTime accumulates the milliseconds a Bundle resided on each forwarding node.
+	CostMetricDwellTime CostMetricType = 1
+)
+
+func (cmt CostMetricType) String() string {
+	switch cmt {
+	case CostMetricBytes:
+		return "bytes"
+	case CostMetricDwellTime:
+		return "dwell time"
+	default:
+		return fmt.Sprintf("custom %d", uint64(cmt))
+	}
+}
+
+// parseCostMetricType parses a CostMetricType from its String representation.
+func parseCostMetricType(s string) (CostMetricType, error) {
+	switch s {
+	case CostMetricBytes.String():
+		return CostMetricBytes, nil
+	case CostMetricDwellTime.String():
+		return CostMetricDwellTime, nil
+	}
+
+	var metric uint64
+	if _, err := fmt.Sscanf(s, "custom %d", &metric); err != nil {
+		return 0, fmt.Errorf("CostMetricBlock: unknown metric %q", s)
+	}
+	return CostMetricType(metric), nil
+}
+
+// CostMetricBlock is a custom block accumulating a numeric cost along a Bundle's path, e.g., for research or diagnostics.
+// Each forwarding node supporting the block's metric adds its share. Nodes not knowing the metric leave it as it is.
+//
+// A Bundle might contain multiple CostMetricBlocks, but only one for each metric.
+//
+// The block-type-specific data in a CostMetricBlock MUST be represented as a CBOR array comprising two elements, the
+// Metric and the accumulated Cost, both as unsigned integers.
+//
+// This block is NOT specified in RFC 9171.
+type CostMetricBlock struct {
+	Metric CostMetricType
+	Cost   uint64
+}
+
+// NewCostMetricBlock creates a new CostMetricBlock for a metric without any costs yet.
+func NewCostMetricBlock(metric CostMetricType) *CostMetricBlock {
+	return &CostMetricBlock{Metric: metric}
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (cmb *CostMetricBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeCostMetricBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (cmb *CostMetricBlock) BlockTypeName() string {
+	return "Cost Metric Block"
+}
+
+// Add a cost to the accumulated Cost, saturating at the maximum value. The new Cost is returned.
+func (cmb *CostMetricBlock) Add(cost uint64) uint64 {
+	if cost > math.MaxUint64-cmb.Cost {
+		cmb.Cost = math.MaxUint64
+	} else {
+		cmb.Cost += cost
+	}
+
+	return cmb.Cost
+}
+
+// MarshalCbor writes the CBOR representation of a CostMetricBlock.
+func (cmb *CostMetricBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+
+	for _, f := range []uint64{uint64(cmb.Metric), cmb.Cost} {
+		if err := cboring.WriteUInt(f, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalCbor reads a CBOR representation of a CostMetricBlock.
+func (cmb *CostMetricBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("CostMetricBlock: array has %d instead of 2 elements", n)
+	}
+
+	if metric, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		cmb.Metric = CostMetricType(metric)
+	}
+
+	if cost, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		cmb.Cost = cost
+	}
+
+	return nil
+}
+
+// MarshalJSON writes a JSON representation of this CostMetricBlock.
+func (cmb *CostMetricBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Metric string `json:"metric"`
+		Cost   uint64 `json:"cost"`
+	}{cmb.Metric.String(), cmb.Cost})
+}
+
+// UnmarshalJSON reads a JSON representation of a CostMetricBlock, as created by MarshalJSON.
+func (cmb *CostMetricBlock) UnmarshalJSON(data []byte) error {
+	var tmpCmb struct {
+		Metric string `json:"metric"`
+		Cost   uint64 `json:"cost"`
+	}
+	if err := json.Unmarshal(data, &tmpCmb); err != nil {
+		return err
+	}
+
+	metric, err := parseCostMetricType(tmpCmb.Metric)
+	if err != nil {
+		return err
+	}
+
+	cmb.Metric = metric
+	cmb.Cost = tmpCmb.Cost
+	return nil
+}
+
+// CheckValid is always successful, as each metric and cost is valid.
+func (cmb *CostMetricBlock) CheckValid() error {
+	return nil
+}
+
+// CheckContextValid that there is no other CostMetricBlock for the same metric.
+func (cmb *CostMetricBlock) CheckContextValid(b *Bundle) error {
+	cbs, err := b.ExtensionBlocks(ExtBlockTypeCostMetricBlock)
+	if err != nil {
+		return err
+	}
+
+	for _, cb := range cbs {
+		if other, ok := cb.Value.(*CostMetricBlock); ok && other != cmb && other.Metric == cmb.Metric {
+			return fmt.Errorf("CostMetricBlock: multiple blocks for the %v metric", cmb.Metric)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestCostMetricBlockCbor(t *testing.T) {
+	tests := []*CostMetricBlock{
+		{CostMetricBytes, 0},
+		{CostMetricDwellTime, 23},
+		{CostMetricType(42), math.MaxUint64},
+	}
+
+	for _, cmb1 := range tests {
+		t.Run(cmb1.Metric.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := cboring.Marshal(cmb1, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			cmb2 := new(CostMetricBlock)
+			if err := cboring.Unmarshal(cmb2, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cmb1, cmb2) {
+				t.Fatalf("CostMetricBlock differs: %v %v", cmb1, cmb2)
+			}
+		})
+	}
+}
+
+func TestCostMetricBlockAdd(t *testing.T) {
+	cmb := NewCostMetricBlock(CostMetricBytes)
+
+	if cost := cmb.Add(23); cost != 23 {
+		t.Fatalf("expected cost 23, got %d", cost)
+	}
+	if cost := cmb.Add(42); cost != 65 {
+		t.Fatalf("expected cost 65, got %d", cost)
+	}
+	if cost := cmb.Add(math.MaxUint64); cost != math.MaxUint64 {
+		t.Fatalf("expected saturated cost, got %d", cost)
+	}
+}
+
+func TestCostMetricBlockUniqueMetric(t *testing.T) {
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		CostMetricBlock(CostMetricBytes).
+		CostMetricBlock(CostMetricDwellTime).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.AddExtensionBlock(NewCanonicalBlock(0, 0, NewCostMetricBlock(CostMetricBytes))); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.CheckValid(); err == nil {
+		t.Fatal("bundle with two CostMetricBlocks for the same metric is valid")
+	}
+}
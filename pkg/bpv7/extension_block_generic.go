@@ -8,6 +8,10 @@ package bpv7
 type GenericExtensionBlock struct {
 	data     []byte
 	typeCode uint64
+
+	// unmarshalErr is set if this block's type is known, but its data could not be unmarshalled, e.g., as it is
+	// encrypted by a BCB.
+	unmarshalErr error
 }
 
 // NewGenericExtensionBlock creates a new GenericExtensionBlock from some payload and a block type code.
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+	"github.com/hashicorp/go-multierror"
+)
+
+// IdentityAssertionBlock is a custom block, in which a Bundle's source node asserts its identity by an ed25519 signature
+// over the Bundle's Primary Block.
+//
+// In contrast to the SignatureBlock, the IdentityAssertionBlock is meant to be verified against the public key known
+// for the Bundle's source node. Thus, a recipient knowing this key can detect Bundles with a forged source, without
+// requiring a full BPSec setup. A recipient without knowledge of the source's key can only check the signature's
+// consistency, which offers no protection against spoofing.
+//
+// The signature covers the Primary Block without its CRC and fragmentation fields. Thus, it remains valid after
+// fragmentation and for recalculated CRCs. The BundleBuilder creates the signature on its own:
+//
+//	b, err := bpv7.Builder()./* ... */.IdentityAssertionBlock(priv).Build()
+//
+// Afterwards, the recipient verifies the Bundle by Bundle.VerifyIdentityAssertion for the source node's public key.
+//
+// The block-type-specific data in an IdentityAssertionBlock MUST be represented as a CBOR array comprising two elements.
+// These elements are firstly the PublicKey and secondly the Signature, both represented as a CBOR byte string.
+//
+// This block is NOT specified in RFC 9171.
+type IdentityAssertionBlock struct {
+	PublicKey []byte
+	Signature []byte
+
+	// privateKey is only set by the BundleBuilder until the signature was created.
+	privateKey ed25519.PrivateKey
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (iab *IdentityAssertionBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeIdentityAssertionBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (iab *IdentityAssertionBlock) BlockTypeName() string {
+	return "Identity Assertion Block"
+}
+
+// identityAssertionData creates the CBOR representation of a Primary Block without its CRC and fragmentation fields,
+// used as the message to be signed.
+func identityAssertionData(pb PrimaryBlock) ([]byte, error) {
+	pb.BundleControlFlags &^= IsFragment
+	pb.FragmentOffset = 0
+	pb.TotalDataLength = 0
+	pb.CRCType = CRCNo
+	pb.CRC = nil
+
+	var buff bytes.Buffer
+	if err := cboring.Marshal(&pb, &buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// NewIdentityAssertionBlock for a Bundle from its source node's private key.
+func NewIdentityAssertionBlock(b Bundle, priv ed25519.PrivateKey) (*IdentityAssertionBlock, error) {
+	if l := len(priv); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("ed25519 private key's length is %d, not %d", l, ed25519.PrivateKeySize)
+	}
+
+	data, err := identityAssertionData(b.PrimaryBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdentityAssertionBlock{
+		PublicKey: priv.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(priv, data),
+	}, nil
+}
+
+// verifySignature checks the signature against the Bundle's Primary Block and the block's own public key.
+func (iab *IdentityAssertionBlock) verifySignature(b Bundle) error {
+	if err := iab.CheckValid(); err != nil {
+		return err
+	}
+
+	data, err := identityAssertionData(b.PrimaryBlock)
+	if err != nil {
+		return fmt.Errorf("IdentityAssertionBlock: %v", err)
+	}
+
+	if !ed25519.Verify(iab.PublicKey, data, iab.Signature) {
+		return fmt.Errorf("IdentityAssertionBlock: signature mismatches the Primary Block")
+	}
+	return nil
+}
+
+// Verify the signature against a Bundle and the public key known for its source node.
+func (iab *IdentityAssertionBlock) Verify(b Bundle, sourceKey ed25519.PublicKey) error {
+	if !bytes.Equal(iab.PublicKey, sourceKey) {
+		return fmt.Errorf("IdentityAssertionBlock: public key is not the one of %v", b.PrimaryBlock.SourceNode)
+	}
+
+	return iab.verifySignature(b)
+}
+
+// MarshalCbor writes the CBOR representation of an IdentityAssertionBlock.
+func (iab *IdentityAssertionBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+
+	for _, field := range []*[]byte{&iab.PublicKey, &iab.Signature} {
+		if err := cboring.WriteByteString(*field, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalCbor reads a CBOR representation of an IdentityAssertionBlock.
+func (iab *IdentityAssertionBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("IdentityAssertionBlock: array has %d instead of 2 elements", n)
+	}
+
+	for _, field := range []*[]byte{&iab.PublicKey, &iab.Signature} {
+		if data, err := cboring.ReadByteString(r); err != nil {
+			return err
+		} else {
+			*field = data
+		}
+	}
+	return nil
+}
+
+// CheckValid checks the field lengths for errors.
+//
+// This DOES NOT verify the signature. Therefore please use the Verify method.
+func (iab *IdentityAssertionBlock) CheckValid() (err error) {
+	if l := len(iab.PublicKey); l != ed25519.PublicKeySize {
+		err = multierror.Append(err,
+			fmt.Errorf("IdentityAssertionBlock: public key's length is %d, not required %d", l, ed25519.PublicKeySize))
+	}
+
+	if l := len(iab.Signature); l != ed25519.SignatureSize {
+		err = multierror.Append(err,
+			fmt.Errorf("IdentityAssertionBlock: signature's length is %d, not required %d", l, ed25519.SignatureSize))
+	}
+
+	return
+}
+
+// CheckContextValid that there is at most one IdentityAssertionBlock and that its signature matches the Primary Block.
+//
+// As the source node's public key is unknown here, this DOES NOT detect a forged source. Therefore please use the
+// Verify method.
+func (iab *IdentityAssertionBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeIdentityAssertionBlock)
+	if err != nil {
+		return err
+	} else if cb.Value != iab {
+		return fmt.Errorf("IdentityAssertionBlock's pointer differs, %p != %p", cb.Value, iab)
+	}
+
+	return iab.verifySignature(*b)
+}
+
+// VerifyIdentityAssertion checks this Bundle's IdentityAssertionBlock against the public key known for its source node.
+// An error is returned both for a failed verification and a missing IdentityAssertionBlock.
+func (b Bundle) VerifyIdentityAssertion(sourceKey ed25519.PublicKey) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypeIdentityAssertionBlock)
+	if err != nil {
+		return err
+	}
+
+	iab, ok := cb.Value.(*IdentityAssertionBlock)
+	if !ok {
+		return fmt.Errorf("IdentityAssertionBlock is encrypted")
+	}
+	return iab.Verify(b, sourceKey)
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+// identityTestKey creates a new ed25519 key pair for testing purpose.
+func identityTestKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func TestIdentityAssertionBlockCbor(t *testing.T) {
+	_, priv := identityTestKey(t)
+
+	b := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		mustBuild()
+
+	iab1, err := NewIdentityAssertionBlock(b, priv)
+	if err != nil {
+		t.Fatal(err)
+	} else if err = iab1.CheckValid(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = cboring.Marshal(iab1, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	iab2 := new(IdentityAssertionBlock)
+	if err = cboring.Unmarshal(iab2, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(iab1, iab2) {
+		t.Fatalf("IdentityAssertionBlock differs: %v %v", iab1, iab2)
+	}
+}
+
+func TestIdentityAssertionBlockVerify(t *testing.T) {
+	alicePub, alicePriv := identityTestKey(t)
+	_, malloryPriv := identityTestKey(t)
+
+	build := func(source string, priv ed25519.PrivateKey) Bundle {
+		b, err := Builder().
+			CRC(CRC32).
+			Source(source).
+			Destination("dtn://bob/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello bob")).
+			IdentityAssertionBlock(priv).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Serialize the Bundle as it would be received by the destination.
+		var buf bytes.Buffer
+		if err := b.MarshalCbor(&buf); err != nil {
+			t.Fatal(err)
+		}
+		received, err := ParseBundle(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return received
+	}
+
+	tests := []struct {
+		name   string
+		bundle Bundle
+		valid  bool
+	}{
+		{"genuine source", build("dtn://alice/app", alicePriv), true},
+		{"forged source", build("dtn://alice/app", malloryPriv), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The signature itself is consistent in both cases.
+			if err := test.bundle.CheckValid(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.bundle.VerifyIdentityAssertion(alicePub); (err == nil) != test.valid {
+				t.Fatalf("expected valid identity assertion = %t, got error %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestIdentityAssertionBlockAlteredPrimaryBlock(t *testing.T) {
+	pub, priv := identityTestKey(t)
+
+	b, err := Builder().
+		Source("dtn://alice/").
+		Destination("dtn://bob/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello bob")).
+		IdentityAssertionBlock(priv).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A recalculated CRC does not affect the signature.
+	b.SetCRCType(CRC16)
+	if err := b.VerifyIdentityAssertion(pub); err != nil {
+		t.Fatal(err)
+	}
+
+	b.PrimaryBlock.SourceNode = MustNewEndpointID("dtn://mallory/")
+	if err := b.VerifyIdentityAssertion(pub); err == nil {
+		t.Fatal("altered source was not detected")
+	} else if err := b.CheckValid(); err == nil {
+		t.Fatal("altered source passed CheckValid")
+	}
+}
+
+func TestIdentityAssertionBlockFragments(t *testing.T) {
+	pub, priv := identityTestKey(t)
+
+	b, err := Builder().
+		CRC(CRC32).
+		Source("dtn://alice/").
+		Destination("dtn://bob/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(bytes.Repeat([]byte("0123456789"), 64)).
+		IdentityAssertionBlock(priv).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fragments, err := b.Fragment(256)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(fragments) < 2 {
+		t.Fatalf("expected multiple fragments, got %d", len(fragments))
+	}
+
+	for i, fragment := range fragments {
+		if err := fragment.VerifyIdentityAssertion(pub); err != nil {
+			t.Fatalf("fragment %d: %v", i, err)
+		}
+	}
+}
+
+func TestIdentityAssertionBlockBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []interface{}
+	}{
+		{"missing key", []interface{}{}},
+		{"wrong type", []interface{}{"secret"}},
+		{"short key", []interface{}{ed25519.PrivateKey{0x23, 0x42}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Builder().
+				Source("dtn://alice/").
+				Destination("dtn://bob/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello bob")).
+				IdentityAssertionBlock(test.args...).
+				Build()
+			if err == nil {
+				t.Fatal("builder accepted invalid arguments")
+			}
+		})
+	}
+}
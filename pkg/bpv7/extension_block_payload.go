@@ -44,10 +44,25 @@ func (pb *PayloadBlock) UnmarshalBinary(data []byte) error {
 // MarshalJSON writes the binary representation of a PayloadBlock.
 //
 // If this type does not implement the json.Marshaler, the CBOR encoding would be returned which might be misleading.
+// An empty payload is always represented as an empty string, not as null.
 func (pb *PayloadBlock) MarshalJSON() ([]byte, error) {
+	if len(pb.Data()) == 0 {
+		return json.Marshal([]byte{})
+	}
 	return json.Marshal(pb.Data())
 }
 
+// UnmarshalJSON reads the JSON representation of a PayloadBlock, as created by MarshalJSON.
+func (pb *PayloadBlock) UnmarshalJSON(data []byte) error {
+	var payload []byte
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	*pb = payload
+	return nil
+}
+
 // CheckValid returns an array of errors for incorrect data.
 func (pb *PayloadBlock) CheckValid() error {
 	return nil
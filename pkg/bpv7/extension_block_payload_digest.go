@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// PayloadDigestAlgorithm identifies the hash function of a PayloadDigestBlock.
+type PayloadDigestAlgorithm uint64
+
+const (
+	// PayloadDigestSHA256 creates a SHA-256 digest.
+	PayloadDigestSHA256 PayloadDigestAlgorithm = 0
+
+	// PayloadDigestSHA512 creates a SHA-512 digest.
+	PayloadDigestSHA512 PayloadDigestAlgorithm = 1
+)
+
+// newHash for this PayloadDigestAlgorithm or an error for an unknown algorithm.
+func (alg PayloadDigestAlgorithm) newHash() (hash.Hash, error) {
+	switch alg {
+	case PayloadDigestSHA256:
+		return sha256.New(), nil
+	case PayloadDigestSHA512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown payload digest algorithm %d", alg)
+	}
+}
+
+func (alg PayloadDigestAlgorithm) String() string {
+	switch alg {
+	case PayloadDigestSHA256:
+		return "SHA-256"
+	case PayloadDigestSHA512:
+		return "SHA-512"
+	default:
+		return "unknown"
+	}
+}
+
+// PayloadDigestBlock is a custom block carrying an application level digest of a Bundle's payload.
+//
+// In contrast to a Canonical Block's CRC, this digest is created once by the Bundle's source and is neither altered nor
+// removed by intermediate nodes. Thus, it allows the destination to check the payload's integrity end-to-end, even if
+// CRCs were stripped or recalculated on the way. However, the digest offers no protection against deliberate
+// modifications; use a BIB or a SignatureBlock for that.
+//
+// The BundleBuilder calculates the digest on its own:
+//
+//	b, err := bpv7.Builder()./* ... */.PayloadBlock(data).PayloadDigestBlock(bpv7.PayloadDigestSHA256).Build()
+//
+// Afterwards, the digest can be verified by Bundle.VerifyPayloadDigest. This also happens as part of the Bundle's
+// CheckValid for non-fragmented Bundles.
+//
+// The block-type-specific data in a PayloadDigestBlock MUST be represented as a CBOR array comprising two elements, the
+// Algorithm as an unsigned integer and the Digest as a byte string.
+//
+// This block is NOT specified in RFC 9171.
+type PayloadDigestBlock struct {
+	Algorithm PayloadDigestAlgorithm
+	Digest    []byte
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (pdb *PayloadDigestBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypePayloadDigestBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (pdb *PayloadDigestBlock) BlockTypeName() string {
+	return "Payload Digest Block"
+}
+
+// payloadDigest calculates the digest of a Bundle's payload data.
+func payloadDigest(b Bundle, alg PayloadDigestAlgorithm) ([]byte, error) {
+	h, err := alg.newHash()
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(pb.Value.(*PayloadBlock).Data())
+	return h.Sum(nil), nil
+}
+
+// NewPayloadDigestBlock for a Bundle's payload, using the given algorithm.
+func NewPayloadDigestBlock(b Bundle, alg PayloadDigestAlgorithm) (*PayloadDigestBlock, error) {
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		return nil, fmt.Errorf("fragmented Bundles cannot be digested")
+	}
+
+	digest, err := payloadDigest(b, alg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayloadDigestBlock{Algorithm: alg, Digest: digest}, nil
+}
+
+// Verify the digest against a Bundle's payload.
+func (pdb *PayloadDigestBlock) Verify(b Bundle) error {
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		return fmt.Errorf("PayloadDigestBlock: fragmented Bundles cannot be verified")
+	}
+
+	digest, err := payloadDigest(b, pdb.Algorithm)
+	if err != nil {
+		return fmt.Errorf("PayloadDigestBlock: %v", err)
+	}
+
+	if !bytes.Equal(digest, pdb.Digest) {
+		return fmt.Errorf("PayloadDigestBlock: %v digest mismatches the payload", pdb.Algorithm)
+	}
+	return nil
+}
+
+// MarshalCbor writes the CBOR representation of a PayloadDigestBlock.
+func (pdb *PayloadDigestBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return err
+	}
+
+	if err := cboring.WriteUInt(uint64(pdb.Algorithm), w); err != nil {
+		return err
+	}
+
+	return cboring.WriteByteString(pdb.Digest, w)
+}
+
+// UnmarshalCbor reads a CBOR representation of a PayloadDigestBlock.
+func (pdb *PayloadDigestBlock) UnmarshalCbor(r io.Reader) error {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return err
+	} else if n != 2 {
+		return fmt.Errorf("PayloadDigestBlock: array has %d instead of 2 elements", n)
+	}
+
+	if alg, err := cboring.ReadUInt(r); err != nil {
+		return err
+	} else {
+		pdb.Algorithm = PayloadDigestAlgorithm(alg)
+	}
+
+	if digest, err := cboring.ReadByteString(r); err != nil {
+		return err
+	} else {
+		pdb.Digest = digest
+	}
+
+	return nil
+}
+
+// MarshalJSON writes a JSON representation of this PayloadDigestBlock.
+func (pdb *PayloadDigestBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Algorithm string `json:"algorithm"`
+		Digest    []byte `json:"digest"`
+	}{pdb.Algorithm.String(), pdb.Digest})
+}
+
+// UnmarshalJSON reads a JSON representation of a PayloadDigestBlock, as created by MarshalJSON.
+func (pdb *PayloadDigestBlock) UnmarshalJSON(data []byte) error {
+	var tmpPdb struct {
+		Algorithm string `json:"algorithm"`
+		Digest    []byte `json:"digest"`
+	}
+	if err := json.Unmarshal(data, &tmpPdb); err != nil {
+		return err
+	}
+
+	for _, alg := range []PayloadDigestAlgorithm{PayloadDigestSHA256, PayloadDigestSHA512} {
+		if alg.String() == tmpPdb.Algorithm {
+			pdb.Algorithm = alg
+			pdb.Digest = tmpPdb.Digest
+			return nil
+		}
+	}
+	return fmt.Errorf("PayloadDigestBlock: unknown algorithm %q", tmpPdb.Algorithm)
+}
+
+// CheckValid checks the algorithm and the digest's length.
+//
+// This DOES NOT verify the digest. Therefore please use the Verify method.
+func (pdb *PayloadDigestBlock) CheckValid() error {
+	h, err := pdb.Algorithm.newHash()
+	if err != nil {
+		return fmt.Errorf("PayloadDigestBlock: %v", err)
+	}
+
+	if l := len(pdb.Digest); l != h.Size() {
+		return fmt.Errorf("PayloadDigestBlock: %v digest's length is %d, not required %d", pdb.Algorithm, l, h.Size())
+	}
+	return nil
+}
+
+// CheckContextValid that there is at most one PayloadDigestBlock and that its digest matches non-fragmented payloads.
+// An encrypted payload cannot be verified until being decrypted, e.g., by VerifyPayloadDigest afterwards.
+func (pdb *PayloadDigestBlock) CheckContextValid(b *Bundle) error {
+	cb, err := b.ExtensionBlock(ExtBlockTypePayloadDigestBlock)
+	if err != nil {
+		return err
+	} else if cb.Value != pdb {
+		return fmt.Errorf("PayloadDigestBlock's pointer differs, %p != %p", cb.Value, pdb)
+	}
+
+	// Cannot verify fragmented Bundles.
+	if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		return nil
+	}
+
+	// Cannot verify a payload, encrypted by a BCB.
+	if payload, err := b.PayloadBlock(); err != nil || b.isConfidentialityTarget(payload.BlockNumber) {
+		return nil
+	}
+
+	return pdb.Verify(*b)
+}
+
+// VerifyPayloadDigest checks this Bundle's payload against its PayloadDigestBlock. An error is returned both for a
+// mismatching digest and a missing PayloadDigestBlock.
+func (b Bundle) VerifyPayloadDigest() error {
+	cb, err := b.ExtensionBlock(ExtBlockTypePayloadDigestBlock)
+	if err != nil {
+		return err
+	}
+
+	pdb, ok := cb.Value.(*PayloadDigestBlock)
+	if !ok {
+		return fmt.Errorf("PayloadDigestBlock is encrypted")
+	}
+	return pdb.Verify(b)
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestPayloadDigestBlockCbor(t *testing.T) {
+	for _, alg := range []PayloadDigestAlgorithm{PayloadDigestSHA256, PayloadDigestSHA512} {
+		t.Run(alg.String(), func(t *testing.T) {
+			b := Builder().
+				Source("dtn://src/").
+				Destination("dtn://dst/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				mustBuild()
+
+			pdb1, err := NewPayloadDigestBlock(b, alg)
+			if err != nil {
+				t.Fatal(err)
+			} else if err = pdb1.CheckValid(); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err = cboring.Marshal(pdb1, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			pdb2 := new(PayloadDigestBlock)
+			if err = cboring.Unmarshal(pdb2, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(pdb1, pdb2) {
+				t.Fatalf("PayloadDigestBlock differs: %v %v", pdb1, pdb2)
+			}
+		})
+	}
+}
+
+func TestPayloadDigestBlockForwarding(t *testing.T) {
+	payload := []byte("end-to-end integrity of this payload matters")
+
+	b, err := Builder().
+		CRC(CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock(payload).
+		PayloadDigestBlock(PayloadDigestSHA256).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	} else if err = b.VerifyPayloadDigest(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Forward the Bundle over some intermediate node, which strips the CRCs and alters other blocks.
+	var buf bytes.Buffer
+	if err = b.WriteBundle(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	fwd, err := ParseBundle(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hcb, err := fwd.ExtensionBlock(ExtBlockTypeHopCountBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hcb.Value.(*HopCountBlock).Increment()
+
+	if err = fwd.AddExtensionBlock(NewCanonicalBlock(0, ReplicateBlock, NewPreviousNodeBlock(MustNewEndpointID("dtn://fwd/")))); err != nil {
+		t.Fatal(err)
+	}
+	fwd.SetCRCType(CRCNo)
+
+	buf.Reset()
+	if err = fwd.WriteBundle(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if delivered, err := ParseBundle(bytes.NewBuffer(data)); err != nil {
+		t.Fatal(err)
+	} else if err = delivered.VerifyPayloadDigest(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the payload without any CRC to detect this.
+	payloadOffset := bytes.Index(data, payload)
+	if payloadOffset < 0 {
+		t.Fatal("payload not found within serialized Bundle")
+	}
+
+	corrupted := make([]byte, len(data))
+	copy(corrupted, data)
+	corrupted[payloadOffset] ^= 0xFF
+
+	if _, err = ParseBundle(bytes.NewBuffer(corrupted)); err == nil {
+		t.Fatal("parsing a Bundle with a corrupted payload did not fail")
+	}
+
+	delivered := MustNewBundle(fwd.PrimaryBlock, nil)
+	for _, cb := range fwd.CanonicalBlocks {
+		if cb.TypeCode() == ExtBlockTypePayloadBlock {
+			cb.Value = NewPayloadBlock(corrupted[payloadOffset : payloadOffset+len(payload)])
+		}
+		delivered.CanonicalBlocks = append(delivered.CanonicalBlocks, cb)
+	}
+
+	if err = delivered.VerifyPayloadDigest(); err == nil {
+		t.Fatal("corrupted payload was verified")
+	}
+}
+
+func TestPayloadDigestBlockEncryptedPayload(t *testing.T) {
+	payload := []byte("confidential payload with an end-to-end digest")
+	key := []byte("0123456789abcdef")
+
+	b, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		PayloadDigestBlock(PayloadDigestSHA256).
+		ConfidentialityBlockForPayload(key).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = b.WriteBundle(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// The encrypted payload must not be checked against the plaintext's digest.
+	received, err := ParseBundle(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bcbBlock, err := received.ExtensionBlock(ExtBlockTypeBlockConfidentialityBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bcbBlock.Value.(*BCBIOPAESGCM).DecryptTarget(received, bcbBlock.BlockNumber, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = received.VerifyPayloadDigest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPayloadDigestBlockMissing(t *testing.T) {
+	b := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		mustBuild()
+
+	if err := b.VerifyPayloadDigest(); err == nil {
+		t.Fatal("Bundle without a PayloadDigestBlock was verified")
+	}
+
+	_, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		PayloadDigestBlock(PayloadDigestAlgorithm(23)).
+		Build()
+	if err == nil {
+		t.Fatal("unknown digest algorithm did not fail")
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestSummaryVectorBlockCbor(t *testing.T) {
+	ts := NewCreationTimestamp(DtnTimeNow(), 23)
+
+	tests := []struct {
+		name string
+		bids []BundleID
+	}{
+		{"empty", []BundleID{}},
+		{"single", []BundleID{{SourceNode: MustNewEndpointID("dtn://src/"), Timestamp: ts}}},
+		{"mixed", []BundleID{
+			{SourceNode: MustNewEndpointID("dtn://src/"), Timestamp: ts},
+			{SourceNode: MustNewEndpointID("ipn:23.42"), Timestamp: ts, IsFragment: true, FragmentOffset: 10, TotalDataLength: 100},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svb1 := NewSummaryVectorBlock(test.bids)
+
+			var buf bytes.Buffer
+			if err := cboring.Marshal(svb1, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			svb2 := new(SummaryVectorBlock)
+			if err := cboring.Unmarshal(svb2, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(svb1.BundleIDs(), svb2.BundleIDs()) {
+				t.Fatalf("SummaryVectorBlock differs: %v %v", svb1, svb2)
+			}
+		})
+	}
+}
+
+func TestSummaryVectorBlockInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	_ = cboring.WriteArrayLength(1, &buf)
+	_ = cboring.WriteArrayLength(3, &buf)
+
+	if err := cboring.Unmarshal(new(SummaryVectorBlock), &buf); err == nil {
+		t.Fatal("unmarshalling a BundleID of three elements succeeded")
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dtn7/cboring"
+)
+
+// MaxTraceBlockLength is the maximum number of EndpointIDs within a TraceBlock, guarding against unbounded growth.
+const MaxTraceBlockLength = 255
+
+// TraceBlock is a custom block listing the EndpointIDs of all nodes which have forwarded its bundle, in the order of
+// traversal. Thus, a bundle is not forwarded to a node already being part of its trace, suppressing routing loops
+// independent of the topology.
+//
+// The block-type-specific data in a TraceBlock MUST be represented as a CBOR array of EndpointIDs, holding at most
+// MaxTraceBlockLength elements.
+//
+// This block is NOT specified in RFC 9171.
+type TraceBlock []EndpointID
+
+// NewTraceBlock creates a new TraceBlock for the given EndpointIDs.
+func NewTraceBlock(eids ...EndpointID) *TraceBlock {
+	tb := TraceBlock(append([]EndpointID{}, eids...))
+	return &tb
+}
+
+// Endpoints listed in this TraceBlock, starting with the oldest one.
+func (tb *TraceBlock) Endpoints() []EndpointID {
+	return *tb
+}
+
+// Contains checks if some EndpointID's node is part of this TraceBlock.
+func (tb *TraceBlock) Contains(eid EndpointID) bool {
+	for _, traced := range *tb {
+		if traced.SameNode(eid) {
+			return true
+		}
+	}
+	return false
+}
+
+// Append an EndpointID to this TraceBlock. If the TraceBlock exceeds its limit afterwards, its oldest EndpointIDs
+// are dropped. The limit is capped by MaxTraceBlockLength; zero also indicates MaxTraceBlockLength.
+func (tb *TraceBlock) Append(eid EndpointID, limit int) {
+	if limit <= 0 || limit > MaxTraceBlockLength {
+		limit = MaxTraceBlockLength
+	}
+
+	eids := append(*tb, eid)
+	if len(eids) > limit {
+		eids = append([]EndpointID{}, eids[len(eids)-limit:]...)
+	}
+	*tb = eids
+}
+
+// BlockTypeCode must return a constant integer, indicating the block type code.
+func (tb *TraceBlock) BlockTypeCode() uint64 {
+	return ExtBlockTypeTraceBlock
+}
+
+// BlockTypeName must return a constant string, this block's name.
+func (tb *TraceBlock) BlockTypeName() string {
+	return "Trace Block"
+}
+
+// CheckValid checks the TraceBlock's length and its EndpointIDs.
+func (tb *TraceBlock) CheckValid() error {
+	if l := len(*tb); l > MaxTraceBlockLength {
+		return fmt.Errorf("TraceBlock holds %d EndpointIDs, exceeding %d", l, MaxTraceBlockLength)
+	}
+
+	for _, eid := range *tb {
+		if err := eid.CheckValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckContextValid is always successful.
+func (tb *TraceBlock) CheckContextValid(*Bundle) error {
+	return nil
+}
+
+// MarshalCbor writes the CBOR representation of a TraceBlock.
+func (tb *TraceBlock) MarshalCbor(w io.Writer) error {
+	if err := cboring.WriteArrayLength(uint64(len(*tb)), w); err != nil {
+		return err
+	}
+
+	for i := range *tb {
+		if err := cboring.Marshal(&(*tb)[i], w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalCbor reads a CBOR representation of a TraceBlock.
+func (tb *TraceBlock) UnmarshalCbor(r io.Reader) error {
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return err
+	} else if n > MaxTraceBlockLength {
+		return fmt.Errorf("TraceBlock holds %d EndpointIDs, exceeding %d", n, MaxTraceBlockLength)
+	}
+
+	eids := make([]EndpointID, 0, n)
+	for i := uint64(0); i < n; i++ {
+		var eid EndpointID
+		if err := cboring.Unmarshal(&eid, r); err != nil {
+			return fmt.Errorf("TraceBlock: %v", err)
+		}
+
+		eids = append(eids, eid)
+	}
+
+	*tb = eids
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+)
+
+func TestTraceBlockCbor(t *testing.T) {
+	tests := []struct {
+		name string
+		eids []EndpointID
+	}{
+		{"empty", []EndpointID{}},
+		{"single", []EndpointID{MustNewEndpointID("dtn://a/")}},
+		{"mixed", []EndpointID{MustNewEndpointID("dtn://a/"), MustNewEndpointID("ipn:23.0"), MustNewEndpointID("dtn://b/")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tb1 := NewTraceBlock(test.eids...)
+
+			var buf bytes.Buffer
+			if err := cboring.Marshal(tb1, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			tb2 := new(TraceBlock)
+			if err := cboring.Unmarshal(tb2, &buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tb1.Endpoints(), tb2.Endpoints()) {
+				t.Fatalf("TraceBlock differs: %v %v", tb1, tb2)
+			}
+		})
+	}
+}
+
+func TestTraceBlockAppend(t *testing.T) {
+	a, b, c := MustNewEndpointID("dtn://a/"), MustNewEndpointID("dtn://b/"), MustNewEndpointID("dtn://c/")
+
+	tb := NewTraceBlock()
+	for _, eid := range []EndpointID{a, b, c} {
+		tb.Append(eid, 2)
+	}
+
+	if eids := tb.Endpoints(); !reflect.DeepEqual(eids, []EndpointID{b, c}) {
+		t.Fatalf("TraceBlock holds %v, expected the latest two", eids)
+	}
+
+	if tb.Contains(a) {
+		t.Fatal("TraceBlock contains dropped endpoint")
+	} else if !tb.Contains(MustNewEndpointID("dtn://b/app")) {
+		t.Fatal("TraceBlock does not contain another endpoint of a traced node")
+	}
+
+	tb = NewTraceBlock()
+	for i := 0; i < MaxTraceBlockLength+10; i++ {
+		tb.Append(a, 0)
+	}
+	if l := len(tb.Endpoints()); l != MaxTraceBlockLength {
+		t.Fatalf("unlimited TraceBlock holds %d endpoints, not %d", l, MaxTraceBlockLength)
+	}
+}
+
+func TestTraceBlockOversized(t *testing.T) {
+	var buf bytes.Buffer
+	_ = cboring.WriteArrayLength(MaxTraceBlockLength+1, &buf)
+
+	if err := cboring.Unmarshal(new(TraceBlock), &buf); err == nil {
+		t.Fatal("unmarshalling an oversized TraceBlock succeeded")
+	}
+
+	tb := TraceBlock(make([]EndpointID, MaxTraceBlockLength+1))
+	for i := range tb {
+		tb[i] = MustNewEndpointID("dtn://a/")
+	}
+	if err := tb.CheckValid(); err == nil {
+		t.Fatal("oversized TraceBlock is valid")
+	}
+}
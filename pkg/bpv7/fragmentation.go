@@ -35,6 +35,12 @@ func (b Bundle) Fragment(mtu int) (bs []Bundle, err error) {
 	}
 	payloadBlockLen = len(payloadBlock.Value.(*PayloadBlock).Data())
 
+	// An empty payload cannot be split up; the Bundle remains as it is.
+	if payloadBlockLen == 0 {
+		bs = []Bundle{b}
+		return
+	}
+
 	if extFirstOverhead, extOtherOverhead, err = fragmentExtensionBlocksLen(b, mtu); err != nil {
 		return
 	}
@@ -103,7 +109,15 @@ func (b Bundle) Fragment(mtu int) (bs []Bundle, err error) {
 }
 
 // fragmentPrimaryBlock creates a fragment's Primary Block and calculates its length.
+//
+// If the Primary Block already belongs to a fragment, the fragment offset is relative to this fragment's payload and
+// the original total data length is kept.
 func fragmentPrimaryBlock(pb PrimaryBlock, fragmentOffset, totalDataLength int) (fragPb PrimaryBlock, l int, err error) {
+	if pb.BundleControlFlags.Has(IsFragment) {
+		fragmentOffset += int(pb.FragmentOffset)
+		totalDataLength = int(pb.TotalDataLength)
+	}
+
 	fragPb = PrimaryBlock{
 		Version:            pb.Version,
 		BundleControlFlags: pb.BundleControlFlags | IsFragment,
@@ -186,8 +200,9 @@ func prepareReassembly(bs []Bundle) error {
 			return fmt.Errorf("next fragment starts at offset %d, gap from %d to %d", fragOff, lastIndex, fragOff)
 		} else if payloadBlock, err := b.PayloadBlock(); err != nil {
 			return err
-		} else {
-			lastIndex = fragOff + uint64(len(payloadBlock.Value.(*PayloadBlock).Data()))
+		} else if fragEnd := fragOff + uint64(len(payloadBlock.Value.(*PayloadBlock).Data())); fragEnd > lastIndex {
+			// Overlapping fragments might end before the previous one.
+			lastIndex = fragEnd
 		}
 	}
 
@@ -221,8 +236,11 @@ func mergeFragmentPayload(bs []Bundle) (data []byte, err error) {
 		}
 		fragPayloadData = fragPayloadBlock.Value.(*PayloadBlock).Data()
 
-		data = append(data, fragPayloadData[lastIndex-fragStartIndex:]...)
-		lastIndex = fragStartIndex + len(fragPayloadData)
+		// Skip duplicate or overlapping fragments which do not contribute new data.
+		if fragEndIndex := fragStartIndex + len(fragPayloadData); fragEndIndex > lastIndex {
+			data = append(data, fragPayloadData[lastIndex-fragStartIndex:]...)
+			lastIndex = fragEndIndex
+		}
 	}
 
 	return
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// reassembly is a partial reassembly of a fragmented Bundle.
+type reassembly struct {
+	fragments []Bundle
+	since     time.Time
+	size      uint64
+}
+
+// ReassemblerLimits guard a Reassembler against memory exhaustion by fragments which are never completed.
+type ReassemblerLimits struct {
+	// MaxMemory limits the sum of all held fragments' payload lengths in bytes. Zero disables this limit.
+	MaxMemory uint64
+
+	// Timeout after which an incomplete reassembly is discarded by ExpireTimedOut. Zero disables this limit.
+	Timeout time.Duration
+}
+
+// Reassembler collects Bundle fragments and reassembles their original Bundle as soon as all fragments are present.
+// Fragments are grouped by their source node and creation timestamp, allowing multiple concurrent reassemblies.
+type Reassembler struct {
+	mutex    sync.Mutex
+	partials map[string]*reassembly
+	limits   ReassemblerLimits
+	memory   uint64
+}
+
+// NewReassembler creates an empty Reassembler without any limits.
+func NewReassembler() *Reassembler {
+	return NewReassemblerWithLimits(ReassemblerLimits{})
+}
+
+// NewReassemblerWithLimits creates an empty Reassembler, restricted by its ReassemblerLimits.
+func NewReassemblerWithLimits(limits ReassemblerLimits) *Reassembler {
+	return &Reassembler{
+		partials: make(map[string]*reassembly),
+		limits:   limits,
+	}
+}
+
+// reassemblyKey identifies all fragments of the same original Bundle.
+func reassemblyKey(b Bundle) string {
+	return b.ID().Scrub().String()
+}
+
+// Insert a fragment. If this fragment completes its original Bundle, the reassembled Bundle is returned and done is
+// true. Duplicate or overlapping fragments are accepted. A non-fragmented Bundle is returned as it is.
+//
+// Fragments are compared by their offset and payload length. A fragment being covered by an already held fragment is
+// ignored, while held fragments being covered by the inserted fragment are replaced.
+//
+// If holding this fragment would exceed the ReassemblerLimits' MaxMemory, it is rejected with an error.
+func (r *Reassembler) Insert(b Bundle) (complete *Bundle, done bool, err error) {
+	if !b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+		return &b, true, nil
+	}
+
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return
+	}
+	size := uint64(len(pb.Value.(*PayloadBlock).Data()))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := reassemblyKey(b)
+	partial, ok := r.partials[key]
+	if ok {
+		if total := partial.fragments[0].PrimaryBlock.TotalDataLength; total != b.PrimaryBlock.TotalDataLength {
+			err = fmt.Errorf("fragment's total data length %d differs from %d", b.PrimaryBlock.TotalDataLength, total)
+			return
+		}
+
+		for _, frag := range partial.fragments {
+			if fragmentCovers(frag, b) {
+				// Duplicate fragment, which cannot complete the Bundle.
+				return
+			}
+		}
+	}
+
+	var coveredSize uint64
+	if ok {
+		for _, frag := range partial.fragments {
+			if fragmentCovers(b, frag) {
+				coveredSize += fragmentPayloadLen(frag)
+			}
+		}
+	}
+
+	if r.limits.MaxMemory > 0 && r.memory-coveredSize+size > r.limits.MaxMemory {
+		err = fmt.Errorf("fragment's %d bytes exceed the reassembly memory limit of %d bytes, %d bytes are held",
+			size, r.limits.MaxMemory, r.memory)
+		return
+	}
+
+	if !ok {
+		partial = &reassembly{since: time.Now()}
+		r.partials[key] = partial
+	}
+
+	fragments := partial.fragments[:0]
+	for _, frag := range partial.fragments {
+		if !fragmentCovers(b, frag) {
+			fragments = append(fragments, frag)
+		}
+	}
+	partial.fragments = append(fragments, b)
+	partial.size += size - coveredSize
+	r.memory += size - coveredSize
+
+	if !IsBundleReassemblable(partial.fragments) {
+		return
+	}
+
+	r.drop(key)
+
+	reassembled, reassembleErr := ReassembleFragments(partial.fragments)
+	if reassembleErr != nil {
+		err = reassembleErr
+		return
+	}
+
+	return &reassembled, true, nil
+}
+
+// fragmentPayloadLen returns the length of a fragment's payload, being zero for an invalid Bundle.
+func fragmentPayloadLen(b Bundle) uint64 {
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		return 0
+	}
+
+	payload, ok := pb.Value.(*PayloadBlock)
+	if !ok {
+		return 0
+	}
+	return uint64(len(payload.Data()))
+}
+
+// fragmentCovers checks if fragment a's payload range includes fragment b's payload range.
+func fragmentCovers(a, b Bundle) bool {
+	aStart, bStart := a.PrimaryBlock.FragmentOffset, b.PrimaryBlock.FragmentOffset
+	aEnd, bEnd := aStart+fragmentPayloadLen(a), bStart+fragmentPayloadLen(b)
+
+	return aStart <= bStart && aEnd >= bEnd
+}
+
+// Expire all partial reassemblies whose first fragment was inserted longer than maxAge ago, e.g., incomplete ones or
+// those started by a late duplicate of an already reassembled Bundle. The amount of dropped reassemblies is returned.
+func (r *Reassembler) Expire(maxAge time.Duration) (expired int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, partial := range r.partials {
+		if time.Since(partial.since) > maxAge {
+			r.drop(key)
+			expired++
+		}
+	}
+	return
+}
+
+// ExpireTimedOut discards all partial reassemblies exceeding the ReassemblerLimits' Timeout. The fragments of those
+// incomplete reassemblies are returned, e.g., to report their deletion.
+func (r *Reassembler) ExpireTimedOut() (fragments []Bundle) {
+	if r.limits.Timeout <= 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, partial := range r.partials {
+		if time.Since(partial.since) > r.limits.Timeout {
+			fragments = append(fragments, partial.fragments...)
+			r.drop(key)
+		}
+	}
+	return
+}
+
+// drop a partial reassembly and release its memory. The mutex must be held.
+func (r *Reassembler) drop(key string) {
+	if partial, ok := r.partials[key]; ok {
+		r.memory -= partial.size
+		delete(r.partials, key)
+	}
+}
+
+// Pending returns the amount of partial reassemblies.
+func (r *Reassembler) Pending() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.partials)
+}
+
+// Memory returns the sum of all held fragments' payload lengths in bytes.
+func (r *Reassembler) Memory() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.memory
+}
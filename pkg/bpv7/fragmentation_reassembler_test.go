@@ -0,0 +1,340 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bpv7
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// reassemblerTestBundle creates a Bundle with a random payload from a source, identified by its number.
+func reassemblerTestBundle(t *testing.T, i int) Bundle {
+	payload := make([]byte, 1024)
+	_, _ = rand.Read(payload)
+
+	b, err := Builder().
+		Source(fmt.Sprintf("dtn://src-%d/", i)).
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func reassemblerTestEqual(t *testing.T, expected Bundle, b *Bundle) {
+	var buff1, buff2 bytes.Buffer
+	if err := expected.MarshalCbor(&buff1); err != nil {
+		t.Fatal(err)
+	} else if err := b.MarshalCbor(&buff2); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buff1.Bytes(), buff2.Bytes()) {
+		t.Fatalf("reassembled bundle differs:\n%x\n%x", buff1.Bytes(), buff2.Bytes())
+	}
+}
+
+func TestReassemblerConcurrentBundles(t *testing.T) {
+	bndls := []Bundle{reassemblerTestBundle(t, 0), reassemblerTestBundle(t, 1)}
+
+	var frags [][]Bundle
+	for _, b := range bndls {
+		if fs, err := b.Fragment(128); err != nil {
+			t.Fatal(err)
+		} else {
+			frags = append(frags, fs)
+		}
+	}
+
+	r := NewReassembler()
+
+	// Interleave the fragments of both bundles and insert each fragment but the completing last one twice.
+	completed := 0
+	for i := 0; i < len(frags[0]) || i < len(frags[1]); i++ {
+		for j := range frags {
+			if i >= len(frags[j]) {
+				continue
+			}
+
+			insertions := 2
+			if i == len(frags[j])-1 {
+				insertions = 1
+			}
+
+			for k := 0; k < insertions; k++ {
+				b, done, err := r.Insert(frags[j][i])
+				if err != nil {
+					t.Fatal(err)
+				} else if !done {
+					continue
+				} else if k > 0 || i != len(frags[j])-1 {
+					t.Fatalf("bundle %d was completed by fragment %d, insertion %d", j, i, k)
+				}
+
+				if b.PrimaryBlock.BundleControlFlags.Has(IsFragment) {
+					t.Fatal("reassembled bundle is still flagged as a fragment")
+				}
+				reassemblerTestEqual(t, bndls[j], b)
+				completed++
+			}
+		}
+	}
+
+	if completed != len(bndls) {
+		t.Fatalf("expected %d reassembled bundles, got %d", len(bndls), completed)
+	}
+	if pending := r.Pending(); pending != 0 {
+		t.Fatalf("expected no pending reassemblies, got %d", pending)
+	}
+}
+
+func TestReassemblerOverlappingFragments(t *testing.T) {
+	bndl := reassemblerTestBundle(t, 0)
+
+	// Fragmenting with different MTUs results in overlapping fragments.
+	small, err := bndl.Fragment(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := bndl.Fragment(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReassembler()
+
+	frags := append(append([]Bundle{}, small[:len(small)/2]...), large[len(large)/2:]...)
+	rand.Shuffle(len(frags), func(i, j int) {
+		frags[i], frags[j] = frags[j], frags[i]
+	})
+
+	var reassembled *Bundle
+	for _, frag := range frags {
+		if b, done, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		} else if done {
+			reassembled = b
+		}
+	}
+
+	// The last inserted fragment might not be the completing one, e.g., if it is fully covered by other fragments.
+	for _, frag := range append(small, large...) {
+		if reassembled != nil {
+			break
+		}
+		if b, done, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		} else if done {
+			reassembled = b
+		}
+	}
+
+	if reassembled == nil {
+		t.Fatal("bundle was not reassembled")
+	}
+	reassemblerTestEqual(t, bndl, reassembled)
+}
+
+func TestReassemblerCoveringFragments(t *testing.T) {
+	bndl := reassemblerTestBundle(t, 0)
+
+	small, err := bndl.Fragment(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := bndl.Fragment(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReassembler()
+
+	// Both first fragments share the same offset, but the large one covers more and must replace the small one.
+	for _, frag := range []Bundle{small[0], large[0], small[0], small[1]} {
+		if _, done, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		} else if done {
+			t.Fatal("bundle was reassembled too early")
+		}
+	}
+
+	if memory, expected := r.Memory(), fragmentPayloadLen(large[0]); memory != expected {
+		t.Fatalf("expected %d bytes held by the covering fragment, got %d", expected, memory)
+	}
+
+	var reassembled *Bundle
+	for _, frag := range large[1:] {
+		if b, done, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		} else if done {
+			reassembled = b
+		}
+	}
+
+	if reassembled == nil {
+		t.Fatal("bundle was not reassembled")
+	}
+	reassemblerTestEqual(t, bndl, reassembled)
+
+	if memory := r.Memory(); memory != 0 {
+		t.Fatalf("expected no held memory, got %d bytes", memory)
+	}
+}
+
+func TestReassemblerNoFragment(t *testing.T) {
+	bndl := reassemblerTestBundle(t, 0)
+
+	if b, done, err := NewReassembler().Insert(bndl); err != nil {
+		t.Fatal(err)
+	} else if !done {
+		t.Fatal("non-fragmented bundle was not returned")
+	} else {
+		reassemblerTestEqual(t, bndl, b)
+	}
+}
+
+func TestReassemblerExpire(t *testing.T) {
+	bndl := reassemblerTestBundle(t, 0)
+	frags, err := bndl.Fragment(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReassembler()
+	if _, done, err := r.Insert(frags[0]); err != nil {
+		t.Fatal(err)
+	} else if done {
+		t.Fatal("first fragment completed the bundle")
+	}
+
+	if expired := r.Expire(time.Hour); expired != 0 {
+		t.Fatalf("expired %d recent reassemblies", expired)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if expired := r.Expire(time.Millisecond); expired != 1 {
+		t.Fatalf("expected one expired reassembly, got %d", expired)
+	}
+	if pending := r.Pending(); pending != 0 {
+		t.Fatalf("expected no pending reassemblies, got %d", pending)
+	}
+
+	// After expiring, the remaining fragments do not suffice.
+	for _, frag := range frags[1:] {
+		if _, done, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		} else if done {
+			t.Fatal("bundle was completed without its expired fragment")
+		}
+	}
+}
+
+func TestReassemblerTimeout(t *testing.T) {
+	bndls := []Bundle{reassemblerTestBundle(t, 0), reassemblerTestBundle(t, 1)}
+
+	var frags [][]Bundle
+	for _, b := range bndls {
+		if fs, err := b.Fragment(128); err != nil {
+			t.Fatal(err)
+		} else {
+			frags = append(frags, fs)
+		}
+	}
+
+	r := NewReassemblerWithLimits(ReassemblerLimits{Timeout: 50 * time.Millisecond})
+
+	// The first bundle's reassembly times out, while the second one starts later.
+	for _, frag := range frags[0][:2] {
+		if _, _, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if discarded := r.ExpireTimedOut(); len(discarded) != 0 {
+		t.Fatalf("discarded %d fragments before the timeout", len(discarded))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, _, err := r.Insert(frags[1][0]); err != nil {
+		t.Fatal(err)
+	}
+
+	discarded := r.ExpireTimedOut()
+	if len(discarded) != 2 {
+		t.Fatalf("expected two discarded fragments, got %d", len(discarded))
+	}
+	for i, frag := range discarded {
+		if frag.ID().Scrub() != bndls[0].ID().Scrub() {
+			t.Fatalf("discarded fragment %d belongs to %v", i, frag.ID())
+		}
+	}
+
+	if pending := r.Pending(); pending != 1 {
+		t.Fatalf("expected one pending reassembly, got %d", pending)
+	}
+
+	for _, frag := range frags[1][1:] {
+		if _, _, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pending, memory := r.Pending(), r.Memory(); pending != 0 || memory != 0 {
+		t.Fatalf("expected an empty reassembler, got %d reassemblies holding %d bytes", pending, memory)
+	}
+}
+
+func TestReassemblerMaxMemory(t *testing.T) {
+	bndl := reassemblerTestBundle(t, 0)
+	frags, err := bndl.Fragment(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The whole 1024 bytes payload fits into the limit. Its memory is released after the reassembly.
+	r := NewReassemblerWithLimits(ReassemblerLimits{MaxMemory: 1024})
+	for i, frag := range frags {
+		if b, done, err := r.Insert(frag); err != nil {
+			t.Fatal(err)
+		} else if done != (i == len(frags)-1) {
+			t.Fatalf("fragment %d has completion state %t", i, done)
+		} else if done {
+			reassemblerTestEqual(t, bndl, b)
+		}
+	}
+	if memory := r.Memory(); memory != 0 {
+		t.Fatalf("completed reassembly's memory was not released, still holding %d bytes", memory)
+	}
+
+	// One byte less does not suffice for the last fragment.
+	r = NewReassemblerWithLimits(ReassemblerLimits{MaxMemory: 1023})
+	for i, frag := range frags {
+		_, done, err := r.Insert(frag)
+		if done {
+			t.Fatalf("fragment %d completed the bundle", i)
+		} else if (err != nil) != (i == len(frags)-1) {
+			t.Fatalf("fragment %d has error state %v", i, err)
+		}
+	}
+
+	held := r.Memory()
+	if held > 1023 {
+		t.Fatalf("reassembler holds %d bytes, exceeding its limit", held)
+	}
+
+	// A duplicate fragment is neither rejected nor counted twice.
+	if _, _, err := r.Insert(frags[0]); err != nil {
+		t.Fatal(err)
+	} else if memory := r.Memory(); memory != held {
+		t.Fatalf("duplicate changed the held memory from %d to %d bytes", held, memory)
+	}
+}
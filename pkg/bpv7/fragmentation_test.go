@@ -65,6 +65,10 @@ func testBundleFragment(t *testing.T, payloadLen, mtu int) {
 			t.Fatalf("Expected offset %d instead of %d", expectedOffset, offset)
 		}
 
+		if total := frag.PrimaryBlock.TotalDataLength; int(total) != payloadLen {
+			t.Fatalf("Expected total data length %d instead of %d", payloadLen, total)
+		}
+
 		if payloadBlock, err := frag.PayloadBlock(); err != nil {
 			t.Fatal(err)
 		} else {
@@ -76,6 +80,74 @@ func testBundleFragment(t *testing.T, payloadLen, mtu int) {
 	}
 }
 
+func TestBundleFragmentFragment(t *testing.T) {
+	payloadData := make([]byte, 1024)
+	rand.Seed(23)
+	_, _ = rand.Read(payloadData)
+
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(payloadData).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frags, err := bndl.Fragment(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fragment each fragment again, e.g., on a later hop with a smaller MTU.
+	var subFrags []Bundle
+	for _, frag := range frags {
+		fs, err := frag.Fragment(160)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(fs) < 2 {
+			t.Fatalf("fragment was not fragmented again, got %d fragments", len(fs))
+		}
+		subFrags = append(subFrags, fs...)
+	}
+
+	expectedOffset := uint64(0)
+	for _, frag := range subFrags {
+		if offset := frag.PrimaryBlock.FragmentOffset; offset != expectedOffset {
+			t.Fatalf("Expected offset %d instead of %d", expectedOffset, offset)
+		}
+
+		if total := frag.PrimaryBlock.TotalDataLength; int(total) != len(payloadData) {
+			t.Fatalf("Expected total data length %d instead of %d", len(payloadData), total)
+		}
+
+		payloadBlock, err := frag.PayloadBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedOffset += uint64(len(payloadBlock.Value.(*PayloadBlock).Data()))
+	}
+
+	bndl2, err := ReassembleFragments(subFrags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buff1, buff2 bytes.Buffer
+	if err = bndl.MarshalCbor(&buff1); err != nil {
+		t.Fatal(err)
+	}
+	if err = bndl2.MarshalCbor(&buff2); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buff1.Bytes(), buff2.Bytes()) {
+		t.Fatalf("Bundles differ:\n%x\n%x", buff1.Bytes(), buff2.Bytes())
+	}
+}
+
 func TestBundleFragmentMustNotFragment(t *testing.T) {
 	bndl, err := Builder().
 		Source("dtn://src/").
@@ -94,6 +166,47 @@ func TestBundleFragmentMustNotFragment(t *testing.T) {
 	}
 }
 
+func TestBundleFragmentReplicateBlock(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		HopCountBlock(64).
+		PayloadBlock(make([]byte, 1024)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the Hop Count Block must be replicated, the Previous Node Block should only be present in the first fragment.
+	hcb, err := bndl.ExtensionBlock(ExtBlockTypeHopCountBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hcb.BlockControlFlags |= ReplicateBlock
+
+	if err := bndl.AddExtensionBlock(NewCanonicalBlock(0, 0, NewPreviousNodeBlock(MustNewEndpointID("dtn://prev/")))); err != nil {
+		t.Fatal(err)
+	}
+
+	frags, err := bndl.Fragment(256)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(frags) < 2 {
+		t.Fatalf("Expected multiple fragments, got %d", len(frags))
+	}
+
+	for i, frag := range frags {
+		if !frag.HasExtensionBlock(ExtBlockTypeHopCountBlock) {
+			t.Fatalf("Fragment %d misses the replicated Hop Count Block", i)
+		}
+		if hasPnb := frag.HasExtensionBlock(ExtBlockTypePreviousNodeBlock); hasPnb != (i == 0) {
+			t.Fatalf("Fragment %d has Previous Node Block: %t", i, hasPnb)
+		}
+	}
+}
+
 func TestBundleFragmentHugeMtu(t *testing.T) {
 	bndl, err := Builder().
 		Source("dtn://src/").
@@ -119,6 +232,31 @@ func TestBundleFragmentHugeMtu(t *testing.T) {
 	}
 }
 
+func TestBundleFragmentEmptyPayload(t *testing.T) {
+	bndl, err := Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("5m").
+		PayloadBlock(nil).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frags, err := bndl.Fragment(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(frags) != 1 {
+		t.Fatalf("Fragmentation of an empty payload resulted in %d fragments, instead of one", len(frags))
+	}
+	if !reflect.DeepEqual(bndl, frags[0]) {
+		t.Fatal("Bundles differ")
+	}
+}
+
 func TestIsBundleReassemblable(t *testing.T) {
 	bndl, err := Builder().
 		Source("dtn://src/").
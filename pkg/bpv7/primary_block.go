@@ -241,23 +241,64 @@ func (pb *PrimaryBlock) UnmarshalCbor(r io.Reader) error {
 	return nil
 }
 
+// primaryBlockJSON is the JSON representation of a PrimaryBlock. The fragment fields are only present for fragments.
+type primaryBlockJSON struct {
+	ControlFlags      BundleControlFlags `json:"bundleControlFlags"`
+	Destination       EndpointID         `json:"destination"`
+	Source            EndpointID         `json:"source"`
+	ReportTo          EndpointID         `json:"reportTo"`
+	CreationTimestamp CreationTimestamp  `json:"creationTimestamp"`
+	Lifetime          uint64             `json:"lifetime"`
+	FragmentOffset    *uint64            `json:"fragmentOffset,omitempty"`
+	TotalDataLength   *uint64            `json:"totalDataLength,omitempty"`
+}
+
 // MarshalJSON writes a JSON object representing this PrimaryBlock.
 func (pb PrimaryBlock) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&struct {
-		ControlFlags      BundleControlFlags `json:"bundleControlFlags"`
-		Destination       string             `json:"destination"`
-		Source            string             `json:"source"`
-		ReportTo          string             `json:"reportTo"`
-		CreationTimestamp CreationTimestamp  `json:"creationTimestamp"`
-		Lifetime          uint64             `json:"lifetime"`
-	}{
+	pbJson := primaryBlockJSON{
 		ControlFlags:      pb.BundleControlFlags,
-		Destination:       pb.Destination.String(),
-		Source:            pb.SourceNode.String(),
-		ReportTo:          pb.ReportTo.String(),
+		Destination:       pb.Destination,
+		Source:            pb.SourceNode,
+		ReportTo:          pb.ReportTo,
 		CreationTimestamp: pb.CreationTimestamp,
 		Lifetime:          pb.Lifetime,
-	})
+	}
+	if pb.HasFragmentation() {
+		pbJson.FragmentOffset = &pb.FragmentOffset
+		pbJson.TotalDataLength = &pb.TotalDataLength
+	}
+
+	return json.Marshal(&pbJson)
+}
+
+// UnmarshalJSON reads a JSON object of a PrimaryBlock, as created by MarshalJSON. As the JSON representation lacks the
+// CRC type, no CRC will be used.
+func (pb *PrimaryBlock) UnmarshalJSON(data []byte) error {
+	var pbJson primaryBlockJSON
+	if err := json.Unmarshal(data, &pbJson); err != nil {
+		return err
+	}
+
+	*pb = PrimaryBlock{
+		Version:            dtnVersion,
+		BundleControlFlags: pbJson.ControlFlags,
+		CRCType:            CRCNo,
+		Destination:        pbJson.Destination,
+		SourceNode:         pbJson.Source,
+		ReportTo:           pbJson.ReportTo,
+		CreationTimestamp:  pbJson.CreationTimestamp,
+		Lifetime:           pbJson.Lifetime,
+	}
+
+	if pb.HasFragmentation() {
+		if pbJson.FragmentOffset == nil || pbJson.TotalDataLength == nil {
+			return fmt.Errorf("PrimaryBlock: fragment lacks its offset or total data length")
+		}
+		pb.FragmentOffset = *pbJson.FragmentOffset
+		pb.TotalDataLength = *pbJson.TotalDataLength
+	}
+
+	return nil
 }
 
 // CheckValid returns an array of errors for incorrect data.
@@ -39,9 +39,23 @@ func (t DtnTime) Time() time.Time {
 	return time.Unix(unixSec, unixNano).UTC()
 }
 
+// dtnTimeLayout is the time.Format layout of DtnTime's String representation.
+const dtnTimeLayout = "2006-01-02 15:04:05.000"
+
 // String returns this DtnTime's string representation.
 func (t DtnTime) String() string {
-	return t.Time().Format("2006-01-02 15:04:05.000")
+	return t.Time().Format(dtnTimeLayout)
+}
+
+// parseDtnTime parses a DtnTime from its String representation.
+func parseDtnTime(s string) (DtnTime, error) {
+	t, err := time.ParseInLocation(dtnTimeLayout, s, time.UTC)
+	if err != nil {
+		return 0, err
+	} else if t.Before(DtnTimeEpoch.Time()) {
+		return 0, fmt.Errorf("time %v precedes the DTN epoch", t)
+	}
+	return DtnTimeFromTime(t), nil
 }
 
 // DtnTimeFromTime returns the DtnTime for the time.Time.
@@ -129,3 +143,22 @@ func (ct CreationTimestamp) MarshalJSON() ([]byte, error) {
 		Seq:  ct.SequenceNumber(),
 	})
 }
+
+// UnmarshalJSON reads a JSON object of a CreationTimestamp, as created by MarshalJSON.
+func (ct *CreationTimestamp) UnmarshalJSON(data []byte) error {
+	var tmpCt struct {
+		Date string `json:"date"`
+		Seq  uint64 `json:"sequenceNo"`
+	}
+	if err := json.Unmarshal(data, &tmpCt); err != nil {
+		return err
+	}
+
+	t, err := parseDtnTime(tmpCt.Date)
+	if err != nil {
+		return err
+	}
+
+	*ct = NewCreationTimestamp(t, tmpCt.Seq)
+	return nil
+}
@@ -197,6 +197,10 @@ func (c *Connector) Address() string {
 	return fmt.Sprintf("bbc://%v", c.modem)
 }
 
+func (c *Connector) GetCLAType() cla.CLAType {
+	return cla.BBC
+}
+
 func (c *Connector) IsPermanent() bool {
 	return c.permanent
 }
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package bibe provides a convergence layer based on the Bundle-in-Bundle Encapsulation (BIBE), as described in
+// draft-ietf-dtn-bibect.
+//
+// A bundle sent over an Endpoint is encapsulated as a BIBEProtocolDataUnit administrative record within an outer
+// bundle, addressed to the tunnel's exit. The outer bundle is routed like any other bundle, e.g., through a DTN region
+// or protected by BPSec as a whole. At the tunnel's exit, another Endpoint receives the outer bundle and reports the
+// decapsulated bundle as a received bundle.
+//
+// Thus, an Endpoint is both a cla.ConvergenceSender and an agent.ApplicationAgent, which must be registered at the
+// same Core. The Endpoint's bundles are passed to the Core through the ApplicationAgent interface.
+package bibe
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// outboxSize is the amount of outer bundles an Endpoint buffers before being passed to the Core.
+const outboxSize = 64
+
+// Endpoint is one side of a BIBE tunnel, both encapsulating bundles for and decapsulating bundles from its peer.
+type Endpoint struct {
+	local bpv7.EndpointID
+	peer  bpv7.EndpointID
+
+	reportChan chan cla.ConvergenceStatus
+
+	receiver chan agent.Message
+	sender   chan agent.Message
+	outbox   chan agent.Message
+
+	stopped chan struct{}
+}
+
+// NewEndpoint for a local BIBE endpoint, e.g., "dtn://entry/bibe", tunneling bundles to the peer's BIBE endpoint,
+// e.g., "dtn://exit/bibe".
+func NewEndpoint(local, peer bpv7.EndpointID) *Endpoint {
+	e := &Endpoint{
+		local: local,
+		peer:  peer,
+
+		reportChan: make(chan cla.ConvergenceStatus),
+
+		receiver: make(chan agent.Message),
+		sender:   make(chan agent.Message),
+		outbox:   make(chan agent.Message, outboxSize),
+
+		stopped: make(chan struct{}),
+	}
+
+	go e.handler()
+
+	return e
+}
+
+func (e *Endpoint) log() *log.Entry {
+	return log.WithFields(log.Fields{"bibe": e.local, "peer": e.peer})
+}
+
+// handler passes outer bundles to the Core and decapsulates received outer bundles.
+func (e *Endpoint) handler() {
+	defer close(e.sender)
+	defer close(e.stopped)
+
+	for {
+		select {
+		case msg := <-e.outbox:
+			e.sender <- msg
+
+		case msg := <-e.receiver:
+			switch msg := msg.(type) {
+			case agent.BundleMessage:
+				e.decapsulate(msg.Bundle)
+
+			case agent.ShutdownMessage:
+				return
+
+			default:
+				e.log().WithField("message", msg).Info("Received unsupported Message")
+			}
+		}
+	}
+}
+
+// decapsulate an outer bundle and report the inner bundle as received.
+func (e *Endpoint) decapsulate(outer bpv7.Bundle) {
+	ar, err := outer.AdministrativeRecord()
+	if err != nil {
+		e.log().WithField("bundle", outer).WithError(err).Warn("Received bundle without an administrative record")
+		return
+	}
+
+	bpdu, ok := ar.(*bpv7.BIBEProtocolDataUnit)
+	if !ok {
+		e.log().WithField("bundle", outer).Warn("Received administrative record is no BIBE protocol data unit")
+		return
+	}
+
+	inner, err := bpdu.Bundle()
+	if err != nil {
+		e.log().WithField("bundle", outer).WithError(err).Warn("Decapsulating bundle failed")
+		return
+	}
+
+	e.log().WithFields(log.Fields{
+		"outer": outer,
+		"inner": inner,
+	}).Debug("Decapsulated bundle")
+
+	select {
+	case e.reportChan <- cla.NewConvergenceReceivedBundle(e, e.local, &inner):
+	case <-e.stopped:
+	}
+}
+
+// Send a bundle to the peer by encapsulating it within an outer bundle, passed to the Core.
+func (e *Endpoint) Send(b bpv7.Bundle) error {
+	// Do not encapsulate outer bundles again, which might be forwarded over this Endpoint for the peer's node.
+	if b.PrimaryBlock.SourceNode == e.local {
+		return fmt.Errorf("BIBE endpoint %v does not encapsulate its own bundle %v", e.local, b.ID())
+	}
+
+	bpdu, err := bpv7.NewBIBEProtocolDataUnit(b)
+	if err != nil {
+		return err
+	}
+
+	outer, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source(e.local).
+		Destination(e.peer).
+		CreationTimestampNow().
+		Lifetime(b.PrimaryBlock.Lifetime).
+		AdministrativeRecord(bpdu).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-e.stopped:
+		return fmt.Errorf("BIBE endpoint %v is closed", e.local)
+	case e.outbox <- agent.BundleMessage{Bundle: outer}:
+		e.log().WithFields(log.Fields{
+			"outer": outer,
+			"inner": b,
+		}).Debug("Encapsulated bundle")
+		return nil
+	default:
+		return fmt.Errorf("BIBE endpoint %v's outbox is full", e.local)
+	}
+}
+
+// Start this Endpoint and report its peer.
+func (e *Endpoint) Start() (error, bool) {
+	go func() {
+		select {
+		case e.reportChan <- cla.NewConvergencePeerAppeared(e, e.peer):
+		case <-e.stopped:
+		}
+	}()
+	return nil, false
+}
+
+// Close is a no-op, as the Endpoint is closed as an ApplicationAgent.
+func (e *Endpoint) Close() error {
+	return nil
+}
+
+// Channel represents a return channel for decapsulated bundles.
+func (e *Endpoint) Channel() chan cla.ConvergenceStatus {
+	return e.reportChan
+}
+
+// Address is an unique identifier of this tunnel.
+func (e *Endpoint) Address() string {
+	return fmt.Sprintf("bibe://%v/%v", e.local, e.peer)
+}
+
+// IsPermanent is true, as a tunnel does not depend on a connection.
+func (e *Endpoint) IsPermanent() bool {
+	return true
+}
+
+// GetPeerEndpointID returns the peer's BIBE endpoint.
+func (e *Endpoint) GetPeerEndpointID() bpv7.EndpointID {
+	return e.peer
+}
+
+// GetCLAType returns cla.BIBE.
+func (e *Endpoint) GetCLAType() cla.CLAType {
+	return cla.BIBE
+}
+
+// Endpoints returns the local BIBE endpoint to receive outer bundles.
+func (e *Endpoint) Endpoints() []bpv7.EndpointID {
+	return []bpv7.EndpointID{e.local}
+}
+
+// MessageReceiver is a channel on which the Endpoint listens for outer bundles.
+func (e *Endpoint) MessageReceiver() chan agent.Message {
+	return e.receiver
+}
+
+// MessageSender is a channel to which the Endpoint sends outer bundles.
+func (e *Endpoint) MessageSender() chan agent.Message {
+	return e.sender
+}
+
+func (e *Endpoint) String() string {
+	return e.Address()
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bibe
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestEndpointTunnel(t *testing.T) {
+	entry := NewEndpoint(bpv7.MustNewEndpointID("dtn://entry/bibe"), bpv7.MustNewEndpointID("dtn://exit/bibe"))
+	exit := NewEndpoint(bpv7.MustNewEndpointID("dtn://exit/bibe"), bpv7.MustNewEndpointID("dtn://entry/bibe"))
+	defer func() {
+		entry.MessageReceiver() <- agent.ShutdownMessage{}
+		exit.MessageReceiver() <- agent.ShutdownMessage{}
+	}()
+
+	inner, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://alice/app").
+		Destination("dtn://bob/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello through the tunnel")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entry.Send(inner); err != nil {
+		t.Fatal(err)
+	}
+
+	var outer bpv7.Bundle
+	select {
+	case msg := <-entry.MessageSender():
+		outer = msg.(agent.BundleMessage).Bundle
+	case <-time.After(time.Second):
+		t.Fatal("entry did not pass an outer bundle")
+	}
+
+	if outer.PrimaryBlock.Destination != exit.local {
+		t.Fatalf("outer bundle is addressed to %v, not to %v", outer.PrimaryBlock.Destination, exit.local)
+	}
+
+	// The outer bundle must not be encapsulated again, e.g., when being forwarded over the entry itself.
+	if err := entry.Send(outer); err == nil {
+		t.Fatal("entry encapsulated its own outer bundle")
+	}
+
+	// Pass the outer bundle through its CBOR representation, as if it was transmitted over another CLA.
+	var outerBuff bytes.Buffer
+	if err := outer.MarshalCbor(&outerBuff); err != nil {
+		t.Fatal(err)
+	}
+	received, err := bpv7.ParseBundle(&outerBuff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exit.MessageReceiver() <- agent.BundleMessage{Bundle: received}
+
+	var decapsulated bpv7.Bundle
+	select {
+	case cs := <-exit.Channel():
+		if cs.MessageType != cla.ReceivedBundle {
+			t.Fatalf("expected a received bundle, got %v", cs)
+		}
+		decapsulated = *cs.Message.(cla.ConvergenceReceivedBundle).Bundle
+	case <-time.After(time.Second):
+		t.Fatal("exit did not report the decapsulated bundle")
+	}
+
+	var innerBuff, decapsulatedBuff bytes.Buffer
+	if err := inner.MarshalCbor(&innerBuff); err != nil {
+		t.Fatal(err)
+	} else if err := decapsulated.MarshalCbor(&decapsulatedBuff); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(innerBuff.Bytes(), decapsulatedBuff.Bytes()) {
+		t.Fatalf("decapsulated bundle differs:\n%x\n%x", innerBuff.Bytes(), decapsulatedBuff.Bytes())
+	}
+}
@@ -7,6 +7,8 @@ package cla
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // CLAType is one of the supported Convergence Layer Adaptors
@@ -28,6 +30,12 @@ const (
 
 	QUICL CLAType = 30
 
+	// BIBE identifies the Bundle-in-Bundle Encapsulation, implemented in cla/bibe.
+	BIBE CLAType = 40
+
+	// WebSocket identifies the bidirectional WebSocket convergence layer, implemented in cla/websocket.
+	WebSocket CLAType = 50
+
 	unknownClaTypeString string = "unknown CLA type"
 )
 
@@ -56,7 +64,58 @@ func (claType CLAType) String() string {
 	case QUICL:
 		return "QUICL"
 
+	case BIBE:
+		return "BIBE"
+
+	case WebSocket:
+		return "WebSocket"
+
 	default:
 		return unknownClaTypeString
 	}
 }
+
+// ParseCLAType returns the CLAType for its configuration name, as used for the "protocol" field of dtnd's
+// configuration, e.g., "tcpclv4" or "mtcp". The comparison is case-insensitive.
+func ParseCLAType(name string) (claType CLAType, err error) {
+	switch strings.ToLower(name) {
+	case "tcpclv4":
+		claType = TCPCLv4
+
+	case "tcpclv4-ws":
+		claType = TCPCLv4WebSocket
+
+	case "mtcp":
+		claType = MTCP
+
+	case "bbc":
+		claType = BBC
+
+	case "quicl":
+		claType = QUICL
+
+	case "bibe":
+		claType = BIBE
+
+	case "websocket":
+		claType = WebSocket
+
+	default:
+		err = fmt.Errorf("%s \"%s\"", unknownClaTypeString, name)
+	}
+	return
+}
+
+// ConvergenceTyped might be implemented by a Convergence to expose its CLAType.
+type ConvergenceTyped interface {
+	// GetCLAType returns the CLAType of this Convergence.
+	GetCLAType() CLAType
+}
+
+// GetCLAType returns the CLAType of a Convergable, if it implements ConvergenceTyped.
+func GetCLAType(conv Convergable) (claType CLAType, ok bool) {
+	if ct, isTyped := conv.(ConvergenceTyped); isTyped {
+		claType, ok = ct.GetCLAType(), true
+	}
+	return
+}
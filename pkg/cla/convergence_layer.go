@@ -95,6 +95,21 @@ type ConvergenceSender interface {
 	GetPeerEndpointID() bpv7.EndpointID
 }
 
+// ConvergenceMTU might be implemented by a ConvergenceSender whose link limits the size of a serialized Bundle.
+// Larger Bundles are fragmented before being sent, unless their control flags forbid fragmentation.
+type ConvergenceMTU interface {
+	// MTU returns the maximum size of a serialized Bundle in bytes. A non-positive MTU disables this limit.
+	MTU() int
+}
+
+// GetMTU returns the MTU of a ConvergenceSender, if it implements ConvergenceMTU and limits the Bundle size.
+func GetMTU(cs ConvergenceSender) (mtu int, ok bool) {
+	if cm, hasMTU := cs.(ConvergenceMTU); hasMTU && cm.MTU() > 0 {
+		mtu, ok = cm.MTU(), true
+	}
+	return
+}
+
 // ConvergenceProvider is a more general kind of CLA service which does not
 // transfer any Bundles by itself, but supplies/creates new Convergence types.
 // Those Convergence objects will be passed to a Manager. Thus, one might think
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RebindingListener accepts TCP connections on a listen address. If binding this address fails, e.g., because the
+// port is still occupied, or if the listening socket dies later, the address is bound again after an exponential
+// backoff. Thus, a listener recovers from transient failures instead of erring once.
+//
+// A RebindingListener is not safe for concurrent use and is intended to be driven by a single accepting goroutine.
+type RebindingListener struct {
+	addr    *net.TCPAddr
+	backoff BackoffConfig
+
+	ln          *net.TCPListener
+	failures    int
+	nextAttempt time.Time
+}
+
+// NewRebindingListener for a TCP listen address. Its BackoffConfig's unset fields fall back to the defaults. An
+// unresolvable address results in an error, as it cannot be fixed by waiting.
+//
+// The address is bound at once. A failure is logged and the next attempt is made by Accept after the backoff.
+func NewRebindingListener(address string, backoff BackoffConfig) (*RebindingListener, error) {
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := &RebindingListener{
+		addr:    addr,
+		backoff: backoff.withDefaults(),
+	}
+	rl.bind()
+
+	return rl, nil
+}
+
+// bind the listen address, scheduling the next attempt on failure.
+func (rl *RebindingListener) bind() {
+	ln, err := net.ListenTCP("tcp", rl.addr)
+	if err != nil {
+		rl.failed(err)
+		return
+	}
+
+	if rl.failures > 0 {
+		log.WithField("address", rl.addr).Info("Listener bound its address again")
+	}
+
+	rl.ln = ln
+	rl.failures = 0
+}
+
+// failed closes a possibly existing socket and schedules the next binding attempt.
+func (rl *RebindingListener) failed(err error) {
+	if rl.ln != nil {
+		_ = rl.ln.Close()
+		rl.ln = nil
+	}
+
+	rl.failures++
+	interval := rl.backoff.interval(rl.failures)
+	rl.nextAttempt = time.Now().Add(interval)
+
+	log.WithFields(log.Fields{
+		"address":  rl.addr,
+		"failures": rl.failures,
+		"retry-in": interval,
+		"error":    err,
+	}).Warn("Listener failed to bind its address, retrying")
+}
+
+// IsBound checks if the listen address is currently bound.
+func (rl *RebindingListener) IsBound() bool {
+	return rl.ln != nil
+}
+
+// Accept waits up to the timeout for an incoming connection. A nil connection is returned after the timeout, while
+// waiting for the next binding attempt, or after a failure of the listening socket. Thus, Accept should be called
+// repeatedly until the listener is closed.
+func (rl *RebindingListener) Accept(timeout time.Duration) net.Conn {
+	if rl.ln == nil {
+		if wait := time.Until(rl.nextAttempt); wait > 0 {
+			if wait > timeout {
+				wait = timeout
+			}
+			time.Sleep(wait)
+			return nil
+		}
+
+		if rl.bind(); rl.ln == nil {
+			return nil
+		}
+	}
+
+	if err := rl.ln.SetDeadline(time.Now().Add(timeout)); err != nil {
+		rl.failed(err)
+		return nil
+	}
+
+	conn, err := rl.ln.Accept()
+	if err == nil {
+		return conn
+	} else if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return nil
+	}
+
+	rl.failed(err)
+	return nil
+}
+
+// Close the listening socket.
+func (rl *RebindingListener) Close() error {
+	if rl.ln == nil {
+		return nil
+	}
+
+	err := rl.ln.Close()
+	rl.ln = nil
+	return err
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// acceptWithin calls Accept until a connection was accepted or the deadline has passed.
+func acceptWithin(rl *RebindingListener, timeout time.Duration) net.Conn {
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); {
+		if conn := rl.Accept(10 * time.Millisecond); conn != nil {
+			return conn
+		}
+	}
+	return nil
+}
+
+// dialWithin dials until a connection was established or the deadline has passed.
+func dialWithin(address string, timeout time.Duration) net.Conn {
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if conn, err := net.Dial("tcp", address); err == nil {
+			return conn
+		}
+	}
+	return nil
+}
+
+func TestRebindingListenerOccupiedPort(t *testing.T) {
+	occupier, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := occupier.Addr().String()
+
+	rl, err := NewRebindingListener(address, BackoffConfig{Base: 20 * time.Millisecond, Max: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = rl.Close() }()
+
+	if rl.IsBound() {
+		t.Fatal("listener is bound to an occupied port")
+	}
+	if conn := rl.Accept(50 * time.Millisecond); conn != nil {
+		t.Fatal("listener accepted a connection without being bound")
+	}
+
+	_ = occupier.Close()
+
+	go func() {
+		if conn := dialWithin(address, time.Second); conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	if conn := acceptWithin(rl, time.Second); conn == nil {
+		t.Fatal("listener did not accept a connection after the port was freed")
+	} else {
+		_ = conn.Close()
+	}
+}
+
+func TestRebindingListenerSocketDeath(t *testing.T) {
+	rl, err := NewRebindingListener("127.0.0.1:0", BackoffConfig{Base: 20 * time.Millisecond, Max: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = rl.Close() }()
+
+	if !rl.IsBound() {
+		t.Fatal("listener is not bound")
+	}
+
+	// Pin the randomly chosen port for rebinding, then let the socket die underneath.
+	rl.addr = rl.ln.Addr().(*net.TCPAddr)
+	_ = rl.ln.Close()
+
+	if conn := rl.Accept(10 * time.Millisecond); conn != nil || rl.IsBound() {
+		t.Fatal("listener did not notice its dead socket")
+	}
+
+	go func() {
+		if conn := dialWithin(rl.addr.String(), time.Second); conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	if conn := acceptWithin(rl, time.Second); conn == nil {
+		t.Fatal("listener did not accept a connection after rebinding")
+	} else {
+		_ = conn.Close()
+	}
+}
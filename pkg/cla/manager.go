@@ -8,6 +8,7 @@
 package cla
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -21,23 +22,42 @@ import (
 // further actions based on these, but does not have to take care of the
 // CLA administration themselves.
 type Manager struct {
-	// queueTtl is the amount of retries for a CLA.
+	// queueTtl is the amount of retries for a CLA, the BackoffConfig's MaxAttempts.
 	queueTtl int32
 
-	// retryTime is the duration between two activation attempts.
-	retryTime time.Duration
+	// backoff configures the duration between two activation attempts.
+	backoff BackoffConfig
+
+	// backoffStates keeps the backoffState of each restarted CLA's address until it is registered again.
+	backoffStates      map[string]backoffState
+	backoffStatesMutex sync.Mutex
+
+	// queueConf is the ReceiveQueueConfig for newly registered CLAs.
+	queueConf      ReceiveQueueConfig
+	queueConfMutex sync.Mutex
 
 	// convs maps each CLA's address to a wrapped convergenceElem struct.
 	// convs: Map[string]*convergenceElem
 	convs *sync.Map
 
-	listenerIDs map[CLAType][]bpv7.EndpointID
+	listenerIDs      map[CLAType][]bpv7.EndpointID
+	listenerIDsMutex sync.RWMutex
 
 	// providers is an array of ConvergenceProvider. Those will report their
 	// created Convergence objects to this Manager, which also supervises it.
 	providers      []ConvergenceProvider
 	providersMutex sync.Mutex
 
+	// preference orders CLATypes for peers reachable by multiple ConvergenceSenders, see SetCLAPreference.
+	preference      []CLAType
+	preferenceMutex sync.RWMutex
+
+	// restarts counts all restarted CLAs while forwarded counts the sent
+	// bundles for each CLA's address. Both are reported by Metrics.
+	restarts     uint64
+	forwarded    map[string]uint64
+	metricsMutex sync.Mutex
+
 	// inChnl receives ConvergenceStatus while outChnl passes it on. Both channels
 	// are not buffered. While this is not a problem for inChnl, outChnl must
 	// always be read, otherwise the Manager will block.
@@ -54,16 +74,25 @@ type Manager struct {
 	stopFlagMutex sync.Mutex
 }
 
-// NewManager creates a new Manager to supervise different CLAs.
-func NewManager() *Manager {
+// NewManager creates a new Manager to supervise different CLAs. Inactive CLAs are retried with an exponential backoff,
+// configured by the BackoffConfig. Its unset fields fall back to the defaults. Each CLA's backoff is adapted to its
+// recent activation attempts, retrying CLAs which usually work faster, compare ManagerMetrics' RetryIntervals. Unless
+// limited by the BackoffConfig's MaxAttempts, inactive CLAs are retried indefinitely.
+func NewManager(backoff BackoffConfig) *Manager {
+	backoff = backoff.withDefaults()
+
 	manager := &Manager{
-		queueTtl:  10,
-		retryTime: 10 * time.Second,
+		queueTtl: int32(backoff.MaxAttempts),
+		backoff:  backoff,
+
+		backoffStates: make(map[string]backoffState),
 
 		convs: new(sync.Map),
 
 		listenerIDs: make(map[CLAType][]bpv7.EndpointID),
 
+		forwarded: make(map[string]uint64),
+
 		inChnl:  make(chan ConvergenceStatus, 100),
 		outChnl: make(chan ConvergenceStatus),
 
@@ -80,7 +109,7 @@ func NewManager() *Manager {
 
 // handler is the internal goroutine for management.
 func (manager *Manager) handler() {
-	activateTicker := time.NewTicker(manager.retryTime)
+	activateTicker := time.NewTicker(manager.backoff.tick())
 	defer activateTicker.Stop()
 
 	for {
@@ -119,16 +148,16 @@ func (manager *Manager) handler() {
 				}).Info("CLA Manager received Peer Disappeared, restarting CLA")
 
 				manager.Restart(cs.Sender)
-				manager.outChnl <- cs
+				manager.passOn(cs)
 
 			default:
-				manager.outChnl <- cs
+				manager.passOn(cs)
 			}
 
-		case <-activateTicker.C:
+		case now := <-activateTicker.C:
 			manager.convs.Range(func(key, convElem interface{}) bool {
 				ce := convElem.(*convergenceElem)
-				if ce.isActive() {
+				if ce.isActive() || !ce.isAttemptDue(now) {
 					return true
 				}
 
@@ -138,6 +167,7 @@ func (manager *Manager) handler() {
 					}).Warn("Startup of CLA failed, a retry should not be made")
 
 					manager.convs.Delete(key)
+					manager.dropMetrics(key.(string))
 				}
 				return true
 			})
@@ -145,6 +175,15 @@ func (manager *Manager) handler() {
 	}
 }
 
+// passOn a ConvergenceStatus to the outgoing channel. A closing Manager does not block, as its recipient, e.g., the
+// Core, might already be waiting for Close to return instead of reading the channel.
+func (manager *Manager) passOn(cs ConvergenceStatus) {
+	select {
+	case manager.outChnl <- cs:
+	case <-manager.stopSyn:
+	}
+}
+
 // Channel references the outgoing channel for ConvergenceStatus messages.
 func (manager *Manager) Channel() chan ConvergenceStatus {
 	return manager.outChnl
@@ -170,6 +209,38 @@ func (manager *Manager) Close() error {
 	return nil
 }
 
+// SetReceiveQueue configures a bounded receive queue for each subsequently registered CLA. This allows a CLA to continue
+// receiving bundles while the Channel's consumer is slow.
+func (manager *Manager) SetReceiveQueue(conf ReceiveQueueConfig) error {
+	if conf.Depth < 0 {
+		return fmt.Errorf("receive queue depth %d is negative", conf.Depth)
+	}
+
+	manager.queueConfMutex.Lock()
+	defer manager.queueConfMutex.Unlock()
+
+	manager.queueConf = conf
+	return nil
+}
+
+// ReceiveQueueStats returns the receive queue's stats for each active CLA with a receive queue, identified by its
+// address.
+func (manager *Manager) ReceiveQueueStats() map[string]ReceiveQueueStats {
+	stats := make(map[string]ReceiveQueueStats)
+	manager.convs.Range(func(address, convElem interface{}) bool {
+		ce := convElem.(*convergenceElem)
+		if !ce.isActive() {
+			return true
+		}
+
+		if ceStats, ok := ce.queueStats(); ok {
+			stats[address.(string)] = ceStats
+		}
+		return true
+	})
+	return stats
+}
+
 // Register any kind of Convergable.
 func (manager *Manager) Register(conv Convergable) {
 	if manager.isStopped() {
@@ -186,9 +257,23 @@ func (manager *Manager) Register(conv Convergable) {
 }
 
 func (manager *Manager) registerConvergence(conv Convergence) {
-	// Check if this CLA is already known. Re-activate a deactivated CLA or abort.
-	var ce *convergenceElem
-	if convElem, exists := manager.convs.Load(conv.Address()); exists {
+	manager.queueConfMutex.Lock()
+	ce := newConvergenceElement(conv, manager.inChnl, manager.queueTtl, manager.queueConf, manager.backoff)
+	manager.queueConfMutex.Unlock()
+
+	// A restarted CLA continues with its previous backoff, otherwise a flapping link would always start afresh.
+	manager.backoffStatesMutex.Lock()
+	if state, ok := manager.backoffStates[conv.Address()]; ok {
+		ce.backoffState = state
+		delete(manager.backoffStates, conv.Address())
+	}
+	manager.backoffStatesMutex.Unlock()
+
+	// Check if this CLA is already known. Re-activate a deactivated CLA or abort. Otherwise, the new and still inactive
+	// convergenceElem is stored at once, preventing concurrent registrations of the same address. As inactive elements
+	// are ignored, e.g., by the Sender method, a CLA becomes visible only after being started.
+	convElem, exists := manager.convs.LoadOrStore(conv.Address(), ce)
+	if exists {
 		ce = convElem.(*convergenceElem)
 		if ce.isActive() {
 			log.WithFields(log.Fields{
@@ -198,8 +283,6 @@ func (manager *Manager) registerConvergence(conv Convergence) {
 
 			return
 		}
-	} else {
-		ce = newConvergenceElement(conv, manager.inChnl, manager.queueTtl)
 	}
 
 	// Check if this CLA is a sender to a registered receiver.
@@ -211,6 +294,9 @@ func (manager *Manager) registerConvergence(conv Convergence) {
 					"address": conv.Address(),
 				}).Debug("CLA registration aborted, because of a known Endpoint ID")
 
+				if !exists {
+					manager.convs.Delete(conv.Address())
+				}
 				return
 			}
 		}
@@ -221,8 +307,15 @@ func (manager *Manager) registerConvergence(conv Convergence) {
 			"cla":     conv,
 			"address": conv.Address(),
 		}).Warn("Startup of CLA  failed, a retry should not be made")
-	} else {
-		manager.convs.Store(conv.Address(), ce)
+
+		if !exists {
+			manager.convs.Delete(conv.Address())
+		}
+	} else if successful {
+		// A concurrent Unregister might have removed this element during its activation.
+		if convElem, loaded := manager.convs.LoadOrStore(conv.Address(), ce); loaded && convElem != ce {
+			ce.deactivate(manager.queueTtl)
+		}
 	}
 }
 
@@ -249,7 +342,7 @@ func (manager *Manager) registerProvider(conv ConvergenceProvider) {
 // Unregister any kind of Convergable.
 func (manager *Manager) Unregister(conv Convergable) {
 	if c, ok := conv.(Convergence); ok {
-		manager.unregisterConvergence(c)
+		manager.unregisterConvergence(c, false)
 	} else if c, ok := conv.(ConvergenceProvider); ok {
 		manager.unregisterProvider(c)
 	} else {
@@ -257,7 +350,9 @@ func (manager *Manager) Unregister(conv Convergable) {
 	}
 }
 
-func (manager *Manager) unregisterConvergence(conv Convergence) {
+// unregisterConvergence stops and removes a Convergence. Its metrics are dropped, unless restarting is set for a
+// Convergence being restarted, which also keeps its backoffState for the next registration.
+func (manager *Manager) unregisterConvergence(conv Convergence, restarting bool) {
 	convElem, exists := manager.convs.Load(conv.Address())
 	if !exists {
 		log.WithFields(log.Fields{
@@ -281,6 +376,14 @@ func (manager *Manager) unregisterConvergence(conv Convergence) {
 
 	element.deactivate(manager.queueTtl)
 	manager.convs.Delete(conv.Address())
+
+	if restarting {
+		manager.backoffStatesMutex.Lock()
+		manager.backoffStates[conv.Address()] = element.currentBackoffState()
+		manager.backoffStatesMutex.Unlock()
+	} else {
+		manager.dropMetrics(conv.Address())
+	}
 }
 
 func (manager *Manager) unregisterProvider(conv ConvergenceProvider) {
@@ -297,11 +400,20 @@ func (manager *Manager) unregisterProvider(conv ConvergenceProvider) {
 
 // Restart a known Convergable.
 func (manager *Manager) Restart(conv Convergable) {
-	manager.Unregister(conv)
+	manager.metricsMutex.Lock()
+	manager.restarts++
+	manager.metricsMutex.Unlock()
+
+	if c, ok := conv.(Convergence); ok {
+		manager.unregisterConvergence(c, true)
+	} else {
+		manager.Unregister(conv)
+	}
 	manager.Register(conv)
 }
 
-// Sender returns an array of all active ConvergenceSenders.
+// Sender returns an array of all active ConvergenceSenders. This is a snapshot, which can be safely iterated while
+// CLAs are being registered or unregistered. It only contains started ConvergenceSenders with a known peer.
 func (manager *Manager) Sender() (css []ConvergenceSender) {
 	manager.convs.Range(func(_, convElem interface{}) bool {
 		ce := convElem.(*convergenceElem)
@@ -309,7 +421,7 @@ func (manager *Manager) Sender() (css []ConvergenceSender) {
 			return true
 		}
 
-		if cs, ok := ce.asSender(); ok {
+		if cs, ok := ce.asSender(); ok && cs.GetPeerEndpointID().EndpointType != nil {
 			css = append(css, cs)
 		}
 		return true
@@ -334,6 +446,9 @@ func (manager *Manager) Receiver() (crs []ConvergenceReceiver) {
 }
 
 func (manager *Manager) RegisterEndpointID(claType CLAType, eid bpv7.EndpointID) {
+	manager.listenerIDsMutex.Lock()
+	defer manager.listenerIDsMutex.Unlock()
+
 	clas, ok := manager.listenerIDs[claType]
 
 	if ok {
@@ -348,14 +463,20 @@ func (manager *Manager) RegisterEndpointID(claType CLAType, eid bpv7.EndpointID)
 // EndpointIDs returns the EndpointIDs of all registered CLAs of the specified type.
 // Returns an empty slice if no CLAs of the tye exist.
 func (manager *Manager) EndpointIDs(claType CLAType) []bpv7.EndpointID {
+	manager.listenerIDsMutex.RLock()
+	defer manager.listenerIDsMutex.RUnlock()
+
 	if clas, ok := manager.listenerIDs[claType]; ok {
-		return clas
+		return append([]bpv7.EndpointID{}, clas...)
 	} else {
 		return make([]bpv7.EndpointID, 0)
 	}
 }
 
 func (manager *Manager) HasEndpoint(endpoint bpv7.EndpointID) bool {
+	manager.listenerIDsMutex.RLock()
+	defer manager.listenerIDsMutex.RUnlock()
+
 	for _, clas := range manager.listenerIDs {
 		for _, adapter := range clas {
 			if adapter.Authority() == endpoint.Authority() {
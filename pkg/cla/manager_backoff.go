@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// defaultBackoffBase is the BackoffConfig's default Base.
+	defaultBackoffBase = 10 * time.Second
+
+	// defaultBackoffMax is the BackoffConfig's default Max.
+	defaultBackoffMax = 10 * time.Minute
+
+	// backoffHistorySize is the number of recent activation attempts of a CLA to adapt its backoff.
+	backoffHistorySize = 8
+)
+
+// BackoffConfig configures the exponential backoff between two activation attempts of an inactive CLA. Each failed
+// attempt doubles the interval, starting at Base and limited by Max. A successful activation resets the backoff.
+// Once capped, an inactive CLA is retried at the Max interval, unless MaxAttempts limits the retries.
+type BackoffConfig struct {
+	// Base is the interval after the first failed attempt, defaults to 10s.
+	Base time.Duration
+
+	// Max limits the interval, defaults to 10m.
+	Max time.Duration
+
+	// Jitter randomly varies each interval by up to this fraction, e.g., 0.1 for ±10%, to not synchronize multiple
+	// nodes' attempts. Must be between 0 and 1.
+	Jitter float64
+
+	// MaxAttempts limits the consecutive failed activation attempts of a CLA which is not permanent before the
+	// Manager gives up on it, defaults to 0 for unlimited attempts. It is ignored for rebinding listeners.
+	MaxAttempts int
+}
+
+// withDefaults returns a copy of this BackoffConfig, whose unset or invalid fields are replaced by the defaults.
+func (conf BackoffConfig) withDefaults() BackoffConfig {
+	if conf.Base <= 0 {
+		conf.Base = defaultBackoffBase
+	}
+	if conf.Max <= 0 {
+		conf.Max = defaultBackoffMax
+	}
+	if conf.Max < conf.Base {
+		conf.Max = conf.Base
+	}
+	if conf.Jitter < 0 || conf.Jitter > 1 {
+		conf.Jitter = 0
+	}
+	if conf.MaxAttempts < 0 {
+		conf.MaxAttempts = 0
+	}
+	return conf
+}
+
+// interval until the next attempt after the given number of consecutive failures.
+func (conf BackoffConfig) interval(failures int) time.Duration {
+	interval := conf.Base
+	for i := 1; i < failures && interval < conf.Max; i++ {
+		interval *= 2
+	}
+	if interval > conf.Max {
+		interval = conf.Max
+	}
+
+	if conf.Jitter > 0 {
+		interval += time.Duration((rand.Float64()*2 - 1) * conf.Jitter * float64(interval))
+	}
+	return interval
+}
+
+// adaptiveInterval is the interval after the given number of consecutive failures, adapted to a CLA's success ratio
+// of its recent activation attempts, compare backoffHistory. A CLA which usually works is retried up to twice as fast,
+// while a chronically failing CLA keeps the full exponential backoff.
+func (conf BackoffConfig) adaptiveInterval(failures int, successRatio float64) time.Duration {
+	return time.Duration(float64(conf.interval(failures)) / math.Pow(2, successRatio))
+}
+
+// backoffState is a CLA's number of consecutive failures and its history of recent activation attempts. The Manager
+// keeps it across restarts of the CLA's address, e.g., for a flapping link whose peer disappears repeatedly.
+type backoffState struct {
+	failures int
+	history  backoffHistory
+}
+
+// backoffHistory records the outcomes of a CLA's recent activation attempts.
+type backoffHistory struct {
+	outcomes [backoffHistorySize]bool
+	length   int
+	next     int
+}
+
+// add an activation attempt's outcome, replacing the oldest one if the history is full.
+func (history *backoffHistory) add(successful bool) {
+	history.outcomes[history.next] = successful
+	history.next = (history.next + 1) % backoffHistorySize
+	if history.length < backoffHistorySize {
+		history.length++
+	}
+}
+
+// successRatio of the recorded activation attempts between 0 and 1; 0 for an empty history.
+func (history *backoffHistory) successRatio() float64 {
+	if history.length == 0 {
+		return 0
+	}
+
+	successes := 0
+	for i := 0; i < history.length; i++ {
+		if history.outcomes[i] {
+			successes++
+		}
+	}
+	return float64(successes) / float64(history.length)
+}
+
+// tick is the Manager's interval to check for due activation attempts.
+func (conf BackoffConfig) tick() time.Duration {
+	if conf.Base < time.Second {
+		return conf.Base
+	}
+	return time.Second
+}
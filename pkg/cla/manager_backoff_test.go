@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestBackoffConfigInterval(t *testing.T) {
+	conf := BackoffConfig{Base: time.Second, Max: 10 * time.Second}.withDefaults()
+
+	expected := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, interval := range expected {
+		if actual := conf.interval(i + 1); actual != interval {
+			t.Fatalf("interval after %d failures is %v, expected %v", i+1, actual, interval)
+		}
+	}
+
+	conf.Jitter = 0.25
+	for i := 0; i < 100; i++ {
+		if interval := conf.interval(2); interval < 1500*time.Millisecond || interval > 2500*time.Millisecond {
+			t.Fatalf("interval %v exceeds the jitter", interval)
+		}
+	}
+}
+
+func TestBackoffConfigDefaults(t *testing.T) {
+	tests := []struct {
+		conf     BackoffConfig
+		expected BackoffConfig
+	}{
+		{BackoffConfig{}, BackoffConfig{defaultBackoffBase, defaultBackoffMax, 0, 0}},
+		{BackoffConfig{Base: time.Second}, BackoffConfig{time.Second, defaultBackoffMax, 0, 0}},
+		{BackoffConfig{Base: time.Hour}, BackoffConfig{time.Hour, time.Hour, 0, 0}},
+		{BackoffConfig{Jitter: 0.1}, BackoffConfig{defaultBackoffBase, defaultBackoffMax, 0.1, 0}},
+		{BackoffConfig{Base: -time.Second, Jitter: 2}, BackoffConfig{defaultBackoffBase, defaultBackoffMax, 0, 0}},
+		{BackoffConfig{MaxAttempts: 3}, BackoffConfig{defaultBackoffBase, defaultBackoffMax, 0, 3}},
+		{BackoffConfig{MaxAttempts: -1}, BackoffConfig{defaultBackoffBase, defaultBackoffMax, 0, 0}},
+	}
+
+	for _, test := range tests {
+		if conf := test.conf.withDefaults(); conf != test.expected {
+			t.Fatalf("%v resulted in %v, expected %v", test.conf, conf, test.expected)
+		}
+	}
+}
+
+func TestConvergenceElemBackoff(t *testing.T) {
+	conv := newMockConvSender(false, "mock://sender/", bpv7.MustNewEndpointID("dtn://ms/"))
+	conv.permanent = true
+
+	backoff := BackoffConfig{Base: time.Minute, Max: 4 * time.Minute}.withDefaults()
+	ce := newConvergenceElement(conv, make(chan ConvergenceStatus, 10), 10, ReceiveQueueConfig{}, backoff)
+
+	if !ce.isAttemptDue(time.Now()) {
+		t.Fatal("first attempt is not due")
+	}
+
+	for i, interval := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+		before := time.Now()
+		if successful, retry := ce.activate(); successful || !retry {
+			t.Fatalf("activation %d resulted in successful = %t, retry = %t", i, successful, retry)
+		}
+
+		if ce.isAttemptDue(before.Add(interval - time.Second)) {
+			t.Fatalf("attempt after %d failures is due before %v", i+1, interval)
+		} else if !ce.isAttemptDue(time.Now().Add(interval)) {
+			t.Fatalf("attempt after %d failures is not due after %v", i+1, interval)
+		}
+	}
+
+	conv.startable = true
+	if successful, _ := ce.activate(); !successful {
+		t.Fatal("activation failed")
+	}
+	defer ce.deactivate(0)
+
+	if ce.failures != 0 || atomic.LoadInt64(&ce.nextAttempt) != 0 {
+		t.Fatalf("backoff was not reset, %d failures", ce.failures)
+	}
+}
+
+func TestConvergenceElemMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		attempts    int
+	}{
+		{"unlimited", 0, 100},
+		{"limited", 3, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conv := newMockConvSender(false, "mock://sender/", bpv7.MustNewEndpointID("dtn://ms/"))
+
+			backoff := BackoffConfig{Base: time.Minute, MaxAttempts: test.maxAttempts}.withDefaults()
+			ttl := int32(backoff.MaxAttempts)
+			ce := newConvergenceElement(conv, make(chan ConvergenceStatus, 10), ttl, ReceiveQueueConfig{}, backoff)
+
+			for i := 0; i < test.attempts; i++ {
+				if successful, retry := ce.activate(); successful || !retry {
+					t.Fatalf("activation %d resulted in successful = %t, retry = %t", i, successful, retry)
+				}
+			}
+
+			_, retry := ce.activate()
+			if expected := test.maxAttempts == 0; retry != expected {
+				t.Fatalf("activation after %d attempts resulted in retry = %t", test.attempts, retry)
+			}
+		})
+	}
+}
+
+func TestConvergenceElemAdaptiveBackoff(t *testing.T) {
+	backoff := BackoffConfig{Base: time.Minute, Max: time.Hour}.withDefaults()
+
+	// failedAttempt activates the convergenceElem unsuccessfully and returns its new retry interval.
+	failedAttempt := func(ce *convergenceElem) time.Duration {
+		if successful, retry := ce.activate(); successful || !retry {
+			t.Fatalf("activation resulted in successful = %t, retry = %t", successful, retry)
+		}
+
+		interval, ok := ce.currentRetryInterval()
+		if !ok {
+			t.Fatal("no retry is scheduled")
+		}
+		return interval
+	}
+
+	t.Run("failing", func(t *testing.T) {
+		conv := newMockConvSender(false, "mock://failing/", bpv7.MustNewEndpointID("dtn://failing/"))
+		conv.permanent = true
+		ce := newConvergenceElement(conv, make(chan ConvergenceStatus, 10), 10, ReceiveQueueConfig{}, backoff)
+
+		var last time.Duration
+		for i := 0; i < 5; i++ {
+			interval := failedAttempt(ce)
+			if interval <= last {
+				t.Fatalf("interval %v after %d failures did not grow from %v", interval, i+1, last)
+			}
+			last = interval
+		}
+	})
+
+	t.Run("flapping", func(t *testing.T) {
+		conv := newMockConvSender(false, "mock://flapping/", bpv7.MustNewEndpointID("dtn://flapping/"))
+		conv.permanent = true
+		ce := newConvergenceElement(conv, make(chan ConvergenceStatus, 10), 10, ReceiveQueueConfig{}, backoff)
+
+		var last time.Duration
+		for i := 0; i < 5; i++ {
+			conv.startable = false
+			interval := failedAttempt(ce)
+			if i > 0 && interval >= last {
+				t.Fatalf("interval %v after recovery %d did not shrink from %v", interval, i, last)
+			}
+			last = interval
+
+			conv.startable = true
+			if successful, _ := ce.activate(); !successful {
+				t.Fatal("activation failed")
+			} else if _, ok := ce.currentRetryInterval(); ok {
+				t.Fatal("active CLA has a scheduled retry")
+			}
+			ce.deactivate(10)
+		}
+
+		if last >= backoff.Base {
+			t.Fatalf("interval %v of a mostly working CLA is not below the base %v", last, backoff.Base)
+		}
+	})
+}
+
+func TestManagerRestartKeepsBackoff(t *testing.T) {
+	backoff := BackoffConfig{Base: time.Hour, Max: 10 * time.Hour}
+
+	tests := []struct {
+		name    string
+		restart func(manager *Manager, conv *mockConvSender)
+	}{
+		{"PeerDisappeared", func(_ *Manager, conv *mockConvSender) {
+			conv.reportChan <- NewConvergencePeerDisappeared(conv, conv.GetPeerEndpointID())
+		}},
+		{"Restart", func(manager *Manager, conv *mockConvSender) {
+			manager.Restart(conv)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewManager(backoff)
+			defer func() { _ = manager.Close() }()
+
+			go func() {
+				for range manager.Channel() {
+				}
+			}()
+
+			conv := newMockConvSender(true, "mock://flapping/", bpv7.MustNewEndpointID("dtn://flapping/"))
+			conv.permanent = true
+			manager.Register(conv)
+
+			for i := 0; i < 5; i++ {
+				// The link flaps: its peer disappears and the restarted CLA fails before working again.
+				conv.startable = false
+				test.restart(manager, conv)
+
+				metrics := waitForMetrics(t, manager, func(m ManagerMetrics) bool {
+					_, ok := m.RetryIntervals[conv.Address()]
+					return ok
+				})
+				if interval := metrics.RetryIntervals[conv.Address()]; interval >= backoff.Base {
+					t.Fatalf("interval %v after restart %d of a mostly working CLA is not below the base %v",
+						interval, i, backoff.Base)
+				}
+
+				conv.startable = true
+				manager.Register(conv)
+
+				waitForMetrics(t, manager, func(m ManagerMetrics) bool {
+					return m.ActiveSenders == 1 && len(m.RetryIntervals) == 0
+				})
+			}
+
+			if restarts := manager.Metrics().Restarts; restarts != 5 {
+				t.Fatalf("expected 5 restarts, got %d", restarts)
+			}
+		})
+	}
+}
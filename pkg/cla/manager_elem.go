@@ -7,6 +7,7 @@ package cla
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -14,6 +15,15 @@ import (
 // convergenceElem is a wrapper around a Convergence to assign a status,
 // supervised by a Manager.
 type convergenceElem struct {
+	// nextAttempt is the UnixNano time of the next activation attempt. It is
+	// accessed atomically, also while activating, and therefore the first field
+	// to be 64-bit aligned.
+	nextAttempt int64
+
+	// retryInterval is the current interval in nanoseconds until nextAttempt, zero if no attempt is scheduled. It is
+	// accessed atomically for the Manager's metrics.
+	retryInterval int64
+
 	// conv is the wrapped Convergence
 	conv Convergence
 
@@ -23,22 +33,35 @@ type convergenceElem struct {
 	// convChnl is the Manager's inChnl.
 	convChnl chan ConvergenceStatus
 
-	// ttl is used both for determining the activity and for counting-off.
+	// queueConf configures the optional queue, buffering ConvergenceStatus messages before being passed to convChnl.
+	queueConf ReceiveQueueConfig
+	queue     *receiveQueue
+
+	// ttl is used both for determining the activity and for counting-off
+	// the remaining attempts, if the BackoffConfig limits its MaxAttempts.
 	// A negative ttl implies an active convergenceElem.
 	ttl int32
 
+	// backoff configures the interval until the next activation attempt after
+	// failures consecutive failed attempts, adapted to the history of recent attempts.
+	backoff BackoffConfig
+	backoffState
+
 	// stop{Syn,Ack} are used to supervise closing this convergenceElem, see deactivate()
-	stopSyn chan struct{}
-	stopAck chan struct{}
+	stopSyn  chan struct{}
+	stopAck  chan struct{}
+	drainAck chan struct{}
 }
 
 // newConvergenceElement creates a new convergenceElem for a Convergence with
-// an initial ttl value.
-func newConvergenceElement(conv Convergence, convChnl chan ConvergenceStatus, ttl int32) *convergenceElem {
+// an initial ttl value, a ReceiveQueueConfig, and a BackoffConfig.
+func newConvergenceElement(conv Convergence, convChnl chan ConvergenceStatus, ttl int32, queueConf ReceiveQueueConfig, backoff BackoffConfig) *convergenceElem {
 	return &convergenceElem{
-		conv:     conv,
-		convChnl: convChnl,
-		ttl:      ttl,
+		conv:      conv,
+		convChnl:  convChnl,
+		queueConf: queueConf,
+		ttl:       ttl,
+		backoff:   backoff,
 	}
 }
 
@@ -61,20 +84,43 @@ func (ce *convergenceElem) isActive() bool {
 	return atomic.LoadInt32(&ce.ttl) < 0
 }
 
+// currentRetryInterval returns the interval until the next scheduled activation attempt, if there is one.
+func (ce *convergenceElem) currentRetryInterval() (interval time.Duration, ok bool) {
+	interval = time.Duration(atomic.LoadInt64(&ce.retryInterval))
+	ok = interval > 0 && !ce.isActive()
+	return
+}
+
+// currentBackoffState returns a copy of the backoffState, e.g., to be kept by the Manager across a restart.
+func (ce *convergenceElem) currentBackoffState() backoffState {
+	ce.mutex.Lock()
+	defer ce.mutex.Unlock()
+
+	return ce.backoffState
+}
+
+// isAttemptDue checks if the backoff after the last failed activation attempt has passed.
+func (ce *convergenceElem) isAttemptDue(now time.Time) bool {
+	return now.UnixNano() >= atomic.LoadInt64(&ce.nextAttempt)
+}
+
 // handler supervises both stopping and ConvergenceStatus forwarding to the Manager.
 func (ce *convergenceElem) handler() {
+	closeHandler := func() {
+		log.WithFields(log.Fields{
+			"cla": ce.conv,
+		}).Debug("Closing CLA's handler")
+
+		if err := ce.conv.Close(); err != nil {
+			log.WithField("cla", ce.conv).WithError(err).Warn("Closing CLA erred")
+		}
+		close(ce.stopAck)
+	}
+
 	for {
 		select {
 		case <-ce.stopSyn:
-			log.WithFields(log.Fields{
-				"cla": ce.conv,
-			}).Debug("Closing CLA's handler")
-
-			if err := ce.conv.Close(); err != nil {
-				log.WithField("cla", ce.conv).WithError(err).Warn("Closing CLA erred")
-			}
-			close(ce.stopAck)
-
+			closeHandler()
 			return
 
 		case cs := <-ce.conv.Channel():
@@ -83,22 +129,71 @@ func (ce *convergenceElem) handler() {
 				"status": cs.String(),
 			}).Debug("Forwarding ConvergenceStatus to Manager")
 
-			ce.convChnl <- cs
+			if ce.queue == nil {
+				// Don't block deactivation, e.g., by a closing Manager which does not read its channel anymore.
+				select {
+				case ce.convChnl <- cs:
+				case <-ce.stopSyn:
+					closeHandler()
+					return
+				}
+			} else if !ce.queue.push(cs) {
+				log.WithFields(log.Fields{
+					"cla":    ce.conv,
+					"status": cs.String(),
+					"policy": ce.queueConf.Policy,
+				}).Info("Dropped ConvergenceStatus due to a full receive queue")
+			}
+		}
+	}
+}
+
+// drain passes the queued ConvergenceStatus messages to the Manager.
+func (ce *convergenceElem) drain() {
+	defer close(ce.drainAck)
+
+	for {
+		cs, ok := ce.queue.pop()
+		if !ok {
+			return
+		}
+
+		select {
+		case ce.convChnl <- cs:
+		case <-ce.stopSyn:
+			return
 		}
 	}
 }
 
+// queueStats returns the receive queue's stats, if this convergenceElem uses a receive queue.
+func (ce *convergenceElem) queueStats() (stats ReceiveQueueStats, ok bool) {
+	ce.mutex.Lock()
+	queue := ce.queue
+	ce.mutex.Unlock()
+
+	if queue == nil {
+		return
+	}
+	return queue.stats(), true
+}
+
 // activate tries to start this convergenceElem. Both a success message and an
-// indicator for a new attempt are returned.
+// indicator for a new attempt are returned. An already active convergenceElem,
+// e.g., started by a concurrent call, is reported as successful.
 func (ce *convergenceElem) activate() (successful, retry bool) {
 	if ce.isActive() {
-		return
+		return true, false
 	}
 
 	ce.mutex.Lock()
 	defer ce.mutex.Unlock()
 
-	if atomic.LoadInt32(&ce.ttl) == 0 && !ce.conv.IsPermanent() {
+	if ce.isActive() {
+		return true, false
+	}
+
+	if ce.backoff.MaxAttempts > 0 && atomic.LoadInt32(&ce.ttl) == 0 && !ce.conv.IsPermanent() {
 		log.WithFields(log.Fields{
 			"cla":   ce.conv,
 			"error": "TTL expired",
@@ -115,8 +210,19 @@ func (ce *convergenceElem) activate() (successful, retry bool) {
 
 		atomic.StoreInt32(&ce.ttl, -1)
 
+		ce.failures = 0
+		ce.history.add(true)
+		atomic.StoreInt64(&ce.nextAttempt, 0)
+		atomic.StoreInt64(&ce.retryInterval, 0)
+
 		ce.stopSyn = make(chan struct{})
 		ce.stopAck = make(chan struct{})
+
+		if ce.queueConf.Depth > 0 {
+			ce.queue = newReceiveQueue(ce.queueConf)
+			ce.drainAck = make(chan struct{})
+			go ce.drain()
+		}
 		go ce.handler()
 
 		return true, false
@@ -130,7 +236,16 @@ func (ce *convergenceElem) activate() (successful, retry bool) {
 		}).Info("Failed to start CLA")
 
 		if claRetry {
-			atomic.AddInt32(&ce.ttl, -1)
+			if ce.backoff.MaxAttempts > 0 {
+				atomic.AddInt32(&ce.ttl, -1)
+			}
+
+			ce.failures++
+			ce.history.add(false)
+
+			interval := ce.backoff.adaptiveInterval(ce.failures, ce.history.successRatio())
+			atomic.StoreInt64(&ce.retryInterval, int64(interval))
+			atomic.StoreInt64(&ce.nextAttempt, time.Now().Add(interval).UnixNano())
 		} else {
 			atomic.StoreInt32(&ce.ttl, 0)
 		}
@@ -154,6 +269,10 @@ func (ce *convergenceElem) deactivate(ttl int32) {
 	}).Info("Deactivating CLA")
 
 	close(ce.stopSyn)
+	if ce.queue != nil {
+		ce.queue.close()
+		<-ce.drainAck
+	}
 	<-ce.stopAck
 
 	atomic.StoreInt32(&ce.ttl, ttl)
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import "time"
+
+// ManagerMetrics is a snapshot of a Manager's supervised CLAs, returned by Manager.Metrics.
+type ManagerMetrics struct {
+	// ActiveSenders and ActiveReceivers count the started CLAs. A CLA being both sender and receiver, e.g., a TCPCLv4
+	// session, is counted for both.
+	ActiveSenders   int
+	ActiveReceivers int
+
+	// InactiveSenders count the registered but currently not started ConvergenceSenders, e.g., waiting for a retry.
+	InactiveSenders int
+
+	// Restarts is the total number of restarted CLAs, e.g., after a peer disappeared.
+	Restarts uint64
+
+	// ForwardedBundles counts the bundles successfully sent by each registered ConvergenceSender's address, as reported
+	// by CountForwarded. A CLA's counter persists its restarts, but is dropped when the CLA is unregistered.
+	ForwardedBundles map[string]uint64
+
+	// RetryIntervals are the current intervals until the next activation attempt of each inactive CLA's address,
+	// adapted to the CLA's recent success and failure history.
+	RetryIntervals map[string]time.Duration
+}
+
+// Metrics returns a snapshot of this Manager's metrics, which can be safely inspected while CLAs are being registered
+// or unregistered.
+func (manager *Manager) Metrics() (metrics ManagerMetrics) {
+	metrics.RetryIntervals = make(map[string]time.Duration)
+
+	manager.convs.Range(func(_, convElem interface{}) bool {
+		ce := convElem.(*convergenceElem)
+		active := ce.isActive()
+
+		if interval, ok := ce.currentRetryInterval(); ok {
+			metrics.RetryIntervals[ce.conv.Address()] = interval
+		}
+
+		if _, ok := ce.asSender(); ok {
+			if active {
+				metrics.ActiveSenders++
+			} else {
+				metrics.InactiveSenders++
+			}
+		}
+		if _, ok := ce.asReceiver(); ok && active {
+			metrics.ActiveReceivers++
+		}
+		return true
+	})
+
+	manager.metricsMutex.Lock()
+	defer manager.metricsMutex.Unlock()
+
+	metrics.Restarts = manager.restarts
+	metrics.ForwardedBundles = make(map[string]uint64, len(manager.forwarded))
+	for address, forwarded := range manager.forwarded {
+		metrics.ForwardedBundles[address] = forwarded
+	}
+	return
+}
+
+// CountForwarded increments the ForwardedBundles counter of a registered ConvergenceSender after a bundle was
+// successfully sent by it.
+func (manager *Manager) CountForwarded(cs ConvergenceSender) {
+	if _, ok := manager.convs.Load(cs.Address()); !ok {
+		return
+	}
+
+	manager.metricsMutex.Lock()
+	defer manager.metricsMutex.Unlock()
+
+	manager.forwarded[cs.Address()]++
+}
+
+// dropMetrics removes the per CLA metrics of an unregistered CLA's address.
+func (manager *Manager) dropMetrics(address string) {
+	manager.metricsMutex.Lock()
+	defer manager.metricsMutex.Unlock()
+
+	delete(manager.forwarded, address)
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// waitForMetrics polls the Manager's Metrics until check succeeds or fails the test after a timeout.
+func waitForMetrics(t *testing.T, manager *Manager, check func(ManagerMetrics) bool) ManagerMetrics {
+	t.Helper()
+
+	var metrics ManagerMetrics
+	for i := 0; i < 100; i++ {
+		if metrics = manager.Metrics(); check(metrics) {
+			return metrics
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("unexpected metrics %+v", metrics)
+	return metrics
+}
+
+func TestManagerMetrics(t *testing.T) {
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager(BackoffConfig{Base: time.Hour})
+	defer func() { _ = manager.Close() }()
+
+	go func() {
+		for range manager.Channel() {
+		}
+	}()
+
+	receiver := newMockConvRec(true, "receiver", bpv7.MustNewEndpointID("dtn://receiver/"))
+	activeSender := newMockConvSender(true, "active", bpv7.MustNewEndpointID("dtn://active/"))
+	inactiveSender := newMockConvSender(false, "inactive", bpv7.MustNewEndpointID("dtn://inactive/"))
+
+	for _, conv := range []Convergence{receiver, activeSender, inactiveSender} {
+		manager.Register(conv)
+	}
+
+	// Received bundles are not counted as forwarded ones.
+	receiver.reportChan <- NewConvergenceReceivedBundle(receiver, receiver.GetEndpointID(), &bndl)
+
+	for i := 0; i < 3; i++ {
+		manager.CountForwarded(activeSender)
+	}
+
+	metrics := waitForMetrics(t, manager, func(m ManagerMetrics) bool {
+		return m.ForwardedBundles["active"] == 3 && m.ActiveReceivers == 1
+	})
+	if _, ok := metrics.ForwardedBundles["receiver"]; ok {
+		t.Fatalf("received bundles were counted as forwarded: %+v", metrics)
+	}
+	if metrics.ActiveSenders != 1 || metrics.InactiveSenders != 1 || metrics.ActiveReceivers != 1 {
+		t.Fatalf("unexpected CLA counts %+v", metrics)
+	}
+	if metrics.Restarts != 0 {
+		t.Fatalf("expected no restarts, got %d", metrics.Restarts)
+	}
+
+	// Restarting keeps the forwarded bundles' counter.
+	manager.Restart(activeSender)
+	manager.CountForwarded(activeSender)
+
+	metrics = waitForMetrics(t, manager, func(m ManagerMetrics) bool {
+		return m.ForwardedBundles["active"] == 4
+	})
+	if metrics.Restarts != 1 {
+		t.Fatalf("expected one restart, got %d", metrics.Restarts)
+	}
+
+	// Unregistering drops the CLA's counter and unregistered CLAs are not counted.
+	manager.Unregister(activeSender)
+	manager.Unregister(receiver)
+	manager.CountForwarded(activeSender)
+
+	metrics = manager.Metrics()
+	if _, ok := metrics.ForwardedBundles["active"]; ok {
+		t.Fatalf("unregistered CLA's counter still exists: %+v", metrics)
+	}
+	if metrics.ActiveReceivers != 0 {
+		t.Fatalf("expected no active receivers, got %d", metrics.ActiveReceivers)
+	}
+}
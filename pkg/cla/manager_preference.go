@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"sort"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// SetCLAPreference configures an ordered list of CLATypes, starting with the most preferred one. If a peer is
+// reachable by multiple ConvergenceSenders, the one with the most preferred CLAType is used, compare
+// PreferredSenderForPeer. CLATypes missing in this list and ConvergenceSenders without a known CLAType come last.
+func (manager *Manager) SetCLAPreference(preference []CLAType) {
+	manager.preferenceMutex.Lock()
+	defer manager.preferenceMutex.Unlock()
+
+	manager.preference = append([]CLAType{}, preference...)
+}
+
+// preferenceRank of a ConvergenceSender's CLAType, where a lower rank is more preferred.
+func (manager *Manager) preferenceRank(cs ConvergenceSender) int {
+	manager.preferenceMutex.RLock()
+	defer manager.preferenceMutex.RUnlock()
+
+	if claType, ok := GetCLAType(cs); ok {
+		for rank, preferred := range manager.preference {
+			if claType == preferred {
+				return rank
+			}
+		}
+	}
+	return len(manager.preference)
+}
+
+// lessPreferred orders two ConvergenceSenders by their preferenceRank and, as a tie-breaker, by their address.
+func (manager *Manager) lessPreferred(a, b ConvergenceSender) bool {
+	if aRank, bRank := manager.preferenceRank(a), manager.preferenceRank(b); aRank != bRank {
+		return aRank < bRank
+	}
+	return a.Address() < b.Address()
+}
+
+// PreferredSenderForPeer returns the active ConvergenceSender for a peer's endpoint ID with the most preferred
+// CLAType, as configured by SetCLAPreference.
+func (manager *Manager) PreferredSenderForPeer(eid bpv7.EndpointID) (cs ConvergenceSender, ok bool) {
+	for _, candidate := range manager.Sender() {
+		if !candidate.GetPeerEndpointID().SameNode(eid) {
+			continue
+		}
+
+		if !ok || manager.lessPreferred(candidate, cs) {
+			cs, ok = candidate, true
+		}
+	}
+	return
+}
+
+// PreferredSenders returns the active ConvergenceSenders like Sender. If a CLA preference was configured by
+// SetCLAPreference, only the preferred ConvergenceSender is returned for each peer.
+func (manager *Manager) PreferredSenders() (css []ConvergenceSender) {
+	css = manager.Sender()
+
+	manager.preferenceMutex.RLock()
+	hasPreference := len(manager.preference) > 0
+	manager.preferenceMutex.RUnlock()
+
+	if !hasPreference {
+		return
+	}
+
+	sort.SliceStable(css, func(i, j int) bool { return manager.lessPreferred(css[i], css[j]) })
+
+	preferred := make([]ConvergenceSender, 0, len(css))
+	for _, cs := range css {
+		seen := false
+		for _, other := range preferred {
+			if other.GetPeerEndpointID().SameNode(cs.GetPeerEndpointID()) {
+				seen = true
+				break
+			}
+		}
+
+		if !seen {
+			preferred = append(preferred, cs)
+		}
+	}
+	return preferred
+}
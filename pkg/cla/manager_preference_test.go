@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// typedMockConvSender is a mockConvSender exposing its CLAType.
+type typedMockConvSender struct {
+	*mockConvSender
+	claType CLAType
+}
+
+func (m *typedMockConvSender) GetCLAType() CLAType { return m.claType }
+
+func TestManagerPreferredSenderForPeer(t *testing.T) {
+	manager := NewManager(BackoffConfig{Base: time.Hour})
+	defer func() { _ = manager.Close() }()
+
+	go func() {
+		for range manager.Channel() {
+		}
+	}()
+
+	peer := bpv7.MustNewEndpointID("dtn://peer/")
+	mtcpSender := &typedMockConvSender{newMockConvSender(true, "mtcp", peer), MTCP}
+	tcpclSender := &typedMockConvSender{newMockConvSender(true, "tcpclv4", peer), TCPCLv4}
+	otherSender := &typedMockConvSender{newMockConvSender(true, "other", bpv7.MustNewEndpointID("dtn://other/")), MTCP}
+
+	for _, conv := range []Convergence{mtcpSender, tcpclSender, otherSender} {
+		manager.Register(conv)
+	}
+
+	for i := 0; len(manager.Sender()) != 3; i++ {
+		if i == 100 {
+			t.Fatalf("expected three active senders, got %d", len(manager.Sender()))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name       string
+		preference []CLAType
+		address    string
+		senders    int
+	}{
+		{"no preference", nil, "mtcp", 3},
+		{"prefer tcpclv4", []CLAType{QUICL, TCPCLv4, MTCP}, "tcpclv4", 2},
+		{"prefer mtcp", []CLAType{MTCP, TCPCLv4}, "mtcp", 2},
+		{"unlisted types", []CLAType{QUICL}, "mtcp", 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager.SetCLAPreference(test.preference)
+
+			if cs, ok := manager.PreferredSenderForPeer(peer); !ok {
+				t.Fatal("no sender for peer")
+			} else if cs.Address() != test.address {
+				t.Fatalf("preferred sender is %q, expected %q", cs.Address(), test.address)
+			}
+
+			if css := manager.PreferredSenders(); len(css) != test.senders {
+				t.Fatalf("expected %d preferred senders, got %d", test.senders, len(css))
+			}
+		})
+	}
+
+	if _, ok := manager.PreferredSenderForPeer(bpv7.MustNewEndpointID("dtn://unknown/")); ok {
+		t.Fatal("found a sender for an unknown peer")
+	}
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ReceiveQueuePolicy defines how a CLA's full receive queue handles another received bundle.
+type ReceiveQueuePolicy int
+
+const (
+	// ReceiveQueueBlock blocks the CLA until the queue has space again.
+	ReceiveQueueBlock ReceiveQueuePolicy = iota
+
+	// ReceiveQueueDropOldest drops the oldest queued bundle in favour of the new one.
+	ReceiveQueueDropOldest
+
+	// ReceiveQueueDropNew drops the new bundle.
+	ReceiveQueueDropNew
+)
+
+// ParseReceiveQueuePolicy from its name, one of "block", "drop-oldest", or "drop-new".
+func ParseReceiveQueuePolicy(name string) (ReceiveQueuePolicy, error) {
+	switch name {
+	case "block":
+		return ReceiveQueueBlock, nil
+	case "drop-oldest":
+		return ReceiveQueueDropOldest, nil
+	case "drop-new":
+		return ReceiveQueueDropNew, nil
+	default:
+		return 0, fmt.Errorf("unknown receive queue policy %s", name)
+	}
+}
+
+func (policy ReceiveQueuePolicy) String() string {
+	switch policy {
+	case ReceiveQueueBlock:
+		return "block"
+	case ReceiveQueueDropOldest:
+		return "drop-oldest"
+	case ReceiveQueueDropNew:
+		return "drop-new"
+	default:
+		return "unknown"
+	}
+}
+
+// ReceiveQueueConfig configures a bounded receive queue for each CLA. Thus, a slow consumer of the Manager's Channel
+// does not block a CLA's read loop until Depth bundles are queued. Afterwards, the Policy applies.
+//
+// A zero Depth disables the receive queue, resulting in each CLA being blocked directly.
+type ReceiveQueueConfig struct {
+	Depth  int
+	Policy ReceiveQueuePolicy
+}
+
+// ReceiveQueueStats describes the state of a CLA's receive queue.
+type ReceiveQueueStats struct {
+	// Queued is the amount of currently queued bundles.
+	Queued int
+
+	// Depth is the configured maximum amount of queued bundles.
+	Depth int
+
+	// Dropped is the total amount of bundles dropped due to the ReceiveQueuePolicy.
+	Dropped uint64
+}
+
+// receiveQueue buffers a CLA's ConvergenceStatus messages. Only ReceivedBundle messages are limited by the depth and
+// might be dropped; other messages are always queued to preserve the peer's state.
+type receiveQueue struct {
+	conf ReceiveQueueConfig
+
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	items   []ConvergenceStatus
+	bundles int
+	dropped uint64
+	closed  bool
+}
+
+// newReceiveQueue for a ReceiveQueueConfig with a positive Depth.
+func newReceiveQueue(conf ReceiveQueueConfig) *receiveQueue {
+	rq := &receiveQueue{conf: conf}
+	rq.cond = sync.NewCond(&rq.mutex)
+	return rq
+}
+
+// push a ConvergenceStatus into the queue, applying the ReceiveQueuePolicy for a full queue. False is returned if the
+// ConvergenceStatus was dropped, either due to the policy or a closed queue.
+func (rq *receiveQueue) push(cs ConvergenceStatus) bool {
+	rq.mutex.Lock()
+	defer rq.mutex.Unlock()
+
+	isBundle := cs.MessageType == ReceivedBundle
+
+	for isBundle && !rq.closed && rq.bundles >= rq.conf.Depth {
+		switch rq.conf.Policy {
+		case ReceiveQueueDropOldest:
+			rq.removeOldestBundle()
+			rq.dropped++
+
+		case ReceiveQueueDropNew:
+			rq.dropped++
+			return false
+
+		default:
+			rq.cond.Wait()
+		}
+	}
+
+	if rq.closed {
+		return false
+	}
+
+	rq.items = append(rq.items, cs)
+	if isBundle {
+		rq.bundles++
+	}
+	rq.cond.Broadcast()
+	return true
+}
+
+// removeOldestBundle drops the first ReceivedBundle message. The mutex must be held.
+func (rq *receiveQueue) removeOldestBundle() {
+	for i, item := range rq.items {
+		if item.MessageType == ReceivedBundle {
+			rq.items = append(rq.items[:i], rq.items[i+1:]...)
+			rq.bundles--
+			return
+		}
+	}
+}
+
+// pop the next ConvergenceStatus, blocking until one is available. False is returned after the queue was closed.
+func (rq *receiveQueue) pop() (cs ConvergenceStatus, ok bool) {
+	rq.mutex.Lock()
+	defer rq.mutex.Unlock()
+
+	for len(rq.items) == 0 && !rq.closed {
+		rq.cond.Wait()
+	}
+	if rq.closed {
+		return
+	}
+
+	cs, rq.items = rq.items[0], rq.items[1:]
+	if cs.MessageType == ReceivedBundle {
+		rq.bundles--
+	}
+	rq.cond.Broadcast()
+	return cs, true
+}
+
+// close the queue, waking up all blocked calls. Queued messages are discarded.
+func (rq *receiveQueue) close() {
+	rq.mutex.Lock()
+	defer rq.mutex.Unlock()
+
+	rq.closed = true
+	rq.items = nil
+	rq.bundles = 0
+	rq.cond.Broadcast()
+}
+
+// stats of this receiveQueue.
+func (rq *receiveQueue) stats() ReceiveQueueStats {
+	rq.mutex.Lock()
+	defer rq.mutex.Unlock()
+
+	return ReceiveQueueStats{
+		Queued:  rq.bundles,
+		Depth:   rq.conf.Depth,
+		Dropped: rq.dropped,
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// queueTestStatus creates a ReceivedBundle ConvergenceStatus, whose bundle is identified by its source's number.
+func queueTestStatus(t *testing.T, conv Convergence, i int) ConvergenceStatus {
+	bndl, err := bpv7.Builder().
+		Source(fmt.Sprintf("dtn://src-%d/", i)).
+		Destination("dtn://dest/").
+		CreationTimestampEpoch().
+		Lifetime("10m").
+		BundleAgeBlock(0).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewConvergenceReceivedBundle(conv, bpv7.MustNewEndpointID("dtn://dest/"), &bndl)
+}
+
+// queueTestSource returns the source of a ConvergenceStatus created by queueTestStatus.
+func queueTestSource(cs ConvergenceStatus) string {
+	return cs.Message.(ConvergenceReceivedBundle).Bundle.PrimaryBlock.SourceNode.String()
+}
+
+func TestReceiveQueuePolicies(t *testing.T) {
+	tests := []struct {
+		policy  ReceiveQueuePolicy
+		sources []string
+	}{
+		{ReceiveQueueDropOldest, []string{"dtn://src-2/", "dtn://src-3/"}},
+		{ReceiveQueueDropNew, []string{"dtn://src-0/", "dtn://src-1/"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			rq := newReceiveQueue(ReceiveQueueConfig{Depth: 2, Policy: test.policy})
+			conv := newMockConvRec(true, "mock://receiver/", bpv7.MustNewEndpointID("dtn://mr/"))
+
+			// Peer messages are neither limited nor dropped.
+			rq.push(NewConvergencePeerAppeared(conv, bpv7.MustNewEndpointID("dtn://peer/")))
+			for i := 0; i < 4; i++ {
+				rq.push(queueTestStatus(t, conv, i))
+			}
+
+			if stats := rq.stats(); stats.Queued != 2 || stats.Dropped != 2 {
+				t.Fatalf("expected 2 queued and 2 dropped bundles, got %+v", stats)
+			}
+
+			if cs, _ := rq.pop(); cs.MessageType != PeerAppeared {
+				t.Fatalf("expected Peer Appeared first, got %v", cs)
+			}
+			for _, source := range test.sources {
+				if cs, _ := rq.pop(); queueTestSource(cs) != source {
+					t.Fatalf("expected bundle from %s, got %s", source, queueTestSource(cs))
+				}
+			}
+		})
+	}
+
+	t.Run("block", func(t *testing.T) {
+		rq := newReceiveQueue(ReceiveQueueConfig{Depth: 1, Policy: ReceiveQueueBlock})
+		conv := newMockConvRec(true, "mock://receiver/", bpv7.MustNewEndpointID("dtn://mr/"))
+
+		rq.push(queueTestStatus(t, conv, 0))
+
+		cs := queueTestStatus(t, conv, 1)
+		pushed := make(chan bool)
+		go func() { pushed <- rq.push(cs) }()
+
+		select {
+		case <-pushed:
+			t.Fatal("push into a full queue did not block")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if cs, _ := rq.pop(); queueTestSource(cs) != "dtn://src-0/" {
+			t.Fatalf("expected first bundle, got %s", queueTestSource(cs))
+		}
+		if ok := <-pushed; !ok {
+			t.Fatal("blocked push failed")
+		}
+
+		rq.close()
+		if ok := rq.push(queueTestStatus(t, conv, 2)); ok {
+			t.Fatal("push into a closed queue succeeded")
+		}
+	})
+}
+
+func TestManagerReceiveQueueSlowConsumer(t *testing.T) {
+	const bundles = 150
+
+	manager := NewManager(BackoffConfig{})
+	defer func() { _ = manager.Close() }()
+
+	if err := manager.SetReceiveQueue(ReceiveQueueConfig{Depth: 5, Policy: ReceiveQueueDropNew}); err != nil {
+		t.Fatal(err)
+	}
+
+	conv := newMockConvRec(true, "mock://receiver/", bpv7.MustNewEndpointID("dtn://mr/"))
+	manager.Register(conv)
+
+	statuses := make([]ConvergenceStatus, bundles)
+	for i := range statuses {
+		statuses[i] = queueTestStatus(t, conv, i)
+	}
+
+	// Nobody reads the Manager's Channel yet, but the CLA must not be blocked.
+	sent := make(chan struct{})
+	go func() {
+		for _, cs := range statuses {
+			conv.reportChan <- cs
+		}
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("CLA was blocked by a slow consumer")
+	}
+
+	stats, ok := manager.ReceiveQueueStats()[conv.Address()]
+	if !ok {
+		t.Fatal("no receive queue stats for the CLA")
+	} else if stats.Queued != 5 || stats.Depth != 5 || stats.Dropped == 0 {
+		t.Fatalf("expected a full queue with dropped bundles, got %+v", stats)
+	}
+
+	received := 0
+	for received < bundles-int(stats.Dropped) {
+		select {
+		case <-manager.Channel():
+			received++
+		case <-time.After(time.Second):
+			t.Fatalf("received %d bundles, expected %d", received, bundles-int(stats.Dropped))
+		}
+	}
+}
@@ -33,7 +33,7 @@ func TestManager(t *testing.T) {
 	}
 
 	/* Setup */
-	var manager = NewManager()
+	var manager = NewManager(BackoffConfig{})
 	defer func() { _ = manager.Close() }()
 
 	// Read the Manager's outbounding channel
@@ -117,3 +117,93 @@ func TestManager(t *testing.T) {
 		}
 	}
 }
+
+func TestManagerConcurrentSender(t *testing.T) {
+	const (
+		senderNo    int = 20
+		registerNo  int = 4
+		iterationNo int = 50
+	)
+
+	var manager = NewManager(BackoffConfig{})
+	defer func() { _ = manager.Close() }()
+
+	go func(ch chan ConvergenceStatus) {
+		for range ch {
+		}
+	}(manager.Channel())
+
+	var sender [senderNo]ConvergenceSender
+	for i := 0; i < senderNo; i++ {
+		sender[i] = newMockConvSender(
+			true, fmt.Sprintf("mock://sender_%d/", i),
+			bpv7.MustNewEndpointID(fmt.Sprintf("dtn://ms_%d/", i)))
+	}
+
+	// A sender without a known peer must never be returned.
+	manager.Register(newMockConvSender(true, "mock://unknown/", bpv7.EndpointID{}))
+
+	var stopIter = make(chan struct{})
+	var iterErr = make(chan error, 1)
+	go func() {
+		defer close(iterErr)
+
+		for {
+			select {
+			case <-stopIter:
+				return
+			default:
+			}
+
+			addrs := make(map[string]bool)
+			for _, cs := range manager.Sender() {
+				if cs.GetPeerEndpointID().EndpointType == nil {
+					iterErr <- fmt.Errorf("sender %s has no peer endpoint", cs.Address())
+					return
+				} else if addrs[cs.Address()] {
+					iterErr <- fmt.Errorf("sender %s is listed twice", cs.Address())
+					return
+				}
+				addrs[cs.Address()] = true
+			}
+		}
+	}()
+
+	// Register each sender concurrently multiple times.
+	var wg sync.WaitGroup
+	for i := 0; i < senderNo; i++ {
+		for j := 0; j < registerNo; j++ {
+			wg.Add(1)
+			go func(cs ConvergenceSender) {
+				defer wg.Done()
+				manager.Register(cs)
+			}(sender[i])
+		}
+	}
+	wg.Wait()
+
+	if css := manager.Sender(); len(css) != senderNo {
+		t.Fatalf("Wrong amount of senders, expected: %d, got: %d", senderNo, len(css))
+	}
+
+	// Re-register the senders concurrently while iterating.
+	for i := 0; i < senderNo; i++ {
+		wg.Add(1)
+		go func(cs ConvergenceSender) {
+			defer wg.Done()
+			for j := 0; j < iterationNo; j++ {
+				manager.Restart(cs)
+			}
+		}(sender[i])
+	}
+	wg.Wait()
+
+	close(stopIter)
+	if err := <-iterErr; err != nil {
+		t.Fatal(err)
+	}
+
+	if css := manager.Sender(); len(css) != senderNo {
+		t.Fatalf("Wrong amount of senders, expected: %d, got: %d", senderNo, len(css))
+	}
+}
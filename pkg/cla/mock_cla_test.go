@@ -121,6 +121,9 @@ func (m *mockConvSender) IsPermanent() bool { return m.permanent }
 
 func (m *mockConvSender) GetPeerEndpointID() bpv7.EndpointID { return m.peerEndpointId }
 
+// String returns the address, not exposing the editable fields to concurrent logging.
+func (m *mockConvSender) String() string { return m.address }
+
 func (m *mockConvSender) Send(bndl bpv7.Bundle) error {
 	if m.sendFail {
 		return fmt.Errorf("sendFail := true")
@@ -10,7 +10,9 @@ package mtcp
 import (
 	"bufio"
 	"bytes"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"net"
 	"sync"
 	"time"
@@ -35,6 +37,13 @@ type MTCPClient struct {
 	permanent bool
 	address   string
 
+	// compressions are offered to the server; writer is the possibly compressed stream.
+	compressions []Compression
+	writer       FlushWriter
+
+	// tlsConfig wraps the connection in TLS, if set.
+	tlsConfig *tls.Config
+
 	stopSyn chan struct{}
 	stopAck chan struct{}
 }
@@ -57,15 +66,59 @@ func NewAnonymousMTCPClient(address string, permanent bool) *MTCPClient {
 	return NewMTCPClient(address, bpv7.DtnNone(), permanent)
 }
 
+// EnableCompression for this MTCPClient's stream. The Compressions are offered to the server in the given order of
+// preference while starting. If the server does not support compression, the stream remains uncompressed.
+//
+// This method must be called before Start.
+func (client *MTCPClient) EnableCompression(compressions ...Compression) {
+	client.compressions = compressions
+}
+
+// EnableTLS for this MTCPClient's connection. The tls.Config must trust the server's certificate. Unless configured,
+// the server's name is taken from the address. For a nil tls.Config, the connection remains cleartext.
+//
+// This method must be called before Start.
+func (client *MTCPClient) EnableTLS(config *tls.Config) {
+	client.tlsConfig = config
+}
+
 func (client *MTCPClient) Start() (err error, retry bool) {
 	retry = true
 
-	conn, connErr := dial(client.address)
+	conn, connErr := dialTLS(client.address, client.tlsConfig)
 	if connErr != nil {
 		err = connErr
 		return
 	}
 
+	client.writer = bufio.NewWriter(conn)
+
+	if len(client.compressions) > 0 {
+		if compression, compErr := offerCompression(conn, client.compressions); compErr != nil {
+			log.WithFields(log.Fields{
+				"client": client.String(),
+				"error":  compErr,
+			}).Info("MTCPClient: Compression negotiation failed, reconnecting without compression")
+
+			_ = conn.Close()
+			if conn, connErr = dialTLS(client.address, client.tlsConfig); connErr != nil {
+				err = connErr
+				return
+			}
+			client.writer = bufio.NewWriter(conn)
+		} else if compression != nil {
+			if client.writer, err = compression.NewWriter(conn); err != nil {
+				_ = conn.Close()
+				return
+			}
+
+			log.WithFields(log.Fields{
+				"client":      client.String(),
+				"compression": compression.Name(),
+			}).Debug("MTCPClient: Negotiated stream compression")
+		}
+	}
+
 	client.reportChan = make(chan cla.ConvergenceStatus)
 	client.stopSyn = make(chan struct{})
 	client.stopAck = make(chan struct{})
@@ -86,7 +139,12 @@ func (client *MTCPClient) handler() {
 	for {
 		select {
 		case <-client.stopSyn:
+			client.mutex.Lock()
+			if closer, ok := client.writer.(io.Closer); ok {
+				_ = closer.Close()
+			}
 			_ = client.conn.Close()
+			client.mutex.Unlock()
 
 			close(client.reportChan)
 			close(client.stopAck)
@@ -95,7 +153,7 @@ func (client *MTCPClient) handler() {
 
 		case <-ticker.C:
 			client.mutex.Lock()
-			err := cboring.WriteByteStringLen(0, client.conn)
+			err := client.writeKeepalive()
 			client.mutex.Unlock()
 
 			if err != nil {
@@ -126,31 +184,29 @@ func (client *MTCPClient) Send(bndl bpv7.Bundle) (err error) {
 	client.mutex.Lock()
 	defer client.mutex.Unlock()
 
-	connWriter := bufio.NewWriter(client.conn)
-
 	buff := new(bytes.Buffer)
 	if cborErr := cboring.Marshal(&bndl, buff); cborErr != nil {
 		err = cborErr
 		return
 	}
 
-	if bsErr := cboring.WriteByteStringLen(uint64(buff.Len()), connWriter); bsErr != nil {
+	if bsErr := cboring.WriteByteStringLen(uint64(buff.Len()), client.writer); bsErr != nil {
 		err = bsErr
 		return
 	}
 
-	if _, plErr := buff.WriteTo(connWriter); plErr != nil {
+	if _, plErr := buff.WriteTo(client.writer); plErr != nil {
 		err = plErr
 		return
 	}
 
-	if flushErr := connWriter.Flush(); flushErr != nil {
+	if flushErr := client.writer.Flush(); flushErr != nil {
 		err = flushErr
 		return
 	}
 
-	// Check if the connection is still alive with an empty, unbuffered packet
-	if probeErr := cboring.WriteByteStringLen(0, client.conn); probeErr != nil {
+	// Check if the connection is still alive with an empty packet
+	if probeErr := client.writeKeepalive(); probeErr != nil {
 		err = probeErr
 		return
 	}
@@ -158,6 +214,14 @@ func (client *MTCPClient) Send(bndl bpv7.Bundle) (err error) {
 	return
 }
 
+// writeKeepalive writes and flushes an empty byte string. The caller must hold the mutex.
+func (client *MTCPClient) writeKeepalive() error {
+	if err := cboring.WriteByteStringLen(0, client.writer); err != nil {
+		return err
+	}
+	return client.writer.Flush()
+}
+
 func (client *MTCPClient) Channel() chan cla.ConvergenceStatus {
 	return client.reportChan
 }
@@ -177,6 +241,10 @@ func (client *MTCPClient) Address() string {
 	return client.address
 }
 
+func (client *MTCPClient) GetCLAType() cla.CLAType {
+	return cla.MTCP
+}
+
 func (client *MTCPClient) IsPermanent() bool {
 	return client.permanent
 }
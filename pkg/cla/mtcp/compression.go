@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mtcp
+
+import (
+	"bufio"
+	"compress/flate"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/dtn7/cboring"
+)
+
+// This file implements an optional compression of the MTCP stream, negotiated by a short handshake.
+//
+// A MTCPClient with enabled compression starts its connection with an offer before sending any bundles. This offer is
+// a CBOR array of two elements, the text string "compression" and an array of the supported algorithms' names as text
+// strings. As a regular MTCP stream consists only of CBOR byte strings, the server can distinguish an offer from the
+// first bundle. The MTCPServer answers with a text string, naming the selected algorithm or "none". Afterwards, the
+// remaining stream is compressed with this algorithm.
+//
+// A server without compression support fails to parse the offer and closes the connection. In this case, the client
+// reconnects without compression.
+
+const (
+	// compressionOfferTag identifies a compression offer.
+	compressionOfferTag = "compression"
+
+	// compressionNone is the server's reply if no offered compression was accepted.
+	compressionNone = "none"
+
+	// compressionHandshakeTimeout limits the time to wait for the server's reply.
+	compressionHandshakeTimeout = 2 * time.Second
+)
+
+// Compression is a pluggable compression algorithm for the MTCP stream.
+type Compression interface {
+	// Name of this Compression, used within the negotiation. It must be unique.
+	Name() string
+
+	// NewWriter creates a compressing writer. Each Flush must make all data written so far decompressible.
+	NewWriter(w io.Writer) (FlushWriter, error)
+
+	// NewReader creates a decompressing reader.
+	NewReader(r io.Reader) (io.Reader, error)
+}
+
+// FlushWriter is an io.Writer whose buffered data can be flushed, e.g., a bufio.Writer.
+type FlushWriter interface {
+	io.Writer
+	Flush() error
+}
+
+// deflateCompression implements the DEFLATE based Compression.
+type deflateCompression struct{}
+
+// Deflate is a Compression based on DEFLATE, RFC 1951.
+var Deflate Compression = deflateCompression{}
+
+func (deflateCompression) Name() string {
+	return "deflate"
+}
+
+func (deflateCompression) NewWriter(w io.Writer) (FlushWriter, error) {
+	return flate.NewWriter(w, flate.DefaultCompression)
+}
+
+func (deflateCompression) NewReader(r io.Reader) (io.Reader, error) {
+	return flate.NewReader(r), nil
+}
+
+// offerCompression sends a compression offer to the server and returns the accepted Compression or nil if the server
+// declined all offered algorithms. An error indicates a failed handshake, e.g., by a server without compression support.
+func offerCompression(conn net.Conn, compressions []Compression) (Compression, error) {
+	w := bufio.NewWriter(conn)
+	if err := cboring.WriteArrayLength(2, w); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteTextString(compressionOfferTag, w); err != nil {
+		return nil, err
+	}
+	if err := cboring.WriteArrayLength(uint64(len(compressions)), w); err != nil {
+		return nil, err
+	}
+	for _, compression := range compressions {
+		if err := cboring.WriteTextString(compression.Name(), w); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(compressionHandshakeTimeout)); err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.SetReadDeadline(time.Time{}) }()
+
+	name, err := cboring.ReadTextString(conn)
+	if err != nil {
+		return nil, fmt.Errorf("reading compression reply failed: %w", err)
+	}
+
+	if name == compressionNone {
+		return nil, nil
+	}
+	for _, compression := range compressions {
+		if compression.Name() == name {
+			return compression, nil
+		}
+	}
+	return nil, fmt.Errorf("server selected the unoffered compression %q", name)
+}
+
+// isCompressionOffer checks if the next stream item is a compression offer without consuming it.
+func isCompressionOffer(r *bufio.Reader) (bool, error) {
+	b, err := r.Peek(1)
+	if err != nil {
+		return false, err
+	}
+
+	// CBOR's major type is stored in the three most significant bits; arrays are major type 4.
+	return b[0]>>5 == 4, nil
+}
+
+// acceptCompression reads a compression offer and replies with the first offered Compression, which is also supported
+// by this side. If none matches, nil is returned.
+func acceptCompression(r io.Reader, w io.Writer, compressions []Compression) (Compression, error) {
+	if n, err := cboring.ReadArrayLength(r); err != nil {
+		return nil, err
+	} else if n != 2 {
+		return nil, fmt.Errorf("compression offer has %d instead of 2 elements", n)
+	}
+
+	if tag, err := cboring.ReadTextString(r); err != nil {
+		return nil, err
+	} else if tag != compressionOfferTag {
+		return nil, fmt.Errorf("compression offer has an unknown tag %q", tag)
+	}
+
+	n, err := cboring.ReadArrayLength(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected Compression
+	for i := uint64(0); i < n; i++ {
+		name, nameErr := cboring.ReadTextString(r)
+		if nameErr != nil {
+			return nil, nameErr
+		}
+
+		for _, compression := range compressions {
+			if selected == nil && compression.Name() == name {
+				selected = compression
+			}
+		}
+	}
+
+	reply := compressionNone
+	if selected != nil {
+		reply = selected.Name()
+	}
+	if err := cboring.WriteTextString(reply, w); err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mtcp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCompressionNegotiation(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   []Compression
+		server   []Compression
+		selected Compression
+	}{
+		{"both", []Compression{Deflate}, []Compression{Deflate}, Deflate},
+		{"server disabled", []Compression{Deflate}, nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer clientConn.Close()
+			defer serverConn.Close()
+
+			errChan := make(chan error, 1)
+			go func() {
+				r := bufio.NewReader(serverConn)
+				if isOffer, err := isCompressionOffer(r); err != nil {
+					errChan <- err
+				} else if !isOffer {
+					errChan <- fmt.Errorf("offer was not detected")
+				} else {
+					_, err = acceptCompression(r, serverConn, test.server)
+					errChan <- err
+				}
+			}()
+
+			selected, err := offerCompression(clientConn, test.client)
+			if err != nil {
+				t.Fatal(err)
+			} else if err := <-errChan; err != nil {
+				t.Fatal(err)
+			}
+
+			if selected != test.selected {
+				t.Fatalf("expected compression %v, got %v", test.selected, selected)
+			}
+		})
+	}
+}
+
+// sendCompressionBundles sends similar bundles from a MTCPClient to a MTCPServer and checks their reception.
+func sendCompressionBundles(t *testing.T, client *MTCPClient, serverChan chan cla.ConvergenceStatus) {
+	const packages = 50
+
+	if err, _ := client.Start(); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for range client.Channel() {
+		}
+	}()
+
+	for i := 0; i < packages; i++ {
+		bndl, err := bpv7.Builder().
+			Source("dtn://src/").
+			Destination("dtn://dest/").
+			CreationTimestampEpoch().
+			Lifetime("60s").
+			BundleCtrlFlags(bpv7.MustNotFragmented).
+			BundleAgeBlock(i).
+			PayloadBlock([]byte(fmt.Sprintf("hello world, this is bundle number %d", i))).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := client.Send(bndl); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case cs := <-serverChan:
+			if recBndl := cs.Message.(cla.ConvergenceReceivedBundle).Bundle; !reflect.DeepEqual(recBndl, &bndl) {
+				t.Fatalf("received bundle differs: %v, %v", recBndl, &bndl)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("bundle %d was not received", i)
+		}
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMTCPCompressionInterop(t *testing.T) {
+	tests := []struct {
+		name   string
+		client []Compression
+		server []Compression
+	}{
+		{"both enabled", []Compression{Deflate}, []Compression{Deflate}},
+		{"client enabled", []Compression{Deflate}, nil},
+		{"server enabled", nil, []Compression{Deflate}},
+		{"both disabled", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			port := getRandomPort(t)
+
+			serv := NewMTCPServer(fmt.Sprintf(":%d", port), bpv7.MustNewEndpointID("dtn://mtcpcla/"), false)
+			serv.EnableCompression(test.server...)
+			if err, _ := serv.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer serv.Close()
+
+			client := NewAnonymousMTCPClient(fmt.Sprintf("localhost:%d", port), false)
+			client.EnableCompression(test.client...)
+
+			sendCompressionBundles(t, client, serv.Channel())
+		})
+	}
+}
+
+func TestMTCPCompressionLegacyServer(t *testing.T) {
+	// A legacy server only knows byte strings and drops connections starting with anything else.
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	serverChan := make(chan cla.ConvergenceStatus)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+				for {
+					if n, err := cboring.ReadByteStringLen(r); err != nil {
+						return
+					} else if n == 0 {
+						continue
+					}
+
+					bndl := new(bpv7.Bundle)
+					if err := cboring.Unmarshal(bndl, r); err != nil {
+						return
+					}
+					serverChan <- cla.NewConvergenceReceivedBundle(nil, bpv7.DtnNone(), bndl)
+				}
+			}(conn)
+		}
+	}()
+
+	client := NewAnonymousMTCPClient(ln.Addr().String(), false)
+	client.EnableCompression(Deflate)
+
+	sendCompressionBundles(t, client, serverChan)
+}
@@ -6,6 +6,7 @@ package mtcp
 
 import (
 	"bufio"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -28,6 +29,15 @@ type MTCPServer struct {
 	endpointID    bpv7.EndpointID
 	permanent     bool
 
+	// compressions are accepted if offered by a client.
+	compressions []Compression
+
+	// tlsConfig wraps incoming connections in TLS, if set.
+	tlsConfig *tls.Config
+
+	// rebindBackoff configures binding attempts after the listen address could not be bound or the socket died.
+	rebindBackoff cla.BackoffConfig
+
 	stopSyn chan struct{}
 	stopAck chan struct{}
 }
@@ -46,18 +56,39 @@ func NewMTCPServer(listenAddress string, endpointID bpv7.EndpointID, permanent b
 	}
 }
 
+// EnableCompression for incoming streams. If a client offers multiple Compressions, the first one supported by this
+// MTCPServer is selected. Clients without compression are still accepted.
+//
+// This method must be called before Start.
+func (serv *MTCPServer) EnableCompression(compressions ...Compression) {
+	serv.compressions = compressions
+}
+
+// EnableTLS for incoming connections. The tls.Config must contain this MTCPServer's certificate. Clients are not
+// authenticated, as MTCP is unidirectional. For a nil tls.Config, connections remain cleartext.
+//
+// This method must be called before Start.
+func (serv *MTCPServer) EnableTLS(config *tls.Config) {
+	serv.tlsConfig = config
+}
+
+// SetRebindBackoff configures the backoff between attempts to bind the listen address, if it is occupied or if the
+// listening socket dies. Its unset fields fall back to cla.BackoffConfig's defaults.
+//
+// This method must be called before Start.
+func (serv *MTCPServer) SetRebindBackoff(backoff cla.BackoffConfig) {
+	serv.rebindBackoff = backoff
+}
+
+// Start this MTCPServer. Only an unresolvable listen address results in an error. Otherwise, binding the address is
+// retried in the background, see cla.RebindingListener.
 func (serv *MTCPServer) Start() (error, bool) {
-	tcpAddr, err := net.ResolveTCPAddr("tcp", serv.listenAddress)
+	ln, err := cla.NewRebindingListener(serv.listenAddress, serv.rebindBackoff)
 	if err != nil {
 		return err, false
 	}
 
-	ln, err := net.ListenTCP("tcp", tcpAddr)
-	if err != nil {
-		return err, true
-	}
-
-	go func(ln *net.TCPListener) {
+	go func(ln *cla.RebindingListener) {
 		for {
 			select {
 			case <-serv.stopSyn:
@@ -68,14 +99,10 @@ func (serv *MTCPServer) Start() (error, bool) {
 				return
 
 			default:
-				if err := ln.SetDeadline(time.Now().Add(50 * time.Millisecond)); err != nil {
-					log.WithFields(log.Fields{
-						"cla":   serv,
-						"error": err,
-					}).Error("MTCPServer failed to set deadline on TCP socket")
-
-					_ = serv.Close()
-				} else if conn, err := ln.Accept(); err == nil {
+				if conn := ln.Accept(50 * time.Millisecond); conn != nil {
+					if serv.tlsConfig != nil {
+						conn = tls.Server(conn, serv.tlsConfig)
+					}
 					go serv.handleSender(conn)
 				}
 			}
@@ -103,7 +130,18 @@ func (serv *MTCPServer) handleSender(conn net.Conn) {
 		"conn": conn,
 	}).Debug("MTCP handleServer connection was established")
 
-	connReader := bufio.NewReader(conn)
+	connReader, err := serv.negotiateCompression(conn)
+	if err != nil {
+		if err != io.EOF {
+			log.WithFields(log.Fields{
+				"cla":   serv,
+				"conn":  conn,
+				"error": err,
+			}).Warn("MTCP handleServer connection failed to negotiate compression")
+		}
+		return
+	}
+
 	for {
 		if n, err := cboring.ReadByteStringLen(connReader); err != nil {
 			if err != io.EOF {
@@ -143,6 +181,36 @@ func (serv *MTCPServer) handleSender(conn net.Conn) {
 	}
 }
 
+// negotiateCompression answers an optional compression offer at the stream's start and returns the stream's reader.
+func (serv *MTCPServer) negotiateCompression(conn net.Conn) (io.Reader, error) {
+	connReader := bufio.NewReader(conn)
+
+	if isOffer, err := isCompressionOffer(connReader); err != nil {
+		return nil, err
+	} else if !isOffer {
+		return connReader, nil
+	}
+
+	compression, err := acceptCompression(connReader, conn, serv.compressions)
+	if err != nil {
+		return nil, err
+	} else if compression == nil {
+		return connReader, nil
+	}
+
+	log.WithFields(log.Fields{
+		"cla":         serv,
+		"conn":        conn,
+		"compression": compression.Name(),
+	}).Debug("MTCP handleServer connection negotiated stream compression")
+
+	compReader, err := compression.NewReader(connReader)
+	if err != nil {
+		return nil, err
+	}
+	return bufio.NewReader(compReader), nil
+}
+
 func (serv *MTCPServer) Channel() chan cla.ConvergenceStatus {
 	return serv.reportChan
 }
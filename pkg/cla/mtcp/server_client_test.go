@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
@@ -129,3 +130,64 @@ func TestMTCPServerClient(t *testing.T) {
 		t.Fatalf("Counter is not zero: %d", c.(int))
 	}
 }
+
+func TestMTCPServerRebind(t *testing.T) {
+	port := getRandomPort(t)
+	address := fmt.Sprintf(":%d", port)
+
+	occupier, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serv := NewMTCPServer(address, bpv7.MustNewEndpointID("dtn://mtcpcla/"), false)
+	serv.SetRebindBackoff(cla.BackoffConfig{Base: 20 * time.Millisecond, Max: 100 * time.Millisecond})
+	if err, _ := serv.Start(); err != nil {
+		t.Fatalf("starting the server on an occupied port erred: %v", err)
+	}
+	defer serv.Close()
+
+	// The server binds its address after the occupying listener is gone.
+	_ = occupier.Close()
+
+	client := NewAnonymousMTCPClient(fmt.Sprintf("localhost:%d", port), false)
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		if err, _ := client.Start(); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("client was not able to connect to the rebound server: %v", err)
+		}
+	}
+	defer client.Close()
+	go func() {
+		for range client.Channel() {
+		}
+	}()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampEpoch().
+		Lifetime("60s").
+		BundleCtrlFlags(bpv7.MustNotFragmented).
+		BundleAgeBlock(0).
+		PayloadBlock([]byte("hello again")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Send(bndl); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case cs := <-serv.Channel():
+		if recBndl := cs.Message.(cla.ConvergenceReceivedBundle).Bundle; !reflect.DeepEqual(recBndl, &bndl) {
+			t.Fatalf("received bundle differs: %v, %v", recBndl, &bndl)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("bundle was not received")
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mtcp
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// tlsHandshakeTimeout limits a client's TLS handshake.
+const tlsHandshakeTimeout = 5 * time.Second
+
+// dialTLS dials a new TCP connection and wraps it in a TLS client session. Without a tls.Config, a plain TCP connection
+// is returned.
+//
+// As MTCP is unidirectional, only the server authenticates itself. If the tls.Config's ServerName is unset, it is
+// derived from the address to verify the server's certificate.
+func dialTLS(address string, config *tls.Config) (net.Conn, error) {
+	conn, err := dial(address)
+	if err != nil || config == nil {
+		return conn, err
+	}
+
+	if config.ServerName == "" {
+		if host, _, hostErr := net.SplitHostPort(address); hostErr == nil {
+			config = config.Clone()
+			config.ServerName = host
+		}
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.SetDeadline(time.Now().Add(tlsHandshakeTimeout)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
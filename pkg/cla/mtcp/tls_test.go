@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mtcp
+
+import (
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// newTestTLSConfigs creates a server's tls.Config with a self-signed certificate for localhost and a client's
+// tls.Config trusting it.
+func newTestTLSConfigs(t *testing.T) (server, client *tls.Config) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(nil, tmpl, tmpl, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	server = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: priv}}}
+	client = &tls.Config{RootCAs: pool}
+	return
+}
+
+func TestMTCPTLS(t *testing.T) {
+	serverConf, clientConf := newTestTLSConfigs(t)
+
+	tests := []struct {
+		name         string
+		compressions []Compression
+	}{
+		{"plain", nil},
+		{"compressed", []Compression{Deflate}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			port := getRandomPort(t)
+
+			serv := NewMTCPServer(fmt.Sprintf(":%d", port), bpv7.MustNewEndpointID("dtn://mtcpcla/"), false)
+			serv.EnableTLS(serverConf)
+			serv.EnableCompression(test.compressions...)
+			if err, _ := serv.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer serv.Close()
+
+			client := NewAnonymousMTCPClient(fmt.Sprintf("localhost:%d", port), false)
+			client.EnableTLS(clientConf)
+			client.EnableCompression(test.compressions...)
+
+			sendCompressionBundles(t, client, serv.Channel())
+		})
+	}
+}
+
+func TestMTCPTLSUntrusted(t *testing.T) {
+	serverConf, _ := newTestTLSConfigs(t)
+	port := getRandomPort(t)
+
+	serv := NewMTCPServer(fmt.Sprintf(":%d", port), bpv7.MustNewEndpointID("dtn://mtcpcla/"), false)
+	serv.EnableTLS(serverConf)
+	if err, _ := serv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer serv.Close()
+
+	client := NewAnonymousMTCPClient(fmt.Sprintf("localhost:%d", port), false)
+	client.EnableTLS(&tls.Config{RootCAs: x509.NewCertPool()})
+
+	if err, _ := client.Start(); err == nil {
+		_ = client.Close()
+		t.Fatal("client started with an untrusted server certificate")
+	}
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cla
+
+import (
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// PeerInfo describes a remote peer, learned by probing it.
+//
+// Probing performs only a CLA's handshake to discover the peer's Endpoint ID and capabilities. Afterwards, the
+// connection is closed again and no Convergence is registered. Thus, one can decide whether to establish a link to
+// this peer at all, e.g., to skip connections to oneself.
+type PeerInfo struct {
+	// EndpointID is the peer's Endpoint ID, as announced during the handshake.
+	EndpointID bpv7.EndpointID
+
+	// CLAType of the probed connection.
+	CLAType CLAType
+
+	// Capabilities are CLA specific properties announced by the peer, e.g., TCPCLv4's segment MRU.
+	Capabilities map[string]string
+}
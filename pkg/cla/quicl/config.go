@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/dtn7/dtn7-go/pkg/cla/quicl/internal"
+)
+
+// defaultHandshakeTimeout is the default time a listener waits for the dialer to initiate the QUICL handshake.
+const defaultHandshakeTimeout = 500 * time.Millisecond
+
+// QuiclConfig configures the QUIC connections of a Listener or a dialing Endpoint.
+//
+// Unset fields fall back to the internal defaults, which are tuned for local networks. Links with a high latency,
+// e.g., satellite links, might require longer timeouts.
+type QuiclConfig struct {
+	// MaxIdleTimeout after which an idle QUIC connection is closed.
+	MaxIdleTimeout time.Duration
+
+	// HandshakeTimeout for the QUICL handshake, exchanging both peers' Endpoint IDs.
+	HandshakeTimeout time.Duration
+
+	// KeepAlivePeriod between two keep-alive packets on a QUIC connection.
+	KeepAlivePeriod time.Duration
+
+	// MaxIncomingStreams a peer is allowed to open, i.e., concurrently transmitted bundles.
+	MaxIncomingStreams int64
+
+	// MaxConcurrentStreams limits the incoming streams, i.e., bundles, handled concurrently over all connections of a
+	// Listener. Freed capacity is shared fairly between the connections. Zero disables this limit.
+	MaxConcurrentStreams int
+
+	// MaxConnectionStreams limits the incoming streams handled concurrently per connection. Zero disables this limit.
+	MaxConnectionStreams int
+}
+
+// quicConfig creates a quic.Config from the internal defaults, overridden by this QuiclConfig's set fields.
+func (conf QuiclConfig) quicConfig() *quic.Config {
+	quicConf := internal.GenerateQUICConfig()
+
+	if conf.MaxIdleTimeout > 0 {
+		quicConf.MaxIdleTimeout = conf.MaxIdleTimeout
+	}
+	if conf.KeepAlivePeriod > 0 {
+		quicConf.KeepAlivePeriod = conf.KeepAlivePeriod
+	}
+	if conf.MaxIncomingStreams > 0 {
+		quicConf.MaxIncomingStreams = conf.MaxIncomingStreams
+	}
+
+	return quicConf
+}
+
+// handshakeTimeout returns the configured HandshakeTimeout or its default.
+func (conf QuiclConfig) handshakeTimeout() time.Duration {
+	if conf.HandshakeTimeout > 0 {
+		return conf.HandshakeTimeout
+	}
+	return defaultHandshakeTimeout
+}
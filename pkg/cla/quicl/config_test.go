@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/cla/quicl/internal"
+)
+
+func TestQuiclConfig(t *testing.T) {
+	defaults := internal.GenerateQUICConfig()
+
+	tests := []struct {
+		name      string
+		conf      QuiclConfig
+		idle      time.Duration
+		keepAlive time.Duration
+		streams   int64
+		handshake time.Duration
+	}{
+		{"defaults", QuiclConfig{},
+			defaults.MaxIdleTimeout, defaults.KeepAlivePeriod, defaults.MaxIncomingStreams, defaultHandshakeTimeout},
+		{"satellite", QuiclConfig{MaxIdleTimeout: time.Minute, HandshakeTimeout: 10 * time.Second, KeepAlivePeriod: 20 * time.Second},
+			time.Minute, 20 * time.Second, defaults.MaxIncomingStreams, 10 * time.Second},
+		{"streams", QuiclConfig{MaxIncomingStreams: 16},
+			defaults.MaxIdleTimeout, defaults.KeepAlivePeriod, 16, defaultHandshakeTimeout},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			quicConf := test.conf.quicConfig()
+
+			if quicConf.MaxIdleTimeout != test.idle {
+				t.Fatalf("max idle timeout is %v, expected %v", quicConf.MaxIdleTimeout, test.idle)
+			}
+			if quicConf.KeepAlivePeriod != test.keepAlive {
+				t.Fatalf("keep-alive period is %v, expected %v", quicConf.KeepAlivePeriod, test.keepAlive)
+			}
+			if quicConf.MaxIncomingStreams != test.streams {
+				t.Fatalf("max incoming streams are %d, expected %d", quicConf.MaxIncomingStreams, test.streams)
+			}
+			if handshake := test.conf.handshakeTimeout(); handshake != test.handshake {
+				t.Fatalf("handshake timeout is %v, expected %v", handshake, test.handshake)
+			}
+		})
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"io"
 	"net"
 	"sync/atomic"
-	"time"
 
 	"github.com/dtn7/cboring"
 
@@ -24,9 +23,6 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// TODO: is this a reasonable value? I don't know...
-const handshakeTimeout = 500 * time.Millisecond
-
 type Endpoint struct {
 	// id is the bundle protocol endpoint id which this CLA is exposing
 	id bpv7.EndpointID
@@ -46,9 +42,20 @@ type Endpoint struct {
 
 	// Whether the protocol handshake has been completed
 	handshake *uint32
+
+	// config of the QUIC connection and the handshake's timeout
+	config QuiclConfig
+
+	// limiter bounds the concurrently handled incoming streams; a Listener shares its limiter between all connections
+	limiter *streamLimiter
 }
 
 func NewListenerEndpoint(id bpv7.EndpointID, session quic.Connection) *Endpoint {
+	return NewListenerEndpointWithConfig(id, session, QuiclConfig{})
+}
+
+// NewListenerEndpointWithConfig is NewListenerEndpoint with a QuiclConfig, e.g., to wait longer for the handshake.
+func NewListenerEndpointWithConfig(id bpv7.EndpointID, session quic.Connection, config QuiclConfig) *Endpoint {
 	return &Endpoint{
 		id:               id,
 		peerAddress:      session.RemoteAddr().String(),
@@ -57,10 +64,17 @@ func NewListenerEndpoint(id bpv7.EndpointID, session quic.Connection) *Endpoint
 		permanent:        false,
 		dialer:           false,
 		handshake:        new(uint32),
+		config:           config,
+		limiter:          newStreamLimiter(0, config.MaxConnectionStreams),
 	}
 }
 
 func NewDialerEndpoint(peerAddress string, id bpv7.EndpointID, permanent bool) *Endpoint {
+	return NewDialerEndpointWithConfig(peerAddress, id, permanent, QuiclConfig{})
+}
+
+// NewDialerEndpointWithConfig is NewDialerEndpoint with a QuiclConfig for the dialed QUIC connection.
+func NewDialerEndpointWithConfig(peerAddress string, id bpv7.EndpointID, permanent bool, config QuiclConfig) *Endpoint {
 	return &Endpoint{
 		id:               id,
 		peerAddress:      peerAddress,
@@ -68,6 +82,8 @@ func NewDialerEndpoint(peerAddress string, id bpv7.EndpointID, permanent bool) *
 		permanent:        permanent,
 		dialer:           true,
 		handshake:        new(uint32),
+		config:           config,
+		limiter:          newStreamLimiter(0, config.MaxConnectionStreams),
 	}
 }
 
@@ -92,7 +108,7 @@ Methods for Convergence interface
 func (endpoint *Endpoint) Start() (error, bool) {
 	// if we are on the dialer-side we need to first initiate the quic-connection
 	if endpoint.dialer {
-		session, err := quic.DialAddr(endpoint.peerAddress, internal.GenerateSimpleDialerTLSConfig(), internal.GenerateQUICConfig())
+		session, err := quic.DialAddr(endpoint.peerAddress, internal.GenerateSimpleDialerTLSConfig(), endpoint.config.quicConfig())
 		endpoint.connection = session
 		if err != nil {
 			return err, endpoint.permanent
@@ -147,6 +163,10 @@ func (endpoint *Endpoint) IsPermanent() bool {
 	return endpoint.permanent
 }
 
+func (endpoint *Endpoint) GetCLAType() cla.CLAType {
+	return cla.QUICL
+}
+
 /**
 Methods for ConvergenceReceiver interface
 */
@@ -180,23 +200,8 @@ func (endpoint *Endpoint) Send(bndl bpv7.Bundle) error {
 		return err
 	}
 
-	buff := new(bytes.Buffer)
-	if err = cboring.Marshal(&bndl, buff); err != nil {
-		stream.CancelWrite(internal.DataMarshalError)
-		_ = stream.Close()
-		return err
-	}
-
-	// TODO: Do we actually need the bufio-wrapper?
-	writer := bufio.NewWriter(stream)
-	if _, err = buff.WriteTo(writer); err != nil {
-		stream.CancelWrite(internal.StreamTransmissionError)
-		_ = stream.Close()
-		return err
-	}
-
-	if err = writer.Flush(); err != nil {
-		stream.CancelWrite(internal.StreamTransmissionError)
+	if code, err := writeBundle(stream, &bndl); err != nil {
+		stream.CancelWrite(code)
 		_ = stream.Close()
 		return err
 	}
@@ -215,10 +220,51 @@ func (endpoint *Endpoint) Send(bndl bpv7.Bundle) error {
 Non-interface methods
 */
 
+// sendBufferSize is the size of the buffer in front of a QUIC stream, collecting the many small writes of a bundle's
+// CBOR serialization.
+const sendBufferSize = 32 * 1024
+
+// streamWriter passes writes on to a stream and remembers the first failed write. Thus, a transmission error can be
+// told apart from a bundle's serialization error.
+type streamWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (sw *streamWriter) Write(p []byte) (n int, err error) {
+	n, err = sw.w.Write(p)
+	if err != nil && sw.err == nil {
+		sw.err = err
+	}
+	return
+}
+
+// writeBundle serializes a bundle directly onto a stream instead of buffering the whole bundle first. Writing to a
+// QUIC stream blocks while its flow control does not permit sending more data. Only blocks with a CRC are buffered
+// while serializing, to calculate their checksum.
+//
+// On error, the returned StreamErrorCode indicates whether the bundle could not be serialized or transmitted.
+func writeBundle(w io.Writer, bndl *bpv7.Bundle) (quic.StreamErrorCode, error) {
+	sw := &streamWriter{w: w}
+	writer := bufio.NewWriterSize(sw, sendBufferSize)
+
+	if err := bndl.MarshalCbor(writer); err != nil {
+		if sw.err != nil {
+			return internal.StreamTransmissionError, err
+		}
+		return internal.DataMarshalError, err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return internal.StreamTransmissionError, err
+	}
+	return 0, nil
+}
+
 // handleConnection continuously listens on the connection and accepts incoming streams
 // This method is meant to be run in its own goroutine.
 // When a new stream is opened, i.e. when the peer wants to send us a bundle, we spawn a new goroutine
-// to handle the incoming data.
+// to handle the incoming data. If the limiter has no free capacity, accepting further streams waits.
 func (endpoint *Endpoint) handleConnection() {
 	log.WithFields(log.Fields{"endpoint": endpoint.GetEndpointID(), "peer": endpoint.GetPeerEndpointID()}).Debug("CLA Started")
 	endpoint.reportingChannel <- cla.NewConvergencePeerAppeared(endpoint, endpoint.GetPeerEndpointID())
@@ -261,8 +307,14 @@ func (endpoint *Endpoint) handleConnection() {
 					"error": err,
 				}).Error("Unexpected error while waiting for stream")
 			}
+		} else if err := endpoint.limiter.acquire(endpoint.connection.Context(), endpoint); err != nil {
+			// The connection was closed while waiting for capacity; the next AcceptStream reports why.
+			stream.CancelRead(internal.StreamRejected)
 		} else {
-			go endpoint.handleStream(stream)
+			go func() {
+				defer endpoint.limiter.release(endpoint)
+				endpoint.handleStream(stream)
+			}()
 		}
 	}
 }
@@ -300,8 +352,8 @@ func (endpoint *Endpoint) handleStream(stream quic.Stream) {
 func (endpoint *Endpoint) handshakeListener() error {
 	log.WithField("cla", endpoint.peerAddress).Debug("Performing handshake")
 
-	// the dialer has half a second to initiate the handshake
-	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	// the dialer has the configured handshake timeout, by default half a second, to initiate the handshake
+	ctx, cancel := context.WithTimeout(context.Background(), endpoint.config.handshakeTimeout())
 	defer cancel()
 
 	// wait for the dialer to open a stream
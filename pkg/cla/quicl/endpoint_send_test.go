@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/cboring"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla/quicl/internal"
+)
+
+// limitedWriter fails after n bytes were written.
+type limitedWriter struct {
+	n int
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > lw.n {
+		n := lw.n
+		lw.n = 0
+		return n, errors.New("stream reset")
+	}
+	lw.n -= len(p)
+	return len(p), nil
+}
+
+func largeTestBundle(t testing.TB, size int) bpv7.Bundle {
+	b, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(randomData(size)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWriteBundle(t *testing.T) {
+	b := largeTestBundle(t, 4*1024*1024)
+
+	var buff bytes.Buffer
+	if _, err := writeBundle(&buff, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	var b2 bpv7.Bundle
+	if err := cboring.Unmarshal(&b2, &buff); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(b, b2) {
+		t.Fatal("streamed bundle differs")
+	}
+}
+
+func TestWriteBundleStreamError(t *testing.T) {
+	b := largeTestBundle(t, 4*1024*1024)
+
+	if code, err := writeBundle(&limitedWriter{n: 1024 * 1024}, &b); err == nil {
+		t.Fatal("writing to a failing stream succeeded")
+	} else if code != internal.StreamTransmissionError {
+		t.Fatalf("failing stream resulted in error code %d", code)
+	}
+
+	b.PrimaryBlock.Destination = bpv7.EndpointID{}
+	if code, err := writeBundle(io.Discard, &b); err == nil {
+		t.Fatal("writing an invalid bundle succeeded")
+	} else if code != internal.DataMarshalError {
+		t.Fatalf("invalid bundle resulted in error code %d", code)
+	}
+}
+
+// BenchmarkWriteBundle streams a large bundle, compared to BenchmarkWriteBundleBuffered.
+func BenchmarkWriteBundle(b *testing.B) {
+	bndl := largeTestBundle(b, 16*1024*1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := writeBundle(io.Discard, &bndl); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkWriteBundleBuffered serializes a large bundle into memory before writing it, as previously done.
+func BenchmarkWriteBundleBuffered(b *testing.B) {
+	bndl := largeTestBundle(b, 16*1024*1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buff := new(bytes.Buffer)
+		if err := cboring.Marshal(&bndl, buff); err != nil {
+			b.Fatal(err)
+		} else if _, err := buff.WriteTo(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -51,7 +51,7 @@ func handleListener(
 
 	defer serverWg.Done()
 
-	manager := cla.NewManager()
+	manager := cla.NewManager(cla.BackoffConfig{})
 	manager.Register(listener)
 
 	go func() {
@@ -23,6 +23,8 @@ const (
 
 	DataMarshalError        quic.StreamErrorCode = 1
 	StreamTransmissionError quic.StreamErrorCode = 2
+	// StreamRejected is sent when an incoming stream cannot be handled, e.g., because the connection was closed
+	StreamRejected quic.StreamErrorCode = 3
 )
 
 // HandshakeError is thrown by either the listener or dialer if there is any problem during the protocol handshake
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"context"
+	"sync"
+)
+
+// streamLimiter bounds the incoming streams being handled concurrently, both in total and per connection.
+//
+// If no capacity is available, a connection waits for another stream to finish. Freed capacity is granted to the
+// waiting connections in a round-robin manner. Thus, a peer flooding streams cannot starve other peers, which still
+// get their share of the capacity.
+type streamLimiter struct {
+	mutex sync.Mutex
+
+	// total and perConnection limit the concurrently handled streams; zero disables a limit.
+	total         int
+	perConnection int
+
+	// active counts all handled streams while conns tracks the streams and waiters of each connection.
+	active int
+	conns  map[interface{}]*connectionStreams
+
+	// ring is the round-robin order of connections with waiting streams.
+	ring []interface{}
+}
+
+// connectionStreams are the handled streams and waiters of a single connection.
+type connectionStreams struct {
+	active  int
+	waiters []chan struct{}
+}
+
+// newStreamLimiter with a total and a per connection limit. A zero limit is disabled.
+func newStreamLimiter(total, perConnection int) *streamLimiter {
+	return &streamLimiter{
+		total:         total,
+		perConnection: perConnection,
+		conns:         make(map[interface{}]*connectionStreams),
+	}
+}
+
+// acquire capacity to handle a stream of a connection. This method blocks until capacity is granted or the context is
+// done. Each successful acquire must be followed by a release.
+func (sl *streamLimiter) acquire(ctx context.Context, conn interface{}) error {
+	sl.mutex.Lock()
+
+	cs, ok := sl.conns[conn]
+	if !ok {
+		cs = &connectionStreams{}
+		sl.conns[conn] = cs
+	}
+
+	if len(cs.waiters) == 0 && sl.available(cs) {
+		sl.active++
+		cs.active++
+
+		sl.mutex.Unlock()
+		return nil
+	}
+
+	waiter := make(chan struct{})
+	if len(cs.waiters) == 0 {
+		sl.ring = append(sl.ring, conn)
+	}
+	cs.waiters = append(cs.waiters, waiter)
+
+	sl.mutex.Unlock()
+
+	select {
+	case <-waiter:
+		return nil
+
+	case <-ctx.Done():
+		sl.mutex.Lock()
+		defer sl.mutex.Unlock()
+
+		select {
+		case <-waiter:
+			// Capacity was granted concurrently and is passed on.
+			sl.releaseLocked(conn)
+		default:
+			sl.removeWaiter(conn, waiter)
+		}
+		return ctx.Err()
+	}
+}
+
+// release capacity after a stream of a connection was handled.
+func (sl *streamLimiter) release(conn interface{}) {
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+
+	sl.releaseLocked(conn)
+}
+
+// releaseLocked is release for an already locked mutex.
+func (sl *streamLimiter) releaseLocked(conn interface{}) {
+	cs, ok := sl.conns[conn]
+	if !ok || cs.active == 0 {
+		return
+	}
+
+	sl.active--
+	cs.active--
+
+	sl.dispatch()
+	sl.forget(conn)
+}
+
+// available checks if a connection might handle another stream.
+func (sl *streamLimiter) available(cs *connectionStreams) bool {
+	return (sl.total <= 0 || sl.active < sl.total) && (sl.perConnection <= 0 || cs.active < sl.perConnection)
+}
+
+// dispatch grants free capacity to the waiting connections in round-robin order. A connection still having waiters
+// after being granted capacity is moved to the end of the ring.
+func (sl *streamLimiter) dispatch() {
+	for i := 0; i < len(sl.ring) && (sl.total <= 0 || sl.active < sl.total); {
+		conn := sl.ring[i]
+		cs := sl.conns[conn]
+
+		if !sl.available(cs) {
+			i++
+			continue
+		}
+
+		waiter := cs.waiters[0]
+		cs.waiters = cs.waiters[1:]
+
+		sl.active++
+		cs.active++
+		close(waiter)
+
+		sl.ring = append(sl.ring[:i], sl.ring[i+1:]...)
+		if len(cs.waiters) > 0 {
+			sl.ring = append(sl.ring, conn)
+		}
+	}
+}
+
+// removeWaiter of a connection, e.g., after its context was canceled.
+func (sl *streamLimiter) removeWaiter(conn interface{}, waiter chan struct{}) {
+	cs, ok := sl.conns[conn]
+	if !ok {
+		return
+	}
+
+	for i, w := range cs.waiters {
+		if w == waiter {
+			cs.waiters = append(cs.waiters[:i], cs.waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(cs.waiters) == 0 {
+		for i, c := range sl.ring {
+			if c == conn {
+				sl.ring = append(sl.ring[:i], sl.ring[i+1:]...)
+				break
+			}
+		}
+	}
+
+	sl.forget(conn)
+}
+
+// forget a connection without both handled streams and waiters.
+func (sl *streamLimiter) forget(conn interface{}) {
+	if cs, ok := sl.conns[conn]; ok && cs.active == 0 && len(cs.waiters) == 0 {
+		delete(sl.conns, conn)
+	}
+}
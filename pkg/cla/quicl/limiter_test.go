@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flood the streamLimiter with concurrent streams of a connection, each being handled for the given duration.
+func flood(sl *streamLimiter, conn interface{}, streams int, duration time.Duration, maxActive *int32) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	var active int32
+
+	wg.Add(streams)
+	for i := 0; i < streams; i++ {
+		go func() {
+			defer wg.Done()
+
+			if err := sl.acquire(context.Background(), conn); err != nil {
+				return
+			}
+
+			if n := atomic.AddInt32(&active, 1); maxActive != nil {
+				for {
+					if m := atomic.LoadInt32(maxActive); n <= m || atomic.CompareAndSwapInt32(maxActive, m, n) {
+						break
+					}
+				}
+			}
+			time.Sleep(duration)
+			atomic.AddInt32(&active, -1)
+
+			sl.release(conn)
+		}()
+	}
+
+	return &wg
+}
+
+func TestStreamLimiterFairness(t *testing.T) {
+	const handling = 20 * time.Millisecond
+
+	sl := newStreamLimiter(4, 0)
+	flooder, peer := "flooder", "peer"
+
+	// The flooder's 100 streams would block a FIFO limiter for about half a second.
+	wg := flood(sl, flooder, 100, handling, nil)
+	time.Sleep(handling / 2)
+
+	start := time.Now()
+	if err := sl.acquire(context.Background(), peer); err != nil {
+		t.Fatal(err)
+	}
+	waited := time.Since(start)
+	sl.release(peer)
+
+	if waited > 5*handling {
+		t.Fatalf("peer waited %v while another peer was flooding", waited)
+	}
+
+	wg.Wait()
+
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+	if sl.active != 0 || len(sl.conns) != 0 || len(sl.ring) != 0 {
+		t.Fatalf("limiter is not empty: %d active, %d connections, %d waiting", sl.active, len(sl.conns), len(sl.ring))
+	}
+}
+
+func TestStreamLimiterPerConnection(t *testing.T) {
+	sl := newStreamLimiter(4, 3)
+
+	var maxActive int32
+	wg := flood(sl, "flooder", 20, 10*time.Millisecond, &maxActive)
+	time.Sleep(5 * time.Millisecond)
+
+	// One slot is always left for another connection.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := sl.acquire(ctx, "peer"); err != nil {
+		t.Fatalf("peer did not get the slot left by the flooder: %v", err)
+	}
+	sl.release("peer")
+
+	wg.Wait()
+
+	if maxActive > 3 {
+		t.Fatalf("flooder handled %d streams concurrently, limit is 3", maxActive)
+	}
+}
+
+func TestStreamLimiterCancel(t *testing.T) {
+	sl := newStreamLimiter(1, 0)
+
+	if err := sl.acquire(context.Background(), "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := sl.acquire(ctx, "b"); err == nil {
+		t.Fatal("acquire succeeded without capacity")
+	}
+
+	sl.release("a")
+
+	if err := sl.acquire(context.Background(), "b"); err != nil {
+		t.Fatal(err)
+	}
+	sl.release("b")
+
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+	if sl.active != 0 || len(sl.conns) != 0 || len(sl.ring) != 0 {
+		t.Fatalf("limiter is not empty: %d active, %d connections, %d waiting", sl.active, len(sl.conns), len(sl.ring))
+	}
+}
@@ -20,14 +20,25 @@ type Listener struct {
 	endpointID    bpv7.EndpointID
 	manager       *cla.Manager
 	listener      quic.Listener
+	config        QuiclConfig
+
+	// limiter is shared by all accepted connections to handle their streams fairly
+	limiter *streamLimiter
 }
 
 func NewQUICListener(listenAddress string, endpointID bpv7.EndpointID) *Listener {
+	return NewQUICListenerWithConfig(listenAddress, endpointID, QuiclConfig{})
+}
+
+// NewQUICListenerWithConfig is NewQUICListener with a QuiclConfig, applied to all accepted connections.
+func NewQUICListenerWithConfig(listenAddress string, endpointID bpv7.EndpointID, config QuiclConfig) *Listener {
 	return &Listener{
 		listenAddress: listenAddress,
 		endpointID:    endpointID,
 		manager:       nil,
 		listener:      nil,
+		config:        config,
+		limiter:       newStreamLimiter(config.MaxConcurrentStreams, config.MaxConnectionStreams),
 	}
 }
 
@@ -50,7 +61,7 @@ func (listener *Listener) RegisterManager(manager *cla.Manager) {
 
 func (listener *Listener) Start() error {
 	log.WithField("address", listener.listenAddress).Info("Starting QUICL-listener")
-	lst, err := quic.ListenAddr(listener.listenAddress, internal.GenerateSimpleListenerTLSConfig(), internal.GenerateQUICConfig())
+	lst, err := quic.ListenAddr(listener.listenAddress, internal.GenerateSimpleListenerTLSConfig(), listener.config.quicConfig())
 	if err != nil {
 		log.WithError(err).Error("Error creating QUICL listener")
 		return err
@@ -88,7 +99,8 @@ func (listener *Listener) handle() {
 				"address": listener.listenAddress,
 				"peer":    session.RemoteAddr(),
 			}).Info("QUICL listener accepted new connection")
-			endpoint := NewListenerEndpoint(listener.endpointID, session)
+			endpoint := NewListenerEndpointWithConfig(listener.endpointID, session, listener.config)
+			endpoint.limiter = listener.limiter
 			go listener.manager.Register(endpoint)
 		}
 	}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"github.com/quic-go/quic-go"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/cla/quicl/internal"
+)
+
+// ProbePeer dials a remote QUICL Listener and performs only the protocol handshake to learn the peer's Endpoint ID.
+// Afterwards, the QUIC connection is closed again. Thus, no Endpoint has to be registered at a cla.Manager.
+//
+// As the QUICL handshake exchanges nothing but the Endpoint IDs, the returned cla.PeerInfo has no capabilities.
+func ProbePeer(address string, endpointID bpv7.EndpointID) (info cla.PeerInfo, err error) {
+	endpoint := NewDialerEndpoint(address, endpointID, false)
+
+	endpoint.connection, err = quic.DialAddr(address, internal.GenerateSimpleDialerTLSConfig(), endpoint.config.quicConfig())
+	if err != nil {
+		return
+	}
+
+	if err = endpoint.handshakeDialer(); err != nil {
+		_ = endpoint.connection.CloseWithError(internal.LocalError, "Probe failed")
+		return
+	}
+
+	info = cla.PeerInfo{
+		EndpointID:   endpoint.GetPeerEndpointID(),
+		CLAType:      endpoint.GetCLAType(),
+		Capabilities: map[string]string{},
+	}
+
+	err = endpoint.connection.CloseWithError(internal.ApplicationShutdown, "Probe finished")
+	return
+}
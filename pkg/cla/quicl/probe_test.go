@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package quicl
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestProbePeer(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", randomTcpPort(t))
+
+	manager := cla.NewManager(cla.BackoffConfig{})
+	defer manager.Close()
+
+	go func() {
+		for range manager.Channel() {
+		}
+	}()
+
+	manager.Register(NewQUICListener(addr, bpv7.MustNewEndpointID("dtn://server/")))
+	time.Sleep(250 * time.Millisecond)
+
+	info, err := ProbePeer(addr, bpv7.MustNewEndpointID("dtn://client/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.EndpointID != bpv7.MustNewEndpointID("dtn://server/") {
+		t.Fatalf("expected peer dtn://server/, got %v", info.EndpointID)
+	}
+	if info.CLAType != cla.QUICL {
+		t.Fatalf("expected CLA type %v, got %v", cla.QUICL, info.CLAType)
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -28,15 +31,25 @@ type Client struct {
 	address    string
 	permanent  bool
 	activePeer bool
+	claType    cla.CLAType
 
 	customStartFunc func(*Client) error
 
+	// sessionConfig is offered to the peer while initializing the session.
+	sessionConfig SessionConfig
+
 	started    bool
 	connCloser io.Closer
 
-	messageSwitch   utils.MessageSwitch
-	stageHandler    *stages.StageHandler
+	messageSwitch utils.MessageSwitch
+	stageHandler  *stages.StageHandler
+
+	// transferManager of the current session, guarded by transferMutex, as it is reset by the handler.
 	transferManager *utils.TransferManager
+	transferMutex   sync.Mutex
+
+	// peerTransferMru is the peer's Transfer MRU of the current session, accessed atomically.
+	peerTransferMru uint64
 
 	nodeId     bpv7.EndpointID
 	peerNodeId bpv7.EndpointID
@@ -68,6 +81,20 @@ func (client *Client) log() *log.Entry {
 	return log.WithField("cla", client.String())
 }
 
+// stageConfiguration for this Client's session.
+func (client *Client) stageConfiguration() stages.Configuration {
+	sessionConfig := client.sessionConfig.withDefaults()
+
+	return stages.Configuration{
+		ActivePeer:   client.activePeer,
+		ContactFlags: 0,
+		Keepalive:    *sessionConfig.Keepalive,
+		SegmentMru:   sessionConfig.SegmentMru,
+		TransferMru:  sessionConfig.TransferMru,
+		NodeId:       client.nodeId,
+	}
+}
+
 // Start this Client and return both an error and a boolean indicating if another Start should be tried later.
 func (client *Client) Start() (err error, retry bool) {
 	if client.started {
@@ -95,16 +122,10 @@ func (client *Client) Start() (err error, retry bool) {
 	}
 	msIncoming, msOutgoing, _ := client.messageSwitch.Exchange()
 
-	conf := stages.Configuration{
-		ActivePeer:   client.activePeer,
-		ContactFlags: 0,
-		Keepalive:    30,
-		SegmentMru:   1048576,
-		TransferMru:  1073741824,
-		NodeId:       client.nodeId,
-	}
+	conf := client.stageConfiguration()
 
-	sMtuChan := make(chan uint64)
+	// The peer's Segment MRU and Transfer MRU, used as MTUs for outgoing transfers.
+	mtuChan := make(chan [2]uint64)
 	stageHandlerStages := []stages.StageSetup{
 		{
 			Stage: &stages.ContactStage{},
@@ -129,7 +150,7 @@ func (client *Client) Start() (err error, retry bool) {
 			PreHook: func(_ *stages.StageHandler, state *stages.State) error {
 				client.log().Debug("Starting Session Established Stage")
 
-				sMtuChan <- state.SegmentMtu
+				mtuChan <- [2]uint64{state.SegmentMtu, state.TransferMtu}
 				return nil
 			},
 		}}
@@ -141,9 +162,12 @@ func (client *Client) Start() (err error, retry bool) {
 		retry = true
 		return
 
-	case sMtu := <-sMtuChan:
+	case mtu := <-mtuChan:
 		stageHandlerIn, stageHandlerOut := client.stageHandler.Exchanges()
-		client.transferManager = utils.NewTransferManager(stageHandlerIn, stageHandlerOut, sMtu)
+		client.transferMutex.Lock()
+		client.transferManager = utils.NewTransferManager(stageHandlerIn, stageHandlerOut, mtu[0], mtu[1])
+		client.transferMutex.Unlock()
+		atomic.StoreUint64(&client.peerTransferMru, mtu[1])
 	}
 
 	client.log().Info("Started TCPCLv4")
@@ -182,7 +206,10 @@ func (client *Client) handle() {
 			}
 		}
 
+		client.transferMutex.Lock()
 		client.transferManager = nil
+		client.transferMutex.Unlock()
+
 		client.stageHandler = nil
 		client.messageSwitch = nil
 
@@ -216,12 +243,33 @@ func (client *Client) handle() {
 	}
 }
 
+// currentTransferManager returns the TransferManager of the current session or nil, if no session is established.
+func (client *Client) currentTransferManager() *utils.TransferManager {
+	client.transferMutex.Lock()
+	defer client.transferMutex.Unlock()
+
+	return client.transferManager
+}
+
 // Send a bundle to this Client's endpoint.
 func (client *Client) Send(b bpv7.Bundle) error {
 	client.log().WithField("bundle", b).Debug("Sending Bundle...")
 	defer client.log().WithField("bundle", b).Info("Sent Bundle")
 
-	return client.transferManager.Send(b)
+	transferManager := client.currentTransferManager()
+	if transferManager == nil {
+		return fmt.Errorf("TCPCLv4 session is not established")
+	}
+	return transferManager.Send(b)
+}
+
+// MTU returns the peer's Transfer MRU, limiting the size of a serialized Bundle. Thus, larger Bundles are fragmented
+// before being sent instead of being rejected by Send. Zero is returned before a session was established.
+func (client *Client) MTU() int {
+	if mru := atomic.LoadUint64(&client.peerTransferMru); mru <= math.MaxInt {
+		return int(mru)
+	}
+	return math.MaxInt
 }
 
 // Close signals this Client to shut down.
@@ -242,6 +290,11 @@ func (client *Client) Address() string {
 	return client.address
 }
 
+// GetCLAType returns either cla.TCPCLv4 or cla.TCPCLv4WebSocket, depending on the underlying connection.
+func (client *Client) GetCLAType() cla.CLAType {
+	return client.claType
+}
+
 // IsPermanent returns true, if this CLA should not be removed after failures.
 func (client *Client) IsPermanent() bool {
 	return client.permanent
@@ -9,8 +9,6 @@ import (
 	"net"
 	"time"
 
-	log "github.com/sirupsen/logrus"
-
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/utils"
@@ -22,8 +20,12 @@ import (
 type TCPListener struct {
 	listenAddress string
 	endpointID    bpv7.EndpointID
+	sessionConfig SessionConfig
 	manager       *cla.Manager
 
+	// rebindBackoff configures binding attempts after the listen address could not be bound or the socket died.
+	rebindBackoff cla.BackoffConfig
+
 	stopSyn chan struct{}
 	stopAck chan struct{}
 }
@@ -31,9 +33,16 @@ type TCPListener struct {
 // ListenTCP creates a new TCPListener which should be bound to the given address and advertises the endpoint ID as
 // its own node identifier.
 func ListenTCP(listenAddress string, endpointID bpv7.EndpointID) *TCPListener {
+	return ListenTCPWithConfig(listenAddress, endpointID, SessionConfig{})
+}
+
+// ListenTCPWithConfig works like ListenTCP, but offers the SessionConfig to each incoming session. Its unset fields
+// fall back to the defaults.
+func ListenTCPWithConfig(listenAddress string, endpointID bpv7.EndpointID, sessionConfig SessionConfig) *TCPListener {
 	return &TCPListener{
 		listenAddress: listenAddress,
 		endpointID:    endpointID,
+		sessionConfig: sessionConfig,
 
 		stopSyn: make(chan struct{}),
 		stopAck: make(chan struct{}),
@@ -45,20 +54,26 @@ func (listener *TCPListener) RegisterManager(manager *cla.Manager) {
 	listener.manager = manager
 }
 
+// SetRebindBackoff configures the backoff between attempts to bind the listen address, if it is occupied or if the
+// listening socket dies. Its unset fields fall back to cla.BackoffConfig's defaults.
+//
+// This method must be called before Start.
+func (listener *TCPListener) SetRebindBackoff(backoff cla.BackoffConfig) {
+	listener.rebindBackoff = backoff
+}
+
 // Start this TCPListener. Before being started, the the RegisterManager method tells this Client its cla.Manager. The
 // cla.Manager will both call the RegisterManager and Start methods.
+//
+// Only an unresolvable listen address results in an error. Otherwise, binding the address is retried in the
+// background, see cla.RebindingListener.
 func (listener *TCPListener) Start() error {
-	tcpAddr, err := net.ResolveTCPAddr("tcp", listener.listenAddress)
+	ln, err := cla.NewRebindingListener(listener.listenAddress, listener.rebindBackoff)
 	if err != nil {
 		return err
 	}
 
-	ln, err := net.ListenTCP("tcp", tcpAddr)
-	if err != nil {
-		return err
-	}
-
-	go func(ln *net.TCPListener) {
+	go func(ln *cla.RebindingListener) {
 		for {
 			select {
 			case <-listener.stopSyn:
@@ -68,13 +83,8 @@ func (listener *TCPListener) Start() error {
 				return
 
 			default:
-				if err := ln.SetDeadline(time.Now().Add(50 * time.Millisecond)); err != nil {
-					log.WithError(err).WithField("cla", listener).Error(
-						"TCPListener failed to set deadline on TCP socket")
-
-					_ = listener.Close()
-				} else if conn, err := ln.Accept(); err == nil {
-					client := newClientTCP(conn, listener.endpointID)
+				if conn := ln.Accept(50 * time.Millisecond); conn != nil {
+					client := newClientTCP(conn, listener.endpointID, listener.sessionConfig)
 					listener.manager.Register(client)
 				}
 			}
@@ -110,11 +120,13 @@ func tcpClientStart(client *Client) error {
 }
 
 // newClientTCP creates a new Client on an existing connection. This function is used from the TCPListener.
-func newClientTCP(conn net.Conn, endpointID bpv7.EndpointID) *Client {
+func newClientTCP(conn net.Conn, endpointID bpv7.EndpointID, sessionConfig SessionConfig) *Client {
 	return &Client{
 		address:         conn.RemoteAddr().String(),
 		activePeer:      false,
+		claType:         cla.TCPCLv4,
 		customStartFunc: tcpClientStart,
+		sessionConfig:   sessionConfig,
 		connCloser:      conn,
 		messageSwitch:   utils.NewMessageSwitchReaderWriter(conn, conn),
 		nodeId:          endpointID,
@@ -123,11 +135,19 @@ func newClientTCP(conn net.Conn, endpointID bpv7.EndpointID) *Client {
 
 // DialTCP tries to establish a new TCPCLv4 Client to a remote TCPListener.
 func DialTCP(address string, endpointID bpv7.EndpointID, permanent bool) *Client {
+	return DialTCPWithConfig(address, endpointID, permanent, SessionConfig{})
+}
+
+// DialTCPWithConfig works like DialTCP, but offers the SessionConfig to the peer. Its unset fields fall back to the
+// defaults.
+func DialTCPWithConfig(address string, endpointID bpv7.EndpointID, permanent bool, sessionConfig SessionConfig) *Client {
 	return &Client{
 		address:         address,
 		permanent:       permanent,
 		activePeer:      true,
+		claType:         cla.TCPCLv4,
 		customStartFunc: tcpClientStart,
+		sessionConfig:   sessionConfig,
 		nodeId:          endpointID,
 	}
 }
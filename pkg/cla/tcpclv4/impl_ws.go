@@ -21,7 +21,8 @@ import (
 //
 // This type implements the cla.ConvergenceProvider and should be supervised by a cla.Manager.
 type WebSocketListener struct {
-	endpointID bpv7.EndpointID
+	endpointID    bpv7.EndpointID
+	sessionConfig SessionConfig
 
 	manager      *cla.Manager
 	managerReady uint32
@@ -31,9 +32,16 @@ type WebSocketListener struct {
 
 // ListenWebSocket creates a new WebSocketListener.
 func ListenWebSocket(endpointID bpv7.EndpointID) *WebSocketListener {
+	return ListenWebSocketWithConfig(endpointID, SessionConfig{})
+}
+
+// ListenWebSocketWithConfig works like ListenWebSocket, but offers the SessionConfig to each incoming session. Its
+// unset fields fall back to the defaults.
+func ListenWebSocketWithConfig(endpointID bpv7.EndpointID, sessionConfig SessionConfig) *WebSocketListener {
 	return &WebSocketListener{
-		endpointID: endpointID,
-		upgrader:   websocket.Upgrader{},
+		endpointID:    endpointID,
+		sessionConfig: sessionConfig,
+		upgrader:      websocket.Upgrader{},
 	}
 }
 
@@ -64,7 +72,7 @@ func (listener *WebSocketListener) ServeHTTP(writer http.ResponseWriter, request
 	if conn, err := listener.upgrader.Upgrade(writer, request, nil); err != nil {
 		log.WithField("cla", listener).WithError(err).Warn("Upgrading connection erred")
 	} else {
-		client := newClientWebSocket(conn, listener.endpointID)
+		client := newClientWebSocket(conn, listener.endpointID, listener.sessionConfig)
 		listener.manager.Register(client)
 	}
 }
@@ -83,11 +91,13 @@ func webSocketClientStart(client *Client) error {
 }
 
 // newClientWebSocket creates a new Client on a new *websocket.Conn. This function is called from the WebSocketListener.
-func newClientWebSocket(conn *websocket.Conn, endpointID bpv7.EndpointID) *Client {
+func newClientWebSocket(conn *websocket.Conn, endpointID bpv7.EndpointID, sessionConfig SessionConfig) *Client {
 	return &Client{
 		address:         conn.RemoteAddr().String(),
 		activePeer:      false,
+		claType:         cla.TCPCLv4WebSocket,
 		customStartFunc: webSocketClientStart,
+		sessionConfig:   sessionConfig,
 		connCloser:      conn,
 		messageSwitch:   utils.NewMessageSwitchWebSocket(conn),
 		nodeId:          endpointID,
@@ -96,11 +106,19 @@ func newClientWebSocket(conn *websocket.Conn, endpointID bpv7.EndpointID) *Clien
 
 // DialWebSocket tries to establish a new TCPCLv4 Client to a remote WebSocketListener.
 func DialWebSocket(address string, endpointID bpv7.EndpointID, permanent bool) *Client {
+	return DialWebSocketWithConfig(address, endpointID, permanent, SessionConfig{})
+}
+
+// DialWebSocketWithConfig works like DialWebSocket, but offers the SessionConfig to the peer. Its unset fields fall
+// back to the defaults.
+func DialWebSocketWithConfig(address string, endpointID bpv7.EndpointID, permanent bool, sessionConfig SessionConfig) *Client {
 	return &Client{
 		address:         address,
 		permanent:       permanent,
 		activePeer:      true,
+		claType:         cla.TCPCLv4WebSocket,
 		customStartFunc: webSocketClientStart,
+		sessionConfig:   sessionConfig,
 		nodeId:          endpointID,
 	}
 }
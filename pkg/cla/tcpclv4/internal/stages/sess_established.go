@@ -91,6 +91,7 @@ func (se *SessEstablishedStage) handleKeepalive() error {
 
 	// Check last received message
 	if receiveDelta < 0 {
+		_ = se.messageOut(msgs.NewSessionTerminationMessage(0, msgs.TerminationIdleTimeout))
 		return fmt.Errorf("stalled session; last message at %v, keepalive of %v", se.lastReceive, keepalive)
 	}
 
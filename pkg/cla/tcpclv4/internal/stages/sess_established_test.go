@@ -95,12 +95,18 @@ func TestSessEstablishedStageKeepaliveTimeout(t *testing.T) {
 	finChan := make(chan struct{})
 	go func() { sess.Handle(state, closer); close(finChan) }()
 
-	// Read outgoing KEEPALIVEs
+	// Read outgoing KEEPALIVEs and the final SESS_TERM
 	keepaliveCounter := int32(0)
+	idleTimeoutCounter := int32(0)
 	go func() {
 		for msg := range msgOut {
-			if _, ok := msg.(*msgs.KeepaliveMessage); ok {
+			switch msg := msg.(type) {
+			case *msgs.KeepaliveMessage:
 				atomic.AddInt32(&keepaliveCounter, 1)
+			case *msgs.SessionTerminationMessage:
+				if msg.ReasonCode == msgs.TerminationIdleTimeout {
+					atomic.AddInt32(&idleTimeoutCounter, 1)
+				}
 			}
 		}
 	}()
@@ -123,6 +129,13 @@ func TestSessEstablishedStageKeepaliveTimeout(t *testing.T) {
 	if atomic.LoadInt32(&keepaliveCounter) == 0 {
 		t.Fatal("no KEEPALIVEs were received")
 	}
+	// The SESS_TERM was sent right before the stage finished; give the reader some time.
+	for i := 0; atomic.LoadInt32(&idleTimeoutCounter) != 1; i++ {
+		if i == 100 {
+			t.Fatal("no SESS_TERM for an idle timeout was received")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
 }
 
 func TestSessEstablishedStageMessageExchange(t *testing.T) {
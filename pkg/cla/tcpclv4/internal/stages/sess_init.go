@@ -44,6 +44,10 @@ func (ci *SessInitStage) Handle(state *State, closeChan <-chan struct{}) {
 		}
 	}
 
+	if err == nil && ciIn.SegmentMru == 0 {
+		err = fmt.Errorf("received Segment MRU of zero does not allow any transfer")
+	}
+
 	if err == nil {
 		ci.state.Keepalive = uint16(math.Min(float64(ci.state.Configuration.Keepalive), float64(ciIn.KeepaliveInterval)))
 		ci.state.SegmentMtu = ciIn.SegmentMru
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package stages
+
+import (
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
+)
+
+// sessTermTimeout is the maximum time to wait for the peer's SESS_TERM reply.
+const sessTermTimeout = 5 * time.Second
+
+// SessTermStage terminates a session directly after its initialization, e.g., to only probe a peer.
+//
+// A SESS_TERM message will be sent and this Stage waits for the peer's reply.
+type SessTermStage struct{}
+
+// Handle this Stage's action based on the previous Stage's State and the StageHandler's close channel.
+func (st *SessTermStage) Handle(state *State, closeChan <-chan struct{}) {
+	state.MsgOut <- msgs.NewSessionTerminationMessage(0, msgs.TerminationUnknown)
+
+	timeout := time.NewTimer(sessTermTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-closeChan:
+			state.StageError = StageClose
+			return
+
+		case <-timeout.C:
+			return
+
+		case msg := <-state.MsgIn:
+			if sessTerm, ok := msg.(*msgs.SessionTerminationMessage); ok {
+				// Both peers might have sent a SESS_TERM simultaneously; acknowledge the peer's one.
+				if sessTerm.Flags&msgs.TerminationReply == 0 {
+					state.MsgOut <- msgs.NewSessionTerminationMessage(msgs.TerminationReply, msgs.TerminationUnknown)
+				}
+				return
+			}
+		}
+	}
+}
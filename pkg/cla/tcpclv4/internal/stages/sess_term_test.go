@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package stages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
+)
+
+func TestSessTermStage(t *testing.T) {
+	tests := []struct {
+		name      string
+		peerFlags msgs.SessionTerminationFlags
+		wantReply bool
+	}{
+		{"peer replies", msgs.TerminationReply, false},
+		{"simultaneous termination", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msgIn := make(chan msgs.Message, 1)
+			msgOut := make(chan msgs.Message, 2)
+			closeChan := make(chan struct{})
+
+			state := &State{MsgIn: msgIn, MsgOut: msgOut}
+			finished := make(chan struct{})
+
+			go func() {
+				(&SessTermStage{}).Handle(state, closeChan)
+				close(finished)
+			}()
+
+			if msg, ok := (<-msgOut).(*msgs.SessionTerminationMessage); !ok {
+				t.Fatalf("first outgoing message is not a SESS_TERM: %v", msg)
+			} else if msg.Flags&msgs.TerminationReply != 0 {
+				t.Fatal("initial SESS_TERM has the reply flag set")
+			}
+
+			msgIn <- msgs.NewSessionTerminationMessage(test.peerFlags, msgs.TerminationUnknown)
+
+			select {
+			case <-finished:
+			case <-time.After(time.Second):
+				t.Fatal("SessTermStage did not finish")
+			}
+
+			if state.StageError != nil {
+				t.Fatal(state.StageError)
+			}
+
+			if gotReply := len(msgOut) == 1; gotReply != test.wantReply {
+				t.Fatalf("SESS_TERM reply was sent: %t, expected %t", gotReply, test.wantReply)
+			}
+		})
+	}
+}
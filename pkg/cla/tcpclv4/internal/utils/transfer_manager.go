@@ -26,7 +26,8 @@ type TransferManager struct {
 	chanBundles chan bpv7.Bundle
 	chanErrors  chan error
 
-	segmentMtu uint64
+	segmentMtu  uint64
+	transferMtu uint64
 
 	inTransfers sync.Map // map[uint64]*IncomingTransfer
 
@@ -37,8 +38,9 @@ type TransferManager struct {
 	stopped  uint32
 }
 
-// NewTransferManager for incoming and outgoing msgs.Message channels and a configured segment MTU.
-func NewTransferManager(msgIn <-chan msgs.Message, msgOut chan<- msgs.Message, segmentMtu uint64) (tm *TransferManager) {
+// NewTransferManager for incoming and outgoing msgs.Message channels and the configured segment and transfer MTUs. A
+// zero transfer MTU does not limit outgoing transfers.
+func NewTransferManager(msgIn <-chan msgs.Message, msgOut chan<- msgs.Message, segmentMtu, transferMtu uint64) (tm *TransferManager) {
 	tm = &TransferManager{
 		msgIn:  msgIn,
 		msgOut: msgOut,
@@ -46,7 +48,8 @@ func NewTransferManager(msgIn <-chan msgs.Message, msgOut chan<- msgs.Message, s
 		chanBundles: make(chan bpv7.Bundle),
 		chanErrors:  make(chan error),
 
-		segmentMtu: segmentMtu,
+		segmentMtu:  segmentMtu,
+		transferMtu: transferMtu,
 
 		stopChan: make(chan struct{}),
 	}
@@ -74,6 +77,36 @@ func (tm *TransferManager) Close() (err error) {
 	return
 }
 
+// TransferRefusedError is returned by Send if the peer refused the transfer by a XFER_REFUSE message.
+//
+// Only this transfer was aborted; the session remains usable for subsequent Bundles.
+type TransferRefusedError struct {
+	TransferId uint64
+	ReasonCode msgs.TransferRefusalCode
+}
+
+func (err *TransferRefusedError) Error() string {
+	return fmt.Sprintf("transfer %d was refused by peer, reason code %d (%v)",
+		err.TransferId, uint8(err.ReasonCode), err.ReasonCode)
+}
+
+// isPastTransfer checks if an outgoing transfer for this ID was already started before.
+//
+// Late messages for past transfers are expected after a transfer was refused or timed out, and are ignored.
+func (tm *TransferManager) isPastTransfer(id uint64) bool {
+	return id < atomic.LoadUint64(&tm.outNextId)
+}
+
+// countWriter is an io.Writer which only counts the written bytes.
+type countWriter struct {
+	n uint64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	cw.n += uint64(len(p))
+	return len(p), nil
+}
+
 func (tm *TransferManager) handle() {
 	for {
 		select {
@@ -84,19 +117,19 @@ func (tm *TransferManager) handle() {
 			switch msg := msg.(type) {
 			// Related to outgoing messages
 			case *msgs.DataAcknowledgementMessage:
-				if ackChan, ok := tm.outFeedback.Load(msg.TransferId); !ok {
+				if ackChan, ok := tm.outFeedback.Load(msg.TransferId); ok {
+					ackChan.(chan msgs.Message) <- msg
+				} else if !tm.isPastTransfer(msg.TransferId) {
 					tm.chanErrors <- fmt.Errorf("received acknowledgement for unknown message %d", msg.TransferId)
 					return
-				} else {
-					ackChan.(chan msgs.Message) <- msg
 				}
 
 			case *msgs.TransferRefusalMessage:
-				if ackChan, ok := tm.outFeedback.Load(msg.TransferId); !ok {
+				if ackChan, ok := tm.outFeedback.Load(msg.TransferId); ok {
+					ackChan.(chan msgs.Message) <- msg
+				} else if !tm.isPastTransfer(msg.TransferId) {
 					tm.chanErrors <- fmt.Errorf("received refusal for unknown message %d", msg.TransferId)
 					return
-				} else {
-					ackChan.(chan msgs.Message) <- msg
 				}
 
 			// Related to incoming messages
@@ -131,7 +164,20 @@ func (tm *TransferManager) handle() {
 }
 
 // Send an outgoing Bundle. This method blocks until the Bundle was sent successfully or an error arises.
+//
+// If the peer refuses this transfer, a *TransferRefusedError is returned and the session stays open.
+//
+// A Bundle exceeding the transfer MTU is not sent, as the peer would refuse it.
 func (tm *TransferManager) Send(b bpv7.Bundle) error {
+	if tm.transferMtu > 0 {
+		var cw countWriter
+		if err := b.MarshalCbor(&cw); err != nil {
+			return err
+		} else if cw.n > tm.transferMtu {
+			return fmt.Errorf("bundle's size of %d bytes exceeds the transfer MTU of %d bytes", cw.n, tm.transferMtu)
+		}
+	}
+
 	transfer := NewBundleOutgoingTransfer(atomic.AddUint64(&tm.outNextId, 1)-1, b)
 
 	ackChan := make(chan msgs.Message, 32)
@@ -188,6 +234,10 @@ func (tm *TransferManager) Send(b bpv7.Bundle) error {
 					return nil
 				}
 
+			case *msgs.TransferRefusalMessage:
+				atomic.StoreUint32(&stopped, 1)
+				return &TransferRefusedError{TransferId: transfer.Id, ReasonCode: response.ReasonCode}
+
 			default:
 				atomic.StoreUint32(&stopped, 1)
 				return fmt.Errorf("received unexpected message: %T, %v", response, response)
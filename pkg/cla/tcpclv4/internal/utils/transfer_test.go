@@ -5,11 +5,13 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
@@ -74,8 +76,8 @@ func TestTransferManager(t *testing.T) {
 	msgIn := make(chan msgs.Message)
 	msgOut := make(chan msgs.Message)
 
-	tm1 := NewTransferManager(msgIn, msgOut, 65535)
-	tm2 := NewTransferManager(msgOut, msgIn, 65535)
+	tm1 := NewTransferManager(msgIn, msgOut, 65535, 0)
+	tm2 := NewTransferManager(msgOut, msgIn, 65535, 0)
 
 	_, tm1Errs := tm1.Exchange()
 	tm2Bundles, tm2Errs := tm2.Exchange()
@@ -127,3 +129,96 @@ func TestTransferManager(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestTransferManagerTransferMtu(t *testing.T) {
+	msgIn := make(chan msgs.Message)
+	msgOut := make(chan msgs.Message, 1)
+
+	tm := NewTransferManager(msgIn, msgOut, 1024, 4096)
+	defer tm.Close()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("30m").
+		PayloadBlock(testGetRandomData(8192)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tm.Send(bndl); err == nil {
+		t.Fatal("bundle exceeding the transfer MTU was sent")
+	}
+
+	select {
+	case msg := <-msgOut:
+		t.Fatalf("unexpected outgoing message %v", msg)
+	default:
+	}
+}
+
+func TestTransferManagerRefusal(t *testing.T) {
+	msgIn := make(chan msgs.Message)
+	msgOut := make(chan msgs.Message)
+
+	tm := NewTransferManager(msgIn, msgOut, 65535, 0)
+	defer tm.Close()
+
+	_, tmErrs := tm.Exchange()
+
+	bndl, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("30m").
+		PayloadBlock(testGetRandomData(64)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The peer refuses the first transfer, but acknowledges the second one.
+	peerResponses := []func(dtm *msgs.DataTransmissionMessage) msgs.Message{
+		func(dtm *msgs.DataTransmissionMessage) msgs.Message {
+			return msgs.NewTransferRefusalMessage(msgs.RefusalNoResources, dtm.TransferId)
+		},
+		func(dtm *msgs.DataTransmissionMessage) msgs.Message {
+			return msgs.NewDataAcknowledgementMessage(dtm.Flags, dtm.TransferId, uint64(len(dtm.Data)))
+		},
+	}
+
+	for i, peerResponse := range peerResponses {
+		sendErr := make(chan error)
+		go func() { sendErr <- tm.Send(bndl) }()
+
+		dtm := (<-msgOut).(*msgs.DataTransmissionMessage)
+		msgIn <- peerResponse(dtm)
+
+		select {
+		case err := <-sendErr:
+			var refusedErr *TransferRefusedError
+			if i == 0 && (!errors.As(err, &refusedErr) || refusedErr.ReasonCode != msgs.RefusalNoResources) {
+				t.Fatalf("expected refusal with reason code No Resources, got %v", err)
+			} else if i == 1 && err != nil {
+				t.Fatal(err)
+			}
+
+		case err := <-tmErrs:
+			t.Fatal(err)
+
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+
+		// A late message for the finished transfer must not terminate the session.
+		msgIn <- msgs.NewDataAcknowledgementMessage(dtm.Flags, dtm.TransferId, uint64(len(dtm.Data)))
+	}
+
+	select {
+	case err := <-tmErrs:
+		t.Fatal(err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package tcpclv4
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/msgs"
+	"github.com/dtn7/dtn7-go/pkg/cla/tcpclv4/internal/stages"
+)
+
+// probeTimeout limits the duration of a probe's handshake.
+const probeTimeout = 15 * time.Second
+
+// ProbePeer dials a remote TCPListener and performs only the TCPCLv4 handshake to learn the peer's Endpoint ID and
+// capabilities. Afterwards, the session is terminated again. Thus, no Client has to be registered at a cla.Manager.
+//
+// The returned cla.PeerInfo's capabilities are "can-tls", the negotiated "keepalive" in seconds, "segment-mru" and
+// "transfer-mru" in bytes.
+func ProbePeer(address string, endpointID bpv7.EndpointID) (cla.PeerInfo, error) {
+	return probe(DialTCP(address, endpointID, false))
+}
+
+// ProbePeerWebSocket works like ProbePeer, but dials a remote WebSocketListener.
+func ProbePeerWebSocket(address string, endpointID bpv7.EndpointID) (cla.PeerInfo, error) {
+	return probe(DialWebSocket(address, endpointID, false))
+}
+
+// probe the peer of a dialing, not yet started Client.
+func probe(client *Client) (info cla.PeerInfo, err error) {
+	if err = client.customStartFunc(client); err != nil {
+		return
+	}
+
+	msIncoming, msOutgoing, msErr := client.messageSwitch.Exchange()
+
+	infoChan := make(chan cla.PeerInfo, 1)
+	stageHandler := stages.NewStageHandler([]stages.StageSetup{
+		{
+			Stage: &stages.ContactStage{},
+		},
+		{
+			Stage: &stages.SessInitStage{},
+			PostHook: func(_ *stages.StageHandler, state *stages.State) error {
+				infoChan <- cla.PeerInfo{
+					EndpointID: state.PeerNodeId,
+					CLAType:    client.claType,
+					Capabilities: map[string]string{
+						"can-tls":      strconv.FormatBool(state.ContactFlags&msgs.ContactCanTls != 0),
+						"keepalive":    strconv.FormatUint(uint64(state.Keepalive), 10),
+						"segment-mru":  strconv.FormatUint(state.SegmentMtu, 10),
+						"transfer-mru": strconv.FormatUint(state.TransferMtu, 10),
+					},
+				}
+				return nil
+			},
+		},
+		{
+			Stage: &stages.SessTermStage{},
+		},
+	}, msIncoming, msOutgoing, client.stageConfiguration())
+
+	defer func() {
+		_ = stageHandler.Close()
+		go func() {
+			for range stageHandler.Error() {
+			}
+		}()
+
+		_ = client.messageSwitch.Close()
+		_ = client.connCloser.Close()
+	}()
+
+	timeout := time.NewTimer(probeTimeout)
+	defer timeout.Stop()
+
+	select {
+	case info = <-infoChan:
+	case err = <-stageHandler.Error():
+		if err == nil {
+			err = fmt.Errorf("TCPCLv4 handshake finished unexpectedly")
+		}
+		return
+	case err = <-msErr:
+		return
+	case <-timeout.C:
+		err = fmt.Errorf("probing %s timed out", client.address)
+		return
+	}
+
+	// Await the session's termination, initiated by the SessTermStage.
+	select {
+	case stageErr := <-stageHandler.Error():
+		if stageErr != nil {
+			client.log().WithError(stageErr).Debug("Terminating probe session erred")
+		}
+	case <-msErr:
+	case <-timeout.C:
+	}
+
+	return
+}
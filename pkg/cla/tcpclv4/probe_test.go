@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package tcpclv4
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestProbePeer(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", randomTcpPort(t))
+
+	manager := cla.NewManager(cla.BackoffConfig{})
+	defer manager.Close()
+
+	go func() {
+		for range manager.Channel() {
+		}
+	}()
+
+	manager.Register(ListenTCP(addr, bpv7.MustNewEndpointID("dtn://server/")))
+	time.Sleep(250 * time.Millisecond)
+
+	info, err := ProbePeer(addr, bpv7.MustNewEndpointID("dtn://client/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.EndpointID != bpv7.MustNewEndpointID("dtn://server/") {
+		t.Fatalf("expected peer dtn://server/, got %v", info.EndpointID)
+	}
+	if info.CLAType != cla.TCPCLv4 {
+		t.Fatalf("expected CLA type %v, got %v", cla.TCPCLv4, info.CLAType)
+	}
+	if mru := info.Capabilities["segment-mru"]; mru != "1048576" {
+		t.Fatalf("unexpected segment MRU %q", mru)
+	}
+
+	// The probe's session must not be kept alive as a ConvergenceSender.
+	time.Sleep(time.Second)
+	if senders := manager.Sender(); len(senders) != 0 {
+		t.Fatalf("probe left %d ConvergenceSenders", len(senders))
+	}
+}
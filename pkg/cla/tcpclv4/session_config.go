@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package tcpclv4
+
+const (
+	// defaultKeepalive is the default keepalive interval in seconds.
+	defaultKeepalive uint16 = 30
+
+	// defaultSegmentMru is the default Segment MRU of 1 MiB.
+	defaultSegmentMru uint64 = 1048576
+
+	// defaultTransferMru is the default Transfer MRU of 1 GiB.
+	defaultTransferMru uint64 = 1073741824
+)
+
+// SessionConfig configures the values offered in a session's SESS_INIT message.
+//
+// The session's keepalive interval is the minimum of both peers' offered intervals. A session is terminated if no
+// message was received within this interval. Outgoing transfers are segmented according to the peer's Segment MRU and
+// are rejected locally if exceeding the peer's Transfer MRU.
+type SessionConfig struct {
+	// Keepalive is the offered keepalive interval in seconds. If unset, the default is used, while an explicit zero
+	// disables keepalives, as defined by TCPCLv4.
+	Keepalive *uint16
+
+	// SegmentMru is the largest single-segment payload to be received in bytes.
+	SegmentMru uint64
+
+	// TransferMru is the largest total-bundle payload to be received in bytes.
+	TransferMru uint64
+}
+
+// withDefaults returns a copy of this SessionConfig with unset fields set to their defaults.
+func (conf SessionConfig) withDefaults() SessionConfig {
+	if conf.Keepalive == nil {
+		keepalive := defaultKeepalive
+		conf.Keepalive = &keepalive
+	}
+	if conf.SegmentMru == 0 {
+		conf.SegmentMru = defaultSegmentMru
+	}
+	if conf.TransferMru == 0 {
+		conf.TransferMru = defaultTransferMru
+	}
+	return conf
+}
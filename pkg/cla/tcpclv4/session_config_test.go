@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package tcpclv4
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestSessionConfigKeepaliveDefault(t *testing.T) {
+	disabled, custom := uint16(0), uint16(10)
+
+	tests := []struct {
+		name      string
+		keepalive *uint16
+		expected  uint16
+	}{
+		{"unset", nil, defaultKeepalive},
+		{"disabled", &disabled, 0},
+		{"custom", &custom, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := SessionConfig{Keepalive: test.keepalive}.withDefaults()
+			if *conf.Keepalive != test.expected {
+				t.Fatalf("expected keepalive of %d, got %d", test.expected, *conf.Keepalive)
+			}
+		})
+	}
+}
+
+func TestSessionConfigNegotiation(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", randomTcpPort(t))
+
+	manager := cla.NewManager(cla.BackoffConfig{})
+	defer manager.Close()
+
+	go func() {
+		for range manager.Channel() {
+		}
+	}()
+
+	keepalive := uint16(10)
+	manager.Register(ListenTCPWithConfig(addr, bpv7.MustNewEndpointID("dtn://server/"),
+		SessionConfig{Keepalive: &keepalive, SegmentMru: 4096, TransferMru: 65536}))
+	time.Sleep(250 * time.Millisecond)
+
+	info, err := ProbePeer(addr, bpv7.MustNewEndpointID("dtn://client/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"keepalive":    "10",
+		"segment-mru":  "4096",
+		"transfer-mru": "65536",
+	}
+	for capability, value := range expected {
+		if info.Capabilities[capability] != value {
+			t.Fatalf("expected %s of %s, got %s", capability, value, info.Capabilities[capability])
+		}
+	}
+}
+
+func TestSessionConfigSegmentation(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", randomTcpPort(t))
+
+	serverManager := cla.NewManager(cla.BackoffConfig{})
+	defer serverManager.Close()
+
+	received := make(chan bpv7.Bundle, 1)
+	go func() {
+		for cs := range serverManager.Channel() {
+			if cs.MessageType == cla.ReceivedBundle {
+				received <- *cs.Message.(cla.ConvergenceReceivedBundle).Bundle
+			}
+		}
+	}()
+
+	serverManager.Register(ListenTCPWithConfig(addr, bpv7.MustNewEndpointID("dtn://server/"),
+		SessionConfig{SegmentMru: 1024, TransferMru: 32768}))
+	time.Sleep(250 * time.Millisecond)
+
+	client := DialTCP(addr, bpv7.MustNewEndpointID("dtn://client/"), false)
+	if mtu, ok := cla.GetMTU(client); ok {
+		t.Fatalf("unstarted client has an MTU of %d", mtu)
+	}
+
+	if err, _ := client.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	go func() {
+		for range client.Channel() {
+		}
+	}()
+
+	if mtu, ok := cla.GetMTU(client); !ok || mtu != 32768 {
+		t.Fatalf("expected the peer's Transfer MRU of 32768 as MTU, got %d", mtu)
+	}
+
+	for _, test := range []struct {
+		size  int
+		valid bool
+	}{
+		{16384, true},
+		{65536, false},
+	} {
+		bndl, err := bpv7.Builder().
+			Source("dtn://client/").
+			Destination("dtn://server/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock(randomData(test.size)).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := client.Send(bndl); (err == nil) != test.valid {
+			t.Fatalf("sending %d bytes: expected valid = %t, got error %v", test.size, test.valid, err)
+		} else if !test.valid {
+			continue
+		}
+
+		select {
+		case b := <-received:
+			pb, _ := b.PayloadBlock()
+			expected, _ := bndl.PayloadBlock()
+			if !bytes.Equal(pb.Value.(*bpv7.PayloadBlock).Data(), expected.Value.(*bpv7.PayloadBlock).Data()) {
+				t.Fatal("received payload differs")
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("bundle of %d bytes was not received", test.size)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package websocket provides a bidirectional convergence layer based on WebSockets, e.g., to traverse HTTP proxies
+// and firewalls blocking other TCP connections.
+//
+// An Endpoint is dialed to a WebSocket URL, e.g., "ws://host:8082/bundles", or created by a Listener for each
+// incoming connection. The Listener is a http.Handler, mounted on a http.ServeMux by Listen.
+//
+// After connecting, both peers exchange their Endpoint IDs in a handshake. The dialer sends its Endpoint ID first and
+// receives the listener's afterwards. Each Endpoint ID is sent as its CBOR encoding in a binary WebSocket message.
+// Afterwards, each bundle is sent CBOR encoded in its own binary WebSocket message in both directions.
+package websocket
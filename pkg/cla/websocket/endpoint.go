@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package websocket
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/cboring"
+	"github.com/gorilla/websocket"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// handshakeTimeout is the time both peers have to exchange their Endpoint IDs.
+const handshakeTimeout = 5 * time.Second
+
+// Endpoint is a bidirectional WebSocket convergence layer, implementing both the cla.ConvergenceReceiver and the
+// cla.ConvergenceSender. It is either dialed by Dial or created by a Listener for an incoming connection.
+type Endpoint struct {
+	id     bpv7.EndpointID
+	peerId bpv7.EndpointID
+
+	// address is the dialed WebSocket URL or, for a listener's Endpoint, the peer's remote address.
+	address   string
+	permanent bool
+	dialer    bool
+
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+
+	reportChan chan cla.ConvergenceStatus
+
+	// stop{Syn,Ack} supervise closing this Endpoint, see Close.
+	stopSyn   chan struct{}
+	stopAck   chan struct{}
+	stopMutex sync.Mutex
+	stopped   bool
+}
+
+// Dial creates a new Endpoint, which connects to a WebSocket URL, e.g., "ws://host:8082/bundles" or "wss://...".
+// The permanent flag indicates if this Endpoint should never be removed from the cla.Manager.
+func Dial(address string, endpointID bpv7.EndpointID, permanent bool) *Endpoint {
+	return &Endpoint{
+		id:         endpointID,
+		address:    address,
+		permanent:  permanent,
+		dialer:     true,
+		reportChan: make(chan cla.ConvergenceStatus),
+	}
+}
+
+// newListenerEndpoint creates a new Endpoint for an accepted *websocket.Conn. This function is called by a Listener.
+func newListenerEndpoint(conn *websocket.Conn, endpointID bpv7.EndpointID) *Endpoint {
+	return &Endpoint{
+		id:         endpointID,
+		address:    conn.RemoteAddr().String(),
+		permanent:  false,
+		dialer:     false,
+		conn:       conn,
+		reportChan: make(chan cla.ConvergenceStatus),
+	}
+}
+
+func (endpoint *Endpoint) String() string {
+	return fmt.Sprintf("WebSocketEndpoint{Peer ID: %v, Address: %v, Dialer: %v, Permanent: %v}",
+		endpoint.peerId, endpoint.address, endpoint.dialer, endpoint.permanent)
+}
+
+func (endpoint *Endpoint) log() *log.Entry {
+	return log.WithField("cla", endpoint.String())
+}
+
+// Start this Endpoint by dialing, if necessary, and performing the handshake.
+func (endpoint *Endpoint) Start() (err error, retry bool) {
+	if endpoint.dialer {
+		if endpoint.conn, _, err = websocket.DefaultDialer.Dial(endpoint.address, nil); err != nil {
+			return err, true
+		}
+	} else if endpoint.conn == nil {
+		return fmt.Errorf("listener's Endpoint has no connection"), false
+	}
+
+	if err = endpoint.handshake(); err != nil {
+		endpoint.log().WithError(err).Warn("Handshake failed")
+		_ = endpoint.conn.Close()
+		return err, endpoint.dialer
+	}
+
+	endpoint.stopMutex.Lock()
+	endpoint.stopSyn = make(chan struct{})
+	endpoint.stopAck = make(chan struct{})
+	endpoint.stopped = false
+	endpoint.stopMutex.Unlock()
+
+	go endpoint.handle()
+	return nil, false
+}
+
+// handshake exchanges both peers' Endpoint IDs. The dialer sends its Endpoint ID first.
+func (endpoint *Endpoint) handshake() (err error) {
+	if err = endpoint.conn.SetReadDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return
+	}
+
+	if endpoint.dialer {
+		if err = endpoint.sendEndpointID(); err != nil {
+			return
+		}
+		err = endpoint.receiveEndpointID()
+	} else {
+		if err = endpoint.receiveEndpointID(); err != nil {
+			return
+		}
+		err = endpoint.sendEndpointID()
+	}
+	if err != nil {
+		return
+	}
+
+	return endpoint.conn.SetReadDeadline(time.Time{})
+}
+
+// sendEndpointID sends this Endpoint's ID CBOR encoded in a binary message.
+func (endpoint *Endpoint) sendEndpointID() error {
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&endpoint.id, buff); err != nil {
+		return fmt.Errorf("marshaling endpoint ID failed: %w", err)
+	}
+
+	return endpoint.conn.WriteMessage(websocket.BinaryMessage, buff.Bytes())
+}
+
+// receiveEndpointID reads the peer's ID from a binary message.
+func (endpoint *Endpoint) receiveEndpointID() error {
+	msgType, data, err := endpoint.conn.ReadMessage()
+	if err != nil {
+		return err
+	} else if msgType != websocket.BinaryMessage {
+		return fmt.Errorf("expected a binary message for the peer's endpoint ID, got type %d", msgType)
+	}
+
+	if err := cboring.Unmarshal(&endpoint.peerId, bytes.NewBuffer(data)); err != nil {
+		return fmt.Errorf("unmarshaling peer's endpoint ID failed: %w", err)
+	}
+
+	endpoint.log().WithField("peer", endpoint.peerId).Debug("Received peer's endpoint ID")
+	return nil
+}
+
+// report a cla.ConvergenceStatus without blocking a closing Endpoint.
+func (endpoint *Endpoint) report(cs cla.ConvergenceStatus) {
+	select {
+	case endpoint.reportChan <- cs:
+	case <-endpoint.stopSyn:
+	}
+}
+
+// handle incoming messages until the connection breaks down or this Endpoint is closed.
+func (endpoint *Endpoint) handle() {
+	defer close(endpoint.stopAck)
+
+	endpoint.report(cla.NewConvergencePeerAppeared(endpoint, endpoint.peerId))
+
+	for {
+		msgType, data, err := endpoint.conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-endpoint.stopSyn:
+				endpoint.log().Debug("Connection was closed")
+			default:
+				endpoint.log().WithError(err).Info("Connection failed")
+				endpoint.report(cla.NewConvergencePeerDisappeared(endpoint, endpoint.peerId))
+			}
+			return
+		} else if msgType != websocket.BinaryMessage {
+			endpoint.log().WithField("type", msgType).Debug("Ignoring non-binary message")
+			continue
+		}
+
+		bndl := new(bpv7.Bundle)
+		if err := cboring.Unmarshal(bndl, bytes.NewBuffer(data)); err != nil {
+			endpoint.log().WithError(err).Warn("Failed to read bundle")
+			continue
+		}
+
+		endpoint.log().WithField("bundle", bndl.ID().String()).Debug("Received bundle")
+		endpoint.report(cla.NewConvergenceReceivedBundle(endpoint, endpoint.id, bndl))
+	}
+}
+
+// Close this Endpoint's connection.
+func (endpoint *Endpoint) Close() error {
+	endpoint.stopMutex.Lock()
+	if endpoint.stopped || endpoint.stopSyn == nil {
+		endpoint.stopMutex.Unlock()
+		return nil
+	}
+	endpoint.stopped = true
+	close(endpoint.stopSyn)
+	endpoint.stopMutex.Unlock()
+
+	endpoint.writeMutex.Lock()
+	_ = endpoint.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	endpoint.writeMutex.Unlock()
+
+	err := endpoint.conn.Close()
+	<-endpoint.stopAck
+
+	return err
+}
+
+// Send a bundle CBOR encoded in a binary message to the peer.
+func (endpoint *Endpoint) Send(bndl bpv7.Bundle) error {
+	buff := new(bytes.Buffer)
+	if err := cboring.Marshal(&bndl, buff); err != nil {
+		return err
+	}
+
+	endpoint.writeMutex.Lock()
+	defer endpoint.writeMutex.Unlock()
+
+	if endpoint.conn == nil {
+		return fmt.Errorf("endpoint is not connected")
+	}
+	return endpoint.conn.WriteMessage(websocket.BinaryMessage, buff.Bytes())
+}
+
+// Channel of this Endpoint's cla.ConvergenceStatus messages.
+func (endpoint *Endpoint) Channel() chan cla.ConvergenceStatus {
+	return endpoint.reportChan
+}
+
+// Address is either the dialed WebSocket URL or the peer's remote address.
+func (endpoint *Endpoint) Address() string {
+	return endpoint.address
+}
+
+// IsPermanent returns true for a permanent dialed Endpoint.
+func (endpoint *Endpoint) IsPermanent() bool {
+	return endpoint.permanent
+}
+
+// GetEndpointID returns this node's Endpoint ID.
+func (endpoint *Endpoint) GetEndpointID() bpv7.EndpointID {
+	return endpoint.id
+}
+
+// GetPeerEndpointID returns the peer's Endpoint ID, known after the handshake.
+func (endpoint *Endpoint) GetPeerEndpointID() bpv7.EndpointID {
+	return endpoint.peerId
+}
+
+// GetCLAType returns cla.WebSocket.
+func (endpoint *Endpoint) GetCLAType() cla.CLAType {
+	return cla.WebSocket
+}
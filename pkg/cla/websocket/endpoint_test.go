@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// awaitStatus reads a Manager's channel until a ConvergenceStatus of the requested type arrives.
+func awaitStatus(t *testing.T, manager *cla.Manager, msgType cla.ConvergenceMessageType) cla.ConvergenceStatus {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case cs := <-manager.Channel():
+			if cs.MessageType == msgType {
+				return cs
+			}
+
+		case <-timeout:
+			t.Fatalf("timeout while waiting for %v", msgType)
+			return cla.ConvergenceStatus{}
+		}
+	}
+}
+
+func TestWebSocketEndpoints(t *testing.T) {
+	listenerEid := bpv7.MustNewEndpointID("dtn://listener/")
+	dialerEid := bpv7.MustNewEndpointID("dtn://dialer/")
+
+	mux := http.NewServeMux()
+	listener := Listen(mux, "/bundles", listenerEid)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	listenerManager := cla.NewManager(cla.BackoffConfig{})
+	defer func() { _ = listenerManager.Close() }()
+	listenerManager.Register(listener)
+
+	dialerManager := cla.NewManager(cla.BackoffConfig{})
+	defer func() { _ = dialerManager.Close() }()
+
+	dialer := Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/bundles", dialerEid, false)
+	dialerManager.Register(dialer)
+
+	if cs := awaitStatus(t, dialerManager, cla.PeerAppeared); cs.Message.(bpv7.EndpointID) != listenerEid {
+		t.Fatalf("dialer's peer is %v, expected %v", cs.Message, listenerEid)
+	}
+	if cs := awaitStatus(t, listenerManager, cla.PeerAppeared); cs.Message.(bpv7.EndpointID) != dialerEid {
+		t.Fatalf("listener's peer is %v, expected %v", cs.Message, dialerEid)
+	}
+
+	senders := listenerManager.Sender()
+	if len(senders) != 1 {
+		t.Fatalf("expected one sender on the listener's side, got %d", len(senders))
+	}
+
+	tests := []struct {
+		name     string
+		sender   *Endpoint
+		receiver *cla.Manager
+	}{
+		{"dialer to listener", dialer, listenerManager},
+		{"listener to dialer", senders[0].(*Endpoint), dialerManager},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bndl, err := bpv7.Builder().
+				Source(test.sender.GetEndpointID()).
+				Destination(test.sender.GetPeerEndpointID()).
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte(test.name)).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.sender.Send(bndl); err != nil {
+				t.Fatal(err)
+			}
+
+			cs := awaitStatus(t, test.receiver, cla.ReceivedBundle)
+			if recBndl := cs.Message.(cla.ConvergenceReceivedBundle).Bundle; recBndl.ID() != bndl.ID() {
+				t.Fatalf("received bundle %v, expected %v", recBndl.ID(), bndl.ID())
+			}
+		})
+	}
+
+	dialerManager.Unregister(dialer)
+	awaitStatus(t, listenerManager, cla.PeerDisappeared)
+}
+
+func TestWebSocketListenerInactive(t *testing.T) {
+	mux := http.NewServeMux()
+	_ = Listen(mux, "/bundles", bpv7.MustNewEndpointID("dtn://listener/"))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dialer := Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/bundles", bpv7.MustNewEndpointID("dtn://dialer/"), false)
+	if err, _ := dialer.Start(); err == nil {
+		t.Fatal("dialing an unstarted listener succeeded")
+	}
+}
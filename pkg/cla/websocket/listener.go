@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package websocket
+
+import (
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// Listener is a http.Handler, accepting incoming WebSocket connections and creating an Endpoint for each.
+//
+// This type implements the cla.ConvergenceProvider and should be supervised by a cla.Manager. Connections are only
+// accepted while the Listener is started.
+type Listener struct {
+	endpointID bpv7.EndpointID
+	upgrader   websocket.Upgrader
+
+	manager *cla.Manager
+	active  bool
+	mutex   sync.RWMutex
+}
+
+// NewListener creates a new Listener for this node's Endpoint ID. It must be served by some http.Server.
+func NewListener(endpointID bpv7.EndpointID) *Listener {
+	return &Listener{
+		endpointID: endpointID,
+		upgrader:   websocket.Upgrader{},
+	}
+}
+
+// Listen creates a new Listener and mounts it on the http.ServeMux for the given pattern, e.g., "/bundles".
+func Listen(mux *http.ServeMux, pattern string, endpointID bpv7.EndpointID) *Listener {
+	listener := NewListener(endpointID)
+	mux.Handle(pattern, listener)
+	return listener
+}
+
+// RegisterManager tells the Listener where to report new Endpoints to.
+func (listener *Listener) RegisterManager(manager *cla.Manager) {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+
+	listener.manager = manager
+}
+
+// Start accepting incoming connections.
+func (listener *Listener) Start() error {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+
+	listener.active = true
+	return nil
+}
+
+// Close this Listener; further connections are rejected. As a http.ServeMux cannot unregister a handler, the
+// underlying http.Server must be shut down separately.
+func (listener *Listener) Close() error {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+
+	listener.active = false
+	return nil
+}
+
+// ServeHTTP upgrades a HTTP connection to a WebSocket connection and registers a new Endpoint for it.
+func (listener *Listener) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	listener.mutex.RLock()
+	manager, active := listener.manager, listener.active
+	listener.mutex.RUnlock()
+
+	if !active || manager == nil {
+		http.Error(writer, "WebSocket listener is not active", http.StatusServiceUnavailable)
+		return
+	}
+
+	if conn, err := listener.upgrader.Upgrade(writer, request, nil); err != nil {
+		log.WithField("remote", request.RemoteAddr).WithError(err).Warn("Upgrading WebSocket connection erred")
+	} else {
+		manager.Register(newListenerEndpoint(conn, listener.endpointID))
+	}
+}
@@ -9,9 +9,12 @@ const (
 	// address4 is the default multicast IPv4 address used for discovery.
 	address4 = "224.23.23.23"
 
-	// address6 is the default multicast IPv4 add6ess used for discovery.
+	// address6 is the default multicast IPv6 address used for discovery.
 	address6 = "ff02::23"
 
 	// port is the default multicast UDP port used for discovery.
 	port = 35039
+
+	// defaultCLAPort is the TCPCLv4 port to dial for legacy beacons without any Announcement.
+	defaultCLAPort = 4556
 )
@@ -17,10 +17,14 @@ import (
 )
 
 // Announcement of some node's CLA.
+//
+// The optional Address is the CLA's listening address. If unset, the CLA is dialed at the Announcement's origin. The
+// Address is only serialized if set, keeping Announcements compatible to older nodes.
 type Announcement struct {
 	Type     cla.CLAType
 	Endpoint bpv7.EndpointID
 	Port     uint
+	Address  string
 }
 
 // UnmarshalAnnouncements creates a new array of Announcement based on a CBOR byte string.
@@ -68,7 +72,12 @@ func MarshalAnnouncements(announcements []Announcement) (data []byte, err error)
 
 // MarshalCbor creates a CBOR representation for an Announcement.
 func (announcement *Announcement) MarshalCbor(w io.Writer) error {
-	if err := cboring.WriteArrayLength(3, w); err != nil {
+	var fields uint64 = 3
+	if announcement.Address != "" {
+		fields = 4
+	}
+
+	if err := cboring.WriteArrayLength(fields, w); err != nil {
 		return err
 	}
 
@@ -82,15 +91,22 @@ func (announcement *Announcement) MarshalCbor(w io.Writer) error {
 		return err
 	}
 
+	if fields == 4 {
+		if err := cboring.WriteTextString(announcement.Address, w); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // UnmarshalCbor creates an Announcement from its CBOR representation.
 func (announcement *Announcement) UnmarshalCbor(r io.Reader) error {
-	if l, err := cboring.ReadArrayLength(r); err != nil {
+	fields, err := cboring.ReadArrayLength(r)
+	if err != nil {
 		return err
-	} else if l != 3 {
-		return fmt.Errorf("wrong array length: %d instead of 3", l)
+	} else if fields != 3 && fields != 4 {
+		return fmt.Errorf("wrong array length: %d instead of 3 or 4", fields)
 	}
 
 	if n, err := cboring.ReadUInt(r); err != nil {
@@ -109,9 +125,22 @@ func (announcement *Announcement) UnmarshalCbor(r io.Reader) error {
 		announcement.Port = uint(n)
 	}
 
+	announcement.Address = ""
+	if fields == 4 {
+		if address, err := cboring.ReadTextString(r); err != nil {
+			return err
+		} else {
+			announcement.Address = address
+		}
+	}
+
 	return nil
 }
 
 func (announcement Announcement) String() string {
+	if announcement.Address != "" {
+		return fmt.Sprintf("Announcement(%v,%v,%s,%d)",
+			announcement.Type, announcement.Endpoint, announcement.Address, announcement.Port)
+	}
 	return fmt.Sprintf("Announcement(%v,%v,%d)", announcement.Type, announcement.Endpoint, announcement.Port)
 }
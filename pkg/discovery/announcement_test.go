@@ -35,6 +35,18 @@ func TestDiscoveryMessageCbor(t *testing.T) {
 			Endpoint: bpv7.MustNewEndpointID("ipn:1337.23"),
 			Port:     12345,
 		},
+		{
+			Type:     cla.TCPCLv4,
+			Endpoint: bpv7.MustNewEndpointID("dtn://foobar/"),
+			Port:     4556,
+			Address:  "192.168.23.42",
+		},
+		{
+			Type:     cla.QUICL,
+			Endpoint: bpv7.MustNewEndpointID("dtn://foobar/"),
+			Port:     35039,
+			Address:  "fe80::23",
+		},
 	}
 
 	for _, dmIn := range tests {
@@ -58,3 +70,17 @@ func TestDiscoveryMessageCbor(t *testing.T) {
 		}
 	}
 }
+
+func TestAnnouncementCborLegacy(t *testing.T) {
+	announcement := Announcement{Type: cla.MTCP, Endpoint: bpv7.MustNewEndpointID("dtn://foobar/"), Port: 8000}
+
+	buff, err := MarshalAnnouncements([]Announcement{announcement})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An array of one Announcement, being an array of three elements as sent by older nodes
+	if buff[0] != 0x81 || buff[1] != 0x83 {
+		t.Fatalf("Announcement without an Address is not encoded as before: %x", buff)
+	}
+}
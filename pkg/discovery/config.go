@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DiscoveryConfig configures the multicast groups and the port used for discovery, e.g., to run multiple isolated
+// networks on the same LAN. Unset fields fall back to the defaults.
+type DiscoveryConfig struct {
+	// Address4 is the IPv4 multicast address, defaults to 224.23.23.23.
+	Address4 string
+	// Address6 is the IPv6 multicast address, defaults to ff02::23.
+	Address6 string
+	// Port is the UDP port, defaults to 35039.
+	Port uint
+	// Interface restricts received Announcements to those of peers within this network interface's networks.
+	//
+	// As the underlying peerdiscovery library cannot bind to an interface, Announcements are still sent on all
+	// multicast capable interfaces.
+	Interface string
+}
+
+// withDefaults returns a copy of this DiscoveryConfig, whose unset fields are replaced by the defaults.
+func (config DiscoveryConfig) withDefaults() DiscoveryConfig {
+	if config.Address4 == "" {
+		config.Address4 = address4
+	}
+	if config.Address6 == "" {
+		config.Address6 = address6
+	}
+	if config.Port == 0 {
+		config.Port = port
+	}
+	return config
+}
+
+// check if the addresses are multicast addresses of the matching IP version and the port is valid.
+func (config DiscoveryConfig) check() error {
+	if ip := net.ParseIP(config.Address4); ip == nil || ip.To4() == nil || !ip.IsMulticast() {
+		return fmt.Errorf("address %q is no IPv4 multicast address", config.Address4)
+	}
+	if ip := net.ParseIP(config.Address6); ip == nil || ip.To4() != nil || !ip.IsMulticast() {
+		return fmt.Errorf("address %q is no IPv6 multicast address", config.Address6)
+	}
+	if config.Port > 65535 {
+		return fmt.Errorf("port %d is out of range", config.Port)
+	}
+	return nil
+}
+
+// interfaceNetworks returns the networks of the configured Interface or nil, if no Interface was configured.
+func (config DiscoveryConfig) interfaceNetworks() ([]*net.IPNet, error) {
+	if config.Interface == "" {
+		return nil, nil
+	}
+
+	iface, err := net.InterfaceByName(config.Interface)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []*net.IPNet
+	for _, addr := range addrs {
+		if network, ok := addr.(*net.IPNet); ok {
+			networks = append(networks, network)
+		}
+	}
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("interface %q has no addresses", config.Interface)
+	}
+	return networks, nil
+}
+
+// parseDiscoveredIP parses a discovered peer's address, optionally enclosed in brackets or with an IPv6 zone.
+func parseDiscoveredIP(addr string) net.IP {
+	addr = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if i := strings.IndexByte(addr, '%'); i >= 0 {
+		addr = addr[:i]
+	}
+	return net.ParseIP(addr)
+}
+
+// withinNetworks checks if a discovered peer's address is part of one of the networks. No networks allow every
+// address.
+func withinNetworks(networks []*net.IPNet, addr string) bool {
+	if networks == nil {
+		return true
+	}
+
+	ip := parseDiscoveredIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"net"
+	"testing"
+)
+
+func TestDiscoveryConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config DiscoveryConfig
+		result DiscoveryConfig
+		valid  bool
+	}{
+		{"defaults", DiscoveryConfig{}, DiscoveryConfig{address4, address6, port, ""}, true},
+		{"custom", DiscoveryConfig{"224.42.42.42", "ff02::42", 42, "lo"}, DiscoveryConfig{"224.42.42.42", "ff02::42", 42, "lo"}, true},
+		{"partial", DiscoveryConfig{Port: 42}, DiscoveryConfig{address4, address6, 42, ""}, true},
+		{"unicast ipv4", DiscoveryConfig{Address4: "10.0.0.1"}, DiscoveryConfig{"10.0.0.1", address6, port, ""}, false},
+		{"ipv6 as ipv4", DiscoveryConfig{Address4: "ff02::42"}, DiscoveryConfig{"ff02::42", address6, port, ""}, false},
+		{"ipv4 as ipv6", DiscoveryConfig{Address6: "224.42.42.42"}, DiscoveryConfig{address4, "224.42.42.42", port, ""}, false},
+		{"invalid address", DiscoveryConfig{Address4: "foo"}, DiscoveryConfig{"foo", address6, port, ""}, false},
+		{"port out of range", DiscoveryConfig{Port: 65536}, DiscoveryConfig{address4, address6, 65536, ""}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config.withDefaults()
+			if config != test.result {
+				t.Fatalf("expected %v, got %v", test.result, config)
+			}
+
+			if err := config.check(); (err == nil) != test.valid {
+				t.Fatalf("expected valid = %t, got error %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestWithinNetworks(t *testing.T) {
+	_, network4, _ := net.ParseCIDR("192.168.23.0/24")
+	_, network6, _ := net.ParseCIDR("fe80::/64")
+	networks := []*net.IPNet{network4, network6}
+
+	tests := []struct {
+		networks []*net.IPNet
+		addr     string
+		within   bool
+	}{
+		{nil, "10.0.0.1", true},
+		{networks, "192.168.23.42", true},
+		{networks, "192.168.42.23", false},
+		{networks, "[fe80::1]", true},
+		{networks, "[fe80::1%eth0]", true},
+		{networks, "[2001:db8::1]", false},
+		{networks, "invalid", false},
+	}
+
+	for _, test := range tests {
+		if within := withinNetworks(test.networks, test.addr); within != test.within {
+			t.Fatalf("%s: expected %t, got %t", test.addr, test.within, within)
+		}
+	}
+}
@@ -7,6 +7,8 @@ package discovery
 
 import (
 	"fmt"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/cla/quicl"
@@ -26,19 +28,49 @@ type Manager struct {
 	NodeId       bpv7.EndpointID
 	RegisterFunc func(cla.Convergable) `json:"-"`
 
+	// localIds are further identities of this node, besides NodeId, whose received Announcements are ignored.
+	localIds []bpv7.EndpointID
+
+	// networks restrict the accepted Announcements' origins, compare DiscoveryConfig's Interface.
+	networks []*net.IPNet
+
+	// neighborFunc is set by SetNeighborFunc, guarded by neighborMutex.
+	neighborFunc  func(bpv7.EndpointID)
+	neighborMutex sync.Mutex
+
 	stopChan4 chan struct{}
 	stopChan6 chan struct{}
 }
 
 // NewManager for Announcements will be created and started.
+//
+// Received Announcements of this node itself, e.g., looped back multicast packets, are ignored to prevent self-dialing.
+// Next to the nodeId, the Endpoints of the own announcements and the optional localIds identify this node.
+//
+// The multicast addresses and port are set by the config, whose unset fields fall back to the defaults.
 func NewManager(
-	nodeId bpv7.EndpointID, registerFunc func(cla.Convergable),
+	nodeId bpv7.EndpointID, localIds []bpv7.EndpointID, registerFunc func(cla.Convergable),
 	announcements []Announcement, announcementInterval time.Duration,
-	ipv4, ipv6 bool) (*Manager, error) {
+	ipv4, ipv6 bool, config DiscoveryConfig) (*Manager, error) {
+
+	config = config.withDefaults()
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+
+	networks, err := config.interfaceNetworks()
+	if err != nil {
+		return nil, err
+	}
 
 	var manager = &Manager{
 		NodeId:       nodeId,
 		RegisterFunc: registerFunc,
+		localIds:     append([]bpv7.EndpointID{}, localIds...),
+		networks:     networks,
+	}
+	for _, announcement := range announcements {
+		manager.localIds = append(manager.localIds, announcement.Endpoint)
 	}
 	if ipv4 {
 		manager.stopChan4 = make(chan struct{})
@@ -52,6 +84,7 @@ func NewManager(
 		"IPv4":          ipv4,
 		"IPv6":          ipv6,
 		"announcements": announcements,
+		"config":        config,
 	}).Info("Starting Manager")
 
 	msg, err := MarshalAnnouncements(announcements)
@@ -66,8 +99,8 @@ func NewManager(
 		ipVersion        peerdiscovery.IPVersion
 		notify           func(discovered peerdiscovery.Discovered)
 	}{
-		{ipv4, address4, manager.stopChan4, peerdiscovery.IPv4, manager.notify},
-		{ipv6, address6, manager.stopChan6, peerdiscovery.IPv6, manager.notify6},
+		{ipv4, config.Address4, manager.stopChan4, peerdiscovery.IPv4, manager.notify},
+		{ipv6, config.Address6, manager.stopChan6, peerdiscovery.IPv6, manager.notify6},
 	}
 
 	for _, set := range sets {
@@ -77,7 +110,7 @@ func NewManager(
 
 		set := peerdiscovery.Settings{
 			Limit:            -1,
-			Port:             fmt.Sprintf("%d", port),
+			Port:             fmt.Sprintf("%d", config.Port),
 			MulticastAddress: set.multicastAddress,
 			Payload:          msg,
 			Delay:            announcementInterval,
@@ -114,8 +147,25 @@ func (manager *Manager) notify6(discovered peerdiscovery.Discovered) {
 	manager.notify(discovered)
 }
 
+// notify handles a received beacon, containing a list of Announcements.
+//
+// Legacy beacons without any Announcement only indicate the node's existence. In this case, the default TCPCLv4 port
+// will be tried, unless the beacon originates from this host.
 func (manager *Manager) notify(discovered peerdiscovery.Discovered) {
-	announcements, err := UnmarshalAnnouncements(discovered.Payload)
+	if !withinNetworks(manager.networks, discovered.Address) {
+		log.WithFields(log.Fields{
+			"discovery": manager,
+			"peer":      discovered.Address,
+		}).Debug("Peer discovery ignored a package from outside the configured interface")
+
+		return
+	}
+
+	var announcements []Announcement
+	var err error
+	if len(discovered.Payload) > 0 {
+		announcements, err = UnmarshalAnnouncements(discovered.Payload)
+	}
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"discovery": manager,
@@ -125,13 +175,74 @@ func (manager *Manager) notify(discovered peerdiscovery.Discovered) {
 		return
 	}
 
+	if len(announcements) == 0 {
+		if isLocalAddress(discovered.Address) {
+			return
+		}
+
+		log.WithFields(log.Fields{
+			"discovery": manager,
+			"peer":      discovered.Address,
+		}).Debug("Peer discovery received a beacon without Announcements, trying the default CLA")
+
+		announcements = []Announcement{{Type: cla.TCPCLv4, Endpoint: bpv7.DtnNone(), Port: defaultCLAPort}}
+	}
+
 	for _, announcement := range announcements {
 		go manager.handleDiscovery(announcement, discovered.Address)
 	}
 }
 
+// isLocalAddress checks if a discovered address belongs to one of this host's interfaces.
+func isLocalAddress(addr string) bool {
+	ip := parseDiscoveredIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	ifaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+
+	for _, ifaceAddr := range ifaceAddrs {
+		if network, ok := ifaceAddr.(*net.IPNet); ok && network.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// dialAddress of an Announcement's CLA, either its announced Address or the address the Announcement was received
+// from, which is already enclosed in brackets for IPv6.
+func dialAddress(announcement Announcement, addr string) string {
+	if announcement.Address != "" {
+		return net.JoinHostPort(announcement.Address, fmt.Sprintf("%d", announcement.Port))
+	}
+	return fmt.Sprintf("%s:%d", addr, announcement.Port)
+}
+
+// isLocal checks if an endpoint ID belongs to one of this node's identities.
+func (manager *Manager) isLocal(eid bpv7.EndpointID) bool {
+	if manager.NodeId.SameNode(eid) {
+		return true
+	}
+
+	for _, localId := range manager.localIds {
+		if localId.SameNode(eid) {
+			return true
+		}
+	}
+	return false
+}
+
 func (manager *Manager) handleDiscovery(announcement Announcement, addr string) {
-	if manager.NodeId.SameNode(announcement.Endpoint) {
+	if manager.isLocal(announcement.Endpoint) {
+		log.WithFields(log.Fields{
+			"discovery": manager,
+			"peer":      addr,
+			"message":   announcement,
+		}).Debug("Peer discovery ignored an announcement of this node")
 		return
 	}
 
@@ -144,13 +255,13 @@ func (manager *Manager) handleDiscovery(announcement Announcement, addr string)
 	var convergable cla.Convergable
 	switch announcement.Type {
 	case cla.MTCP:
-		convergable = mtcp.NewMTCPClient(fmt.Sprintf("%s:%d", addr, announcement.Port), announcement.Endpoint, false)
+		convergable = mtcp.NewMTCPClient(dialAddress(announcement, addr), announcement.Endpoint, false)
 
 	case cla.TCPCLv4:
-		convergable = tcpclv4.DialTCP(fmt.Sprintf("%s:%d", addr, announcement.Port), manager.NodeId, false)
+		convergable = tcpclv4.DialTCP(dialAddress(announcement, addr), manager.NodeId, false)
 
 	case cla.QUICL:
-		convergable = quicl.NewDialerEndpoint(fmt.Sprintf("%s:%d", addr, announcement.Port), manager.NodeId, false)
+		convergable = quicl.NewDialerEndpoint(dialAddress(announcement, addr), manager.NodeId, false)
 
 	default:
 		log.WithFields(log.Fields{
@@ -162,9 +273,27 @@ func (manager *Manager) handleDiscovery(announcement Announcement, addr string)
 		return
 	}
 
+	manager.neighborMutex.Lock()
+	neighborFunc := manager.neighborFunc
+	manager.neighborMutex.Unlock()
+
+	if neighborFunc != nil && announcement.Endpoint != bpv7.DtnNone() {
+		neighborFunc(announcement.Endpoint)
+	}
+
 	manager.RegisterFunc(convergable)
 }
 
+// SetNeighborFunc sets an optional function, being called with each discovered neighbor's endpoint ID, if announced,
+// before its CLA is registered. This allows the routing to provisionally know this neighbor before the CLA is
+// established. A nil function disables these calls.
+func (manager *Manager) SetNeighborFunc(neighborFunc func(bpv7.EndpointID)) {
+	manager.neighborMutex.Lock()
+	defer manager.neighborMutex.Unlock()
+
+	manager.neighborFunc = neighborFunc
+}
+
 // Close this Manager.
 func (manager *Manager) Close() {
 	for _, c := range []chan struct{}{manager.stopChan4, manager.stopChan6} {
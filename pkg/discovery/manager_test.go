@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package discovery
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/schollz/peerdiscovery"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestManagerIgnoresSelf(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint bpv7.EndpointID
+		dialed   bool
+	}{
+		{"node id", bpv7.MustNewEndpointID("dtn://self/"), false},
+		{"node id endpoint", bpv7.MustNewEndpointID("dtn://self/app"), false},
+		{"listener id", bpv7.MustNewEndpointID("dtn://listener/"), false},
+		{"local id", bpv7.MustNewEndpointID("ipn:23.0"), false},
+		{"peer", bpv7.MustNewEndpointID("dtn://peer/"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var convergables []cla.Convergable
+
+			manager := &Manager{
+				NodeId: bpv7.MustNewEndpointID("dtn://self/"),
+				RegisterFunc: func(c cla.Convergable) {
+					mutex.Lock()
+					defer mutex.Unlock()
+					convergables = append(convergables, c)
+				},
+				localIds: []bpv7.EndpointID{
+					bpv7.MustNewEndpointID("dtn://listener/"),
+					bpv7.MustNewEndpointID("ipn:23.0"),
+				},
+			}
+
+			manager.handleDiscovery(Announcement{Type: cla.MTCP, Endpoint: test.endpoint, Port: 35037}, "127.0.0.1")
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if dialed := len(convergables) > 0; dialed != test.dialed {
+				t.Fatalf("expected dialing %t, got %t", test.dialed, dialed)
+			}
+		})
+	}
+}
+
+func TestManagerLegacyBeacon(t *testing.T) {
+	emptyList, err := MarshalAnnouncements(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		payload []byte
+		dialed  string
+	}{
+		{"empty payload", "192.0.2.23", nil, "192.0.2.23:4556"},
+		{"empty list", "192.0.2.23", emptyList, "192.0.2.23:4556"},
+		{"empty list ipv6", "[2001:db8::23]", emptyList, "[2001:db8::23]:4556"},
+		{"own beacon", "127.0.0.1", emptyList, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			convergables := make(chan cla.Convergable, 1)
+
+			manager := &Manager{
+				NodeId:       bpv7.MustNewEndpointID("dtn://self/"),
+				RegisterFunc: func(c cla.Convergable) { convergables <- c },
+			}
+
+			manager.notify(peerdiscovery.Discovered{Address: test.address, Payload: test.payload})
+
+			select {
+			case c := <-convergables:
+				if test.dialed == "" {
+					t.Fatalf("expected no dialing, got %v", c)
+				} else if addr := c.(cla.Convergence).Address(); addr != test.dialed {
+					t.Fatalf("expected dialing %s, got %s", test.dialed, addr)
+				}
+
+			case <-time.After(100 * time.Millisecond):
+				if test.dialed != "" {
+					t.Fatalf("expected dialing %s", test.dialed)
+				}
+			}
+		})
+	}
+}
+
+func TestDialAddress(t *testing.T) {
+	tests := []struct {
+		announcement Announcement
+		addr         string
+		dialed       string
+	}{
+		{Announcement{Port: 4556}, "192.0.2.23", "192.0.2.23:4556"},
+		{Announcement{Port: 4556}, "[2001:db8::23]", "[2001:db8::23]:4556"},
+		{Announcement{Port: 4556, Address: "192.0.2.42"}, "192.0.2.23", "192.0.2.42:4556"},
+		{Announcement{Port: 4556, Address: "2001:db8::42"}, "192.0.2.23", "[2001:db8::42]:4556"},
+	}
+
+	for _, test := range tests {
+		if dialed := dialAddress(test.announcement, test.addr); dialed != test.dialed {
+			t.Fatalf("%v from %s: expected %s, got %s", test.announcement, test.addr, test.dialed, dialed)
+		}
+	}
+}
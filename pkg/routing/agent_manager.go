@@ -6,6 +6,7 @@ package routing
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -20,6 +21,10 @@ type AgentManager struct {
 
 	mux *agent.MuxAgent
 
+	// payloadOnly are the endpoints receiving PayloadMessages instead of BundleMessages.
+	payloadOnly      map[bpv7.EndpointID]struct{}
+	payloadOnlyMutex sync.RWMutex
+
 	closeSyn chan struct{}
 	closeAck chan struct{}
 }
@@ -27,10 +32,11 @@ type AgentManager struct {
 // NewAgentManager creates a new AgentManager to proxy different ApplicationAgents within the routing package.
 func NewAgentManager(core *Core) (manager *AgentManager) {
 	manager = &AgentManager{
-		core:     core,
-		mux:      agent.NewMuxAgent(),
-		closeSyn: make(chan struct{}),
-		closeAck: make(chan struct{}),
+		core:        core,
+		mux:         agent.NewMuxAgent(),
+		payloadOnly: make(map[bpv7.EndpointID]struct{}),
+		closeSyn:    make(chan struct{}),
+		closeAck:    make(chan struct{}),
 	}
 
 	go manager.handler()
@@ -74,6 +80,28 @@ func (manager *AgentManager) Register(appAgent agent.ApplicationAgent) {
 	manager.mux.Register(appAgent)
 }
 
+// SetPayloadOnly configures an endpoint to receive only the payload of its Bundles as PayloadMessages instead of the
+// whole Bundle as BundleMessages.
+func (manager *AgentManager) SetPayloadOnly(eid bpv7.EndpointID, payloadOnly bool) {
+	manager.payloadOnlyMutex.Lock()
+	defer manager.payloadOnlyMutex.Unlock()
+
+	if payloadOnly {
+		manager.payloadOnly[eid] = struct{}{}
+	} else {
+		delete(manager.payloadOnly, eid)
+	}
+}
+
+// isPayloadOnly checks if an endpoint was configured to only receive PayloadMessages.
+func (manager *AgentManager) isPayloadOnly(eid bpv7.EndpointID) bool {
+	manager.payloadOnlyMutex.RLock()
+	defer manager.payloadOnlyMutex.RUnlock()
+
+	_, ok := manager.payloadOnly[eid]
+	return ok
+}
+
 // HasEndpoint checks if some specific EndpointID is registered for some ApplicationAgent.
 func (manager *AgentManager) HasEndpoint(eid bpv7.EndpointID) bool {
 	return agent.AppAgentHasEndpoint(manager.mux, eid)
@@ -97,11 +125,36 @@ func (manager *AgentManager) Deliver(descriptor BundleDescriptor) error {
 		return err
 	}
 
+	if manager.isPayloadOnly(b.PrimaryBlock.Destination) {
+		msg := agent.PayloadMessage{
+			Source:            b.PrimaryBlock.SourceNode,
+			Destination:       b.PrimaryBlock.Destination,
+			CreationTimestamp: b.PrimaryBlock.CreationTimestamp,
+		}
+		if pb, err := b.PayloadBlock(); err == nil {
+			msg.Payload = pb.Value.(*bpv7.PayloadBlock).Data()
+		}
+
+		log.WithField("bundle", b).Debug("AgentManager delivers Bundle's payload to client")
+		manager.mux.MessageReceiver() <- msg
+		return nil
+	}
+
 	log.WithField("bundle", b).Debug("AgentManager delivers Bundle to client")
 	manager.mux.MessageReceiver() <- agent.BundleMessage{Bundle: *b}
 	return nil
 }
 
+// ConfirmDelivery of a Bundle to the registered ApplicationAgent of its source.
+func (manager *AgentManager) ConfirmDelivery(bid bpv7.BundleID) {
+	if !manager.HasEndpoint(bid.SourceNode) {
+		return
+	}
+
+	log.WithField("bundle", bid).Debug("AgentManager confirms delivered Bundle to client")
+	manager.mux.MessageReceiver() <- agent.DeliveryConfirmationMessage{BundleID: bid}
+}
+
 // Close down this AgentManager and its underlying ApplicationAgents.
 func (manager *AgentManager) Close() error {
 	manager.mux.MessageReceiver() <- agent.ShutdownMessage{}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreWebSocketDeliveryConfirmation(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	ws := agent.NewWebSocketAgent()
+	c.RegisterApplicationAgent(ws)
+
+	server := httptest.NewServer(http.HandlerFunc(ws.ServeHTTP))
+	defer server.Close()
+
+	wac, err := agent.NewWebSocketAgentConnector("ws"+strings.TrimPrefix(server.URL, "http"), "dtn://node/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wac.Close()
+
+	var bndls []bpv7.Bundle
+	for _, flags := range []bpv7.BundleControlFlags{0, bpv7.StatusRequestDelivery} {
+		b, err := bpv7.Builder().
+			Source("dtn://node/app").
+			Destination("dtn://other/app").
+			ReportTo("dtn://node/").
+			BundleCtrlFlags(flags).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello world")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := wac.WriteBundle(b); err != nil {
+			t.Fatal(err)
+		}
+		bndls = append(bndls, b)
+	}
+
+	for _, b := range bndls {
+		for i := 0; !c.Store.KnowsBundle(b.ID()); i++ {
+			if i == 100 {
+				t.Fatalf("bundle %v was not stored", b.ID())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// Both bundles are reported as delivered, but only the second one has requested a delivery report.
+	for _, b := range bndls {
+		report, err := bpv7.Builder().
+			Source("dtn://other/").
+			Destination("dtn://node/").
+			CreationTimestampNow().
+			Lifetime("10m").
+			StatusReport(b, bpv7.DeliveredBundle, bpv7.NoInformation).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.localDelivery(NewBundleDescriptorFromBundle(report, c.Store))
+	}
+
+	confirmed := make(chan bpv7.BundleID)
+	go func() {
+		if bid, err := wac.ReadDeliveryConfirmation(); err == nil {
+			confirmed <- bid
+		}
+	}()
+
+	select {
+	case bid := <-confirmed:
+		if bid.String() != bndls[1].ID().String() {
+			t.Fatalf("expected delivery confirmation for %v, got %v", bndls[1].ID(), bid)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("no delivery confirmation was received")
+	}
+}
+
+func TestCorePayloadOnlyDelivery(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	payloadEid := bpv7.MustNewEndpointID("dtn://node/payload")
+	bundleEid := bpv7.MustNewEndpointID("dtn://node/bundle")
+
+	m := newMockAgent(payloadEid, bundleEid)
+	c.RegisterApplicationAgent(m)
+	c.SetPayloadOnlyDelivery(payloadEid, true)
+
+	for _, dst := range []bpv7.EndpointID{payloadEid, bundleEid} {
+		b, err := bpv7.Builder().
+			Source("dtn://other/app").
+			Destination(dst).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello world")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.localDelivery(NewBundleDescriptorFromBundle(b, c.Store))
+
+		select {
+		case msg := <-m.inbox:
+			switch msg := msg.(type) {
+			case agent.PayloadMessage:
+				if dst != payloadEid {
+					t.Fatalf("%v received a PayloadMessage", dst)
+				}
+				if msg.Source != b.PrimaryBlock.SourceNode || msg.Destination != dst {
+					t.Fatalf("PayloadMessage has endpoints %v -> %v", msg.Source, msg.Destination)
+				}
+				if msg.CreationTimestamp != b.PrimaryBlock.CreationTimestamp {
+					t.Fatalf("PayloadMessage has timestamp %v", msg.CreationTimestamp)
+				}
+				if string(msg.Payload) != "hello world" {
+					t.Fatalf("PayloadMessage has payload %q", msg.Payload)
+				}
+
+			case agent.BundleMessage:
+				if dst != bundleEid {
+					t.Fatalf("%v received a BundleMessage", dst)
+				}
+
+			default:
+				t.Fatalf("unexpected message %T", msg)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("nothing was delivered to %v", dst)
+		}
+	}
+}
+
+func TestCoreDeliveryFanOut(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	eid := bpv7.MustNewEndpointID("dtn://node/app")
+	agents := []*mockAgent{newMockAgent(eid), newMockAgent(eid)}
+	for _, m := range agents {
+		c.RegisterApplicationAgent(m)
+	}
+
+	b, err := bpv7.Builder().
+		Source("dtn://other/app").
+		Destination(eid).
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.localDelivery(NewBundleDescriptorFromBundle(b, c.Store))
+
+	var received []bpv7.Bundle
+	for i, m := range agents {
+		rb, ok := m.received(time.Second)
+		if !ok {
+			t.Fatalf("agent %d received no bundle", i)
+		} else if rb.ID().String() != b.ID().String() {
+			t.Fatalf("agent %d received %v instead of %v", i, rb.ID(), b.ID())
+		}
+		received = append(received, rb)
+	}
+
+	// Each agent has received its own copy, not affected by the other agent's modifications.
+	var hcbs []*bpv7.HopCountBlock
+	for _, rb := range received {
+		hcb, err := rb.ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hcbs = append(hcbs, hcb.Value.(*bpv7.HopCountBlock))
+	}
+
+	count := hcbs[1].Count
+	hcbs[0].Increment()
+	if hcbs[1].Count != count {
+		t.Fatalf("second agent's hop count changed from %d to %d", count, hcbs[1].Count)
+	}
+}
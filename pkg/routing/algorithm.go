@@ -47,13 +47,42 @@ type Algorithm interface {
 	ReportPeerDisappeared(peer cla.Convergence)
 }
 
+// DuplicateAwareAlgorithm is an optional extension of an Algorithm to be notified about duplicate receptions, if
+// enabled by the RoutingConf's DuplicatePolicy.
+type DuplicateAwareAlgorithm interface {
+	// ReportDuplicate notifies the Algorithm that an already known bundle was received again from some peer. Thus,
+	// this peer also has this bundle and does not need to receive it.
+	ReportDuplicate(descriptor BundleDescriptor, peer bpv7.EndpointID)
+}
+
+// DeliveryAwareAlgorithm is an optional extension of an Algorithm to be notified about bundles which were successfully
+// delivered to a local application agent.
+type DeliveryAwareAlgorithm interface {
+	// ReportDelivery notifies the Algorithm that a bundle has reached its destination at this node.
+	ReportDelivery(descriptor BundleDescriptor)
+}
+
+// NeighborAwareAlgorithm is an optional extension of an Algorithm to be notified about neighbors which were discovered,
+// but are not yet connected by a CLA. Those neighbors might be added provisionally to the routing state until their
+// CLA appears, reported by ReportPeerAppeared, or until they are lost again.
+type NeighborAwareAlgorithm interface {
+	// ReportNeighborDiscovered notifies the Algorithm about a discovered, but not yet connected neighbor.
+	ReportNeighborDiscovered(peer bpv7.EndpointID)
+
+	// ReportNeighborLost notifies the Algorithm that a discovered neighbor was not connected in time.
+	ReportNeighborLost(peer bpv7.EndpointID)
+}
+
 // RoutingConf contains necessary configuration data to initialize a routing algorithm.
 type RoutingConf struct {
 	// Algorithm is one of the implemented routing algorithms.
 	//
-	// One of: "epidemic", "spray", "binary_spray", "dtlsr", "prophet", "sensor-mule"
+	// One of: "epidemic", "spray", "binary_spray", "dtlsr", "prophet", "sensor-mule", "static"
 	Algorithm string
 
+	// EpidemicConf contains optional data to initialize "epidemic"
+	EpidemicConf EpidemicConfig
+
 	// SprayConf contains data to initialize "spray" or "binary_spray"
 	SprayConf SprayConfig
 
@@ -65,13 +94,91 @@ type RoutingConf struct {
 
 	// SensorNetworkMuleConfig contains data to initialize "sensor-mule"
 	SensorMuleConf SensorNetworkMuleConfig `toml:"sensor-mule-conf"`
+
+	// StaticConf contains the routing table to initialize "static"
+	StaticConf StaticRoutingConfig `toml:"static"`
+
+	// CLAAllowlist restricts Bundles to be only forwarded over certain CLA types, based on their destination.
+	CLAAllowlist []CLAAllowlistRule `toml:"cla-allowlist"`
+
+	// ForwardingDelay is an optional window, e.g., "50ms", to collect Bundles for the same peer and send them together.
+	// Bundles are forwarded immediately by default.
+	ForwardingDelay string `toml:"forwarding-delay"`
+
+	// MaxHoldTime optionally limits how long, e.g., "1h", a contraindicated Bundle is held after its reception,
+	// independent of its lifetime. Afterwards, it is deleted when being retried.
+	MaxHoldTime string `toml:"max-hold-time"`
+
+	// DuplicatePolicy for already known Bundles being received again. Duplicates are always counted, but ignored by
+	// default, "ignore". For "report", a duplicate's previous node is reported to a DuplicateAwareAlgorithm.
+	DuplicatePolicy string `toml:"duplicate-policy"`
+
+	// DuplicateSuppression defines how long a disposed Bundle, e.g., being forwarded, delivered, or deleted, is treated
+	// as a duplicate when being received again. By default, "held", only Bundles still being held are duplicates. For
+	// "global", disposed Bundles are remembered until their expiry. For "window", they are remembered for the
+	// DuplicateSuppressionWindow, at most until their expiry.
+	DuplicateSuppression string `toml:"duplicate-suppression"`
+
+	// DuplicateSuppressionWindow is the duration, e.g., "10m", disposed Bundles are remembered for "window".
+	DuplicateSuppressionWindow string `toml:"duplicate-suppression-window"`
+
+	// DuplicateNewPeerOverride accepts a remembered disposed Bundle again, if it is received from a peer other than
+	// those it was previously received from, e.g., a peer closer to its destination.
+	DuplicateNewPeerOverride bool `toml:"duplicate-new-peer-override"`
+
+	// UnknownEndpointPolicy for Bundles addressed to this node, but to an endpoint without a registered application
+	// agent. By default, "buffer", such Bundles are held and retried with the other pending Bundles, to be delivered
+	// once their endpoint registers. For "catch-all", they are passed to the UnknownEndpointCatchAll endpoint, CBOR
+	// encoded as a new Bundle's payload. For "reject", they are deleted.
+	UnknownEndpointPolicy string `toml:"unknown-endpoint-policy"`
+
+	// UnknownEndpointBuffer optionally limits how long, e.g., "10m", a Bundle for an unknown local endpoint is buffered
+	// after its reception. Afterwards, it is deleted when being retried. Without a limit, it is held until it expires.
+	UnknownEndpointBuffer string `toml:"unknown-endpoint-buffer"`
+
+	// UnknownEndpointCatchAll is the local endpoint receiving Bundles for unknown local endpoints for "catch-all".
+	UnknownEndpointCatchAll string `toml:"unknown-endpoint-catch-all"`
+
+	// ReassemblyMaxMemory optionally limits the sum of all held fragments' payload lengths in bytes, which are addressed
+	// to this node and waiting for their reassembly. Exceeding fragments are deleted.
+	ReassemblyMaxMemory uint64 `toml:"reassembly-max-memory"`
+
+	// ReassemblyTimeout optionally limits how long, e.g., "10m", an incomplete reassembly is held after its first
+	// fragment's reception. Afterwards, its fragments are deleted.
+	ReassemblyTimeout string `toml:"reassembly-timeout"`
+
+	// MaxDecompressedLength optionally limits the length in bytes of a compressed payload being decompressed before its
+	// local delivery, as announced by its CompressionBlock. Exceeding Bundles are deleted. By default, the limit is
+	// bpv7.DefaultMaxDecompressedLength.
+	MaxDecompressedLength uint64 `toml:"max-decompressed-length"`
+
+	// MaxSequenceNumber optionally limits the creation timestamp's sequence number for this node's bundles within the
+	// same DTN time tick. Further bundles within this tick are rejected.
+	MaxSequenceNumber uint64 `toml:"max-sequence-number"`
+
+	// SuppressPreviousNode neither adds nor updates a PreviousNodeBlock while forwarding Bundles, not disclosing this
+	// node to the next one. A received PreviousNodeBlock is removed. Thus, routing algorithms might bounce Bundles
+	// back to their previous node.
+	SuppressPreviousNode bool `toml:"suppress-previous-node"`
+
+	// SourceVerification optionally lists source prefixes, e.g., "dtn://" or "ipn:23.", for an anti-spoofing ingress
+	// policy in closed networks. Received Bundles with a matching source, coming directly from their origin, are
+	// rejected unless their source belongs to the receiving CLA's peer. A Bundle comes directly from its origin if it
+	// has no PreviousNodeBlock or one naming its source's node.
+	SourceVerification []string `toml:"source-verification"`
+
+	// TraceBlockLimit optionally enables the TraceBlock, to which each forwarding node appends itself, keeping at most
+	// this number of the latest nodes, up to bpv7.MaxTraceBlockLength. Independent of this setting, Bundles are never
+	// forwarded to a node being part of their TraceBlock. This is independent of SuppressPreviousNode, which only
+	// affects the PreviousNodeBlock.
+	TraceBlockLimit int `toml:"trace-block-limit"`
 }
 
 // RoutingAlgorithm from its configuration.
 func (routingConf RoutingConf) RoutingAlgorithm(c *Core) (algo Algorithm, err error) {
 	switch routingConf.Algorithm {
 	case "epidemic":
-		algo = NewEpidemicRouting(c)
+		algo = NewEpidemicRoutingWithConfig(c, routingConf.EpidemicConf)
 
 	case "spray":
 		algo = NewSprayAndWait(c, routingConf.SprayConf)
@@ -94,6 +201,13 @@ func (routingConf RoutingConf) RoutingAlgorithm(c *Core) (algo Algorithm, err er
 			algo = NewSensorNetworkMuleRouting(muleAlgo, sensorNode)
 		}
 
+	case "static":
+		if staticAlgo, staticErr := NewStaticRouting(c, routingConf.StaticConf); staticErr != nil {
+			err = staticErr
+		} else {
+			algo = staticAlgo
+		}
+
 	default:
 		err = fmt.Errorf("unknown routing algorithm %s", routingConf.Algorithm)
 	}
@@ -130,6 +244,52 @@ func sendMetadataBundle(c *Core, source bpv7.EndpointID, destination bpv7.Endpoi
 	return nil
 }
 
+// previousNode returns the EndpointID of the node from which a bundle was received, as stated in its PreviousNodeBlock.
+// If there is no such block and peerFallback is set, the receiving CLA's peer will be used instead.
+func previousNode(bp BundleDescriptor, peerFallback bool) (prevNode bpv7.EndpointID, ok bool) {
+	if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
+		if pn, isPn := pnBlock.Value.(*bpv7.PreviousNodeBlock); isPn {
+			return pn.Endpoint(), true
+		}
+	}
+
+	if peerFallback && bp.HasPreviousNode() {
+		return bp.PreviousNode, true
+	}
+	return
+}
+
+// addSentEid adds a node to the Bundle's list of nodes which already received it, "routing/${algorithm}/sent". Thus,
+// this node will be skipped by filterCLAs.
+func addSentEid(store storage.BundleStore, bp BundleDescriptor, algorithm string, eid bpv7.EndpointID) {
+	bi, biErr := store.QueryId(bp.Id)
+	if biErr != nil {
+		log.WithFields(log.Fields{
+			"bundle": bp.ID().String(),
+			"error":  biErr,
+		}).Warn("Failed to proceed a non-stored Bundle")
+		return
+	}
+
+	sentEids, ok := bi.Properties["routing/"+algorithm+"/sent"].([]bpv7.EndpointID)
+	if !ok {
+		sentEids = make([]bpv7.EndpointID, 0)
+	}
+
+	for _, sentEid := range sentEids {
+		if sentEid == eid {
+			return
+		}
+	}
+
+	bi.Properties["routing/"+algorithm+"/sent"] = append(sentEids, eid)
+	if err := store.Update(bi); err != nil {
+		log.WithFields(log.Fields{
+			"error": err,
+		}).Warn("Updating BundleItem failed")
+	}
+}
+
 // filterCLAs filters the nodes which already received a Bundle for a specific routing algorithm, e.g., "epidemic".
 // It returns a list of unused ConvergenceSenders and an updated list of all sent EndpointIDs. The second should be
 // stored as "routing/${algorithm}/sent" within the specific algorithm.
@@ -30,6 +30,9 @@ type DTLSRConfig struct {
 	// PurgeTime is the interval after which a disconnected peer is removed from the peer list.
 	// Note: Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	PurgeTime string
+	// PreviousNodeFallback uses the receiving CLA's peer as the previous node of bundles without a PreviousNodeBlock,
+	// preventing bouncing bundles back to their previous node.
+	PreviousNodeFallback bool
 }
 
 // DTLSR is an implementation of "Delay Tolerant Link State Routing"
@@ -42,6 +45,8 @@ type DTLSR struct {
 	peerChange bool
 	// peers is our own peerData
 	peers bpv7.DTLSRPeerData
+	// provisional peers were discovered and added to peers, but are not yet connected by a CLA
+	provisional map[bpv7.EndpointID]struct{}
 	// receivedChange denotes whether we received new data since we last computed our routing table
 	receivedChange bool
 	// receivedData is peerData received from other nodes
@@ -55,6 +60,8 @@ type DTLSR struct {
 	broadcastAddress bpv7.EndpointID
 	// purgeTime is the time until a peer gets removed from the peer list
 	purgeTime time.Duration
+	// previousNodeFallback uses the receiving CLA's peer if there is no PreviousNodeBlock
+	previousNodeFallback bool
 	// dataMutex is a RW-mutex which protects change operations to the algorithm's metadata
 	dataMutex sync.RWMutex
 }
@@ -87,6 +94,7 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 			Timestamp: bpv7.DtnTimeNow(),
 			Peers:     make(map[bpv7.EndpointID]bpv7.DtnTime),
 		},
+		provisional:      make(map[bpv7.EndpointID]struct{}),
 		receivedChange:   false,
 		receivedData:     make(map[bpv7.EndpointID]bpv7.DTLSRPeerData),
 		nodeIndex:        map[bpv7.EndpointID]int{c.NodeId: 0},
@@ -94,6 +102,8 @@ func NewDTLSR(c *Core, config DTLSRConfig) *DTLSR {
 		length:           1,
 		broadcastAddress: bAddress,
 		purgeTime:        purgeTime,
+
+		previousNodeFallback: config.PreviousNodeFallback,
 	}
 
 	err = c.Cron.Register("dtlsr_purge", dtlsr.purgePeers, purgeTime)
@@ -147,7 +157,12 @@ func (dtlsr *DTLSR) NotifyNewBundle(bp BundleDescriptor) {
 			"peer": bp.MustBundle().PrimaryBlock.SourceNode,
 		}).Debug("Received metadata")
 
-		dtlsrBlock := metaDataBlock.Value.(*bpv7.DTLSRBlock)
+		dtlsrBlock, ok := metaDataBlock.Value.(*bpv7.DTLSRBlock)
+		if !ok {
+			log.WithField("peer", bp.MustBundle().PrimaryBlock.SourceNode).Debug("Received metadata is encrypted")
+			return
+		}
+
 		data := dtlsrBlock.GetPeerData()
 
 		log.WithFields(log.Fields{
@@ -196,11 +211,7 @@ func (dtlsr *DTLSR) NotifyNewBundle(bp BundleDescriptor) {
 		return
 	}
 
-	bndl := bp.MustBundle()
-
-	if pnBlock, err := bndl.ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
-		prevNode := pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint()
-
+	if prevNode, ok := previousNode(bp, dtlsr.previousNodeFallback); ok {
 		sentEids, ok := bundleItem.Properties["routing/dtlsr/sent"].([]bpv7.EndpointID)
 		if !ok {
 			sentEids = make([]bpv7.EndpointID, 0)
@@ -239,7 +250,7 @@ func (dtlsr *DTLSR) SenderForBundle(bp BundleDescriptor) (sender []cla.Convergen
 			return
 		}
 
-		sender, sentEids := filterCLAs(bundleItem, dtlsr.c.claManager.Sender(), "dtlsr")
+		sender, sentEids := filterCLAs(bundleItem, dtlsr.c.senders(), "dtlsr")
 
 		// broadcast bundles are always forwarded to everyone
 		log.WithFields(log.Fields{
@@ -276,7 +287,7 @@ func (dtlsr *DTLSR) SenderForBundle(bp BundleDescriptor) (sender []cla.Convergen
 		return
 	}
 
-	for _, cs := range dtlsr.c.claManager.Sender() {
+	for _, cs := range dtlsr.c.senders() {
 		if cs.GetPeerEndpointID() == forwarder {
 			sender = append(sender, cs)
 			log.WithFields(log.Fields{
@@ -326,12 +337,56 @@ func (dtlsr *DTLSR) ReportPeerAppeared(peer cla.Convergence) {
 	dtlsr.peers.Peers[peerID] = 0
 	dtlsr.peers.Timestamp = bpv7.DtnTimeNow()
 	dtlsr.peerChange = true
+	delete(dtlsr.provisional, peerID)
 
 	log.WithFields(log.Fields{
 		"peer": peerID,
 	}).Debug("Peer is now being tracked")
 }
 
+// ReportNeighborDiscovered provisionally adds a discovered, but not yet connected neighbor to the peer list.
+func (dtlsr *DTLSR) ReportNeighborDiscovered(peer bpv7.EndpointID) {
+	dtlsr.dataMutex.Lock()
+	defer dtlsr.dataMutex.Unlock()
+
+	if timestamp, present := dtlsr.peers.Peers[peer]; present && timestamp == 0 {
+		if _, provisional := dtlsr.provisional[peer]; !provisional {
+			// peer is already connected
+			return
+		}
+	}
+
+	dtlsr.newNode(peer)
+	dtlsr.provisional[peer] = struct{}{}
+
+	dtlsr.peers.Peers[peer] = 0
+	dtlsr.peers.Timestamp = bpv7.DtnTimeNow()
+	dtlsr.peerChange = true
+
+	log.WithFields(log.Fields{
+		"peer": peer,
+	}).Debug("Discovered peer is now provisionally being tracked")
+}
+
+// ReportNeighborLost removes a provisional neighbor from the peer list, if it has not been connected in the meantime.
+func (dtlsr *DTLSR) ReportNeighborLost(peer bpv7.EndpointID) {
+	dtlsr.dataMutex.Lock()
+	defer dtlsr.dataMutex.Unlock()
+
+	if _, provisional := dtlsr.provisional[peer]; !provisional {
+		return
+	}
+
+	delete(dtlsr.provisional, peer)
+	delete(dtlsr.peers.Peers, peer)
+	dtlsr.peers.Timestamp = bpv7.DtnTimeNow()
+	dtlsr.peerChange = true
+
+	log.WithFields(log.Fields{
+		"peer": peer,
+	}).Debug("Provisional peer was removed")
+}
+
 func (dtlsr *DTLSR) ReportPeerDisappeared(peer cla.Convergence) {
 	log.WithFields(log.Fields{
 		"address": peer,
@@ -362,6 +417,11 @@ func (dtlsr *DTLSR) ReportPeerDisappeared(peer cla.Convergence) {
 	}).Debug("Peer timeout is now running")
 }
 
+// ReportDuplicate marks the peer of a duplicate reception as already having received this bundle.
+func (dtlsr *DTLSR) ReportDuplicate(bp BundleDescriptor, peer bpv7.EndpointID) {
+	addSentEid(dtlsr.c.Store, bp, "dtlsr", peer)
+}
+
 // DispatchingAllowed allows the processing of all packages.
 func (_ *DTLSR) DispatchingAllowed(_ BundleDescriptor) bool {
 	// TODO: for future optimisation, we might track the timestamp of the last recomputation of the routing table
@@ -6,30 +6,61 @@
 package routing
 
 import (
+	"sync"
+
 	log "github.com/sirupsen/logrus"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/storage"
 )
 
+// EpidemicConfig contains the optional configuration of an EpidemicRouting.
+type EpidemicConfig struct {
+	// SummaryVector sends a summary vector of all held bundles to each appearing peer. Peers do not forward bundles
+	// listed in a received summary vector back to its sender. This reduces redundant transmissions in dense networks.
+	SummaryVector bool
+}
+
 // EpidemicRouting is an implementation of a Algorithm and behaves in a
 // flooding-based epidemic way.
 type EpidemicRouting struct {
-	c *Core
+	c      *Core
+	config EpidemicConfig
+
+	// summaries maps peers to the scrubbed BundleIDs of their last received summary vector.
+	summaries      map[bpv7.EndpointID]map[string]struct{}
+	summariesMutex sync.RWMutex
 }
 
 // NewEpidemicRouting creates a new EpidemicRouting Algorithm interacting
 // with the given Core.
 func NewEpidemicRouting(c *Core) *EpidemicRouting {
-	log.Debug("Initialised epidemic routing")
+	return NewEpidemicRoutingWithConfig(c, EpidemicConfig{})
+}
+
+// NewEpidemicRoutingWithConfig creates a new EpidemicRouting Algorithm interacting with the given Core, based on an
+// EpidemicConfig.
+func NewEpidemicRoutingWithConfig(c *Core, config EpidemicConfig) *EpidemicRouting {
+	log.WithField("summary_vector", config.SummaryVector).Debug("Initialised epidemic routing")
 
-	return &EpidemicRouting{c: c}
+	return &EpidemicRouting{
+		c:         c,
+		config:    config,
+		summaries: make(map[bpv7.EndpointID]map[string]struct{}),
+	}
 }
 
 // NotifyNewBundle tells the EpidemicRouting about new bundles.
 //
-// In our case, the PreviousNodeBlock will be inspected.
+// In our case, the PreviousNodeBlock and a peer's summary vector will be inspected.
 func (er *EpidemicRouting) NotifyNewBundle(bp BundleDescriptor) {
+	if svBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeSummaryVectorBlock); err == nil {
+		if sv, ok := svBlock.Value.(*bpv7.SummaryVectorBlock); ok {
+			er.receiveSummaryVector(bp, sv)
+		}
+	}
+
 	bi, biErr := er.c.Store.QueryId(bp.Id)
 	if biErr != nil {
 		log.WithFields(log.Fields{
@@ -51,10 +82,12 @@ func (er *EpidemicRouting) NotifyNewBundle(bp BundleDescriptor) {
 
 	// Check if we got a PreviousNodeBlock and extract its EndpointID
 	var prevNode bpv7.EndpointID
-	if pnBlock, err := bndl.ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
-		prevNode = pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint()
-	} else {
+	if pnBlock, err := bndl.ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err != nil {
+		return
+	} else if pn, ok := pnBlock.Value.(*bpv7.PreviousNodeBlock); !ok {
 		return
+	} else {
+		prevNode = pn.Endpoint()
 	}
 
 	sentEids, ok := bi.Properties["routing/epidemic/sent"].([]bpv7.EndpointID)
@@ -92,7 +125,8 @@ func (er *EpidemicRouting) clasForBundle(bp BundleDescriptor, updateDb bool) (cs
 		return nil, false
 	}
 
-	css, sentEids := filterCLAs(bi, er.c.claManager.Sender(), "epidemic")
+	er.addSummarizedPeers(&bi)
+	css, sentEids := filterCLAs(bi, er.c.senders(), "epidemic")
 
 	log.WithFields(log.Fields{
 		"bundle": bp.ID().String(),
@@ -151,6 +185,11 @@ func (er *EpidemicRouting) DispatchingAllowed(bp BundleDescriptor) bool {
 
 // SenderForBundle returns the Core's ConvergenceSenders.
 func (er *EpidemicRouting) SenderForBundle(bp BundleDescriptor) (css []cla.ConvergenceSender, del bool) {
+	if _, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeSummaryVectorBlock); err == nil {
+		// Summary vectors are only sent directly to a peer and are not forwarded.
+		return nil, true
+	}
+
 	return er.clasForBundle(bp, true)
 }
 
@@ -189,9 +228,110 @@ func (er *EpidemicRouting) ReportFailure(bp BundleDescriptor, sender cla.Converg
 	}
 }
 
-func (_ *EpidemicRouting) ReportPeerAppeared(_ cla.Convergence) {}
+// ReportPeerAppeared sends a summary vector to the new peer, if configured.
+func (er *EpidemicRouting) ReportPeerAppeared(peer cla.Convergence) {
+	if !er.config.SummaryVector {
+		return
+	}
+
+	if cs, ok := peer.(cla.ConvergenceSender); ok {
+		er.sendSummaryVector(cs.GetPeerEndpointID())
+	}
+}
+
+// ReportPeerDisappeared forgets the peer's summary vector. A new one will be received on its next appearance.
+func (er *EpidemicRouting) ReportPeerDisappeared(peer cla.Convergence) {
+	if cs, ok := peer.(cla.ConvergenceSender); ok {
+		er.summariesMutex.Lock()
+		delete(er.summaries, cs.GetPeerEndpointID())
+		er.summariesMutex.Unlock()
+	}
+}
+
+// sendSummaryVector of all held bundles to a peer.
+func (er *EpidemicRouting) sendSummaryVector(peer bpv7.EndpointID) {
+	bis, err := er.c.Store.QueryAll()
+	if err != nil {
+		log.WithError(err).Warn("Failed to fetch bundles for the summary vector")
+		return
+	}
+
+	bids := make([]bpv7.BundleID, 0, len(bis))
+	for _, bi := range bis {
+		bids = append(bids, bi.BId.Scrub())
+	}
+
+	log.WithFields(log.Fields{
+		"peer":    peer,
+		"bundles": len(bids),
+	}).Debug("EpidemicRouting sends summary vector")
+
+	if err := sendMetadataBundle(er.c, er.c.NodeId, peer, bpv7.NewSummaryVectorBlock(bids)); err != nil {
+		log.WithFields(log.Fields{
+			"peer":  peer,
+			"error": err,
+		}).Warn("Unable to send summary vector")
+	}
+}
+
+// receiveSummaryVector stores a peer's summary vector, addressed to this node.
+func (er *EpidemicRouting) receiveSummaryVector(bp BundleDescriptor, svb *bpv7.SummaryVectorBlock) {
+	bndl := bp.MustBundle()
+	if bndl.PrimaryBlock.Destination != er.c.NodeId {
+		return
+	}
+
+	summary := make(map[string]struct{}, len(svb.BundleIDs()))
+	for _, bid := range svb.BundleIDs() {
+		summary[bid.Scrub().String()] = struct{}{}
+	}
+
+	log.WithFields(log.Fields{
+		"peer":    bndl.PrimaryBlock.SourceNode,
+		"bundles": len(summary),
+	}).Debug("EpidemicRouting received summary vector")
+
+	er.summariesMutex.Lock()
+	er.summaries[bndl.PrimaryBlock.SourceNode] = summary
+	er.summariesMutex.Unlock()
+}
+
+// addSummarizedPeers adds all peers, whose summary vector lists this bundle, to its list of sent EndpointIDs.
+func (er *EpidemicRouting) addSummarizedPeers(bi *storage.BundleItem) {
+	er.summariesMutex.RLock()
+	defer er.summariesMutex.RUnlock()
+
+	if len(er.summaries) == 0 {
+		return
+	}
+
+	sentEids, ok := bi.Properties["routing/epidemic/sent"].([]bpv7.EndpointID)
+	if !ok {
+		sentEids = make([]bpv7.EndpointID, 0)
+	}
+
+	bid := bi.BId.Scrub().String()
+	for peer, summary := range er.summaries {
+		if _, ok := summary[bid]; !ok {
+			continue
+		}
+
+		known := false
+		for _, sentEid := range sentEids {
+			known = known || sentEid == peer
+		}
+		if !known {
+			sentEids = append(sentEids, peer)
+		}
+	}
+
+	bi.Properties["routing/epidemic/sent"] = sentEids
+}
 
-func (_ *EpidemicRouting) ReportPeerDisappeared(_ cla.Convergence) {}
+// ReportDuplicate marks the peer of a duplicate reception as already having received this bundle.
+func (er *EpidemicRouting) ReportDuplicate(bp BundleDescriptor, peer bpv7.EndpointID) {
+	addSentEid(er.c.Store, bp, "epidemic", peer)
+}
 
 func (_ *EpidemicRouting) String() string {
 	return "epidemic"
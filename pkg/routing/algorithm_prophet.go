@@ -6,6 +6,7 @@
 package routing
 
 import (
+	"math/rand"
 	"sync"
 	"time"
 
@@ -24,6 +25,12 @@ type ProphetConfig struct {
 	Gamma float64
 	// AgeInterval is the duration after which entries are aged
 	AgeInterval string
+	// WeightedSelection forwards a bundle only to one of the better suited peers, chosen randomly with a probability
+	// proportional to the peer's delivery predictability, instead of all of them
+	WeightedSelection bool
+	// PreviousNodeFallback uses the receiving CLA's peer as the previous node of bundles without a PreviousNodeBlock,
+	// preventing bouncing bundles back to their previous node.
+	PreviousNodeFallback bool
 }
 
 type Prophet struct {
@@ -60,11 +67,11 @@ func NewProphet(c *Core, config ProphetConfig) *Prophet {
 		}).Fatal("Unable to parse duration")
 	}
 
-	err = c.Cron.Register("dtlsr_recompute", prophet.ageCron, ageInterval)
+	err = c.Cron.Register("prophet_age", prophet.ageCron, ageInterval)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"reason": err.Error(),
-		}).Warn("Could not register DTLSR recompute job")
+		}).Warn("Could not register Prophet ageing job")
 	}
 
 	// register our custom metadata-block
@@ -102,13 +109,23 @@ func (prophet *Prophet) agePred(peer bpv7.EndpointID) {
 	}).Debug("Updated predictability via ageing")
 }
 
-// ageCron gets called periodically by the routing's cron ange ages all peer predictabilities
+// ageCron gets called periodically by the routing's cron and ages all own and received peer predictabilities
 func (prophet *Prophet) ageCron() {
 	prophet.dataMutex.Lock()
 	defer prophet.dataMutex.Unlock()
 	for peer := range prophet.predictabilities {
 		prophet.agePred(peer)
 	}
+
+	// received predictabilities are aged as well, otherwise a stale vector of a long gone peer would dominate forever
+	for peer, peerPredictabilities := range prophet.peerPredictabilities {
+		for otherPeer, pred := range peerPredictabilities {
+			peerPredictabilities[otherPeer] = pred * prophet.config.Gamma
+		}
+		log.WithFields(log.Fields{
+			"peer": peer,
+		}).Debug("Aged peer's predictabilities")
+	}
 }
 
 // transitivity increases predictability for nodes based on a peer's corresponding predictability
@@ -178,7 +195,12 @@ func (prophet *Prophet) NotifyNewBundle(bp BundleDescriptor) {
 			return
 		}
 
-		prophetBlock := metaDataBlock.Value.(*bpv7.ProphetBlock)
+		prophetBlock, ok := metaDataBlock.Value.(*bpv7.ProphetBlock)
+		if !ok {
+			log.WithField("source", bp.MustBundle().PrimaryBlock.SourceNode).Debug("Received Metadata is encrypted")
+			return
+		}
+
 		data := prophetBlock.GetPredictabilities()
 		peerID := bp.MustBundle().PrimaryBlock.SourceNode
 
@@ -221,19 +243,16 @@ func (prophet *Prophet) NotifyNewBundle(bp BundleDescriptor) {
 		return
 	}
 
-	bndl, err := bp.Bundle()
-	if err != nil {
+	if _, err := bp.Bundle(); err != nil {
 		log.WithFields(log.Fields{
 			"error": err.Error(),
 		}).Warn("Couldn't get bundle data")
 		return
 	}
 
-	// Check if we got a PreviousNodeBlock and extract its EndpointID
-	var prevNode bpv7.EndpointID
-	if pnBlock, err := bndl.ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
-		prevNode = pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint()
-	} else {
+	// Check if we got a PreviousNodeBlock or, as a fallback, the receiving CLA's peer and extract its EndpointID
+	prevNode, ok := previousNode(bp, prophet.config.PreviousNodeFallback)
+	if !ok {
 		return
 	}
 
@@ -302,13 +321,26 @@ func (prophet *Prophet) SenderForBundle(bp BundleDescriptor) (sender []cla.Conve
 	destination := bndl.PrimaryBlock.Destination
 	sender = make([]cla.ConvergenceSender, 0)
 
-	for _, cs := range prophet.c.claManager.Sender() {
+	var candidates []cla.ConvergenceSender
+	var weights []float64
+
+	prophet.dataMutex.RLock()
+	defer prophet.dataMutex.RUnlock()
+
+	ownPred := prophet.predictabilities[destination]
+
+	for _, cs := range prophet.c.senders() {
 		peerID := cs.GetPeerEndpointID()
 		peerPred := prophet.peerPredictabilities[peerID][destination]
-		ownPred := prophet.predictabilities[destination]
+
+		// a peer being the destination itself will certainly deliver the bundle
+		direct := peerID.SameNode(destination)
+		if direct {
+			peerPred = 1
+		}
 
 		// is the peers delivery predictability for the destination greater than ours?
-		if peerPred > ownPred {
+		if direct || peerPred > ownPred {
 			// TODO: this is again very similar to epidemic - could we put that in a function as well?
 
 			log.WithFields(log.Fields{
@@ -331,13 +363,14 @@ func (prophet *Prophet) SenderForBundle(bp BundleDescriptor) (sender []cla.Conve
 				}
 			}
 
-			if !skip {
-				sender = append(sender, cs)
-				sentEids = append(sentEids, peerID)
-				log.WithFields(log.Fields{
-					"bundle": bndl.ID(),
-					"peer":   peerID,
-				}).Debug("Will forward bundle to peer.")
+			if !skip && direct {
+				// no need to hand the bundle to any carrier if we can deliver it directly
+				candidates = []cla.ConvergenceSender{cs}
+				weights = []float64{peerPred}
+				break
+			} else if !skip {
+				candidates = append(candidates, cs)
+				weights = append(weights, peerPred)
 			}
 		} else {
 			log.WithFields(log.Fields{
@@ -350,6 +383,20 @@ func (prophet *Prophet) SenderForBundle(bp BundleDescriptor) (sender []cla.Conve
 		}
 	}
 
+	if prophet.config.WeightedSelection && len(candidates) > 1 {
+		i := chooseWeighted(weights, rand.Float64)
+		candidates = candidates[i : i+1]
+	}
+
+	for _, cs := range candidates {
+		sender = append(sender, cs)
+		sentEids = append(sentEids, cs.GetPeerEndpointID())
+		log.WithFields(log.Fields{
+			"bundle": bndl.ID(),
+			"peer":   cs.GetPeerEndpointID(),
+		}).Debug("Will forward bundle to peer.")
+	}
+
 	if len(sender) == 0 {
 		log.WithFields(
 			log.Fields{
@@ -454,3 +501,8 @@ func (prophet *Prophet) ReportPeerDisappeared(peer cla.Convergence) {
 	}).Debug("Peer disappeared")
 	// there really isn't anything to do upon a peer's disappearance
 }
+
+// ReportDuplicate marks the peer of a duplicate reception as already having received this bundle.
+func (prophet *Prophet) ReportDuplicate(bp BundleDescriptor, peer bpv7.EndpointID) {
+	addSentEid(prophet.c.Store, bp, "prophet", peer)
+}
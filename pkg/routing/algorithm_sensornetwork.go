@@ -11,6 +11,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
 	"github.com/dtn7/dtn7-go/pkg/cla"
 )
 
@@ -111,6 +112,20 @@ func (snm *SensorNetworkMuleRouting) ReportPeerDisappeared(peer cla.Convergence)
 	snm.algorithm.ReportPeerDisappeared(peer)
 }
 
+// ReportDuplicate will be handled by the underlying algorithm, if it is a DuplicateAwareAlgorithm.
+func (snm *SensorNetworkMuleRouting) ReportDuplicate(bp BundleDescriptor, peer bpv7.EndpointID) {
+	if daa, ok := snm.algorithm.(DuplicateAwareAlgorithm); ok {
+		daa.ReportDuplicate(bp, peer)
+	}
+}
+
+// ReportDelivery will be handled by the underlying algorithm, if it is a DeliveryAwareAlgorithm.
+func (snm *SensorNetworkMuleRouting) ReportDelivery(bp BundleDescriptor) {
+	if daa, ok := snm.algorithm.(DeliveryAwareAlgorithm); ok {
+		daa.ReportDelivery(bp)
+	}
+}
+
 func (snm *SensorNetworkMuleRouting) String() string {
 	return fmt.Sprintf("sensor mule overlaying %v", snm.algorithm)
 }
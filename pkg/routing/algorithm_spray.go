@@ -18,6 +18,10 @@ import (
 type SprayConfig struct {
 	// Multiplicity is the number of copies of a bundle which are sprayed
 	Multiplicity uint64
+
+	// DeliveryAcks broadcasts a DeliveryAcknowledgement after a bundle was delivered locally. Other nodes drop their
+	// remaining copies of this bundle on reception.
+	DeliveryAcks bool
 }
 
 // SprayAndWait implements the vanilla Spray and Wait routing protocol
@@ -30,6 +34,8 @@ type SprayAndWait struct {
 	bundleData map[bpv7.BundleID]sprayMetaData
 	// Mutex for concurrent modification of data by multiple goroutines
 	dataMutex sync.RWMutex
+	// acks handles optional delivery acknowledgements
+	acks *sprayAcks
 }
 
 // sprayMetaData stores bundle-specific metadata
@@ -54,12 +60,14 @@ func cleanupMetaData(c *Core, metadata *map[bpv7.BundleID]sprayMetaData) {
 func NewSprayAndWait(c *Core, config SprayConfig) *SprayAndWait {
 	log.WithFields(log.Fields{
 		"Multiplicity": config.Multiplicity,
+		"DeliveryAcks": config.DeliveryAcks,
 	}).Debug("Initialised SprayAndWait")
 
 	sprayAndWait := SprayAndWait{
 		c:          c,
 		l:          config.Multiplicity,
 		bundleData: make(map[bpv7.BundleID]sprayMetaData),
+		acks:       newSprayAcks(c, config.DeliveryAcks),
 	}
 
 	err := c.Cron.Register("spray_and_wait_gc", sprayAndWait.GarbageCollect, time.Second*60)
@@ -77,6 +85,8 @@ func (sw *SprayAndWait) GarbageCollect() {
 	sw.dataMutex.Lock()
 	cleanupMetaData(sw.c, &sw.bundleData)
 	sw.dataMutex.Unlock()
+
+	sw.acks.cleanup()
 }
 
 // NotifyNewBundle tells the routing algorithm about new bundles.
@@ -84,6 +94,15 @@ func (sw *SprayAndWait) GarbageCollect() {
 // In this case, we simply check if we originated this bundle and set Multiplicity if we did
 // If we are not the originator, we don't further distribute the bundle
 func (sw *SprayAndWait) NotifyNewBundle(bp BundleDescriptor) {
+	if sw.acks.isAck(bp) {
+		if bid, ok := sw.acks.receive(bp); ok {
+			sw.dataMutex.Lock()
+			delete(sw.bundleData, bid)
+			sw.dataMutex.Unlock()
+		}
+		return
+	}
+
 	if sw.c.HasEndpoint(bp.MustBundle().PrimaryBlock.SourceNode) {
 		metadata := sprayMetaData{
 			sent:            make([]bpv7.EndpointID, 0),
@@ -105,7 +124,9 @@ func (sw *SprayAndWait) NotifyNewBundle(bp BundleDescriptor) {
 
 		// if the bundle has a PreviousNodeBlock, add it to the list of nodes which we know to have the bundle
 		if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
-			metadata.sent = append(metadata.sent, pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint())
+			if pn, ok := pnBlock.Value.(*bpv7.PreviousNodeBlock); ok {
+				metadata.sent = append(metadata.sent, pn.Endpoint())
+			}
 		}
 
 		sw.dataMutex.Lock()
@@ -127,6 +148,10 @@ func (_ *SprayAndWait) DispatchingAllowed(_ BundleDescriptor) bool {
 // The bundle's originator will distribute Multiplicity copies amongst its peers
 // Forwarders will only every deliver the bundle to its final destination
 func (sw *SprayAndWait) SenderForBundle(bp BundleDescriptor) (css []cla.ConvergenceSender, del bool) {
+	if css, del, ok := sw.acks.senderForBundle(bp); ok {
+		return css, del
+	}
+
 	sw.dataMutex.RLock()
 	metadata, ok := sw.bundleData[bp.Id]
 	sw.dataMutex.RUnlock()
@@ -144,7 +169,7 @@ func (sw *SprayAndWait) SenderForBundle(bp BundleDescriptor) (css []cla.Converge
 		return nil, false
 	}
 
-	for _, cs := range sw.c.claManager.Sender() {
+	for _, cs := range sw.c.senders() {
 		// if we ran out of copies, then don't send it to any further peers
 		if metadata.remainingCopies < 2 {
 			break
@@ -212,6 +237,11 @@ func (_ *SprayAndWait) ReportPeerAppeared(_ cla.Convergence) {}
 
 func (_ *SprayAndWait) ReportPeerDisappeared(_ cla.Convergence) {}
 
+// ReportDelivery broadcasts a DeliveryAcknowledgement, if enabled.
+func (sw *SprayAndWait) ReportDelivery(bp BundleDescriptor) {
+	sw.acks.acknowledge(bp)
+}
+
 // BinarySpray implements the binary Spray and Wait routing protocol
 // In this case, each node hands over floor(copies/2) during the spray phase
 type BinarySpray struct {
@@ -222,12 +252,15 @@ type BinarySpray struct {
 	bundleData map[bpv7.BundleID]sprayMetaData
 	// Mutex for concurrent modification of data by multiple goroutines
 	dataMutex sync.RWMutex
+	// acks handles optional delivery acknowledgements
+	acks *sprayAcks
 }
 
 // NewBinarySpray creates new instance of BinarySpray
 func NewBinarySpray(c *Core, config SprayConfig) *BinarySpray {
 	log.WithFields(log.Fields{
 		"Multiplicity": config.Multiplicity,
+		"DeliveryAcks": config.DeliveryAcks,
 	}).Debug("Initialised BinarySpray")
 
 	// register our custom metadata-block
@@ -241,6 +274,7 @@ func NewBinarySpray(c *Core, config SprayConfig) *BinarySpray {
 		c:          c,
 		l:          config.Multiplicity,
 		bundleData: make(map[bpv7.BundleID]sprayMetaData),
+		acks:       newSprayAcks(c, config.DeliveryAcks),
 	}
 
 	err := c.Cron.Register("binary_spray_gc", binarySpray.GarbageCollect, time.Second*60)
@@ -258,6 +292,8 @@ func (bs *BinarySpray) GarbageCollect() {
 	bs.dataMutex.Lock()
 	cleanupMetaData(bs.c, &bs.bundleData)
 	bs.dataMutex.Unlock()
+
+	bs.acks.cleanup()
 }
 
 // NotifyNewBundle tells the routing algorithm about new bundles.
@@ -266,16 +302,31 @@ func (bs *BinarySpray) GarbageCollect() {
 // If yes, then we initialise the remaining Copies to Multiplicity
 // If not we attempt to ready the routing-metadata-block end get the remaining copies
 func (bs *BinarySpray) NotifyNewBundle(bp BundleDescriptor) {
+	if bs.acks.isAck(bp) {
+		if bid, ok := bs.acks.receive(bp); ok {
+			bs.dataMutex.Lock()
+			delete(bs.bundleData, bid)
+			bs.dataMutex.Unlock()
+		}
+		return
+	}
+
 	if metadataBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeBinarySprayBlock); err == nil {
-		binarySprayBlock := metadataBlock.Value.(*bpv7.BinarySprayBlock)
 		metadata := sprayMetaData{
 			sent:            make([]bpv7.EndpointID, 0),
-			remainingCopies: binarySprayBlock.RemainingCopies(),
+			remainingCopies: 1,
+		}
+
+		// an encrypted metadata-block cannot be read, thus falling back to a single copy
+		if binarySprayBlock, ok := metadataBlock.Value.(*bpv7.BinarySprayBlock); ok {
+			metadata.remainingCopies = binarySprayBlock.RemainingCopies()
 		}
 
 		// if the bundle has a PreviousNodeBlock, add it to the list of nodes which we know to have the bundle
 		if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
-			metadata.sent = append(metadata.sent, pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint())
+			if pn, ok := pnBlock.Value.(*bpv7.PreviousNodeBlock); ok {
+				metadata.sent = append(metadata.sent, pn.Endpoint())
+			}
 		}
 
 		bs.dataMutex.Lock()
@@ -309,6 +360,10 @@ func (_ *BinarySpray) DispatchingAllowed(_ BundleDescriptor) bool {
 // If a node has more than 1 copy left it will send floor(copies/2) to the peer
 // and keep roof(copies/2) for itself
 func (bs *BinarySpray) SenderForBundle(bp BundleDescriptor) (css []cla.ConvergenceSender, del bool) {
+	if css, del, ok := bs.acks.senderForBundle(bp); ok {
+		return css, del
+	}
+
 	bs.dataMutex.RLock()
 	metadata, ok := bs.bundleData[bp.Id]
 	bs.dataMutex.RUnlock()
@@ -323,7 +378,7 @@ func (bs *BinarySpray) SenderForBundle(bp BundleDescriptor) (css []cla.Convergen
 		return nil, false
 	}
 
-	for _, cs := range bs.c.claManager.Sender() {
+	for _, cs := range bs.c.senders() {
 		var skip = false
 		for _, eid := range metadata.sent {
 			if cs.GetPeerEndpointID() == eid {
@@ -348,8 +403,9 @@ func (bs *BinarySpray) SenderForBundle(bp BundleDescriptor) (css []cla.Convergen
 
 			// if the bundle already has a metadata-block
 			if metadataBlock, err := bndl.ExtensionBlock(bpv7.ExtBlockTypeBinarySprayBlock); err == nil {
-				binarySprayBlock := metadataBlock.Value.(*bpv7.BinarySprayBlock)
-				binarySprayBlock.SetCopies(sendCopies)
+				if binarySprayBlock, ok := metadataBlock.Value.(*bpv7.BinarySprayBlock); ok {
+					binarySprayBlock.SetCopies(sendCopies)
+				}
 			} else {
 				// if it doesn't, then create one
 				metadataBlock := bpv7.NewBinarySprayBlock(sendCopies)
@@ -400,7 +456,11 @@ func (bs *BinarySpray) ReportFailure(bp BundleDescriptor, sender cla.Convergence
 		return
 	}
 
-	binarySprayBlock := metadataBlock.Value.(*bpv7.BinarySprayBlock)
+	binarySprayBlock, ok := metadataBlock.Value.(*bpv7.BinarySprayBlock)
+	if !ok {
+		log.WithField("bundle", bp.ID().String()).Warn("Bundle metadata Block is encrypted")
+		return
+	}
 
 	bs.dataMutex.RLock()
 	metadata, ok := bs.bundleData[bp.Id]
@@ -429,3 +489,8 @@ func (bs *BinarySpray) ReportFailure(bp BundleDescriptor, sender cla.Convergence
 func (_ *BinarySpray) ReportPeerAppeared(_ cla.Convergence) {}
 
 func (_ *BinarySpray) ReportPeerDisappeared(_ cla.Convergence) {}
+
+// ReportDelivery broadcasts a DeliveryAcknowledgement, if enabled.
+func (bs *BinarySpray) ReportDelivery(bp BundleDescriptor) {
+	bs.acks.acknowledge(bp)
+}
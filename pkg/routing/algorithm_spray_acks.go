@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// sprayAckAddress is the broadcast address for delivery acknowledgements.
+const sprayAckAddress = "dtn://routing/spray/ack/"
+
+// sprayAcks implements the optional delivery acknowledgements of SprayAndWait and BinarySpray.
+//
+// After delivering a bundle locally, a DeliveryAcknowledgement is flooded to all nodes. Each node receiving it drops
+// its remaining copy of the delivered bundle together with the algorithm's metadata.
+type sprayAcks struct {
+	c       *Core
+	enabled bool
+	address bpv7.EndpointID
+
+	// acked maps acknowledged bundles to their expiry time to drop copies received afterwards
+	acked      map[bpv7.BundleID]time.Time
+	ackedMutex sync.Mutex
+}
+
+// newSprayAcks creates a new sprayAcks, which does nothing unless enabled.
+func newSprayAcks(c *Core, enabled bool) *sprayAcks {
+	return &sprayAcks{
+		c:       c,
+		enabled: enabled,
+		address: bpv7.MustNewEndpointID(sprayAckAddress),
+		acked:   make(map[bpv7.BundleID]time.Time),
+	}
+}
+
+// isAck checks if a bundle is a delivery acknowledgement.
+func (sa *sprayAcks) isAck(bp BundleDescriptor) bool {
+	if !sa.enabled {
+		return false
+	}
+
+	b, err := bp.Bundle()
+	return err == nil && b.PrimaryBlock.Destination == sa.address
+}
+
+// isAcked checks if a bundle was already acknowledged.
+func (sa *sprayAcks) isAcked(bid bpv7.BundleID) bool {
+	sa.ackedMutex.Lock()
+	defer sa.ackedMutex.Unlock()
+
+	_, ok := sa.acked[bid.Scrub()]
+	return ok
+}
+
+// remember an acknowledged bundle until its expiry.
+func (sa *sprayAcks) remember(bid bpv7.BundleID, expiry time.Time) {
+	sa.ackedMutex.Lock()
+	sa.acked[bid.Scrub()] = expiry
+	sa.ackedMutex.Unlock()
+}
+
+// cleanup forgets acknowledged bundles after their expiry.
+func (sa *sprayAcks) cleanup() {
+	sa.ackedMutex.Lock()
+	defer sa.ackedMutex.Unlock()
+
+	now := time.Now()
+	for bid, expiry := range sa.acked {
+		if now.After(expiry) {
+			delete(sa.acked, bid)
+		}
+	}
+}
+
+// acknowledge the local delivery of a bundle by broadcasting a DeliveryAcknowledgement, living as long as the
+// delivered bundle.
+func (sa *sprayAcks) acknowledge(bp BundleDescriptor) {
+	if !sa.enabled {
+		return
+	}
+
+	b, err := bp.Bundle()
+	if err != nil {
+		return
+	}
+
+	expiry := b.ExpiryTime()
+	lifetime := time.Until(expiry)
+	if lifetime < time.Millisecond {
+		return
+	}
+
+	sa.remember(bp.Id, expiry)
+
+	ack, err := bpv7.Builder().
+		Source(sa.c.NodeId).
+		Destination(sa.address).
+		CreationTimestampNow().
+		Lifetime(lifetime).
+		BundleCtrlFlags(bpv7.MustNotFragmented).
+		AdministrativeRecord(bpv7.NewDeliveryAcknowledgement(bp.Id)).
+		Build()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Creating delivery acknowledgement erred")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bp.ID().String(),
+		"ack":    ack.ID().String(),
+	}).Debug("Broadcasting delivery acknowledgement")
+
+	sa.c.SendBundle(&ack)
+}
+
+// receive a delivery acknowledgement and drop the stored copy of the acknowledged bundle, unless it was delivered at
+// this node. The acknowledged BundleID is returned together with true for a valid acknowledgement.
+func (sa *sprayAcks) receive(bp BundleDescriptor) (bid bpv7.BundleID, ok bool) {
+	ar, err := bp.MustBundle().AdministrativeRecord()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Debug("Delivery acknowledgement is invalid")
+		return
+	}
+
+	da, isDa := ar.(*bpv7.DeliveryAcknowledgement)
+	if !isDa {
+		log.WithField("bundle", bp.ID().String()).Debug("Delivery acknowledgement has an unexpected record type")
+		return
+	}
+
+	bid, ok = da.RefBundle, true
+	sa.remember(bid, bp.MustBundle().ExpiryTime())
+
+	if !sa.c.Store.KnowsBundle(bid) {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bid.String(),
+		"ack":    bp.ID().String(),
+	}).Info("Dropping acknowledged bundle")
+
+	// PurgeConstraints keeps the LocalEndpoint constraint. Thus, a bundle delivered at this node stays available.
+	acked := NewBundleDescriptor(bid, sa.c.Store)
+	acked.PurgeConstraints()
+	_ = acked.Sync()
+
+	return
+}
+
+// senderForBundle floods delivery acknowledgements to all peers and deletes already acknowledged bundles. The handled
+// flag is set if the bundle was one of these.
+func (sa *sprayAcks) senderForBundle(bp BundleDescriptor) (css []cla.ConvergenceSender, del bool, handled bool) {
+	if !sa.enabled {
+		return
+	}
+
+	if sa.isAcked(bp.Id) {
+		log.WithField("bundle", bp.ID().String()).Debug("Deleting already acknowledged bundle")
+		return nil, true, true
+	}
+
+	if !sa.isAck(bp) {
+		return
+	}
+
+	handled = true
+
+	bundleItem, err := sa.c.Store.QueryId(bp.Id)
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Debug("Delivery acknowledgement not in store")
+		return
+	}
+
+	css, sentEids := filterCLAs(bundleItem, sa.c.senders(), "spray")
+	bundleItem.Properties["routing/spray/sent"] = sentEids
+	if err := sa.c.Store.Update(bundleItem); err != nil {
+		log.WithError(err).Warn("Updating BundleItem failed")
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bp.ID().String(),
+		"peers":  css,
+	}).Debug("Relaying delivery acknowledgement")
+
+	return
+}
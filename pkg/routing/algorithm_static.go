@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// StaticRoutingConfig maps the authority of a Bundle's destination, e.g., "foo" for "dtn://foo/bar" or "23" for
+// "ipn:23.42", to the Endpoint ID of its next hop.
+type StaticRoutingConfig map[string]string
+
+// StaticRouting is an Algorithm for fixed topologies, forwarding Bundles based on a static routing table.
+//
+// A Bundle is forwarded to its destination, if directly connected. Otherwise, it is forwarded to the next hop
+// configured for its destination's authority. Bundles for unknown destinations are only delivered directly.
+type StaticRouting struct {
+	c *Core
+
+	// routes maps destination authorities to their next hop.
+	routes map[string]bpv7.EndpointID
+}
+
+// NewStaticRouting creates a new StaticRouting Algorithm from its routing table. An invalid next hop results in an
+// error.
+func NewStaticRouting(c *Core, config StaticRoutingConfig) (*StaticRouting, error) {
+	routes := make(map[string]bpv7.EndpointID, len(config))
+	for authority, nextHop := range config {
+		if eid, err := bpv7.NewEndpointID(nextHop); err != nil {
+			return nil, fmt.Errorf("static route for %s has an invalid next hop: %v", authority, err)
+		} else {
+			routes[authority] = eid
+		}
+	}
+
+	log.WithField("routes", routes).Debug("Initialised StaticRouting")
+
+	return &StaticRouting{
+		c:      c,
+		routes: routes,
+	}, nil
+}
+
+// NotifyNewBundle is ignored, as the routing table is static.
+func (_ *StaticRouting) NotifyNewBundle(_ BundleDescriptor) {}
+
+// DispatchingAllowed allows the processing of all packages.
+func (_ *StaticRouting) DispatchingAllowed(_ BundleDescriptor) bool {
+	return true
+}
+
+// SenderForBundle returns the ConvergenceSender to the Bundle's destination or, if not connected, to its configured
+// next hop. As only a single copy is forwarded, the Bundle is deleted afterwards.
+func (sr *StaticRouting) SenderForBundle(bp BundleDescriptor) (css []cla.ConvergenceSender, del bool) {
+	bndl, err := bp.Bundle()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Debug("Bundle no longer exists")
+		return
+	}
+
+	destination := bndl.PrimaryBlock.Destination
+	nextHop, routed := sr.routes[destination.Authority()]
+
+	var routedCs cla.ConvergenceSender
+	for _, cs := range sr.c.senders() {
+		if cs.GetPeerEndpointID().SameNode(destination) {
+			log.WithFields(log.Fields{
+				"bundle":             bp.ID().String(),
+				"convergence-sender": cs,
+			}).Debug("StaticRouting delivers bundle directly")
+
+			return []cla.ConvergenceSender{cs}, true
+		} else if routed && routedCs == nil && cs.GetPeerEndpointID().SameNode(nextHop) {
+			routedCs = cs
+		}
+	}
+
+	if routedCs == nil {
+		log.WithFields(log.Fields{
+			"bundle":      bp.ID().String(),
+			"destination": destination,
+			"routed":      routed,
+		}).Debug("StaticRouting found no connected next hop")
+
+		return nil, false
+	}
+
+	log.WithFields(log.Fields{
+		"bundle":             bp.ID().String(),
+		"next-hop":           nextHop,
+		"convergence-sender": routedCs,
+	}).Debug("StaticRouting selected Convergence Sender of the next hop")
+
+	return []cla.ConvergenceSender{routedCs}, true
+}
+
+// ReportFailure is ignored, as the Bundle is kept and retried later.
+func (_ *StaticRouting) ReportFailure(_ BundleDescriptor, _ cla.ConvergenceSender) {}
+
+// ReportPeerAppeared is ignored, as the routing table is static.
+func (_ *StaticRouting) ReportPeerAppeared(_ cla.Convergence) {}
+
+// ReportPeerDisappeared is ignored, as the routing table is static.
+func (_ *StaticRouting) ReportPeerDisappeared(_ cla.Convergence) {}
+
+func (_ *StaticRouting) String() string {
+	return "static"
+}
@@ -0,0 +1,399 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestPreviousNodeFallback(t *testing.T) {
+	destination := bpv7.MustNewEndpointID("dtn://destination/")
+
+	algorithms := []struct {
+		name        string
+		destination bpv7.EndpointID
+		algorithm   func(c *Core, fallback bool) Algorithm
+	}{
+		{"dtlsr", bpv7.MustNewEndpointID(dtlsrBroadcastAddress), func(c *Core, fallback bool) Algorithm {
+			return NewDTLSR(c, DTLSRConfig{
+				RecomputeTime:        "1m",
+				BroadcastTime:        "1m",
+				PurgeTime:            "1m",
+				PreviousNodeFallback: fallback,
+			})
+		}},
+		{"prophet", destination, func(c *Core, fallback bool) Algorithm {
+			prophet := NewProphet(c, ProphetConfig{
+				PInit:                0.75,
+				Beta:                 0.25,
+				Gamma:                0.98,
+				AgeInterval:          "1m",
+				PreviousNodeFallback: fallback,
+			})
+			prophet.dataMutex.Lock()
+			defer prophet.dataMutex.Unlock()
+			for _, peer := range []string{"dtn://peer-a/", "dtn://peer-b/"} {
+				prophet.peerPredictabilities[bpv7.MustNewEndpointID(peer)] = map[bpv7.EndpointID]float64{destination: 0.5}
+			}
+			return prophet
+		}},
+	}
+
+	for _, algorithm := range algorithms {
+		for _, fallback := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s fallback=%t", algorithm.name, fallback), func(t *testing.T) {
+				c := newTestCore(t, "dtn://node/")
+
+				routing := algorithm.algorithm(c, fallback)
+				c.SetRoutingAlgorithm(routing)
+
+				for _, peer := range []string{"dtn://peer-a/", "dtn://peer-b/"} {
+					c.claManager.Register(newMockSender(peer, peer, cla.MTCP))
+				}
+
+				b, err := bpv7.Builder().
+					CRC(bpv7.CRC32).
+					Source("dtn://src/").
+					Destination(algorithm.destination).
+					CreationTimestampNow().
+					Lifetime("10m").
+					PayloadBlock([]byte("hello world")).
+					Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				bp := NewBundleDescriptorFromBundle(b, c.Store)
+				bp.PreviousNode = bpv7.MustNewEndpointID("dtn://peer-a/")
+				bp.AddConstraint(ForwardPending)
+				_ = bp.Sync()
+
+				routing.NotifyNewBundle(bp)
+
+				bounced := false
+				css, _ := routing.SenderForBundle(bp)
+				for _, cs := range css {
+					bounced = bounced || cs.GetPeerEndpointID() == bp.PreviousNode
+				}
+
+				if len(css) == 0 {
+					t.Fatal("bundle was not forwarded at all")
+				} else if bounced == fallback {
+					t.Fatalf("bundle bounced back to its previous node = %t, with fallback = %t", bounced, fallback)
+				}
+			})
+		}
+	}
+}
+
+func TestEpidemicSummaryVector(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	er := NewEpidemicRoutingWithConfig(c, EpidemicConfig{SummaryVector: true})
+	c.SetRoutingAlgorithm(er)
+
+	peerA := newMockSender("peer-a", "dtn://peer-a/", cla.MTCP)
+	peerB := newMockSender("peer-b", "dtn://peer-b/", cla.MTCP)
+	for _, peer := range []*mockSender{peerA, peerB} {
+		c.claManager.Register(peer)
+	}
+
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://src/").
+		Destination("dtn://destination/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bp := NewBundleDescriptorFromBundle(b, c.Store)
+	bp.AddConstraint(ForwardPending)
+	_ = bp.Sync()
+	er.NotifyNewBundle(bp)
+
+	// Our summary vector for peer A lists our bundle.
+	er.ReportPeerAppeared(peerA)
+
+	var ownSummary []bpv7.BundleID
+	peerA.mutex.Lock()
+	sentToA := append([]bpv7.Bundle(nil), peerA.sent...)
+	peerA.mutex.Unlock()
+	for _, sent := range sentToA {
+		if svBlock, err := sent.ExtensionBlock(bpv7.ExtBlockTypeSummaryVectorBlock); err == nil {
+			ownSummary = svBlock.Value.(*bpv7.SummaryVectorBlock).BundleIDs()
+		}
+	}
+	found := false
+	for _, bid := range ownSummary {
+		found = found || bid == b.ID()
+	}
+	if !found {
+		t.Fatalf("summary vector %v sent to peer A misses bundle %v", ownSummary, b.ID())
+	}
+
+	// Peer A's summary vector also lists our bundle, which is therefore only forwarded to peer B.
+	sv, err := bpv7.Builder().
+		Source("dtn://peer-a/").
+		Destination("dtn://node/").
+		CreationTimestampNow().
+		Lifetime("1m").
+		PayloadBlock(byte(1)).
+		Canonical(bpv7.NewSummaryVectorBlock([]bpv7.BundleID{b.ID()})).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	er.NotifyNewBundle(NewBundleDescriptorFromBundle(sv, c.Store))
+
+	css, _ := er.SenderForBundle(bp)
+	if len(css) != 1 || css[0].GetPeerEndpointID() != peerB.GetPeerEndpointID() {
+		t.Fatalf("expected only peer B as sender, got %v", css)
+	}
+
+	// Peer A's summary vector is forgotten after its disappearance.
+	er.ReportPeerDisappeared(peerA)
+	er.summariesMutex.RLock()
+	_, known := er.summaries[peerA.GetPeerEndpointID()]
+	er.summariesMutex.RUnlock()
+	if known {
+		t.Fatal("summary vector of disappeared peer A is still known")
+	}
+}
+
+func TestProphetDirectDelivery(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	prophet := NewProphet(c, ProphetConfig{PInit: 0.75, Beta: 0.25, Gamma: 0.98, AgeInterval: "1m", WeightedSelection: true})
+	c.SetRoutingAlgorithm(prophet)
+
+	// The carrier is a better forwarder towards the destination than we are, but the destination is connected itself.
+	prophet.dataMutex.Lock()
+	prophet.peerPredictabilities[bpv7.MustNewEndpointID("dtn://carrier/")] = map[bpv7.EndpointID]float64{
+		bpv7.MustNewEndpointID("dtn://destination/app"): 0.9,
+	}
+	prophet.dataMutex.Unlock()
+
+	for _, peer := range []string{"dtn://carrier/", "dtn://destination/"} {
+		c.claManager.Register(newMockSender(peer, peer, cla.MTCP))
+	}
+
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://src/").
+		Destination("dtn://destination/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bp := NewBundleDescriptorFromBundle(b, c.Store)
+	bp.AddConstraint(ForwardPending)
+	_ = bp.Sync()
+
+	css, _ := prophet.SenderForBundle(bp)
+	if len(css) != 1 || css[0].GetPeerEndpointID() != bpv7.MustNewEndpointID("dtn://destination/") {
+		t.Fatalf("expected only the destination as sender, got %v", css)
+	}
+}
+
+func TestProphetAgePeerPredictabilities(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	prophet := NewProphet(c, ProphetConfig{PInit: 0.75, Beta: 0.25, Gamma: 0.5, AgeInterval: "1m"})
+
+	peer, other := bpv7.MustNewEndpointID("dtn://peer/"), bpv7.MustNewEndpointID("dtn://other/")
+	prophet.dataMutex.Lock()
+	prophet.predictabilities[peer] = 0.8
+	prophet.peerPredictabilities[peer] = map[bpv7.EndpointID]float64{other: 0.6}
+	prophet.dataMutex.Unlock()
+
+	prophet.ageCron()
+
+	prophet.dataMutex.RLock()
+	defer prophet.dataMutex.RUnlock()
+
+	if pred := prophet.predictabilities[peer]; pred != 0.4 {
+		t.Fatalf("expected own predictability 0.4, got %f", pred)
+	}
+	if pred := prophet.peerPredictabilities[peer][other]; pred != 0.3 {
+		t.Fatalf("expected peer's predictability 0.3, got %f", pred)
+	}
+}
+
+func TestSprayDeliveryAcks(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm func(c *Core, config SprayConfig) Algorithm
+	}{
+		{"spray", func(c *Core, config SprayConfig) Algorithm { return NewSprayAndWait(c, config) }},
+		{"binary_spray", func(c *Core, config SprayConfig) Algorithm { return NewBinarySpray(c, config) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := SprayConfig{Multiplicity: 4, DeliveryAcks: true}
+
+			// The destination acknowledges the local delivery towards its peer.
+			dst := newTestCore(t, "dtn://destination/")
+			dst.SetRoutingAlgorithm(test.algorithm(dst, conf))
+			dstPeer := newMockSender("peer", "dtn://node/", cla.MTCP)
+			dst.claManager.Register(dstPeer)
+			dst.RegisterApplicationAgent(newMockAgent(bpv7.MustNewEndpointID("dtn://destination/app")))
+
+			b, err := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				Source("dtn://src/").
+				Destination("dtn://destination/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dstBp := NewBundleDescriptorFromBundle(b, dst.Store)
+			dst.routingAlgorithm().NotifyNewBundle(dstBp)
+			dst.localDelivery(dstBp)
+
+			// Besides the delivery acknowledgement, a status report might be sent.
+			var ack bpv7.Bundle
+			for deadline := time.Now().Add(time.Second); ack.PrimaryBlock.Destination != bpv7.MustNewEndpointID(sprayAckAddress); time.Sleep(10 * time.Millisecond) {
+				if time.Now().After(deadline) {
+					t.Fatal("no delivery acknowledgement was sent")
+				}
+
+				dstPeer.mutex.Lock()
+				for _, sent := range dstPeer.sent {
+					if sent.PrimaryBlock.Destination == bpv7.MustNewEndpointID(sprayAckAddress) {
+						ack = sent
+					}
+				}
+				dstPeer.mutex.Unlock()
+			}
+
+			ar, err := ack.AdministrativeRecord()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if da, ok := ar.(*bpv7.DeliveryAcknowledgement); !ok || da.RefBundle != b.ID() {
+				t.Fatalf("expected delivery acknowledgement for %v, got %v", b.ID(), ar)
+			}
+
+			// Another node drops its copy on receiving the acknowledgement and relays the acknowledgement.
+			c := newTestCore(t, "dtn://node/")
+			c.SetRoutingAlgorithm(test.algorithm(c, conf))
+			peer := newMockSender("other-peer", "dtn://other-peer/", cla.MTCP)
+			c.claManager.Register(peer)
+
+			bp := NewBundleDescriptorFromBundle(b, c.Store)
+			bp.AddConstraint(ForwardPending)
+			_ = bp.Sync()
+			c.routingAlgorithm().NotifyNewBundle(bp)
+
+			ackBp := NewBundleDescriptorFromBundle(ack, c.Store)
+			c.routingAlgorithm().NotifyNewBundle(ackBp)
+
+			if c.Store.KnowsBundle(b.ID()) {
+				t.Fatal("acknowledged bundle is still stored")
+			}
+
+			var bundleData map[bpv7.BundleID]sprayMetaData
+			switch algo := c.routingAlgorithm().(type) {
+			case *SprayAndWait:
+				bundleData = algo.bundleData
+			case *BinarySpray:
+				bundleData = algo.bundleData
+			}
+			if _, ok := bundleData[b.ID()]; ok {
+				t.Fatal("metadata of acknowledged bundle is still known")
+			}
+
+			if css, _ := c.routingAlgorithm().SenderForBundle(ackBp); len(css) != 1 || css[0] != cla.ConvergenceSender(peer) {
+				t.Fatalf("expected delivery acknowledgement to be relayed to the peer, got %v", css)
+			}
+
+			// A late copy of the acknowledged bundle is deleted.
+			lateBp := NewBundleDescriptorFromBundle(b, c.Store)
+			c.routingAlgorithm().NotifyNewBundle(lateBp)
+			if css, del := c.routingAlgorithm().SenderForBundle(lateBp); len(css) != 0 || !del {
+				t.Fatalf("expected late copy to be deleted, got %v, %t", css, del)
+			}
+		})
+	}
+}
+
+func TestStaticRouting(t *testing.T) {
+	c := newTestCoreConf(t, "dtn://node/", RoutingConf{
+		Algorithm: "static",
+		StaticConf: StaticRoutingConfig{
+			"gateway": "dtn://relay/",
+			"23":      "ipn:42.0",
+			"far":     "dtn://absent/",
+		},
+	})
+
+	relay := newMockSender("relay", "dtn://relay/", cla.MTCP)
+	direct := newMockSender("direct", "dtn://direct/", cla.MTCP)
+	ipnRelay := newMockSender("ipn-relay", "ipn:42.0", cla.MTCP)
+	for _, peer := range []*mockSender{relay, direct, ipnRelay} {
+		c.claManager.Register(peer)
+	}
+
+	tests := []struct {
+		destination string
+		sender      *mockSender
+	}{
+		{"dtn://gateway/app", relay},
+		{"ipn:23.1", ipnRelay},
+		{"dtn://direct/app", direct},
+		{"dtn://far/app", nil},
+		{"dtn://unknown/app", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.destination, func(t *testing.T) {
+			b, err := bpv7.Builder().
+				Source("dtn://src/").
+				Destination(test.destination).
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			css, del := c.routingAlgorithm().SenderForBundle(NewBundleDescriptorFromBundle(b, c.Store))
+			if test.sender == nil {
+				if len(css) != 0 || del {
+					t.Fatalf("expected no sender, got %v, %t", css, del)
+				}
+			} else if len(css) != 1 || css[0] != cla.ConvergenceSender(test.sender) || !del {
+				t.Fatalf("expected sender %v, got %v, %t", test.sender, css, del)
+			}
+		})
+	}
+}
+
+func TestStaticRoutingInvalidNextHop(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	if _, err := NewStaticRouting(c, StaticRoutingConfig{"gateway": "invalid"}); err == nil {
+		t.Fatal("invalid next hop was accepted")
+	}
+}
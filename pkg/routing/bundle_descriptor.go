@@ -18,25 +18,33 @@ import (
 
 // BundleDescriptor is a meta wrapper around a bpv7.Bundle to supply routing information without having to pass or
 // alter the original bpv7.Bundle.
+//
+// The Receiver is the local endpoint of the receiving CLA, while the PreviousNode is this CLA's peer, if known.
+//
+// Validated is set for a bpv7.Bundle which was already checked on its reception. Loading it from the store again, e.g.,
+// for forwarding, skips its CheckValid.
 type BundleDescriptor struct {
-	Id          bpv7.BundleID
-	Receiver    bpv7.EndpointID
-	Timestamp   time.Time
-	Constraints map[Constraint]bool
-	Tags        map[Tag]struct{}
+	Id           bpv7.BundleID
+	Receiver     bpv7.EndpointID
+	PreviousNode bpv7.EndpointID
+	Timestamp    time.Time
+	Constraints  map[Constraint]bool
+	Tags         map[Tag]struct{}
+	Validated    bool
 
 	bndl  *bpv7.Bundle
-	store *storage.Store
+	store storage.BundleStore
 }
 
 // NewBundleDescriptor for a bpv7.BundleID from a Store.
-func NewBundleDescriptor(bid bpv7.BundleID, store *storage.Store) BundleDescriptor {
+func NewBundleDescriptor(bid bpv7.BundleID, store storage.BundleStore) BundleDescriptor {
 	descriptor := BundleDescriptor{
-		Id:          bid,
-		Receiver:    bpv7.DtnNone(),
-		Timestamp:   time.Now(),
-		Constraints: make(map[Constraint]bool),
-		Tags:        make(map[Tag]struct{}),
+		Id:           bid,
+		Receiver:     bpv7.DtnNone(),
+		PreviousNode: bpv7.DtnNone(),
+		Timestamp:    time.Now(),
+		Constraints:  make(map[Constraint]bool),
+		Tags:         make(map[Tag]struct{}),
 
 		bndl:  nil,
 		store: store,
@@ -46,19 +54,25 @@ func NewBundleDescriptor(bid bpv7.BundleID, store *storage.Store) BundleDescript
 		if v, ok := bi.Properties["bundlepack/receiver"]; ok {
 			descriptor.Receiver = v.(bpv7.EndpointID)
 		}
+		if v, ok := bi.Properties["bundlepack/previous-node"]; ok {
+			descriptor.PreviousNode = v.(bpv7.EndpointID)
+		}
 		if v, ok := bi.Properties["bundlepack/timestamp"]; ok {
 			descriptor.Timestamp = v.(time.Time)
 		}
 		if v, ok := bi.Properties["bundlepack/constraints"]; ok {
 			descriptor.Constraints = v.(map[Constraint]bool)
 		}
+		if v, ok := bi.Properties["bundlepack/validated"]; ok {
+			descriptor.Validated = v.(bool)
+		}
 	}
 
 	return descriptor
 }
 
 // NewBundleDescriptorFromBundle for a bpv7.Bundle to be inserted into a Store.
-func NewBundleDescriptorFromBundle(b bpv7.Bundle, store *storage.Store) BundleDescriptor {
+func NewBundleDescriptorFromBundle(b bpv7.Bundle, store storage.BundleStore) BundleDescriptor {
 	descriptor := NewBundleDescriptor(b.ID(), store)
 	descriptor.bndl = &b
 
@@ -79,8 +93,10 @@ func (descriptor BundleDescriptor) Sync() error {
 			(descriptor.HasConstraint(ForwardPending) || descriptor.HasConstraint(Contraindicated))
 
 		bi.Properties["bundlepack/receiver"] = descriptor.Receiver
+		bi.Properties["bundlepack/previous-node"] = descriptor.PreviousNode
 		bi.Properties["bundlepack/timestamp"] = descriptor.Timestamp
 		bi.Properties["bundlepack/constraints"] = descriptor.Constraints
+		bi.Properties["bundlepack/validated"] = descriptor.Validated
 
 		log.WithFields(log.Fields{
 			"bundle":      descriptor.Id,
@@ -96,15 +112,24 @@ func (descriptor BundleDescriptor) Sync() error {
 	}
 }
 
-// Bundle returns this BundleDescriptor's internal bpv7.Bundle.
+// Bundle returns this BundleDescriptor's internal bpv7.Bundle. If necessary, it is loaded from the store, skipping the
+// CheckValid of a Validated one.
 func (descriptor *BundleDescriptor) Bundle() (*bpv7.Bundle, error) {
 	if descriptor.bndl != nil {
 		return descriptor.bndl, nil
 	}
 
-	if bi, err := descriptor.store.QueryId(descriptor.Id.Scrub()); err != nil {
+	bi, err := descriptor.store.QueryId(descriptor.Id.Scrub())
+	if err != nil {
 		return nil, err
-	} else if bndl, err := bi.Parts[0].Load(); err != nil {
+	}
+
+	load := bi.Parts[0].Load
+	if descriptor.Validated {
+		load = bi.Parts[0].LoadValidated
+	}
+
+	if bndl, err := load(); err != nil {
 		return nil, err
 	} else {
 		descriptor.bndl = &bndl
@@ -131,6 +156,11 @@ func (descriptor BundleDescriptor) HasReceiver() bool {
 	return !descriptor.Receiver.SameNode(bpv7.DtnNone())
 }
 
+// HasPreviousNode returns true if this BundleDescriptor has a PreviousNode value.
+func (descriptor BundleDescriptor) HasPreviousNode() bool {
+	return !descriptor.PreviousNode.SameNode(bpv7.DtnNone())
+}
+
 // HasConstraint returns true if the given constraint contains.
 func (descriptor BundleDescriptor) HasConstraint(c Constraint) bool {
 	_, ok := descriptor.Constraints[c]
@@ -190,7 +220,10 @@ func (descriptor *BundleDescriptor) UpdateBundleAge() (uint64, error) {
 		return 0, fmt.Errorf("no bundle age block exists")
 	}
 
-	age := ageBlock.Value.(*bpv7.BundleAgeBlock)
+	age, ok := ageBlock.Value.(*bpv7.BundleAgeBlock)
+	if !ok {
+		return 0, fmt.Errorf("bundle age block is encrypted")
+	}
 	return age.Increment(uint64(time.Since(descriptor.Timestamp)) / 1000), nil
 }
 
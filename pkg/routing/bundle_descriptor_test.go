@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestBundleDescriptorValidated(t *testing.T) {
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://src/").
+		Destination("dtn://dst/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadDigestBlock(bpv7.PayloadDigestSHA256).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Modifying the payload breaks the PayloadDigestBlock, which is only detected by CheckValid.
+	pb, err := b.PayloadBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb.Value = bpv7.NewPayloadBlock([]byte("modified payload"))
+
+	for _, validated := range []bool{false, true} {
+		t.Run(fmt.Sprintf("validated-%t", validated), func(t *testing.T) {
+			store := newTestCore(t, "dtn://node/").Store
+
+			bp := NewBundleDescriptorFromBundle(b, store)
+			bp.Validated = validated
+			bp.AddConstraint(ForwardPending)
+			if err := bp.Sync(); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded := NewBundleDescriptor(b.ID(), store)
+			if loaded.Validated != validated {
+				t.Fatalf("expected validated = %t, got %t", validated, loaded.Validated)
+			}
+			if _, err := loaded.Bundle(); (err == nil) != validated {
+				t.Fatalf("loading bundle resulted in %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// BundleRing is an in-memory ring buffer of the most recent serialized Bundles, intended to debug transient issues
+// without persistent logging. When being full, the oldest Bundle is evicted for each new one.
+//
+// A BundleRing might be attached to a Core by EnableBundleRing, capturing each dispatched Bundle. Its content can be
+// downloaded as a CBOR sequence of Bundles, either by WriteTo or by its http.Handler implementation.
+type BundleRing struct {
+	mutex   sync.Mutex
+	entries [][]byte
+	next    int
+	count   int
+}
+
+// NewBundleRing for a positive capacity of Bundles.
+func NewBundleRing(capacity int) (*BundleRing, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("bundle ring capacity %d is not positive", capacity)
+	}
+
+	return &BundleRing{entries: make([][]byte, capacity)}, nil
+}
+
+// Add a Bundle to the ring buffer, possibly evicting the oldest one.
+func (br *BundleRing) Add(b bpv7.Bundle) error {
+	var buff bytes.Buffer
+	if err := b.MarshalCbor(&buff); err != nil {
+		return err
+	}
+
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	br.entries[br.next] = buff.Bytes()
+	br.next = (br.next + 1) % len(br.entries)
+	if br.count < len(br.entries) {
+		br.count++
+	}
+	return nil
+}
+
+// Bundles returns the serialized Bundles, ordered from the oldest to the most recent one.
+func (br *BundleRing) Bundles() [][]byte {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	bundles := make([][]byte, 0, br.count)
+	start := (br.next - br.count + len(br.entries)) % len(br.entries)
+	for i := 0; i < br.count; i++ {
+		bundles = append(bundles, br.entries[(start+i)%len(br.entries)])
+	}
+	return bundles
+}
+
+// WriteTo writes all Bundles as a CBOR sequence, ordered from the oldest to the most recent one.
+func (br *BundleRing) WriteTo(w io.Writer) (n int64, err error) {
+	for _, data := range br.Bundles() {
+		var m int
+		m, err = w.Write(data)
+		n += int64(m)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ServeHTTP offers the Bundles as a downloadable CBOR sequence.
+func (br *BundleRing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/cbor-seq")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"bundles.cbor\"")
+
+	if _, err := br.WriteTo(w); err != nil {
+		log.WithError(err).Warn("Writing bundle ring to HTTP client erred")
+	}
+}
+
+// dispatchHook captures each dispatched Bundle.
+func (br *BundleRing) dispatchHook(bp BundleDescriptor) {
+	bndl, err := bp.Bundle()
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Bundle ring failed to load bundle")
+		return
+	}
+
+	if err := br.Add(*bndl); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Bundle ring failed to serialize bundle")
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// newRingTestBundle creates a distinct Bundle for testing purpose.
+func newRingTestBundle(t *testing.T, i int) bpv7.Bundle {
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source(fmt.Sprintf("dtn://node/app-%d", i)).
+		Destination("dtn://elsewhere/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte(fmt.Sprintf("bundle %d", i))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// parseBundleSequence parses a CBOR sequence of Bundles.
+func parseBundleSequence(t *testing.T, r io.Reader) (ids []bpv7.BundleID) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := bytes.NewBuffer(data)
+	for buff.Len() > 0 {
+		b, err := bpv7.ParseBundle(buff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, b.ID())
+	}
+	return
+}
+
+func TestBundleRingEviction(t *testing.T) {
+	if _, err := NewBundleRing(0); err == nil {
+		t.Fatal("bundle ring without capacity was created")
+	}
+
+	br, err := NewBundleRing(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bundles []bpv7.Bundle
+	for i := 0; i < 5; i++ {
+		b := newRingTestBundle(t, i)
+		bundles = append(bundles, b)
+
+		if err := br.Add(b); err != nil {
+			t.Fatal(err)
+		}
+
+		// The buffer fills up until reaching its capacity, evicting the oldest Bundles afterwards.
+		expected := bundles
+		if len(expected) > 3 {
+			expected = expected[len(expected)-3:]
+		}
+
+		var buff bytes.Buffer
+		if _, err := br.WriteTo(&buff); err != nil {
+			t.Fatal(err)
+		}
+
+		ids := parseBundleSequence(t, &buff)
+		if len(ids) != len(expected) {
+			t.Fatalf("bundle ring holds %d bundles, expected %d", len(ids), len(expected))
+		}
+		for j, id := range ids {
+			if id != expected[j].ID() {
+				t.Fatalf("bundle ring's entry %d is %v, expected %v", j, id, expected[j].ID())
+			}
+		}
+	}
+}
+
+func TestCoreBundleRing(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	c.claManager.Register(newMockSender("peer", "dtn://peer/", cla.MTCP))
+
+	br, err := c.EnableBundleRing(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bundles []bpv7.Bundle
+	for i := 0; i < 3; i++ {
+		b := newRingTestBundle(t, i)
+		bundles = append(bundles, b)
+
+		c.dispatching(NewBundleDescriptorFromBundle(b, c.Store))
+	}
+
+	srv := httptest.NewServer(br)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	ids := parseBundleSequence(t, resp.Body)
+	if len(ids) != 2 || ids[0] != bundles[1].ID() || ids[1] != bundles[2].ID() {
+		t.Fatalf("bundle ring holds %v, expected the two most recent bundles", ids)
+	}
+}
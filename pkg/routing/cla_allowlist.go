@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// CLAAllowlistRule restricts the forwarding of Bundles with a matching destination to certain CLA types.
+type CLAAllowlistRule struct {
+	// Destination is a regular expression, matched against a Bundle's destination, e.g., "^dtn://sensitive/".
+	Destination string
+
+	// CLATypes are the permitted CLA types, named as for the CLA's configured protocol, e.g., "tcpclv4" or "mtcp".
+	CLATypes []string `toml:"cla-types"`
+}
+
+// claAllowlistEntry is the parsed representation of a CLAAllowlistRule.
+type claAllowlistEntry struct {
+	destination *regexp.Regexp
+	claTypes    map[cla.CLAType]bool
+}
+
+// claAllowlist limits the ConvergenceSenders a Bundle might be forwarded over. The first rule matching a Bundle's
+// destination applies. Bundles without a matching rule are not restricted.
+type claAllowlist []claAllowlistEntry
+
+// newCLAAllowlist from its configuration rules.
+func newCLAAllowlist(rules []CLAAllowlistRule) (cal claAllowlist, err error) {
+	for _, rule := range rules {
+		var entry claAllowlistEntry
+
+		if entry.destination, err = regexp.Compile(rule.Destination); err != nil {
+			return nil, fmt.Errorf("CLA allowlist destination \"%s\" is invalid: %v", rule.Destination, err)
+		}
+
+		entry.claTypes = make(map[cla.CLAType]bool)
+		for _, name := range rule.CLATypes {
+			if claType, claTypeErr := cla.ParseCLAType(name); claTypeErr != nil {
+				return nil, fmt.Errorf("CLA allowlist for \"%s\" is invalid: %v", rule.Destination, claTypeErr)
+			} else {
+				entry.claTypes[claType] = true
+			}
+		}
+
+		cal = append(cal, entry)
+	}
+	return
+}
+
+// filter the ConvergenceSenders for a Bundle. ConvergenceSenders of an unknown CLA type are excluded for restricted
+// Bundles.
+func (cal claAllowlist) filter(bp BundleDescriptor, css []cla.ConvergenceSender) []cla.ConvergenceSender {
+	if len(cal) == 0 || len(css) == 0 {
+		return css
+	}
+
+	destination := bp.MustBundle().PrimaryBlock.Destination.String()
+
+	for _, entry := range cal {
+		if !entry.destination.MatchString(destination) {
+			continue
+		}
+
+		var filtered []cla.ConvergenceSender
+		for _, cs := range css {
+			if claType, ok := cla.GetCLAType(cs); ok && entry.claTypes[claType] {
+				filtered = append(filtered, cs)
+			} else {
+				log.WithFields(log.Fields{
+					"bundle":             bp.ID().String(),
+					"convergence-sender": cs,
+				}).Debug("CLA allowlist excludes Convergence Sender for Bundle forwarding")
+			}
+		}
+		return filtered
+	}
+
+	return css
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestNewCLAAllowlistInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []CLAAllowlistRule
+	}{
+		{"invalid regexp", []CLAAllowlistRule{{Destination: "^dtn://(", CLATypes: []string{"mtcp"}}}},
+		{"unknown CLA type", []CLAAllowlistRule{{Destination: "^dtn://", CLATypes: []string{"carrier-pigeon"}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := newCLAAllowlist(test.rules); err == nil {
+				t.Fatal("invalid rules were accepted")
+			}
+		})
+	}
+}
+
+func TestCoreCLAAllowlist(t *testing.T) {
+	c := newTestCoreConf(t, "dtn://node/", RoutingConf{
+		Algorithm: "epidemic",
+		CLAAllowlist: []CLAAllowlistRule{
+			{Destination: "^dtn://sensitive/", CLATypes: []string{"tcpclv4", "mtcp"}},
+			{Destination: "^dtn://radio/", CLATypes: []string{"bbc"}},
+		},
+	})
+
+	tcpcl := newMockSender("tcpcl", "dtn://peer-a/", cla.TCPCLv4)
+	mtcp := newMockSender("mtcp", "dtn://peer-b/", cla.MTCP)
+	quicl := newMockSender("quicl", "dtn://peer-c/", cla.QUICL)
+	for _, cs := range []*mockSender{tcpcl, mtcp, quicl} {
+		c.claManager.Register(cs)
+	}
+
+	tests := []struct {
+		destination string
+		tcpcl       int
+		mtcp        int
+		quicl       int
+	}{
+		{"dtn://sensitive/inbox", 1, 1, 0},
+		{"dtn://radio/inbox", 1, 1, 0},
+		{"dtn://public/inbox", 2, 2, 1},
+	}
+
+	for _, test := range tests {
+		bndl, err := bpv7.Builder().
+			Source("dtn://node/app").
+			Destination(test.destination).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello world")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.forward(NewBundleDescriptorFromBundle(bndl, c.Store))
+
+		if n := tcpcl.sentBundles(); n != test.tcpcl {
+			t.Fatalf("%s: TCPCLv4 sent %d bundles, expected %d", test.destination, n, test.tcpcl)
+		}
+		if n := mtcp.sentBundles(); n != test.mtcp {
+			t.Fatalf("%s: MTCP sent %d bundles, expected %d", test.destination, n, test.mtcp)
+		}
+		if n := quicl.sentBundles(); n != test.quicl {
+			t.Fatalf("%s: QUICL sent %d bundles, expected %d", test.destination, n, test.quicl)
+		}
+	}
+}
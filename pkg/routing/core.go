@@ -9,6 +9,9 @@ import (
 	"crypto/ed25519"
 	"encoding/gob"
 	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -25,14 +28,51 @@ type Core struct {
 	InspectAllBundles bool
 	NodeId            bpv7.EndpointID
 
-	agentManager *AgentManager
-	Cron         *Cron
-	claManager   *cla.Manager
-	IdKeeper     IdKeeper
-	routing      Algorithm
-	signPriv     ed25519.PrivateKey
-
-	Store *storage.Store
+	agentManager       *AgentManager
+	Cron               *Cron
+	claManager         *cla.Manager
+	IdKeeper           IdKeeper
+	routing            Algorithm
+	routingMutex       sync.RWMutex
+	claAllowlist       claAllowlist
+	sourceVerification sourceVerification
+	forwardBatcher     *forwardBatcher
+	forwardWg          sync.WaitGroup
+	maxHoldTime        time.Duration
+	suppressPrevNode   bool
+	traceBlockLimit    int
+	signPriv           ed25519.PrivateKey
+	identityKeys       map[bpv7.EndpointID]ed25519.PublicKey
+	deadLetter         bpv7.EndpointID
+	securityKeys       SecurityKeyStore
+	dispatchHooks      []DispatchHook
+	hooksMutex         sync.RWMutex
+	ndjsonSinks        []*NDJSONSink
+	reassembler        *bpv7.Reassembler
+	maxDecompressed    uint64
+
+	// duplicates counts the receptions of already known Bundles, which are reported to a DuplicateAwareAlgorithm for
+	// reportDuplicates.
+	duplicates       uint64
+	duplicatesMutex  sync.Mutex
+	reportDuplicates bool
+
+	// disposedBundles remembers disposed Bundles to suppress them as duplicates, compare RoutingConf's
+	// DuplicateSuppression.
+	disposedBundles *disposedBundles
+
+	// unknownEndpoint* configure the handling of Bundles for local endpoints without a registered application agent.
+	unknownEndpointPolicy   unknownEndpointPolicy
+	unknownEndpointBuffer   time.Duration
+	unknownEndpointCatchAll bpv7.EndpointID
+
+	// provisionalNeighbors were discovered and reported to a NeighborAwareAlgorithm, but are not yet connected.
+	provisionalNeighbors *provisionalNeighbors
+
+	costMetrics      map[bpv7.CostMetricType]CostMetric
+	costMetricsMutex sync.RWMutex
+
+	Store storage.BundleStore
 
 	stopSyn chan struct{}
 	stopAck chan struct{}
@@ -46,6 +86,16 @@ type Core struct {
 //	routingConf: selected routing algorithm and its configuration
 //	signPriv: optional ed25519 private key (64 bytes long) to sign all outgoing bundles; or nil to not use this feature
 func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, routingConf RoutingConf, signPriv ed25519.PrivateKey) (*Core, error) {
+	store, err := storage.NewStore(storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCoreWithStore(store, nodeId, inspectAllBundles, routingConf, signPriv)
+}
+
+// NewCoreWithStore is NewCore with an already opened storage.BundleStore, e.g., a storage.BBoltStore.
+func NewCoreWithStore(store storage.BundleStore, nodeId bpv7.EndpointID, inspectAllBundles bool, routingConf RoutingConf, signPriv ed25519.PrivateKey) (*Core, error) {
 	var c = new(Core)
 
 	gob.Register([]bpv7.EndpointID{})
@@ -61,18 +111,35 @@ func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, r
 	}
 	c.InspectAllBundles = inspectAllBundles
 	c.NodeId = nodeId
-
-	if store, err := storage.NewStore(storePath); err != nil {
-		return nil, err
-	} else {
-		c.Store = store
-	}
+	c.deadLetter = bpv7.DtnNone()
+	c.Store = store
 
 	c.agentManager = NewAgentManager(c)
 
-	c.claManager = cla.NewManager()
+	c.claManager = cla.NewManager(cla.BackoffConfig{})
+
+	c.IdKeeper = NewIdKeeperWithMaxSequence(routingConf.MaxSequenceNumber)
 
-	c.IdKeeper = NewIdKeeper()
+	c.provisionalNeighbors = newProvisionalNeighbors(provisionalNeighborTimeout)
+
+	reassemblerLimits := bpv7.ReassemblerLimits{MaxMemory: routingConf.ReassemblyMaxMemory}
+	if routingConf.ReassemblyTimeout != "" {
+		if timeout, timeoutErr := time.ParseDuration(routingConf.ReassemblyTimeout); timeoutErr != nil {
+			return nil, fmt.Errorf("reassembly timeout \"%s\" is invalid: %v", routingConf.ReassemblyTimeout, timeoutErr)
+		} else if timeout <= 0 {
+			return nil, fmt.Errorf("reassembly timeout \"%s\" is not positive", routingConf.ReassemblyTimeout)
+		} else {
+			reassemblerLimits.Timeout = timeout
+		}
+	}
+	c.reassembler = bpv7.NewReassemblerWithLimits(reassemblerLimits)
+
+	c.maxDecompressed = routingConf.MaxDecompressedLength
+
+	c.costMetrics = make(map[bpv7.CostMetricType]CostMetric)
+	for _, metric := range []CostMetric{BytesCostMetric{}, DwellTimeCostMetric{}} {
+		c.RegisterCostMetric(metric)
+	}
 
 	if ra, raErr := routingConf.RoutingAlgorithm(c); raErr != nil {
 		return nil, raErr
@@ -80,6 +147,65 @@ func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, r
 		c.routing = ra
 	}
 
+	if cal, calErr := newCLAAllowlist(routingConf.CLAAllowlist); calErr != nil {
+		return nil, calErr
+	} else {
+		c.claAllowlist = cal
+	}
+
+	if sv, svErr := newSourceVerification(routingConf.SourceVerification); svErr != nil {
+		return nil, svErr
+	} else {
+		c.sourceVerification = sv
+	}
+
+	if routingConf.ForwardingDelay != "" {
+		if delay, delayErr := time.ParseDuration(routingConf.ForwardingDelay); delayErr != nil {
+			return nil, fmt.Errorf("forwarding delay \"%s\" is invalid: %v", routingConf.ForwardingDelay, delayErr)
+		} else if delay < 0 {
+			return nil, fmt.Errorf("forwarding delay \"%s\" is negative", routingConf.ForwardingDelay)
+		} else if delay > 0 {
+			c.forwardBatcher = newForwardBatcher(delay)
+		}
+	}
+
+	if routingConf.MaxHoldTime != "" {
+		if holdTime, holdErr := time.ParseDuration(routingConf.MaxHoldTime); holdErr != nil {
+			return nil, fmt.Errorf("max hold time \"%s\" is invalid: %v", routingConf.MaxHoldTime, holdErr)
+		} else if holdTime <= 0 {
+			return nil, fmt.Errorf("max hold time \"%s\" is not positive", routingConf.MaxHoldTime)
+		} else {
+			c.maxHoldTime = holdTime
+		}
+	}
+
+	switch routingConf.DuplicatePolicy {
+	case "", "ignore":
+		c.reportDuplicates = false
+	case "report":
+		c.reportDuplicates = true
+	default:
+		return nil, fmt.Errorf("unknown duplicate policy %s", routingConf.DuplicatePolicy)
+	}
+
+	if db, dbErr := newDisposedBundles(routingConf); dbErr != nil {
+		return nil, dbErr
+	} else {
+		c.disposedBundles = db
+	}
+
+	if err := c.setUnknownEndpointPolicy(routingConf); err != nil {
+		return nil, err
+	}
+
+	c.suppressPrevNode = routingConf.SuppressPreviousNode
+
+	if routingConf.TraceBlockLimit < 0 || routingConf.TraceBlockLimit > bpv7.MaxTraceBlockLength {
+		return nil, fmt.Errorf("trace block limit %d is not within [0, %d]",
+			routingConf.TraceBlockLimit, bpv7.MaxTraceBlockLength)
+	}
+	c.traceBlockLimit = routingConf.TraceBlockLimit
+
 	if signPriv != nil {
 		if l := len(signPriv); l != ed25519.PrivateKeySize {
 			return nil, fmt.Errorf("ed25519 private key's length is %d, not %d", l, ed25519.PrivateKeySize)
@@ -100,13 +226,183 @@ func NewCore(storePath string, nodeId bpv7.EndpointID, inspectAllBundles bool, r
 }
 
 // SetRoutingAlgorithm overwrites the used Algorithm, which defaults to
-// EpidemicRouting.
+// EpidemicRouting. This might also happen at runtime.
+//
+// Before being activated, the new Algorithm is notified about all pending
+// bundles and all currently known peers to build up its own state. Metadata
+// stored by the previous Algorithm is left untouched, but no longer used.
 func (c *Core) SetRoutingAlgorithm(routing Algorithm) {
+	if bis, err := c.Store.QueryPending(); err != nil {
+		log.WithError(err).Warn("Failed to fetch pending bundles for the new routing algorithm")
+	} else {
+		for _, bi := range bis {
+			routing.NotifyNewBundle(NewBundleDescriptor(bi.BId, c.Store))
+		}
+	}
+
+	for _, cs := range c.senders() {
+		routing.ReportPeerAppeared(cs)
+	}
+
+	c.routingMutex.Lock()
+	previous := c.routing
 	c.routing = routing
+	c.routingMutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"previous": previous,
+		"routing":  routing,
+	}).Info("Switched routing algorithm")
+}
+
+// SetIdentityKeys configures the ed25519 public keys of known source nodes. Bundles from those nodes are only delivered
+// locally if they carry an IdentityAssertionBlock which was signed by the node's key. Bundles from other nodes are not
+// affected. This should be configured before any bundles are processed.
+func (c *Core) SetIdentityKeys(identityKeys map[bpv7.EndpointID]ed25519.PublicKey) {
+	c.identityKeys = identityKeys
+}
+
+// SetDeadLetterEndpoint configures a local endpoint, receiving a copy of each bundle which is about to be dropped,
+// e.g., because it is undeliverable, expired, or exceeded its hop limit. Thus, an operator's application might
+// inspect those bundles. The dropped bundle is CBOR encoded as the payload of a new bundle from this node. Passing
+// dtn:none disables this feature, which is the default.
+func (c *Core) SetDeadLetterEndpoint(eid bpv7.EndpointID) {
+	c.deadLetter = eid
+}
+
+// SetCLAReceiveQueue configures a bounded receive queue for each subsequently registered CLA, see
+// cla.ReceiveQueueConfig. Thus, a slow Core does not stall a fast link.
+func (c *Core) SetCLAReceiveQueue(conf cla.ReceiveQueueConfig) error {
+	return c.claManager.SetReceiveQueue(conf)
+}
+
+// SetCLAPreference configures an ordered list of CLATypes, starting with the most preferred one. For peers reachable
+// by multiple CLAs, only the ConvergenceSender of the most preferred CLAType is used, see cla.Manager.
+func (c *Core) SetCLAPreference(preference []cla.CLAType) {
+	c.claManager.SetCLAPreference(preference)
+}
+
+// CLAReceiveQueueStats returns the receive queue's stats for each active CLA, identified by its address.
+func (c *Core) CLAReceiveQueueStats() map[string]cla.ReceiveQueueStats {
+	return c.claManager.ReceiveQueueStats()
+}
+
+// DuplicateReceptions returns the amount of received Bundles which were already known.
+func (c *Core) DuplicateReceptions() uint64 {
+	c.duplicatesMutex.Lock()
+	defer c.duplicatesMutex.Unlock()
+
+	return c.duplicates
+}
+
+// CLAMetrics returns a snapshot of the CLA Manager's metrics, e.g., the amount of active and inactive CLAs.
+func (c *Core) CLAMetrics() cla.ManagerMetrics {
+	return c.claManager.Metrics()
+}
+
+// NodeStatus returns this node's current status, e.g., to be replied by an agent.StatusAgent.
+func (c *Core) NodeStatus() agent.NodeStatus {
+	claMetrics := c.CLAMetrics()
+
+	storeSize := 0
+	if bis, err := c.Store.QueryAll(); err == nil {
+		storeSize = len(bis)
+	}
+
+	neighbors := make(map[string]struct{})
+	for _, cs := range c.senders() {
+		neighbors[cs.GetPeerEndpointID().Authority()] = struct{}{}
+	}
+
+	return agent.NodeStatus{
+		NodeId:          c.NodeId.String(),
+		Version:         buildVersion(),
+		Time:            time.Now(),
+		ActiveSenders:   claMetrics.ActiveSenders,
+		ActiveReceivers: claMetrics.ActiveReceivers,
+		StoreSize:       storeSize,
+		Neighbors:       len(neighbors),
+	}
+}
+
+// buildVersion returns the main module's version from the binary's build information.
+func buildVersion() string {
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" {
+		return bi.Main.Version
+	}
+	return "unknown"
+}
+
+// DispatchHook is called for each Bundle being dispatched, before it is either delivered locally or forwarded. A hook
+// must not modify the Bundle.
+type DispatchHook func(bp BundleDescriptor)
+
+// AddDispatchHook registers a DispatchHook, called for all subsequently dispatched Bundles.
+func (c *Core) AddDispatchHook(hook DispatchHook) {
+	c.hooksMutex.Lock()
+	defer c.hooksMutex.Unlock()
+
+	c.dispatchHooks = append(c.dispatchHooks, hook)
+}
+
+// EnableBundleRing captures the last capacity dispatched Bundles in a BundleRing, which is returned for downloading.
+func (c *Core) EnableBundleRing(capacity int) (*BundleRing, error) {
+	br, err := NewBundleRing(capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	c.AddDispatchHook(br.dispatchHook)
+	return br, nil
+}
+
+// EnableNDJSONSink appends the metadata of each newly received, locally delivered, and forwarded Bundle as
+// newline-delimited JSON to a rotating file, configured by the NDJSONSinkConfig. Retries of pending Bundles are not
+// recorded. The NDJSONSink is closed together with this Core.
+func (c *Core) EnableNDJSONSink(conf NDJSONSinkConfig) (*NDJSONSink, error) {
+	sink, err := NewNDJSONSink(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	c.hooksMutex.Lock()
+	c.ndjsonSinks = append(c.ndjsonSinks, sink)
+	c.hooksMutex.Unlock()
+
+	return sink, nil
+}
+
+// recordNDJSON writes a Bundle's NDJSONRecord for an NDJSONEvent to all enabled NDJSONSinks.
+func (c *Core) recordNDJSON(bp BundleDescriptor, event NDJSONEvent) {
+	c.hooksMutex.RLock()
+	defer c.hooksMutex.RUnlock()
+
+	for _, sink := range c.ndjsonSinks {
+		sink.record(bp, event)
+	}
+}
+
+// runDispatchHooks calls all registered DispatchHooks for a Bundle.
+func (c *Core) runDispatchHooks(bp BundleDescriptor) {
+	c.hooksMutex.RLock()
+	defer c.hooksMutex.RUnlock()
+
+	for _, hook := range c.dispatchHooks {
+		hook(bp)
+	}
+}
+
+// routingAlgorithm returns the currently used Algorithm.
+func (c *Core) routingAlgorithm() Algorithm {
+	c.routingMutex.RLock()
+	defer c.routingMutex.RUnlock()
+
+	return c.routing
 }
 
 // CheckPendingBundles queries pending bundle (packs) from the store and
-// tries to dispatch them.
+// tries to dispatch them. Contraindicated bundles exceeding the max hold time
+// are deleted instead.
 func (c *Core) CheckPendingBundles() {
 	if bis, err := c.Store.QueryPending(); err != nil {
 		log.WithFields(log.Fields{
@@ -118,11 +414,81 @@ func (c *Core) CheckPendingBundles() {
 				"bundle": bi.Id,
 			}).Info("Retrying bundle from store")
 
-			c.dispatching(NewBundleDescriptor(bi.BId, c.Store))
+			bp := NewBundleDescriptor(bi.BId, c.Store)
+			if c.isHoldTimeExceeded(bp) {
+				log.WithFields(log.Fields{
+					"bundle":    bp.ID().String(),
+					"hold_time": c.maxHoldTime,
+				}).Info("Contraindicated bundle exceeded the max hold time")
+
+				c.bundleDeletion(bp, bpv7.NoNextNodeContact)
+				continue
+			}
+
+			c.dispatching(bp)
+		}
+	}
+}
+
+// DeleteExpiredBundles sweeps through all stored bundles and deletes those whose lifetime is exceeded. A DeletedBundle
+// status report is sent for each bundle requesting one. Otherwise, bundles nobody touches would stay in the store
+// until being retried or forwarded. Timed out reassemblies are discarded as well. This should be registered at the
+// Cron.
+func (c *Core) DeleteExpiredBundles() {
+	c.expireReassemblies()
+
+	bis, err := c.Store.QueryAll()
+	if err != nil {
+		log.WithError(err).Warn("Failed to fetch stored bundles for deleting expired ones")
+		return
+	}
+
+	now := time.Now()
+	for _, bi := range bis {
+		bp := NewBundleDescriptor(bi.BId, c.Store)
+		logger := log.WithField("bundle", bi.Id)
+
+		if b, bErr := bp.Bundle(); bErr != nil {
+			// Bundles which cannot be loaded are deleted based on their stored expiry time, without a status report.
+			if bi.Expires.Before(now) {
+				logger.WithError(bErr).Info("Deleting expired bundle which cannot be loaded")
+				_ = c.Store.Delete(bi.BId)
+			}
+			continue
+		} else if !b.IsLifetimeExceeded() {
+			continue
+		}
+
+		logger.Info("Stored bundle's lifetime is exceeded")
+		c.bundleDeletion(bp, bpv7.LifetimeExpired)
+
+		// Locally delivered bundles are kept by bundleDeletion, but there is nothing left to do for an expired one.
+		if err := c.Store.Delete(bi.BId); err != nil {
+			logger.WithError(err).Warn("Failed to delete expired bundle")
 		}
 	}
 }
 
+// QueryDestination returns the BundleDescriptors of all stored bundles addressed to an endpoint, based on the store's
+// destination index.
+func (c *Core) QueryDestination(eid bpv7.EndpointID) ([]BundleDescriptor, error) {
+	bis, err := c.Store.QueryDestination(eid)
+	if err != nil {
+		return nil, err
+	}
+
+	bps := make([]BundleDescriptor, len(bis))
+	for i, bi := range bis {
+		bps[i] = NewBundleDescriptor(bi.BId, c.Store)
+	}
+	return bps, nil
+}
+
+// isHoldTimeExceeded checks if a contraindicated bundle was held longer than the optional max hold time.
+func (c *Core) isHoldTimeExceeded(bp BundleDescriptor) bool {
+	return c.maxHoldTime > 0 && bp.HasConstraint(Contraindicated) && time.Since(bp.Timestamp) > c.maxHoldTime
+}
+
 // handler does the Core's background tasks
 func (c *Core) handler() {
 	for {
@@ -130,11 +496,23 @@ func (c *Core) handler() {
 		// Invoked by Close(), shuts down
 		case <-c.stopSyn:
 			c.Cron.Stop()
+			c.provisionalNeighbors.close()
 
 			if err := c.claManager.Close(); err != nil {
 				log.WithError(err).Warn("Closing CLA Manager while shutting down erred")
 			}
 
+			// Wait for batched forwarding to finish before closing the store.
+			c.forwardWg.Wait()
+
+			c.hooksMutex.RLock()
+			for _, sink := range c.ndjsonSinks {
+				if err := sink.Close(); err != nil {
+					log.WithError(err).Warn("Closing NDJSON sink while shutting down erred")
+				}
+			}
+			c.hooksMutex.RUnlock()
+
 			if err := c.Store.Close(); err != nil {
 				log.WithError(err).Warn("Closing store while shutting down erred")
 			}
@@ -148,18 +526,26 @@ func (c *Core) handler() {
 			case cla.ReceivedBundle:
 				crb := cs.Message.(cla.ConvergenceReceivedBundle)
 
+				// The CLA has already validated the received bundle while parsing it.
 				bp := NewBundleDescriptorFromBundle(*crb.Bundle, c.Store)
 				bp.Receiver = crb.Endpoint
+				bp.Validated = true
+				if peer, ok := cs.Sender.(cla.ConvergenceSender); ok {
+					bp.PreviousNode = peer.GetPeerEndpointID()
+				}
 				_ = bp.Sync()
 
 				c.receive(bp)
 
 			case cla.PeerAppeared:
-				c.routing.ReportPeerAppeared(cs.Sender)
+				if peer, ok := cs.Sender.(cla.ConvergenceSender); ok {
+					c.provisionalNeighbors.confirm(peer.GetPeerEndpointID())
+				}
+				c.routingAlgorithm().ReportPeerAppeared(cs.Sender)
 				c.CheckPendingBundles()
 
 			case cla.PeerDisappeared:
-				c.routing.ReportPeerDisappeared(cs.Sender)
+				c.routingAlgorithm().ReportPeerDisappeared(cs.Sender)
 
 			default:
 				log.WithFields(log.Fields{
@@ -184,11 +570,32 @@ func (c *Core) RegisterApplicationAgent(app agent.ApplicationAgent) {
 	c.agentManager.Register(app)
 }
 
+// SetPayloadOnlyDelivery configures an endpoint to receive only the payload and minimal metadata of its Bundles as an
+// agent.PayloadMessage instead of the whole Bundle.
+func (c *Core) SetPayloadOnlyDelivery(eid bpv7.EndpointID, payloadOnly bool) {
+	c.agentManager.SetPayloadOnly(eid, payloadOnly)
+}
+
+// senders returns all active ConvergenceSenders, ordered by their peer's endpoint ID and their address. This order
+// serves as a deterministic tie-breaker for routing algorithms iterating over equally suited ConvergenceSenders.
+// If a CLA preference is configured, only each peer's preferred ConvergenceSender is returned.
+func (c *Core) senders() (css []cla.ConvergenceSender) {
+	css = c.claManager.PreferredSenders()
+	sort.SliceStable(css, func(i, j int) bool {
+		iEid, jEid := css[i].GetPeerEndpointID().String(), css[j].GetPeerEndpointID().String()
+		if iEid != jEid {
+			return iEid < jEid
+		}
+		return css[i].Address() < css[j].Address()
+	})
+	return
+}
+
 // senderForDestination returns an array of ConvergenceSenders whose endpoint ID
 // equals the requested one. This is used for direct delivery, comparing the
 // PrimaryBlock's destination to the assigned endpoint ID of each CLA.
 func (c *Core) senderForDestination(endpoint bpv7.EndpointID) (css []cla.ConvergenceSender) {
-	for _, cs := range c.claManager.Sender() {
+	for _, cs := range c.senders() {
 		if cs.GetPeerEndpointID().SameNode(endpoint) {
 			css = append(css, cs)
 		}
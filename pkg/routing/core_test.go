@@ -0,0 +1,1529 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// mockAgent is a trivial ApplicationAgent, collecting all received Messages for later inspection.
+type mockAgent struct {
+	endpoints []bpv7.EndpointID
+	receiver  chan agent.Message
+	sender    chan agent.Message
+	inbox     chan agent.Message
+}
+
+func newMockAgent(endpoints ...bpv7.EndpointID) *mockAgent {
+	m := &mockAgent{
+		endpoints: endpoints,
+		receiver:  make(chan agent.Message),
+		sender:    make(chan agent.Message),
+		inbox:     make(chan agent.Message, 64),
+	}
+
+	go func() {
+		for msg := range m.receiver {
+			if _, isShutdown := msg.(agent.ShutdownMessage); isShutdown {
+				close(m.sender)
+				return
+			}
+			m.inbox <- msg
+		}
+	}()
+
+	return m
+}
+
+func (m *mockAgent) Endpoints() []bpv7.EndpointID        { return m.endpoints }
+func (m *mockAgent) MessageReceiver() chan agent.Message { return m.receiver }
+func (m *mockAgent) MessageSender() chan agent.Message   { return m.sender }
+
+// received returns the next BundleMessage's Bundle or false after a timeout. Other Messages are skipped.
+func (m *mockAgent) received(timeout time.Duration) (bpv7.Bundle, bool) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case msg := <-m.inbox:
+			if bm, ok := msg.(agent.BundleMessage); ok {
+				return bm.Bundle, true
+			}
+
+		case <-deadline:
+			return bpv7.Bundle{}, false
+		}
+	}
+}
+
+// mockSender is a ConvergenceSender of a specific CLA type, collecting all sent Bundles.
+type mockSender struct {
+	address string
+	peer    bpv7.EndpointID
+	claType cla.CLAType
+	sendErr error
+
+	reportChan chan cla.ConvergenceStatus
+
+	mutex  sync.Mutex
+	sent   []bpv7.Bundle
+	sentAt []time.Time
+}
+
+func newMockSender(address string, peer string, claType cla.CLAType) *mockSender {
+	return &mockSender{
+		address:    address,
+		peer:       bpv7.MustNewEndpointID(peer),
+		claType:    claType,
+		reportChan: make(chan cla.ConvergenceStatus),
+	}
+}
+
+func (m *mockSender) Start() (error, bool)                { return nil, false }
+func (m *mockSender) Close() error                        { return nil }
+func (m *mockSender) Channel() chan cla.ConvergenceStatus { return m.reportChan }
+func (m *mockSender) Address() string                     { return m.address }
+func (m *mockSender) IsPermanent() bool                   { return true }
+func (m *mockSender) GetPeerEndpointID() bpv7.EndpointID  { return m.peer }
+func (m *mockSender) GetCLAType() cla.CLAType             { return m.claType }
+func (m *mockSender) String() string                      { return m.address }
+
+func (m *mockSender) Send(b bpv7.Bundle) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+
+	m.sent = append(m.sent, b)
+	m.sentAt = append(m.sentAt, time.Now())
+	return nil
+}
+
+// sentBundles returns the amount of Bundles sent over this mockSender.
+func (m *mockSender) sentBundles() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.sent)
+}
+
+// sentTimes returns the points in time of each Send call.
+func (m *mockSender) sentTimes() []time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]time.Time(nil), m.sentAt...)
+}
+
+// newTestCore creates a Core with epidemic routing for testing purpose, backed by a temporary store.
+func newTestCore(t *testing.T, nodeId string) *Core {
+	return newTestCoreConf(t, nodeId, RoutingConf{Algorithm: "epidemic"})
+}
+
+// newTestCoreConf creates a Core for testing purpose with a specific RoutingConf, backed by a temporary store.
+func newTestCoreConf(t *testing.T, nodeId string, routingConf RoutingConf) *Core {
+	c, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID(nodeId), false, routingConf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Cron = NewCron()
+
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestCoreAdministrativeEndpoint(t *testing.T) {
+	c := newTestCore(t, "ipn:23.0")
+
+	appAgent := newMockAgent(bpv7.MustNewEndpointID("ipn:23.0"), bpv7.MustNewEndpointID("ipn:23.42"))
+	c.RegisterApplicationAgent(appAgent)
+
+	refBndl, err := bpv7.Builder().
+		Source("ipn:23.42").
+		Destination("ipn:42.1").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adminBndl, err := bpv7.Builder().
+		Source("ipn:42.0").
+		Destination("ipn:23.0").
+		CreationTimestampNow().
+		Lifetime("10m").
+		StatusReport(refBndl, bpv7.ReceivedBundle, bpv7.NoInformation).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appBndl, err := bpv7.Builder().
+		Source("ipn:42.1").
+		Destination("ipn:23.42").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello agent")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.localDelivery(NewBundleDescriptorFromBundle(adminBndl, c.Store))
+	if b, ok := appAgent.received(250 * time.Millisecond); ok {
+		t.Fatalf("administrative bundle %v was delivered to an application agent", b)
+	}
+	if c.Store.KnowsBundle(adminBndl.ID()) {
+		t.Fatal("administrative bundle is still stored after being handled")
+	}
+
+	c.localDelivery(NewBundleDescriptorFromBundle(appBndl, c.Store))
+	if b, ok := appAgent.received(time.Second); !ok {
+		t.Fatal("application bundle was not delivered to the application agent")
+	} else if b.ID() != appBndl.ID() {
+		t.Fatalf("expected bundle %v, got %v", appBndl.ID(), b.ID())
+	}
+}
+
+func TestCoreCompressedPayloadDelivery(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/app"))
+	c.RegisterApplicationAgent(appAgent)
+
+	payload := bytes.Repeat([]byte("hello agent "), 32)
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://peer/app").
+		Destination("dtn://node/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	} else if err := bpv7.CompressPayload(&b, bpv7.CompressionGzip); err != nil {
+		t.Fatal(err)
+	}
+
+	c.localDelivery(NewBundleDescriptorFromBundle(b, c.Store))
+
+	if delivered, ok := appAgent.received(time.Second); !ok {
+		t.Fatal("compressed bundle was not delivered")
+	} else if delivered.HasExtensionBlock(bpv7.ExtBlockTypeCompressionBlock) {
+		t.Fatal("delivered bundle still has a CompressionBlock")
+	} else if pb, err := delivered.PayloadBlock(); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(pb.Value.(*bpv7.PayloadBlock).Data(), payload) {
+		t.Fatal("delivered payload was not decompressed")
+	}
+}
+
+func TestCoreEmptyPayload(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/app"))
+	c.RegisterApplicationAgent(appAgent)
+
+	peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+	c.claManager.Register(peer)
+
+	outBndl, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://node/app").
+		Destination("dtn://peer/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.forward(NewBundleDescriptorFromBundle(outBndl, c.Store))
+	if n := peer.sentBundles(); n != 1 {
+		t.Fatalf("empty payload bundle was sent %d times", n)
+	}
+
+	inBndl, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://peer/app").
+		Destination("dtn://node/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte{}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.localDelivery(NewBundleDescriptorFromBundle(inBndl, c.Store))
+	if b, ok := appAgent.received(time.Second); !ok {
+		t.Fatal("empty payload bundle was not delivered")
+	} else if pb, err := b.PayloadBlock(); err != nil {
+		t.Fatal(err)
+	} else if data := pb.Value.(*bpv7.PayloadBlock).Data(); len(data) != 0 {
+		t.Fatalf("delivered payload is not empty: %x", data)
+	}
+}
+
+func TestCoreReassembleFragments(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/app"))
+	c.RegisterApplicationAgent(appAgent)
+
+	payload := bytes.Repeat([]byte("hello fragments "), 64)
+	bndl, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://peer/app").
+		Destination("dtn://node/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock(payload).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frags, err := bndl.Fragment(256)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(frags) < 2 {
+		t.Fatalf("expected multiple fragments, got %d", len(frags))
+	}
+
+	for i, frag := range frags {
+		c.localDelivery(NewBundleDescriptorFromBundle(frag, c.Store))
+
+		if i < len(frags)-1 {
+			if b, ok := appAgent.received(50 * time.Millisecond); ok {
+				t.Fatalf("fragment %v was delivered before reassembly", b.ID())
+			}
+		}
+	}
+
+	if b, ok := appAgent.received(time.Second); !ok {
+		t.Fatal("reassembled bundle was not delivered")
+	} else if b.ID() != bndl.ID() {
+		t.Fatalf("expected bundle %v, got %v", bndl.ID(), b.ID())
+	} else if pb, err := b.PayloadBlock(); err != nil {
+		t.Fatal(err)
+	} else if data := pb.Value.(*bpv7.PayloadBlock).Data(); !bytes.Equal(data, payload) {
+		t.Fatal("reassembled payload differs")
+	}
+}
+
+func TestCoreReassemblyLimits(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+
+	tests := []struct {
+		name        string
+		routingConf RoutingConf
+		fragments   int
+		wait        time.Duration
+	}{
+		{"timeout", RoutingConf{Algorithm: "epidemic", ReassemblyTimeout: timeout.String()}, 1, timeout + 50*time.Millisecond},
+		{"max memory", RoutingConf{Algorithm: "epidemic", ReassemblyMaxMemory: 256}, 2, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", test.routingConf)
+
+			reporter := newMockSender("reporter", "dtn://reporter/", cla.MTCP)
+			c.claManager.Register(reporter)
+
+			appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/app"))
+			c.RegisterApplicationAgent(appAgent)
+
+			bndl, err := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				BundleCtrlFlags(bpv7.StatusRequestDeletion).
+				Source("dtn://peer/app").
+				Destination("dtn://node/app").
+				ReportTo("dtn://reporter/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock(bytes.Repeat([]byte("hello fragments "), 64)).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			frags, err := bndl.Fragment(256)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, frag := range frags[:test.fragments] {
+				c.localDelivery(NewBundleDescriptorFromBundle(frag, c.Store))
+			}
+
+			time.Sleep(test.wait)
+			c.DeleteExpiredBundles()
+
+			// The remaining fragments do not suffice for the reassembly.
+			for _, frag := range frags[test.fragments:] {
+				c.localDelivery(NewBundleDescriptorFromBundle(frag, c.Store))
+			}
+			if b, ok := appAgent.received(50 * time.Millisecond); ok {
+				t.Fatalf("bundle %v was delivered", b.ID())
+			}
+
+			reporter.mutex.Lock()
+			defer reporter.mutex.Unlock()
+
+			if len(reporter.sent) == 0 {
+				t.Fatal("no status report was sent")
+			}
+			for _, sent := range reporter.sent {
+				if ar, err := sent.AdministrativeRecord(); err != nil {
+					t.Fatal(err)
+				} else if sr := ar.(*bpv7.StatusReport); sr.ReportReason != bpv7.NoInformation {
+					t.Fatalf("expected reason %v, got %v", bpv7.NoInformation, sr.ReportReason)
+				} else if sips := sr.StatusInformations(); len(sips) != 1 || sips[0] != bpv7.DeletedBundle {
+					t.Fatalf("expected status %v, got %v", bpv7.DeletedBundle, sips)
+				}
+			}
+		})
+	}
+}
+
+func TestCoreInvalidReassemblyTimeout(t *testing.T) {
+	for _, timeout := range []string{"soon", "0s", "-1m"} {
+		routingConf := RoutingConf{Algorithm: "epidemic", ReassemblyTimeout: timeout}
+		if _, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://node/"), false, routingConf, nil); err == nil {
+			t.Fatalf("reassembly timeout %s was accepted", timeout)
+		}
+	}
+}
+
+func TestCoreForwardingDelay(t *testing.T) {
+	const window = 250 * time.Millisecond
+
+	c := newTestCoreConf(t, "dtn://node/", RoutingConf{Algorithm: "epidemic", ForwardingDelay: window.String()})
+
+	peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+	c.claManager.Register(peer)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(fmt.Sprintf("dtn://node/app-%d", i)).
+			Destination("dtn://peer/app").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte(fmt.Sprintf("bundle %d", i))).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.forward(NewBundleDescriptorFromBundle(b, c.Store))
+	}
+
+	if n := peer.sentBundles(); n != 0 {
+		t.Fatalf("%d bundles were sent before the forwarding delay elapsed", n)
+	}
+
+	for i := 0; i < 20 && peer.sentBundles() < 3; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	sentTimes := peer.sentTimes()
+	if len(sentTimes) != 3 {
+		t.Fatalf("expected 3 sent bundles, got %d", len(sentTimes))
+	}
+
+	for _, sentTime := range sentTimes {
+		if delay := sentTime.Sub(start); delay < window {
+			t.Fatalf("bundle was sent after %v, before the forwarding delay of %v", delay, window)
+		} else if spread := sentTime.Sub(sentTimes[0]); spread > window/2 {
+			t.Fatalf("bundles were not sent as a batch, spread over %v", spread)
+		}
+	}
+}
+
+func TestCoreForwardedMetrics(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+	c.claManager.Register(peer)
+
+	failing := newMockSender("failing", "dtn://failing/", cla.MTCP)
+	failing.sendErr = fmt.Errorf("no contact")
+	c.claManager.Register(failing)
+
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://node/app").
+		Destination("dtn://peer/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.forward(NewBundleDescriptorFromBundle(b, c.Store))
+
+	forwarded := c.CLAMetrics().ForwardedBundles
+	if n := forwarded[peer.Address()]; n != 1 {
+		t.Fatalf("expected one forwarded bundle for %s, got %d", peer.Address(), n)
+	} else if n := forwarded[failing.Address()]; n != 0 {
+		t.Fatalf("expected no forwarded bundle for %s, got %d", failing.Address(), n)
+	}
+}
+
+func TestCoreNoNextNodeContact(t *testing.T) {
+	const holdTime = 100 * time.Millisecond
+
+	tests := []struct {
+		name   string
+		flags  bpv7.BundleControlFlags
+		report bool
+	}{
+		{"no report requested", 0, false},
+		{"forward report", bpv7.StatusRequestForward, false},
+		{"deletion report", bpv7.StatusRequestDeletion, true},
+		{"both reports", bpv7.StatusRequestForward | bpv7.StatusRequestDeletion, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", RoutingConf{Algorithm: "epidemic", MaxHoldTime: holdTime.String()})
+
+			peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+			peer.sendErr = fmt.Errorf("no contact")
+			c.claManager.Register(peer)
+
+			reporter := newMockSender("reporter", "dtn://reporter/", cla.MTCP)
+			c.claManager.Register(reporter)
+
+			b, err := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				BundleCtrlFlags(test.flags).
+				Source("dtn://reporter/app").
+				Destination("dtn://peer/app").
+				ReportTo("dtn://reporter/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bp := NewBundleDescriptorFromBundle(b, c.Store)
+			bp.Receiver = c.NodeId
+			c.forward(bp)
+
+			sent := func() []bpv7.Bundle {
+				reporter.mutex.Lock()
+				defer reporter.mutex.Unlock()
+
+				return append([]bpv7.Bundle{}, reporter.sent...)
+			}
+
+			// Failed attempts, including retries, must not be reported as the bundle is kept for a later contact.
+			c.CheckPendingBundles()
+			if n := len(sent()); n != 0 {
+				t.Fatalf("expected no status report before the bundle's deletion, got %d bundles", n)
+			}
+
+			time.Sleep(holdTime + 50*time.Millisecond)
+			c.CheckPendingBundles()
+
+			reports := sent()
+			if !test.report {
+				if len(reports) != 0 {
+					t.Fatalf("expected no status report, got %d bundles", len(reports))
+				}
+				return
+			} else if len(reports) != 1 {
+				t.Fatalf("expected one status report, got %d bundles", len(reports))
+			}
+
+			ar, err := reports[0].AdministrativeRecord()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sr, ok := ar.(*bpv7.StatusReport)
+			if !ok {
+				t.Fatalf("administrative record is no status report: %T", ar)
+			} else if sr.ReportReason != bpv7.NoNextNodeContact {
+				t.Fatalf("expected reason %v, got %v", bpv7.NoNextNodeContact, sr.ReportReason)
+			} else if sr.RefBundle != b.ID() {
+				t.Fatalf("status report refers to %v, not %v", sr.RefBundle, b.ID())
+			} else if sips := sr.StatusInformations(); len(sips) != 1 || sips[0] != bpv7.DeletedBundle {
+				t.Fatalf("expected status %v, got %v", bpv7.DeletedBundle, sips)
+			}
+		})
+	}
+}
+
+func TestCoreMaxHoldTime(t *testing.T) {
+	const holdTime = 100 * time.Millisecond
+
+	c := newTestCoreConf(t, "dtn://node/", RoutingConf{Algorithm: "epidemic", MaxHoldTime: holdTime.String()})
+
+	reporter := newMockSender("reporter", "dtn://reporter/", cla.MTCP)
+	c.claManager.Register(reporter)
+
+	b, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		BundleCtrlFlags(bpv7.StatusRequestDeletion).
+		Source("dtn://node/app").
+		Destination("dtn://peer/app").
+		ReportTo("dtn://reporter/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bp := NewBundleDescriptorFromBundle(b, c.Store)
+	bp.Receiver = c.NodeId
+	c.forward(bp)
+
+	statusReports := func() (srs []*bpv7.StatusReport) {
+		reporter.mutex.Lock()
+		defer reporter.mutex.Unlock()
+
+		for _, sent := range reporter.sent {
+			if ar, err := sent.AdministrativeRecord(); err == nil {
+				srs = append(srs, ar.(*bpv7.StatusReport))
+			}
+		}
+		return
+	}
+
+	c.CheckPendingBundles()
+	if !c.Store.KnowsBundle(b.ID()) {
+		t.Fatal("bundle was deleted before its hold time was exceeded")
+	} else if srs := statusReports(); len(srs) != 0 {
+		t.Fatalf("expected no status report, got %d", len(srs))
+	}
+
+	time.Sleep(holdTime + 50*time.Millisecond)
+
+	c.CheckPendingBundles()
+	if c.Store.KnowsBundle(b.ID()) {
+		t.Fatal("bundle was not deleted after its hold time was exceeded")
+	}
+
+	srs := statusReports()
+	if len(srs) != 1 {
+		t.Fatalf("expected one status report, got %d", len(srs))
+	} else if srs[0].ReportReason != bpv7.NoNextNodeContact {
+		t.Fatalf("expected reason %v, got %v", bpv7.NoNextNodeContact, srs[0].ReportReason)
+	} else if sips := srs[0].StatusInformations(); len(sips) != 1 || sips[0] != bpv7.DeletedBundle {
+		t.Fatalf("expected status %v, got %v", bpv7.DeletedBundle, sips)
+	}
+}
+
+// mtuSender is a mockSender limiting the size of its Bundles.
+type mtuSender struct {
+	*mockSender
+	mtu int
+}
+
+func (m *mtuSender) MTU() int { return m.mtu }
+
+func TestCoreForwardMTU(t *testing.T) {
+	const mtu = 512
+
+	tests := []struct {
+		name  string
+		flags bpv7.BundleControlFlags
+	}{
+		{"fragment", 0},
+		{"must not fragment", bpv7.MustNotFragmented},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://node/")
+
+			reporter := newMockSender("reporter", "dtn://reporter/", cla.MTCP)
+			c.claManager.Register(reporter)
+
+			peer := &mtuSender{mockSender: newMockSender("peer", "dtn://peer/", cla.MTCP), mtu: mtu}
+			c.claManager.Register(peer)
+
+			b, err := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				BundleCtrlFlags(test.flags | bpv7.StatusRequestDeletion).
+				Source("dtn://node/app").
+				Destination("dtn://peer/app").
+				ReportTo("dtn://reporter/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock(bytes.Repeat([]byte("x"), 4*mtu)).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bp := NewBundleDescriptorFromBundle(b, c.Store)
+			bp.Receiver = c.NodeId
+			c.forward(bp)
+
+			peer.mutex.Lock()
+			sent := peer.sent
+			peer.mutex.Unlock()
+
+			reporter.mutex.Lock()
+			var srs []*bpv7.StatusReport
+			for _, sent := range reporter.sent {
+				if ar, err := sent.AdministrativeRecord(); err == nil {
+					srs = append(srs, ar.(*bpv7.StatusReport))
+				}
+			}
+			reporter.mutex.Unlock()
+
+			if test.flags.Has(bpv7.MustNotFragmented) {
+				if len(sent) != 0 {
+					t.Fatalf("bundle was sent as %d bundles", len(sent))
+				} else if c.Store.KnowsBundle(b.ID()) {
+					t.Fatal("bundle was not dropped")
+				} else if len(srs) != 1 {
+					t.Fatalf("expected one status report, got %d", len(srs))
+				} else if srs[0].ReportReason != bpv7.TransmissionCanceled {
+					t.Fatalf("expected reason %v, got %v", bpv7.TransmissionCanceled, srs[0].ReportReason)
+				} else if sips := srs[0].StatusInformations(); len(sips) != 1 || sips[0] != bpv7.DeletedBundle {
+					t.Fatalf("expected status %v, got %v", bpv7.DeletedBundle, sips)
+				}
+				return
+			}
+
+			if len(sent) < 2 {
+				t.Fatalf("expected fragments, got %d bundles", len(sent))
+			} else if len(srs) != 0 {
+				t.Fatalf("expected no status report, got %d", len(srs))
+			}
+
+			for _, fragment := range sent {
+				var buf bytes.Buffer
+				if err := fragment.MarshalCbor(&buf); err != nil {
+					t.Fatal(err)
+				} else if buf.Len() > mtu {
+					t.Fatalf("fragment of %d bytes exceeds the MTU", buf.Len())
+				} else if !fragment.PrimaryBlock.BundleControlFlags.Has(bpv7.IsFragment) {
+					t.Fatal("sent bundle is no fragment")
+				}
+			}
+
+			if reassembled, err := bpv7.ReassembleFragments(sent); err != nil {
+				t.Fatal(err)
+			} else if reassembled.ID() != b.ID() {
+				t.Fatalf("reassembled %v, not %v", reassembled.ID(), b.ID())
+			}
+		})
+	}
+}
+
+func TestCoreForwardPreserveBlockOrder(t *testing.T) {
+	defer bpv7.SetPreserveBlockOrder(bpv7.PreserveBlockOrder())
+	bpv7.SetPreserveBlockOrder(true)
+
+	c := newTestCore(t, "dtn://node/")
+
+	peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+	c.claManager.Register(peer)
+
+	b, err := bpv7.Builder().
+		Source("dtn://src/app").
+		Destination("dtn://peer/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		HopCountBlock(64).
+		BundleAgeBlock(0).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The extension blocks are received in reverse order.
+	b.CanonicalBlocks[0], b.CanonicalBlocks[1] = b.CanonicalBlocks[1], b.CanonicalBlocks[0]
+
+	bp := NewBundleDescriptorFromBundle(b, c.Store)
+	bp.Receiver = c.NodeId
+	c.forward(bp)
+
+	peer.mutex.Lock()
+	defer peer.mutex.Unlock()
+
+	if len(peer.sent) != 1 {
+		t.Fatalf("peer received %d bundles, expected 1", len(peer.sent))
+	}
+
+	var types []uint64
+	for _, cb := range peer.sent[0].CanonicalBlocks {
+		types = append(types, cb.TypeCode())
+	}
+	expected := []uint64{bpv7.ExtBlockTypeBundleAgeBlock, bpv7.ExtBlockTypeHopCountBlock,
+		bpv7.ExtBlockTypePreviousNodeBlock, bpv7.ExtBlockTypePayloadBlock}
+	if !reflect.DeepEqual(types, expected) {
+		t.Fatalf("expected block types %v, got %v", expected, types)
+	}
+}
+
+func TestCoreDeleteExpiredBundles(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	reporter := newMockSender("reporter", "dtn://reporter/", cla.MTCP)
+	c.claManager.Register(reporter)
+
+	store := func(lifetime string) bpv7.Bundle {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			BundleCtrlFlags(bpv7.StatusRequestDeletion).
+			Source("dtn://node/app").
+			Destination("dtn://peer/app").
+			ReportTo("dtn://reporter/").
+			CreationTimestampNow().
+			Lifetime(lifetime).
+			PayloadBlock([]byte("hello world")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bp := NewBundleDescriptorFromBundle(b, c.Store)
+		bp.AddConstraint(Contraindicated)
+		if err := bp.Sync(); err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	statusReports := func() (srs []*bpv7.StatusReport) {
+		reporter.mutex.Lock()
+		defer reporter.mutex.Unlock()
+
+		for _, sent := range reporter.sent {
+			if ar, err := sent.AdministrativeRecord(); err == nil {
+				srs = append(srs, ar.(*bpv7.StatusReport))
+			}
+		}
+		return
+	}
+
+	expiring := store("100ms")
+	living := store("10m")
+
+	time.Sleep(150 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		c.DeleteExpiredBundles()
+
+		if c.Store.KnowsBundle(expiring.ID()) {
+			t.Fatal("expired bundle was not deleted")
+		} else if !c.Store.KnowsBundle(living.ID()) {
+			t.Fatal("living bundle was deleted")
+		}
+
+		srs := statusReports()
+		if len(srs) != 1 {
+			t.Fatalf("expected one status report, got %d", len(srs))
+		} else if srs[0].RefBundle != expiring.ID() {
+			t.Fatalf("expected status report for %v, got %v", expiring.ID(), srs[0].RefBundle)
+		} else if srs[0].ReportReason != bpv7.LifetimeExpired {
+			t.Fatalf("expected reason %v, got %v", bpv7.LifetimeExpired, srs[0].ReportReason)
+		} else if sips := srs[0].StatusInformations(); len(sips) != 1 || sips[0] != bpv7.DeletedBundle {
+			t.Fatalf("expected status %v, got %v", bpv7.DeletedBundle, sips)
+		}
+	}
+}
+
+func TestCoreStatusAgent(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	querier := newMockSender("querier", "dtn://querier/", cla.MTCP)
+	c.claManager.Register(querier)
+	c.claManager.Register(newMockSender("querier-2", "dtn://querier/", cla.MTCP))
+	c.claManager.Register(newMockSender("peer", "dtn://peer/", cla.MTCP))
+
+	c.RegisterApplicationAgent(agent.NewStatusAgent(bpv7.MustNewEndpointID("dtn://node/status"), c.NodeStatus))
+
+	stored := NewBundleDescriptorFromBundle(newRingTestBundle(t, 0), c.Store)
+	stored.AddConstraint(Contraindicated)
+	if err := stored.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	query, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://querier/").
+		Destination("dtn://node/status").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bp := NewBundleDescriptorFromBundle(query, c.Store)
+	bp.Receiver = c.NodeId
+	c.dispatching(bp)
+
+	var reply *bpv7.Bundle
+	for deadline := time.Now().Add(time.Second); reply == nil && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+
+		querier.mutex.Lock()
+		for i := range querier.sent {
+			if !querier.sent[i].IsAdministrativeRecord() {
+				reply = &querier.sent[i]
+			}
+		}
+		querier.mutex.Unlock()
+	}
+	if reply == nil {
+		t.Fatal("no status reply was sent to the querier")
+	}
+
+	payloadBlock, err := reply.PayloadBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var status agent.NodeStatus
+	if err := json.Unmarshal(payloadBlock.Value.(*bpv7.PayloadBlock).Data(), &status); err != nil {
+		t.Fatal(err)
+	}
+
+	if status.NodeId != "dtn://node/" {
+		t.Fatalf("expected node ID dtn://node/, got %s", status.NodeId)
+	} else if status.Version == "" {
+		t.Fatal("status has no version")
+	} else if status.ActiveSenders != 3 {
+		t.Fatalf("expected 3 active senders, got %d", status.ActiveSenders)
+	} else if status.Neighbors != 2 {
+		t.Fatalf("expected 2 neighbors, got %d", status.Neighbors)
+	} else if status.StoreSize < 1 {
+		t.Fatalf("expected at least one stored bundle, got %d", status.StoreSize)
+	}
+}
+
+// staticTestRouting forwards all Bundles to a fixed ConvergenceSender and records its notifications.
+type staticTestRouting struct {
+	sender cla.ConvergenceSender
+
+	mutex   sync.Mutex
+	bundles map[bpv7.BundleID]bool
+	peers   map[string]bool
+}
+
+func newStaticTestRouting(sender cla.ConvergenceSender) *staticTestRouting {
+	return &staticTestRouting{
+		sender:  sender,
+		bundles: make(map[bpv7.BundleID]bool),
+		peers:   make(map[string]bool),
+	}
+}
+
+func (s *staticTestRouting) NotifyNewBundle(bp BundleDescriptor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.bundles[bp.ID()] = true
+}
+
+func (s *staticTestRouting) DispatchingAllowed(_ BundleDescriptor) bool { return true }
+
+func (s *staticTestRouting) SenderForBundle(_ BundleDescriptor) ([]cla.ConvergenceSender, bool) {
+	return []cla.ConvergenceSender{s.sender}, true
+}
+
+func (s *staticTestRouting) ReportFailure(_ BundleDescriptor, _ cla.ConvergenceSender) {}
+
+func (s *staticTestRouting) ReportPeerAppeared(peer cla.Convergence) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.peers[peer.Address()] = true
+}
+
+func (s *staticTestRouting) ReportPeerDisappeared(peer cla.Convergence) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.peers, peer.Address())
+}
+
+func TestCoreDuplicatePolicy(t *testing.T) {
+	peer := bpv7.MustNewEndpointID("dtn://peer/")
+
+	tests := []struct {
+		policy   string
+		reported bool
+	}{
+		{"", false},
+		{"ignore", false},
+		{"report", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", RoutingConf{Algorithm: "epidemic", DuplicatePolicy: test.policy})
+
+			b, err := bpv7.Builder().
+				Source("dtn://src/app").
+				Destination("dtn://dst/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.receive(NewBundleDescriptorFromBundle(b, c.Store))
+			if n := c.DuplicateReceptions(); n != 0 {
+				t.Fatalf("expected no duplicates, got %d", n)
+			}
+
+			dup := NewBundleDescriptorFromBundle(b, c.Store)
+			dup.PreviousNode = peer
+			c.receive(dup)
+
+			if n := c.DuplicateReceptions(); n != 1 {
+				t.Fatalf("expected one duplicate, got %d", n)
+			}
+
+			bi, err := c.Store.QueryId(b.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sentEids, _ := bi.Properties["routing/epidemic/sent"].([]bpv7.EndpointID)
+			reported := false
+			for _, eid := range sentEids {
+				if eid == peer {
+					reported = true
+				}
+			}
+			if reported != test.reported {
+				t.Fatalf("peer in sent list %v is %t, expected %t", sentEids, reported, test.reported)
+			}
+		})
+	}
+}
+
+func TestCoreInvalidDuplicatePolicy(t *testing.T) {
+	if _, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://node/"), false,
+		RoutingConf{Algorithm: "epidemic", DuplicatePolicy: "forward"}, nil); err == nil {
+		t.Fatal("unknown duplicate policy was accepted")
+	}
+}
+
+func TestCoreSetRoutingAlgorithm(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	peerA := newMockSender("peer-a", "dtn://peer-a/", cla.MTCP)
+	peerB := newMockSender("peer-b", "dtn://peer-b/", cla.MTCP)
+	c.claManager.Register(peerA)
+	c.claManager.Register(peerB)
+
+	newBundle := func(i int) BundleDescriptor {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(fmt.Sprintf("dtn://node/app-%d", i)).
+			Destination("dtn://elsewhere/app").
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte(fmt.Sprintf("bundle %d", i))).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewBundleDescriptorFromBundle(b, c.Store)
+	}
+
+	// Epidemic routing forwards to both peers and keeps the Bundle pending.
+	epidemicBp := newBundle(0)
+	c.forward(epidemicBp)
+	if a, b := peerA.sentBundles(), peerB.sentBundles(); a != 1 || b != 1 {
+		t.Fatalf("epidemic routing sent %d and %d bundles, expected one each", a, b)
+	}
+
+	// Switch while other bundles are being forwarded concurrently.
+	static := newStaticTestRouting(peerB)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.forward(newBundle(i))
+		}(i)
+	}
+	c.SetRoutingAlgorithm(static)
+	wg.Wait()
+
+	static.mutex.Lock()
+	if !static.peers["peer-a"] || !static.peers["peer-b"] {
+		t.Fatalf("static routing was not notified about current peers: %v", static.peers)
+	}
+	if !static.bundles[epidemicBp.ID()] {
+		t.Fatal("static routing was not notified about the pending bundle")
+	}
+	static.mutex.Unlock()
+
+	sentA, sentB := peerA.sentBundles(), peerB.sentBundles()
+	c.forward(newBundle(9))
+	if a, b := peerA.sentBundles(), peerB.sentBundles(); a != sentA || b != sentB+1 {
+		t.Fatalf("static routing sent %d and %d new bundles, expected 0 and 1", a-sentA, b-sentB)
+	}
+}
+
+func TestCoreSenderTieBreaker(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	prophet := NewProphet(c, ProphetConfig{PInit: 0.75, Beta: 0.25, Gamma: 0.98, AgeInterval: "1m"})
+	c.SetRoutingAlgorithm(prophet)
+
+	destination := bpv7.MustNewEndpointID("dtn://destination/")
+
+	expected := []string{"dtn://peer-a/", "dtn://peer-b/", "dtn://peer-c/", "dtn://peer-d/", "dtn://peer-e/"}
+	for _, i := range []int{3, 1, 4, 0, 2} {
+		peer := newMockSender(fmt.Sprintf("peer-%d", i), expected[i], cla.MTCP)
+		c.claManager.Register(peer)
+
+		prophet.dataMutex.Lock()
+		prophet.peerPredictabilities[peer.peer] = map[bpv7.EndpointID]float64{destination: 0.5}
+		prophet.dataMutex.Unlock()
+	}
+
+	for i := 0; i < 10; i++ {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(fmt.Sprintf("dtn://node/app-%d", i)).
+			Destination(destination).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte(fmt.Sprintf("bundle %d", i))).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		css, _ := prophet.SenderForBundle(NewBundleDescriptorFromBundle(b, c.Store))
+		if len(css) != len(expected) {
+			t.Fatalf("expected %d senders, got %d", len(expected), len(css))
+		}
+		for j, cs := range css {
+			if eid := cs.GetPeerEndpointID().String(); eid != expected[j] {
+				t.Fatalf("sender %d is %s, expected %s", j, eid, expected[j])
+			}
+		}
+	}
+}
+
+func TestCoreIdentityAssertion(t *testing.T) {
+	alicePub, alicePriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, malloryPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		source    string
+		priv      ed25519.PrivateKey
+		delivered bool
+	}{
+		{"genuine source", "dtn://alice/app", alicePriv, true},
+		{"forged source", "dtn://alice/app", malloryPriv, false},
+		{"missing assertion", "dtn://alice/app", nil, false},
+		{"unknown source", "dtn://carol/app", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://bob/")
+			c.SetIdentityKeys(map[bpv7.EndpointID]ed25519.PublicKey{bpv7.MustNewEndpointID("dtn://alice/"): alicePub})
+
+			appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://bob/app"))
+			c.RegisterApplicationAgent(appAgent)
+
+			bldr := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				Source(test.source).
+				Destination("dtn://bob/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello bob"))
+			if test.priv != nil {
+				bldr = bldr.IdentityAssertionBlock(test.priv)
+			}
+
+			b, err := bldr.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.localDelivery(NewBundleDescriptorFromBundle(b, c.Store))
+			if _, delivered := appAgent.received(250 * time.Millisecond); delivered != test.delivered {
+				t.Fatalf("expected delivery = %t, got %t", test.delivered, delivered)
+			}
+		})
+	}
+}
+
+func TestCoreDeadLetterEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		lifetime    string
+		process     func(c *Core, bp BundleDescriptor)
+	}{
+		{"undeliverable", "dtn://node/unknown", "10m", (*Core).localDelivery},
+		{"lifetime exceeded", "dtn://peer/app", "50ms", (*Core).forward},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://node/")
+			c.SetDeadLetterEndpoint(bpv7.MustNewEndpointID("dtn://node/dead-letter"))
+
+			deadLetterAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/dead-letter"))
+			c.RegisterApplicationAgent(deadLetterAgent)
+
+			b, err := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				Source("dtn://sender/app").
+				Destination(test.destination).
+				CreationTimestampNow().
+				Lifetime(test.lifetime).
+				PayloadBlock([]byte("hello nobody")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			test.process(c, NewBundleDescriptorFromBundle(b, c.Store))
+
+			deadLetter, ok := deadLetterAgent.received(time.Second)
+			if !ok {
+				t.Fatal("dropped bundle was not delivered to the dead-letter endpoint")
+			}
+			if src := deadLetter.PrimaryBlock.SourceNode; src != c.NodeId {
+				t.Fatalf("expected dead-letter source %v, got %v", c.NodeId, src)
+			}
+
+			// The dropped bundle might be invalid, e.g., expired, and is therefore compared in its CBOR representation.
+			var buf bytes.Buffer
+			if err := b.MarshalCbor(&buf); err != nil {
+				t.Fatal(err)
+			}
+			pb, err := deadLetter.PayloadBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if payload := pb.Value.(*bpv7.PayloadBlock).Data(); !bytes.Equal(payload, buf.Bytes()) {
+				t.Fatalf("dead-letter payload %x differs from the dropped bundle %x", payload, buf.Bytes())
+			}
+
+			if c.Store.KnowsBundle(deadLetter.ID()) {
+				t.Fatal("dead-letter bundle is still stored")
+			}
+
+			// Further bundles of this node within the same millisecond, e.g., status reports, get other sequence numbers.
+			for i := 0; i < 2; i++ {
+				report := deadLetter
+				report.PrimaryBlock.CreationTimestamp = bpv7.NewCreationTimestamp(
+					deadLetter.PrimaryBlock.CreationTimestamp.DtnTime(), 0)
+
+				reportBp := BundleDescriptor{Id: report.ID(), bndl: &report}
+				if err := c.IdKeeper.update(&reportBp); err != nil {
+					t.Fatal(err)
+				} else if reportBp.ID() == deadLetter.ID() {
+					t.Fatalf("bundle %d shares the dead-letter bundle's ID %v", i, deadLetter.ID())
+				}
+			}
+		})
+	}
+}
+
+func TestCoreUnknownEndpointPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     RoutingConf
+		buffered bool
+		catchAll bool
+	}{
+		{"buffer", RoutingConf{Algorithm: "epidemic"}, true, false},
+		{"buffer exceeded", RoutingConf{Algorithm: "epidemic", UnknownEndpointBuffer: "50ms"}, false, false},
+		{"catch-all", RoutingConf{Algorithm: "epidemic", UnknownEndpointPolicy: "catch-all", UnknownEndpointCatchAll: "dtn://node/catch-all"}, false, true},
+		{"reject", RoutingConf{Algorithm: "epidemic", UnknownEndpointPolicy: "reject"}, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", test.conf)
+
+			catchAllAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/catch-all"))
+			c.RegisterApplicationAgent(catchAllAgent)
+
+			b, err := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				Source("dtn://sender/app").
+				Destination("dtn://node/unknown").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello unknown")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bp := NewBundleDescriptorFromBundle(b, c.Store)
+			c.routingAlgorithm().NotifyNewBundle(bp)
+			c.dispatching(bp)
+
+			// Retrying a bundle whose endpoint is still unknown deletes it after its buffer was exceeded.
+			if test.conf.UnknownEndpointBuffer != "" {
+				time.Sleep(100 * time.Millisecond)
+			}
+			c.CheckPendingBundles()
+
+			// Register the formerly unknown endpoint; a buffered bundle is delivered when being retried.
+			appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/unknown"))
+			c.RegisterApplicationAgent(appAgent)
+			c.CheckPendingBundles()
+
+			if delivered, ok := appAgent.received(250 * time.Millisecond); ok != test.buffered {
+				t.Fatalf("expected delivery after registration = %t, got %t", test.buffered, ok)
+			} else if ok && delivered.ID() != b.ID() {
+				t.Fatalf("expected bundle %v, got %v", b.ID(), delivered.ID())
+			}
+
+			if caught, ok := catchAllAgent.received(250 * time.Millisecond); ok != test.catchAll {
+				t.Fatalf("expected catch-all delivery = %t, got %t", test.catchAll, ok)
+			} else if ok {
+				var buf bytes.Buffer
+				if err := b.MarshalCbor(&buf); err != nil {
+					t.Fatal(err)
+				}
+				pb, err := caught.PayloadBlock()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if payload := pb.Value.(*bpv7.PayloadBlock).Data(); !bytes.Equal(payload, buf.Bytes()) {
+					t.Fatalf("catch-all payload %x differs from the bundle %x", payload, buf.Bytes())
+				}
+			}
+
+			if c.Store.KnowsBundle(b.ID()) {
+				t.Fatal("bundle is still stored")
+			}
+		})
+	}
+}
+
+func TestCoreInvalidUnknownEndpointPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		conf RoutingConf
+	}{
+		{"unknown policy", RoutingConf{Algorithm: "epidemic", UnknownEndpointPolicy: "forward"}},
+		{"missing catch-all", RoutingConf{Algorithm: "epidemic", UnknownEndpointPolicy: "catch-all"}},
+		{"invalid buffer", RoutingConf{Algorithm: "epidemic", UnknownEndpointBuffer: "-1m"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://node/"), false, test.conf, nil); err == nil {
+				t.Fatal("invalid configuration was accepted")
+			}
+		})
+	}
+}
+
+func TestCoreSuppressPreviousNode(t *testing.T) {
+	tests := []struct {
+		name     string
+		suppress bool
+		received bool
+		expected bool
+	}{
+		{"add", false, false, true},
+		{"update", false, true, true},
+		{"suppress", true, false, false},
+		{"suppress received", true, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", RoutingConf{Algorithm: "epidemic", SuppressPreviousNode: test.suppress})
+
+			peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+			c.claManager.Register(peer)
+
+			bldr := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				Source("dtn://src/app").
+				Destination("dtn://peer/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello peer"))
+			if test.received {
+				bldr = bldr.PreviousNodeBlock("dtn://src/")
+			}
+
+			b, err := bldr.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.forward(NewBundleDescriptorFromBundle(b, c.Store))
+			if n := peer.sentBundles(); n != 1 {
+				t.Fatalf("bundle was sent %d times", n)
+			}
+
+			peer.mutex.Lock()
+			sent := peer.sent[0]
+			peer.mutex.Unlock()
+
+			pnBlock, err := sent.ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock)
+			if exists := err == nil; exists != test.expected {
+				t.Fatalf("expected PreviousNodeBlock = %t, got %t", test.expected, exists)
+			} else if exists {
+				if prevNode := pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint(); prevNode != c.NodeId {
+					t.Fatalf("expected previous node %v, got %v", c.NodeId, prevNode)
+				}
+			}
+		})
+	}
+}
+
+func TestCoreReceiveEncryptedExtensionBlocks(t *testing.T) {
+	key := []byte("dtnislovedtnislovedtnislovedtnis")
+
+	algorithms := []struct {
+		name      string
+		algorithm func(c *Core) Algorithm
+	}{
+		{"epidemic", func(c *Core) Algorithm { return NewEpidemicRouting(c) }},
+		{"spray", func(c *Core) Algorithm { return NewSprayAndWait(c, SprayConfig{Multiplicity: 4}) }},
+		{"binary_spray", func(c *Core) Algorithm { return NewBinarySpray(c, SprayConfig{Multiplicity: 4}) }},
+	}
+
+	// Blocks which must be processed by forwarding nodes, being encrypted by a BCB.
+	targets := []struct {
+		name     string
+		typeCode uint64
+	}{
+		{"hop count block", bpv7.ExtBlockTypeHopCountBlock},
+		{"bundle age block", bpv7.ExtBlockTypeBundleAgeBlock},
+		{"previous node block", bpv7.ExtBlockTypePreviousNodeBlock},
+	}
+
+	for _, target := range targets {
+		for _, algorithm := range algorithms {
+			t.Run(target.name+"/"+algorithm.name, func(t *testing.T) {
+				c := newTestCore(t, "dtn://node/")
+				c.SetRoutingAlgorithm(algorithm.algorithm(c))
+
+				peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+				c.claManager.Register(peer)
+
+				b, err := bpv7.Builder().
+					CRC(bpv7.CRC32).
+					Source("dtn://src/app").
+					Destination("dtn://peer/app").
+					CreationTimestampNow().
+					Lifetime("10m").
+					HopCountBlock(64).
+					BundleAgeBlock(0).
+					Canonical(bpv7.NewPreviousNodeBlock(bpv7.MustNewEndpointID("dtn://prev/"))).
+					PayloadBlock([]byte("hello world")).
+					Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				targetBlock, err := b.ExtensionBlock(target.typeCode)
+				if err != nil {
+					t.Fatal(err)
+				}
+				plainText := targetBlock.Value
+
+				bcb := bpv7.NewBCBIOPAESGCM(nil, nil, nil, targetBlock.BlockNumber, b.PrimaryBlock.SourceNode)
+				if err := b.AddExtensionBlock(bpv7.NewCanonicalBlock(0, 0, bcb)); err != nil {
+					t.Fatal(err)
+				} else if bcbBlock, err := b.ExtensionBlock(bpv7.ExtBlockTypeBlockConfidentialityBlock); err != nil {
+					t.Fatal(err)
+				} else if err := bcb.EncryptTarget(b, bcbBlock.BlockNumber, key); err != nil {
+					t.Fatal(err)
+				}
+
+				buff := new(bytes.Buffer)
+				if err := b.WriteBundle(buff); err != nil {
+					t.Fatal(err)
+				}
+				received, err := bpv7.ParseBundle(buff)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				bp := NewBundleDescriptorFromBundle(received, c.Store)
+				bp.Receiver = c.NodeId
+				c.receive(bp)
+
+				peer.mutex.Lock()
+				defer peer.mutex.Unlock()
+
+				if len(peer.sent) != 1 {
+					t.Fatalf("peer received %d bundles, expected 1", len(peer.sent))
+				}
+
+				// The encrypted block must be left untouched, thus still being decryptable.
+				forwarded := peer.sent[0]
+				bcbBlock, err := forwarded.ExtensionBlock(bpv7.ExtBlockTypeBlockConfidentialityBlock)
+				if err != nil {
+					t.Fatal(err)
+				} else if err := bcbBlock.Value.(*bpv7.BCBIOPAESGCM).DecryptTarget(forwarded, bcbBlock.BlockNumber, key); err != nil {
+					t.Fatal(err)
+				}
+
+				if decrypted, err := forwarded.ExtensionBlock(target.typeCode); err != nil {
+					t.Fatal(err)
+				} else if !reflect.DeepEqual(decrypted.Value, plainText) {
+					t.Fatalf("decrypted block %v differs from %v", decrypted.Value, plainText)
+				}
+			})
+		}
+	}
+}
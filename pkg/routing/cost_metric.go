@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// CostMetric calculates this node's share of a bpv7.CostMetricBlock's accumulated cost while forwarding a bundle.
+// Custom metrics can be registered by Core.RegisterCostMetric.
+type CostMetric interface {
+	// MetricType of the bpv7.CostMetricBlock to be updated by this CostMetric.
+	MetricType() bpv7.CostMetricType
+
+	// Cost of forwarding this bundle by this node.
+	Cost(bp BundleDescriptor) uint64
+}
+
+// BytesCostMetric adds the serialized bundle's length, resulting in the total amount of transferred bytes.
+type BytesCostMetric struct{}
+
+// MetricType is bpv7.CostMetricBytes.
+func (_ BytesCostMetric) MetricType() bpv7.CostMetricType {
+	return bpv7.CostMetricBytes
+}
+
+// countingWriter counts the bytes written into it.
+type countingWriter struct {
+	n uint64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.n += uint64(len(p))
+	return len(p), nil
+}
+
+// Cost is the serialized bundle's length.
+func (_ BytesCostMetric) Cost(bp BundleDescriptor) uint64 {
+	var cw countingWriter
+	if err := bp.MustBundle().MarshalCbor(&cw); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Serializing bundle for its length failed")
+		return 0
+	}
+	return cw.n
+}
+
+// DwellTimeCostMetric adds the milliseconds since the bundle's reception by this node.
+type DwellTimeCostMetric struct{}
+
+// MetricType is bpv7.CostMetricDwellTime.
+func (_ DwellTimeCostMetric) MetricType() bpv7.CostMetricType {
+	return bpv7.CostMetricDwellTime
+}
+
+// Cost is the time since the bundle's reception in milliseconds.
+func (_ DwellTimeCostMetric) Cost(bp BundleDescriptor) uint64 {
+	return uint64(time.Since(bp.Timestamp).Milliseconds())
+}
+
+// RegisterCostMetric to update a bundle's bpv7.CostMetricBlock of this metric type while forwarding. An already
+// registered CostMetric for the same metric type, e.g., the default BytesCostMetric or DwellTimeCostMetric, is replaced.
+func (c *Core) RegisterCostMetric(metric CostMetric) {
+	c.costMetricsMutex.Lock()
+	defer c.costMetricsMutex.Unlock()
+
+	c.costMetrics[metric.MetricType()] = metric
+}
+
+// accumulateCosts adds this node's share to each bpv7.CostMetricBlock of a known metric.
+func (c *Core) accumulateCosts(bp BundleDescriptor) {
+	cbs, err := bp.MustBundle().ExtensionBlocks(bpv7.ExtBlockTypeCostMetricBlock)
+	if err != nil {
+		return
+	}
+
+	c.costMetricsMutex.RLock()
+	defer c.costMetricsMutex.RUnlock()
+
+	for _, cb := range cbs {
+		cmb, ok := cb.Value.(*bpv7.CostMetricBlock)
+		if !ok {
+			continue
+		}
+
+		metric, ok := c.costMetrics[cmb.Metric]
+		if !ok {
+			continue
+		}
+
+		cost := cmb.Add(metric.Cost(bp))
+
+		log.WithFields(log.Fields{
+			"bundle": bp.ID().String(),
+			"metric": cmb.Metric,
+			"cost":   cost,
+		}).Debug("Cost Metric Block updated")
+	}
+}
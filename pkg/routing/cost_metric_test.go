@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/cla/mtcp"
+)
+
+// constantCostMetric is a custom CostMetric, adding a constant cost for each hop.
+type constantCostMetric struct{}
+
+func (_ constantCostMetric) MetricType() bpv7.CostMetricType { return bpv7.CostMetricType(23) }
+func (_ constantCostMetric) Cost(_ BundleDescriptor) uint64  { return 5 }
+
+func TestCostMetricLine(t *testing.T) {
+	// Create the line network a <-> b <-> c, connected via MTCP.
+	nodeIds := []string{"dtn://a/", "dtn://b/", "dtn://c/"}
+	cores := make([]*Core, len(nodeIds))
+	addrs := make([]string, len(nodeIds))
+
+	for i, nodeId := range nodeIds {
+		cores[i] = newTestCore(t, nodeId)
+		cores[i].RegisterCostMetric(constantCostMetric{})
+		addrs[i] = testListenAddress(t)
+
+		cores[i].RegisterCLA(mtcp.NewMTCPServer(addrs[i], bpv7.MustNewEndpointID(nodeId), false), cla.MTCP, bpv7.MustNewEndpointID(nodeId))
+	}
+	time.Sleep(250 * time.Millisecond)
+
+	for i := 0; i < len(nodeIds)-1; i++ {
+		cores[i].RegisterConvergable(mtcp.NewMTCPClient(addrs[i+1], bpv7.MustNewEndpointID(nodeIds[i+1]), false))
+		cores[i+1].RegisterConvergable(mtcp.NewMTCPClient(addrs[i], bpv7.MustNewEndpointID(nodeIds[i]), false))
+	}
+	time.Sleep(250 * time.Millisecond)
+
+	appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://c/app"))
+	cores[2].RegisterApplicationAgent(appAgent)
+
+	bndl, err := bpv7.Builder().
+		CRC(bpv7.CRC32).
+		Source("dtn://a/app").
+		Destination("dtn://c/app").
+		CreationTimestampNow().
+		Lifetime("10m").
+		CostMetricBlock(bpv7.CostMetricBytes).
+		CostMetricBlock(bpv7.CostMetricDwellTime).
+		CostMetricBlock(bpv7.CostMetricType(23)).
+		CostMetricBlock(bpv7.CostMetricType(42)).
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buff bytes.Buffer
+	if err := bndl.MarshalCbor(&buff); err != nil {
+		t.Fatal(err)
+	}
+	minBytes := uint64(2 * buff.Len())
+
+	cores[0].SendBundle(&bndl)
+
+	b, ok := appAgent.received(5 * time.Second)
+	if !ok {
+		t.Fatal("bundle was not delivered")
+	}
+
+	cbs, err := b.ExtensionBlocks(bpv7.ExtBlockTypeCostMetricBlock)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(cbs) != 4 {
+		t.Fatalf("expected four CostMetricBlocks, got %d", len(cbs))
+	}
+
+	for _, cb := range cbs {
+		cmb := cb.Value.(*bpv7.CostMetricBlock)
+
+		switch cmb.Metric {
+		case bpv7.CostMetricBytes:
+			// Both a and b have forwarded the bundle, which grew on its way, e.g., by its Previous Node Block.
+			if cmb.Cost < minBytes {
+				t.Fatalf("expected at least %d bytes, got %d", minBytes, cmb.Cost)
+			}
+
+		case bpv7.CostMetricDwellTime:
+			if cmb.Cost > uint64((5 * time.Second).Milliseconds()) {
+				t.Fatalf("dwell time of %d ms exceeds the test's duration", cmb.Cost)
+			}
+
+		case bpv7.CostMetricType(23):
+			if cmb.Cost != 10 {
+				t.Fatalf("expected a custom cost of 10 for two hops, got %d", cmb.Cost)
+			}
+
+		case bpv7.CostMetricType(42):
+			if cmb.Cost != 0 {
+				t.Fatalf("unknown metric was updated to %d", cmb.Cost)
+			}
+
+		default:
+			t.Fatalf("unexpected metric %v", cmb.Metric)
+		}
+	}
+}
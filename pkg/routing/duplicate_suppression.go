@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// duplicateSuppressionScope defines how long disposed Bundles are suppressed, see RoutingConf's DuplicateSuppression.
+type duplicateSuppressionScope int
+
+const (
+	// duplicateSuppressionHeld only suppresses Bundles which are still held.
+	duplicateSuppressionHeld duplicateSuppressionScope = iota
+
+	// duplicateSuppressionGlobal suppresses disposed Bundles until their expiry.
+	duplicateSuppressionGlobal
+
+	// duplicateSuppressionWindow suppresses disposed Bundles for a time window, at most until their expiry.
+	duplicateSuppressionWindow
+)
+
+// disposedBundlesSweepInterval between removing outdated entries of disposedBundles.
+const disposedBundlesSweepInterval = time.Minute
+
+// disposedBundle is an entry of disposedBundles.
+type disposedBundle struct {
+	until time.Time
+	peers []bpv7.EndpointID
+}
+
+// disposedBundles remembers the IDs of disposed Bundles together with the peers they were received from, to suppress
+// their reappearance based on a duplicateSuppressionScope.
+type disposedBundles struct {
+	scope    duplicateSuppressionScope
+	window   time.Duration
+	override bool
+
+	mutex     sync.Mutex
+	bundles   map[string]disposedBundle
+	lastSweep time.Time
+}
+
+// newDisposedBundles from a RoutingConf.
+func newDisposedBundles(routingConf RoutingConf) (*disposedBundles, error) {
+	db := &disposedBundles{
+		override: routingConf.DuplicateNewPeerOverride,
+		bundles:  make(map[string]disposedBundle),
+	}
+
+	switch routingConf.DuplicateSuppression {
+	case "", "held":
+		db.scope = duplicateSuppressionHeld
+
+	case "global":
+		db.scope = duplicateSuppressionGlobal
+
+	case "window":
+		db.scope = duplicateSuppressionWindow
+
+		window, err := time.ParseDuration(routingConf.DuplicateSuppressionWindow)
+		if err != nil {
+			return nil, fmt.Errorf("duplicate suppression window \"%s\" is invalid: %v",
+				routingConf.DuplicateSuppressionWindow, err)
+		} else if window <= 0 {
+			return nil, fmt.Errorf("duplicate suppression window \"%s\" is not positive",
+				routingConf.DuplicateSuppressionWindow)
+		}
+		db.window = window
+
+	default:
+		return nil, fmt.Errorf("unknown duplicate suppression %s", routingConf.DuplicateSuppression)
+	}
+
+	return db, nil
+}
+
+// sweep outdated entries. The mutex must be held.
+func (db *disposedBundles) sweep(now time.Time) {
+	if now.Sub(db.lastSweep) < disposedBundlesSweepInterval {
+		return
+	}
+	db.lastSweep = now
+
+	for id, entry := range db.bundles {
+		if now.After(entry.until) {
+			delete(db.bundles, id)
+		}
+	}
+}
+
+// remember a disposed Bundle, received from a peer, which might be dtn:none for an unknown or no peer.
+func (db *disposedBundles) remember(b *bpv7.Bundle, peer bpv7.EndpointID) {
+	if db.scope == duplicateSuppressionHeld {
+		return
+	}
+
+	now := time.Now()
+	until := b.ExpiryTime()
+	if db.scope == duplicateSuppressionWindow && now.Add(db.window).Before(until) {
+		until = now.Add(db.window)
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.sweep(now)
+
+	id := b.ID().String()
+	entry := db.bundles[id]
+	entry.until = until
+	if !bagHasEndpoint(entry.peers, peer) {
+		entry.peers = append(entry.peers, peer)
+	}
+	db.bundles[id] = entry
+}
+
+// bagHasEndpoint checks if a slice of EndpointIDs contains an EndpointID.
+func bagHasEndpoint(bag []bpv7.EndpointID, eid bpv7.EndpointID) bool {
+	for _, e := range bag {
+		if e == eid {
+			return true
+		}
+	}
+	return false
+}
+
+// suppresses checks if a reappearing Bundle, received from a peer, was disposed and is still suppressed. With the
+// new peer override, it is only suppressed when being received from a peer it was already received from.
+func (db *disposedBundles) suppresses(b *bpv7.Bundle, peer bpv7.EndpointID) bool {
+	if db.scope == duplicateSuppressionHeld {
+		return false
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	entry, ok := db.bundles[b.ID().String()]
+	if !ok || time.Now().After(entry.until) {
+		return false
+	}
+
+	return !db.override || bagHasEndpoint(entry.peers, peer)
+}
+
+// disposeBundle releases a Bundle from the store, remembering it for the duplicate suppression.
+func (c *Core) disposeBundle(bp BundleDescriptor) {
+	c.disposedBundles.remember(bp.MustBundle(), bp.PreviousNode)
+
+	bp.PurgeConstraints()
+	_ = bp.Sync()
+}
+
+// receiveDisposed checks if a newly received Bundle was already disposed and is still suppressed. Such a Bundle is
+// counted as a duplicate and dropped again.
+func (c *Core) receiveDisposed(bp BundleDescriptor) bool {
+	if !c.disposedBundles.suppresses(bp.MustBundle(), bp.PreviousNode) {
+		return false
+	}
+
+	log.WithFields(log.Fields{
+		"bundle": bp.ID().String(),
+		"peer":   bp.PreviousNode,
+	}).Debug("Received bundle was already disposed")
+
+	c.receiveDuplicate(bp)
+
+	if err := c.Store.Delete(bp.Id); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Failed to delete suppressed bundle")
+	}
+	return true
+}
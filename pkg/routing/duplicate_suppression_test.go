@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// receiveFrom passes a Bundle to the Core's receive, as being received from a peer.
+func receiveFrom(c *Core, b bpv7.Bundle, peer string) {
+	bp := NewBundleDescriptorFromBundle(b, c.Store)
+	bp.PreviousNode = bpv7.MustNewEndpointID(peer)
+	_ = bp.Sync()
+
+	c.receive(bp)
+}
+
+func TestCoreDuplicateSuppression(t *testing.T) {
+	type reception struct {
+		delay     time.Duration
+		peer      string
+		delivered bool
+	}
+
+	tests := []struct {
+		name       string
+		conf       RoutingConf
+		receptions []reception
+	}{
+		{"held", RoutingConf{}, []reception{
+			{0, "dtn://a/", true}, {0, "dtn://a/", true}, {0, "dtn://b/", true}}},
+		{"global", RoutingConf{DuplicateSuppression: "global"}, []reception{
+			{0, "dtn://a/", true}, {0, "dtn://a/", false}, {0, "dtn://b/", false}}},
+		{"global with new peer override", RoutingConf{DuplicateSuppression: "global", DuplicateNewPeerOverride: true}, []reception{
+			{0, "dtn://a/", true}, {0, "dtn://a/", false}, {0, "dtn://b/", true}, {0, "dtn://b/", false}}},
+		{"window", RoutingConf{DuplicateSuppression: "window", DuplicateSuppressionWindow: "250ms"}, []reception{
+			{0, "dtn://a/", true}, {0, "dtn://a/", false}, {300 * time.Millisecond, "dtn://a/", true}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.conf.Algorithm = "epidemic"
+			c := newTestCoreConf(t, "dtn://node/", test.conf)
+
+			m := newMockAgent(bpv7.MustNewEndpointID("dtn://node/app"))
+			c.RegisterApplicationAgent(m)
+
+			b, err := bpv7.Builder().
+				Source("dtn://origin/app").
+				Destination("dtn://node/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			duplicates := uint64(0)
+			for i, rec := range test.receptions {
+				time.Sleep(rec.delay)
+				receiveFrom(c, b, rec.peer)
+
+				if _, delivered := m.received(100 * time.Millisecond); delivered != rec.delivered {
+					t.Fatalf("reception %d from %s was delivered: %t, expected %t", i, rec.peer, delivered, rec.delivered)
+				}
+
+				if !rec.delivered {
+					duplicates++
+				}
+				if n := c.DuplicateReceptions(); n != duplicates {
+					t.Fatalf("reception %d resulted in %d duplicates, expected %d", i, n, duplicates)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDisposedBundlesInvalid(t *testing.T) {
+	for _, conf := range []RoutingConf{
+		{DuplicateSuppression: "nope"},
+		{DuplicateSuppression: "window"},
+		{DuplicateSuppression: "window", DuplicateSuppressionWindow: "-1m"},
+	} {
+		if _, err := newDisposedBundles(conf); err == nil {
+			t.Fatalf("invalid configuration %v was accepted", conf)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// forwardBatch are the Bundles waiting to be sent to the same ConvergenceSender, together with the channels to report
+// each Send's result.
+type forwardBatch struct {
+	bundles []bpv7.Bundle
+	results []chan error
+}
+
+// forwardBatcher delays the forwarding of Bundles for a short window to send all Bundles for the same peer together.
+//
+// The first Bundle for a ConvergenceSender opens a new batch, which is sent back-to-back after the window elapsed.
+// Thus, a CLA might use its connection more efficiently, e.g., by pipelining multiple Bundles.
+type forwardBatcher struct {
+	window time.Duration
+
+	mutex   sync.Mutex
+	batches map[cla.ConvergenceSender]*forwardBatch
+}
+
+// newForwardBatcher for a window, which must be positive.
+func newForwardBatcher(window time.Duration) *forwardBatcher {
+	return &forwardBatcher{
+		window:  window,
+		batches: make(map[cla.ConvergenceSender]*forwardBatch),
+	}
+}
+
+// send a Bundle as part of the next batch to this ConvergenceSender. This method blocks until the batch was sent and
+// returns the ConvergenceSender's error for this Bundle.
+func (fb *forwardBatcher) send(cs cla.ConvergenceSender, b bpv7.Bundle) error {
+	result := make(chan error, 1)
+
+	fb.mutex.Lock()
+	batch, exists := fb.batches[cs]
+	if !exists {
+		batch = &forwardBatch{}
+		fb.batches[cs] = batch
+
+		time.AfterFunc(fb.window, func() { fb.flush(cs) })
+	}
+	batch.bundles = append(batch.bundles, b)
+	batch.results = append(batch.results, result)
+	fb.mutex.Unlock()
+
+	return <-result
+}
+
+// flush the current batch of a ConvergenceSender.
+func (fb *forwardBatcher) flush(cs cla.ConvergenceSender) {
+	fb.mutex.Lock()
+	batch := fb.batches[cs]
+	delete(fb.batches, cs)
+	fb.mutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"cla":     cs,
+		"bundles": len(batch.bundles),
+	}).Debug("Sending batch of bundles to a CLA (ConvergenceSender)")
+
+	for i, b := range batch.bundles {
+		batch.results[i] <- cs.Send(b)
+	}
+}
@@ -5,7 +5,10 @@
 package routing
 
 import (
+	"fmt"
+	"math"
 	"sync"
+	"time"
 
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
@@ -25,48 +28,91 @@ func newIdTuple(bndl *bpv7.Bundle) idTuple {
 	}
 }
 
+// idKeeperCleanInterval is the interval of a minute, as DtnTime is in milliseconds, after which an IdKeeper removes
+// outdated states on its own.
+const idKeeperCleanInterval = bpv7.DtnTime(time.Minute / time.Millisecond)
+
 // IdKeeper keeps track of the creation timestamp's sequence number for
 // outbounding bundles.
+//
+// Each source node's DTN time tick allows sequence numbers up to a maximum. Further bundles within the same tick are
+// rejected instead of wrapping around, which would result in duplicate bundle IDs.
 type IdKeeper struct {
-	data  map[idTuple]uint64
-	mutex sync.Mutex
+	data        map[idTuple]uint64
+	maxSequence uint64
+	lastClean   bpv7.DtnTime
+	mutex       sync.Mutex
 }
 
-// NewIdKeeper creates a new, empty IdKeeper.
+// NewIdKeeper creates a new, empty IdKeeper, allowing the whole range of sequence numbers.
 func NewIdKeeper() IdKeeper {
+	return NewIdKeeperWithMaxSequence(0)
+}
+
+// NewIdKeeperWithMaxSequence creates a new, empty IdKeeper, allowing sequence numbers up to maxSequence within each
+// DTN time tick. A maxSequence of zero allows the whole range of sequence numbers.
+func NewIdKeeperWithMaxSequence(maxSequence uint64) IdKeeper {
+	if maxSequence == 0 {
+		maxSequence = math.MaxUint64
+	}
+
 	return IdKeeper{
-		data: make(map[idTuple]uint64),
+		data:        make(map[idTuple]uint64),
+		maxSequence: maxSequence,
+		lastClean:   bpv7.DtnTimeNow(),
 	}
 }
 
 // update updates the IdKeeper's state regarding this bundle and sets this
-// bundle's sequence number.
-func (idk *IdKeeper) update(bp *BundleDescriptor) {
+// bundle's sequence number. An error is returned if the bundle's tick has
+// already exhausted its sequence numbers.
+func (idk *IdKeeper) update(bp *BundleDescriptor) error {
 	bndl := bp.MustBundle()
 	var tpl = newIdTuple(bndl)
 
 	idk.mutex.Lock()
-	if state, ok := idk.data[tpl]; ok {
-		idk.data[tpl] = state + 1
-	} else {
-		idk.data[tpl] = 0
+	defer idk.mutex.Unlock()
+
+	if now := bpv7.DtnTimeNow(); now >= idk.lastClean+idKeeperCleanInterval {
+		idk.clean(now)
 	}
 
-	bndl.PrimaryBlock.CreationTimestamp[1] = idk.data[tpl]
-	bp.Id.Timestamp[1] = idk.data[tpl]
-	idk.mutex.Unlock()
+	seq, ok := idk.data[tpl]
+	if ok {
+		if seq >= idk.maxSequence {
+			return fmt.Errorf("sequence numbers for %v at %v are exhausted after %d bundles",
+				tpl.source, tpl.time, idk.maxSequence)
+		}
+		seq++
+	}
+	idk.data[tpl] = seq
+
+	bndl.PrimaryBlock.CreationTimestamp[1] = seq
+	bp.Id.Timestamp[1] = seq
+	return nil
 }
 
-// Clean removes states which are older an hour and aren't the epoch time.
+// Clean removes states which are older than a minute and aren't the epoch time.
 func (idk *IdKeeper) Clean() {
 	idk.mutex.Lock()
+	defer idk.mutex.Unlock()
 
-	var threshold = bpv7.DtnTimeNow() - 60
+	idk.clean(bpv7.DtnTimeNow())
+}
+
+// clean removes states older than a minute before now, except those for the epoch time. Bundles without a clock share
+// the epoch time and must continue their sequence numbers. The mutex must be held.
+func (idk *IdKeeper) clean(now bpv7.DtnTime) {
+	var threshold bpv7.DtnTime
+	if now > idKeeperCleanInterval {
+		threshold = now - idKeeperCleanInterval
+	}
 
 	for tpl := range idk.data {
-		if tpl.time < threshold {
+		if tpl.time != 0 && tpl.time < threshold {
 			delete(idk.data, tpl)
 		}
 	}
-	idk.mutex.Unlock()
+
+	idk.lastClean = now
 }
@@ -74,3 +74,99 @@ func TestIdKeeper(t *testing.T) {
 		t.Errorf("Second bundle's sequence number is %d", seq)
 	}
 }
+
+// newIdKeeperTestDescriptor for a bundle from src, created at a fixed time.
+func newIdKeeperTestDescriptor(t *testing.T, src string, created time.Time) *BundleDescriptor {
+	b, err := bpv7.Builder().
+		Source(src).
+		Destination("dtn://dest/").
+		CreationTimestampTime(created).
+		Lifetime("1h").
+		PayloadBlock([]byte("hello world!")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &BundleDescriptor{
+		Id:          b.ID(),
+		Receiver:    bpv7.DtnNone(),
+		Timestamp:   time.Now(),
+		Constraints: make(map[Constraint]bool),
+		Tags:        make(map[Tag]struct{}),
+
+		bndl: &b,
+	}
+}
+
+func TestIdKeeperMaxSequence(t *testing.T) {
+	const maxSequence = 999
+
+	created := time.Now()
+	keeper := NewIdKeeperWithMaxSequence(maxSequence)
+
+	for i := uint64(0); i <= maxSequence; i++ {
+		bp := newIdKeeperTestDescriptor(t, "dtn://src/", created)
+		if err := keeper.update(bp); err != nil {
+			t.Fatalf("bundle %d was rejected: %v", i, err)
+		} else if seq := bp.MustBundle().PrimaryBlock.CreationTimestamp.SequenceNumber(); seq != i {
+			t.Fatalf("bundle %d got sequence number %d", i, seq)
+		} else if bp.ID().Timestamp.SequenceNumber() != i {
+			t.Fatalf("bundle %d's ID has sequence number %d", i, bp.ID().Timestamp.SequenceNumber())
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := keeper.update(newIdKeeperTestDescriptor(t, "dtn://src/", created)); err == nil {
+			t.Fatal("bundle exceeding the maximum sequence number was accepted")
+		}
+	}
+
+	// Neither another source nor the next tick, a millisecond later, are affected.
+	for _, bp := range []*BundleDescriptor{
+		newIdKeeperTestDescriptor(t, "dtn://other/", created),
+		newIdKeeperTestDescriptor(t, "dtn://src/", created.Add(time.Millisecond)),
+	} {
+		if err := keeper.update(bp); err != nil {
+			t.Fatal(err)
+		} else if seq := bp.MustBundle().PrimaryBlock.CreationTimestamp.SequenceNumber(); seq != 0 {
+			t.Fatalf("bundle %v got sequence number %d", bp.ID(), seq)
+		}
+	}
+}
+
+func TestIdKeeperCleanup(t *testing.T) {
+	keeper := NewIdKeeper()
+	now := time.Now()
+
+	outdated := newIdKeeperTestDescriptor(t, "dtn://src/", now.Add(-10*time.Minute))
+	current := newIdKeeperTestDescriptor(t, "dtn://src/", now)
+
+	epoch := newIdKeeperTestDescriptor(t, "dtn://src/", now)
+	epoch.MustBundle().PrimaryBlock.CreationTimestamp = bpv7.NewCreationTimestamp(bpv7.DtnTimeEpoch, 0)
+
+	for _, bp := range []*BundleDescriptor{outdated, current, epoch} {
+		if err := keeper.update(bp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Force the next update to clean up the outdated state on its own.
+	keeper.lastClean -= idKeeperCleanInterval
+	if err := keeper.update(newIdKeeperTestDescriptor(t, "dtn://src/", now)); err != nil {
+		t.Fatal(err)
+	}
+
+	keeper.mutex.Lock()
+	defer keeper.mutex.Unlock()
+
+	if _, ok := keeper.data[newIdTuple(outdated.MustBundle())]; ok {
+		t.Fatal("outdated state was not removed")
+	} else if _, ok := keeper.data[newIdTuple(current.MustBundle())]; !ok {
+		t.Fatal("current state was removed")
+	} else if _, ok := keeper.data[newIdTuple(epoch.MustBundle())]; !ok {
+		t.Fatal("epoch state was removed")
+	} else if len(keeper.data) != 2 {
+		t.Fatalf("expected two states, got %d", len(keeper.data))
+	}
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// NDJSONSinkConfig configures a NDJSONSink.
+type NDJSONSinkConfig struct {
+	// Path of the current file. Rotated files are renamed to this Path, suffixed by their rotation time.
+	Path string
+
+	// MaxSize in bytes after which the current file is rotated; disabled for zero.
+	MaxSize int64
+
+	// MaxAge after which the current file is rotated; disabled for zero.
+	MaxAge time.Duration
+
+	// Payload also includes each Bundle's payload within its NDJSONRecord.
+	Payload bool
+}
+
+// NDJSONEvent names the step of a Bundle's processing being recorded by a NDJSONRecord.
+type NDJSONEvent string
+
+const (
+	// NDJSONReceived is recorded for each newly received Bundle, but not for duplicates.
+	NDJSONReceived NDJSONEvent = "received"
+
+	// NDJSONDelivered is recorded after a Bundle was delivered to a local application agent.
+	NDJSONDelivered NDJSONEvent = "delivered"
+
+	// NDJSONForwarded is recorded after a Bundle was sent to at least one CLA.
+	NDJSONForwarded NDJSONEvent = "forwarded"
+)
+
+// NDJSONRecord is a Bundle's metadata for one NDJSONEvent, written as a single JSON line by a NDJSONSink.
+type NDJSONRecord struct {
+	Time  time.Time   `json:"time"`
+	Event NDJSONEvent `json:"event"`
+
+	BundleID          string                  `json:"bundle_id"`
+	Source            string                  `json:"source"`
+	Destination       string                  `json:"destination"`
+	ReportTo          string                  `json:"report_to"`
+	ControlFlags      bpv7.BundleControlFlags `json:"control_flags"`
+	CreationTimestamp bpv7.CreationTimestamp  `json:"creation_timestamp"`
+	Lifetime          uint64                  `json:"lifetime"` // in milliseconds
+	IsFragment        bool                    `json:"is_fragment"`
+
+	Receiver     string `json:"receiver,omitempty"`
+	PreviousNode string `json:"previous_node,omitempty"`
+
+	PayloadLength int    `json:"payload_length"`
+	Payload       []byte `json:"payload,omitempty"`
+}
+
+// newNDJSONRecord for a BundleDescriptor's NDJSONEvent, optionally including its payload.
+func newNDJSONRecord(bp BundleDescriptor, event NDJSONEvent, withPayload bool) (record NDJSONRecord, err error) {
+	b, err := bp.Bundle()
+	if err != nil {
+		return
+	}
+
+	pb := b.PrimaryBlock
+	record = NDJSONRecord{
+		Time:  time.Now(),
+		Event: event,
+
+		BundleID:          bp.ID().String(),
+		Source:            pb.SourceNode.String(),
+		Destination:       pb.Destination.String(),
+		ReportTo:          pb.ReportTo.String(),
+		ControlFlags:      pb.BundleControlFlags,
+		CreationTimestamp: pb.CreationTimestamp,
+		Lifetime:          pb.Lifetime,
+		IsFragment:        pb.BundleControlFlags.Has(bpv7.IsFragment),
+	}
+
+	if bp.HasReceiver() {
+		record.Receiver = bp.Receiver.String()
+	}
+	if bp.HasPreviousNode() {
+		record.PreviousNode = bp.PreviousNode.String()
+	}
+
+	if payloadBlock, payloadErr := b.PayloadBlock(); payloadErr == nil {
+		data := payloadBlock.Value.(*bpv7.PayloadBlock).Data()
+
+		record.PayloadLength = len(data)
+		if withPayload {
+			record.Payload = data
+		}
+	}
+	return
+}
+
+// NDJSONSink appends a Bundle's metadata as newline-delimited JSON to a file, e.g., for offline analytics. Each line
+// is a NDJSONRecord. The file is rotated by its size or age, based on the NDJSONSinkConfig.
+//
+// A NDJSONSink might be attached to a Core by EnableNDJSONSink.
+type NDJSONSink struct {
+	conf NDJSONSinkConfig
+
+	mutex  sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewNDJSONSink creates a NDJSONSink, appending to an already existing file.
+func NewNDJSONSink(conf NDJSONSinkConfig) (*NDJSONSink, error) {
+	if conf.Path == "" {
+		return nil, fmt.Errorf("NDJSON sink's path is empty")
+	} else if conf.MaxSize < 0 {
+		return nil, fmt.Errorf("NDJSON sink's max size %d is negative", conf.MaxSize)
+	} else if conf.MaxAge < 0 {
+		return nil, fmt.Errorf("NDJSON sink's max age %v is negative", conf.MaxAge)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.Path), 0700); err != nil {
+		return nil, err
+	}
+
+	sink := &NDJSONSink{conf: conf}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// open the current file for appending. The mutex must be held.
+func (sink *NDJSONSink) open() error {
+	f, err := os.OpenFile(sink.conf.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	sink.file = f
+	sink.size = fi.Size()
+	sink.opened = time.Now()
+	return nil
+}
+
+// rotate the current file by renaming it and opening a new one. If renaming fails, the current file is opened again.
+// The mutex must be held.
+func (sink *NDJSONSink) rotate() error {
+	_ = sink.file.Close()
+
+	rotated := fmt.Sprintf("%s.%s", sink.conf.Path, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	for i := 1; ; i++ {
+		if _, err := os.Stat(rotated); err != nil {
+			break
+		}
+		rotated = fmt.Sprintf("%s.%s-%d", sink.conf.Path, time.Now().UTC().Format("20060102T150405.000000000Z"), i)
+	}
+	renameErr := os.Rename(sink.conf.Path, rotated)
+
+	if err := sink.open(); err != nil {
+		sink.file = nil
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"path":    sink.conf.Path,
+		"rotated": rotated,
+		"error":   renameErr,
+	}).Debug("NDJSON sink rotated its file")
+
+	return renameErr
+}
+
+// needsRotation checks if the current file must be rotated before writing the next n bytes. An empty file is never
+// rotated. The mutex must be held.
+func (sink *NDJSONSink) needsRotation(n int) bool {
+	if sink.size == 0 {
+		return false
+	}
+
+	return (sink.conf.MaxSize > 0 && sink.size+int64(n) > sink.conf.MaxSize) ||
+		(sink.conf.MaxAge > 0 && time.Since(sink.opened) > sink.conf.MaxAge)
+}
+
+// Write a NDJSONRecord as a new line, possibly rotating the file before.
+func (sink *NDJSONSink) Write(record NDJSONRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.file == nil {
+		return fmt.Errorf("NDJSON sink is closed")
+	}
+
+	if sink.needsRotation(len(line)) {
+		if err := sink.rotate(); err != nil && sink.file == nil {
+			return err
+		} else if err != nil {
+			// The current file was opened again; the line is appended to it instead of being lost.
+			log.WithField("path", sink.conf.Path).WithError(err).Warn("NDJSON sink failed to rotate its file")
+		}
+	}
+
+	n, err := sink.file.Write(line)
+	sink.size += int64(n)
+	return err
+}
+
+// Close the current file. Afterwards, no more records are written.
+func (sink *NDJSONSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.file == nil {
+		return nil
+	}
+
+	err := sink.file.Close()
+	sink.file = nil
+	return err
+}
+
+// record writes a Bundle's NDJSONRecord for an NDJSONEvent.
+func (sink *NDJSONSink) record(bp BundleDescriptor, event NDJSONEvent) {
+	record, err := newNDJSONRecord(bp, event, sink.conf.Payload)
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("NDJSON sink failed to load bundle")
+		return
+	}
+
+	if err := sink.Write(record); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("NDJSON sink failed to write bundle")
+	}
+}
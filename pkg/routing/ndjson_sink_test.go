@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// readNDJSON parses each line of a file as a NDJSONRecord.
+func readNDJSON(t *testing.T, path string) (records []NDJSONRecord) {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record NDJSONRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %q is no valid JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestCoreNDJSONSink(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	c.claManager.Register(newMockSender("peer", "dtn://peer/", cla.MTCP))
+
+	path := filepath.Join(t.TempDir(), "bundles.ndjson")
+	if _, err := c.EnableNDJSONSink(NDJSONSinkConfig{Path: path, Payload: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var bundles []bpv7.Bundle
+	for i := 0; i < 3; i++ {
+		b := newRingTestBundle(t, i)
+		bundles = append(bundles, b)
+
+		c.dispatching(NewBundleDescriptorFromBundle(b, c.Store))
+	}
+
+	records := readNDJSON(t, path)
+	if len(records) != len(bundles) {
+		t.Fatalf("expected %d records, got %d", len(bundles), len(records))
+	}
+
+	for i, record := range records {
+		b := bundles[i]
+		payload := []byte(fmt.Sprintf("bundle %d", i))
+
+		if record.BundleID != b.ID().String() {
+			t.Fatalf("record %d is for %s, not %v", i, record.BundleID, b.ID())
+		} else if record.Event != NDJSONForwarded {
+			t.Fatalf("record %d has event %q", i, record.Event)
+		} else if record.Source != b.PrimaryBlock.SourceNode.String() || record.Destination != "dtn://elsewhere/app" {
+			t.Fatalf("record %d has source %s and destination %s", i, record.Source, record.Destination)
+		} else if record.CreationTimestamp != b.PrimaryBlock.CreationTimestamp {
+			t.Fatalf("record %d has creation timestamp %v", i, record.CreationTimestamp)
+		} else if record.PayloadLength != len(payload) || !bytes.Equal(record.Payload, payload) {
+			t.Fatalf("record %d has payload %q of length %d", i, record.Payload, record.PayloadLength)
+		}
+	}
+}
+
+func TestCoreNDJSONSinkRetries(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	peer := newMockSender("peer", "dtn://peer/", cla.MTCP)
+	peer.sendErr = fmt.Errorf("no contact")
+	c.claManager.Register(peer)
+
+	path := filepath.Join(t.TempDir(), "bundles.ndjson")
+	if _, err := c.EnableNDJSONSink(NDJSONSinkConfig{Path: path}); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newRingTestBundle(t, 0)
+	bp := NewBundleDescriptorFromBundle(b, c.Store)
+	bp.Receiver = c.NodeId
+	c.receive(bp)
+
+	// Neither the failed forwarding attempts nor retrying the pending bundle are recorded.
+	for i := 0; i < 3; i++ {
+		c.CheckPendingBundles()
+	}
+
+	peer.mutex.Lock()
+	peer.sendErr = nil
+	peer.mutex.Unlock()
+
+	c.CheckPendingBundles()
+
+	var events []NDJSONEvent
+	for _, record := range readNDJSON(t, path) {
+		if record.BundleID != b.ID().String() {
+			t.Fatalf("record is for %s, not %v", record.BundleID, b.ID())
+		}
+		events = append(events, record.Event)
+	}
+
+	if expected := []NDJSONEvent{NDJSONReceived, NDJSONForwarded}; !reflect.DeepEqual(events, expected) {
+		t.Fatalf("expected events %v, got %v", expected, events)
+	}
+}
+
+func TestNDJSONSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundles.ndjson")
+
+	record := NDJSONRecord{BundleID: "dtn://node/-0-0", Source: "dtn://node/", Destination: "dtn://elsewhere/"}
+	line, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lineLen := int64(len(line) + 1)
+
+	// Each file holds two records at most.
+	sink, err := NewNDJSONSink(NDJSONSinkConfig{Path: path, MaxSize: 2*lineLen + 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := sink.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(path + "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+
+	// The current file comes first, followed by the rotated files in their rotation order.
+	expected := []int{1, 2, 2}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files, got %v", len(expected), files)
+	}
+
+	for i, file := range files {
+		if fi, err := os.Stat(file); err != nil {
+			t.Fatal(err)
+		} else if fi.Size() > 2*lineLen+1 {
+			t.Fatalf("file %s has %d bytes, exceeding its max size", file, fi.Size())
+		}
+
+		if records := readNDJSON(t, file); len(records) != expected[i] {
+			t.Fatalf("file %s holds %d records, not %d", file, len(records), expected[i])
+		}
+	}
+
+	if err := sink.Write(record); err == nil {
+		t.Fatal("closed sink accepted a record")
+	}
+}
+
+func TestNDJSONSinkRotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundles.ndjson")
+
+	sink, err := NewNDJSONSink(NDJSONSinkConfig{Path: path, MaxSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	record := NDJSONRecord{BundleID: "dtn://node/-0-0", Source: "dtn://node/", Destination: "dtn://elsewhere/"}
+	if err := sink.Write(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming the vanished file fails while rotating, but the next record must still be written.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if records := readNDJSON(t, path); len(records) != 1 {
+		t.Fatalf("expected one record after the failed rotation, got %d", len(records))
+	}
+}
+
+func TestNDJSONSinkInvalidConfig(t *testing.T) {
+	for _, conf := range []NDJSONSinkConfig{
+		{},
+		{Path: filepath.Join(t.TempDir(), "bundles.ndjson"), MaxSize: -1},
+		{Path: filepath.Join(t.TempDir(), "bundles.ndjson"), MaxAge: -1},
+	} {
+		if _, err := NewNDJSONSink(conf); err == nil {
+			t.Fatalf("invalid configuration %v was accepted", conf)
+		}
+	}
+}
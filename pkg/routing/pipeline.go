@@ -15,7 +15,7 @@ import (
 type Pipeline struct {
 	NodeId bpv7.EndpointID
 
-	Store        *storage.Store
+	Store        storage.BundleStore
 	Algorithm    Algorithm
 	AgentManager AgentManager
 
@@ -33,10 +33,17 @@ func CheckLifetime(_ *Pipeline, descriptor BundleDescriptor) (err error) {
 
 // CheckHopCount of an optionally exceeded HopCountBlock.
 func CheckHopCount(_ *Pipeline, descriptor BundleDescriptor) (err error) {
-	if block, blockErr := descriptor.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); blockErr == nil {
-		if block.Value.(*bpv7.HopCountBlock).IsExceeded() {
-			err = errors.New("hop count block is exceeded")
-		}
+	if hc, ok := hopCountBlock(descriptor); ok && hc.IsExceeded() {
+		err = errors.New("hop count block is exceeded")
 	}
 	return
 }
+
+// hopCountBlock returns a Bundle's HopCountBlock, if present and not encrypted by a BCB.
+func hopCountBlock(descriptor BundleDescriptor) (*bpv7.HopCountBlock, bool) {
+	if cb, err := descriptor.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
+		hc, ok := cb.Value.(*bpv7.HopCountBlock)
+		return hc, ok
+	}
+	return nil, false
+}
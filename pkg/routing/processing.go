@@ -6,7 +6,12 @@
 package routing
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -21,7 +26,7 @@ func (c *Core) SendBundle(bndl *bpv7.Bundle) {
 	}
 	bp := NewBundleDescriptorFromBundle(*bndl, c.Store)
 
-	c.routing.NotifyNewBundle(bp)
+	c.routingAlgorithm().NotifyNewBundle(bp)
 	c.transmit(bp)
 }
 
@@ -53,7 +58,11 @@ func (c *Core) sendBundleAttachSignature(bndl *bpv7.Bundle) {
 // transmit starts the transmission of an outgoing bundle pack.
 // Therefore, the source's endpoint ID must be dtn:none or a member of this node.
 func (c *Core) transmit(bp BundleDescriptor) {
-	c.IdKeeper.update(&bp)
+	if err := c.IdKeeper.update(&bp); err != nil {
+		// The store is left untouched, as bp.Id still holds a sequence number already assigned to another bundle.
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Bundle cannot be assigned a sequence number")
+		return
+	}
 
 	log.WithField("bundle", bp.ID().String()).Info("Transmission of bundle requested")
 
@@ -74,6 +83,32 @@ func (c *Core) transmit(bp BundleDescriptor) {
 	c.dispatching(bp)
 }
 
+// receiveDuplicate counts a received bundle whose ID is already known. Depending on the duplicate policy, its previous
+// node is reported to a DuplicateAwareAlgorithm, as this node also has the bundle.
+func (c *Core) receiveDuplicate(bp BundleDescriptor) {
+	c.duplicatesMutex.Lock()
+	c.duplicates++
+	c.duplicatesMutex.Unlock()
+
+	if !c.reportDuplicates {
+		return
+	}
+
+	peer, ok := previousNode(bp, true)
+	if !ok {
+		return
+	}
+
+	if daa, ok := c.routingAlgorithm().(DuplicateAwareAlgorithm); ok {
+		log.WithFields(log.Fields{
+			"bundle": bp.ID().String(),
+			"peer":   peer,
+		}).Debug("Reporting duplicate reception to routing algorithm")
+
+		daa.ReportDuplicate(bp, peer)
+	}
+}
+
 // receive handles received/incoming bundles.
 func (c *Core) receive(bp BundleDescriptor) {
 	log.WithField("bundle", bp.ID().String()).Debug("Received new bundle")
@@ -81,20 +116,44 @@ func (c *Core) receive(bp BundleDescriptor) {
 	if len(bp.Constraints) > 0 {
 		log.WithField("bundle", bp.ID().String()).Debug("Received bundle's ID is already known.")
 
+		c.receiveDuplicate(bp)
+
 		// bundleDeletion is _not_ called because this would delete the already
 		// stored BundleDescriptor.
 		return
 	}
 
+	if c.receiveDisposed(bp) {
+		return
+	}
+
+	if !c.receiveSourceVerification(bp) {
+		return
+	}
+
 	log.WithField("bundle", bp.ID().String()).Info("Processing newly received bundle")
 
 	bp.AddConstraint(DispatchPending)
 	_ = bp.Sync()
 
+	c.recordNDJSON(bp, NDJSONReceived)
+
 	if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestReception) {
 		c.SendStatusReport(bp, bpv7.ReceivedBundle, bpv7.NoInformation)
 	}
 
+	for _, cb := range bp.MustBundle().CorruptBlocks() {
+		log.WithFields(log.Fields{
+			"bundle": bp.ID().String(),
+			"number": cb.BlockNumber,
+			"type":   cb.TypeCode(),
+		}).Warn("Bundle's canonical block with an invalid CRC was removed")
+
+		if cb.BlockControlFlags.Has(bpv7.StatusReportBlock) {
+			c.SendStatusReport(bp, bpv7.ReceivedBundle, bpv7.BlockUnintelligible)
+		}
+	}
+
 	for i := len(bp.MustBundle().CanonicalBlocks) - 1; i >= 0; i-- {
 		var cb = &bp.MustBundle().CanonicalBlocks[i]
 
@@ -141,7 +200,12 @@ func (c *Core) receive(bp BundleDescriptor) {
 		}
 	}
 
-	c.routing.NotifyNewBundle(bp)
+	if !c.checkIntegrity(bp) {
+		c.bundleDeletion(bp, bpv7.FailedSecurityOperation)
+		return
+	}
+
+	c.routingAlgorithm().NotifyNewBundle(bp)
 
 	c.dispatching(bp)
 }
@@ -150,10 +214,10 @@ func (c *Core) receive(bp BundleDescriptor) {
 func (c *Core) dispatching(bp BundleDescriptor) {
 	log.WithField("bundle", bp.ID().String()).Info("Dispatching bundle")
 
-	if !c.routing.DispatchingAllowed(bp) {
+	if routing := c.routingAlgorithm(); !routing.DispatchingAllowed(bp) {
 		log.WithFields(log.Fields{
 			"bundle":  bp.ID().String(),
-			"routing": c.routing,
+			"routing": routing,
 		}).Info("Routing Algorithm has not allowed dispatching of bundle")
 		return
 	}
@@ -167,7 +231,11 @@ func (c *Core) dispatching(bp BundleDescriptor) {
 		return
 	}
 
-	if c.HasEndpoint(bndl.PrimaryBlock.Destination) {
+	c.runDispatchHooks(bp)
+
+	if c.isUnknownLocalEndpoint(bndl) {
+		c.unknownEndpointDelivery(bp)
+	} else if c.HasEndpoint(bndl.PrimaryBlock.Destination) {
 		c.localDelivery(bp)
 	} else {
 		c.forward(bp)
@@ -182,10 +250,8 @@ func (c *Core) forward(bp BundleDescriptor) {
 	bp.RemoveConstraint(DispatchPending)
 	_ = bp.Sync()
 
-	if hcBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
-		hc := hcBlock.Value.(*bpv7.HopCountBlock)
+	if hc, ok := hopCountBlock(bp); ok {
 		hc.Increment()
-		hcBlock.Value = hc
 
 		log.WithFields(log.Fields{
 			"bundle":    bp.ID().String(),
@@ -222,16 +288,27 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}
 	}
 
-	if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
-		// Replace the PreviousNodeBlock
-		prevEid := pnBlock.Value.(*bpv7.PreviousNodeBlock).Endpoint()
-		pnBlock.Value = bpv7.NewPreviousNodeBlock(c.NodeId)
+	if c.suppressPrevNode {
+		// Remove a received PreviousNodeBlock instead of disclosing this node
+		if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
+			bp.MustBundle().RemoveExtensionBlockByBlockNumber(pnBlock.BlockNumber)
 
-		log.WithFields(log.Fields{
-			"bundle":  bp.ID().String(),
-			"old_eid": prevEid,
-			"new_eid": c.NodeId,
-		}).Debug("Previous Node Block updated")
+			log.WithField("bundle", bp.ID().String()).Debug("Previous Node Block removed")
+		}
+	} else if pnBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypePreviousNodeBlock); err == nil {
+		if prevNodeBlock, ok := pnBlock.Value.(*bpv7.PreviousNodeBlock); ok {
+			// Replace the PreviousNodeBlock
+			pnBlock.Value = bpv7.NewPreviousNodeBlock(c.NodeId)
+
+			log.WithFields(log.Fields{
+				"bundle":  bp.ID().String(),
+				"old_eid": prevNodeBlock.Endpoint(),
+				"new_eid": c.NodeId,
+			}).Debug("Previous Node Block updated")
+		} else {
+			// An encrypted PreviousNodeBlock cannot be replaced without breaking its BCB
+			log.WithField("bundle", bp.ID().String()).Debug("Previous Node Block is encrypted, leaving it untouched")
+		}
 	} else {
 		// Append a new PreviousNodeBlock
 		if err := bp.MustBundle().AddExtensionBlock(bpv7.NewCanonicalBlock(
@@ -243,16 +320,40 @@ func (c *Core) forward(bp BundleDescriptor) {
 		}
 	}
 
+	if c.traceBlockLimit > 0 {
+		c.appendTrace(bp)
+	}
+
+	c.accumulateCosts(bp)
+
 	var nodes []cla.ConvergenceSender
 	var deleteAfterwards = true
+	var routing = c.routingAlgorithm()
 
 	// Try a direct delivery or consult the Algorithm otherwise.
 	nodes = c.senderForDestination(bp.MustBundle().PrimaryBlock.Destination)
 	if nodes == nil {
-		nodes, deleteAfterwards = c.routing.SenderForBundle(bp)
+		nodes, deleteAfterwards = routing.SenderForBundle(bp)
 	}
+	nodes = c.claAllowlist.filter(bp, nodes)
+	nodes = filterTraced(bp, nodes)
+
+	if c.forwardBatcher != nil {
+		c.forwardWg.Add(1)
+		go func() {
+			defer c.forwardWg.Done()
+			c.forwardToSenders(bp, routing, nodes, deleteAfterwards)
+		}()
+	} else {
+		c.forwardToSenders(bp, routing, nodes, deleteAfterwards)
+	}
+}
 
+// forwardToSenders sends a bundle pack's bundle to the ConvergenceSenders selected by the routing Algorithm and
+// handles the outcome.
+func (c *Core) forwardToSenders(bp BundleDescriptor, routing Algorithm, nodes []cla.ConvergenceSender, deleteAfterwards bool) {
 	var bundleSent = false
+	var oversized int32
 
 	var wg sync.WaitGroup
 	var once sync.Once
@@ -266,20 +367,28 @@ func (c *Core) forward(bp BundleDescriptor) {
 				"cla":    node,
 			}).Info("Sending bundle to a CLA (ConvergenceSender)")
 
-			if err := node.Send(*bp.MustBundle()); err != nil {
+			if err := c.sendFragmented(node, *bp.MustBundle()); errors.Is(err, errMustNotFragment) {
+				log.WithFields(log.Fields{
+					"bundle": bp.ID().String(),
+					"cla":    node,
+				}).Info("Bundle exceeds the CLA's MTU, but must not be fragmented")
+
+				atomic.AddInt32(&oversized, 1)
+			} else if err != nil {
 				log.WithFields(log.Fields{
 					"bundle": bp.ID().String(),
 					"cla":    node,
 					"error":  err,
 				}).Warn("Sending bundle failed")
 
-				c.routing.ReportFailure(bp, node)
+				routing.ReportFailure(bp, node)
 			} else {
 				log.WithFields(log.Fields{
 					"bundle": bp.ID().String(),
 					"cla":    node,
 				}).Printf("Sending bundle succeeded")
 
+				c.claManager.CountForwarded(node)
 				once.Do(func() { bundleSent = true })
 			}
 
@@ -289,10 +398,8 @@ func (c *Core) forward(bp BundleDescriptor) {
 
 	wg.Wait()
 
-	if hcBlock, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeHopCountBlock); err == nil {
-		hc := hcBlock.Value.(*bpv7.HopCountBlock)
+	if hc, ok := hopCountBlock(bp); ok {
 		hc.Decrement()
-		hcBlock.Value = hc
 
 		log.WithFields(log.Fields{
 			"bundle":    bp.ID().String(),
@@ -301,25 +408,82 @@ func (c *Core) forward(bp BundleDescriptor) {
 	}
 
 	if bundleSent {
+		c.recordNDJSON(bp, NDJSONForwarded)
+
 		if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestForward) {
 			c.SendStatusReport(bp, bpv7.ForwardedBundle, bpv7.NoInformation)
 		}
 
 		if deleteAfterwards {
-			bp.PurgeConstraints()
-			_ = bp.Sync()
+			c.disposeBundle(bp)
 		} else if c.InspectAllBundles && bp.MustBundle().IsAdministrativeRecord() {
 			c.bundleContraindicated(bp)
 			c.checkAdministrativeRecord(bp)
 		} else {
 			c.bundleContraindicated(bp)
 		}
+	} else if len(nodes) > 0 && int(oversized) == len(nodes) {
+		log.WithField("bundle", bp.ID().String()).Info("Bundle exceeds all CLAs' MTU, but must not be fragmented")
+
+		c.bundleDeletion(bp, bpv7.TransmissionCanceled)
 	} else {
 		log.WithField("bundle", bp.ID().String()).Info("Failed to forward bundle to any CLA")
+
 		c.bundleContraindicated(bp)
 	}
 }
 
+// errMustNotFragment is returned by sendFragmented for a Bundle exceeding the MTU, which must not be fragmented.
+var errMustNotFragment = errors.New("bundle exceeds the MTU, but must not be fragmented")
+
+// sendFragmented sends a Bundle to a ConvergenceSender. If the Bundle exceeds the ConvergenceSender's MTU, its
+// fragments are sent instead. A Bundle whose control flags forbid fragmentation is not sent, returning
+// errMustNotFragment.
+func (c *Core) sendFragmented(cs cla.ConvergenceSender, b bpv7.Bundle) error {
+	mtu, ok := cla.GetMTU(cs)
+	if !ok {
+		return c.sendToSender(cs, b)
+	}
+
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		return err
+	} else if buf.Len() <= mtu {
+		return c.sendToSender(cs, b)
+	}
+
+	if b.PrimaryBlock.BundleControlFlags.Has(bpv7.MustNotFragmented) {
+		return errMustNotFragment
+	}
+
+	fragments, err := b.Fragment(mtu)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"bundle":    b.ID().String(),
+		"cla":       cs,
+		"mtu":       mtu,
+		"fragments": len(fragments),
+	}).Debug("Bundle exceeds the CLA's MTU and was fragmented")
+
+	for _, fragment := range fragments {
+		if err := c.sendToSender(cs, fragment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendToSender sends a Bundle to a ConvergenceSender, either immediately or as part of a batch.
+func (c *Core) sendToSender(cs cla.ConvergenceSender, b bpv7.Bundle) error {
+	if c.forwardBatcher != nil {
+		return c.forwardBatcher.send(cs, b)
+	}
+	return cs.Send(b)
+}
+
 // checkAdministrativeRecord checks administrative records. If this method
 // returns false, an error occured.
 func (c *Core) checkAdministrativeRecord(bp BundleDescriptor) bool {
@@ -422,6 +586,8 @@ func (c *Core) inspectStatusReport(bp BundleDescriptor, ar bpv7.AdministrativeRe
 				logger.Info("Status report indicates delivered bundle, deleting bundle")
 			}
 
+			c.agentManager.ConfirmDelivery(status.RefBundle)
+
 		default:
 			log.WithFields(log.Fields{
 				"bundle":        bp.ID().String(),
@@ -434,10 +600,25 @@ func (c *Core) inspectStatusReport(bp BundleDescriptor, ar bpv7.AdministrativeRe
 }
 
 func (c *Core) localDelivery(bp BundleDescriptor) {
-	// TODO: check fragmentation
-
 	log.WithField("bundle", bp.ID().String()).Info("Received bundle for local delivery")
 
+	if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.IsFragment) {
+		var reassembled bool
+		if bp, reassembled = c.reassemble(bp); !reassembled {
+			return
+		}
+	}
+
+	if !c.checkIdentityAssertion(bp) {
+		c.bundleDeletion(bp, bpv7.NoInformation)
+		return
+	}
+
+	if bp.MustBundle().PrimaryBlock.Destination.IsAdministrativeEndpoint() {
+		c.administrativeDelivery(bp)
+		return
+	}
+
 	if bp.MustBundle().IsAdministrativeRecord() {
 		if !c.checkAdministrativeRecord(bp) {
 			c.bundleDeletion(bp, bpv7.NoInformation)
@@ -445,19 +626,121 @@ func (c *Core) localDelivery(bp BundleDescriptor) {
 		}
 	}
 
+	if err := bpv7.DecompressPayload(bp.MustBundle(), c.maxDecompressed); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Decompressing local bundle's payload erred")
+
+		c.bundleDeletion(bp, bpv7.BlockUnintelligible)
+		return
+	}
+
 	bp.AddConstraint(LocalEndpoint)
 	_ = bp.Sync()
 
 	if err := c.agentManager.Deliver(bp); err != nil {
 		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Delivering local bundle erred")
+
+		c.deadLetterDelivery(bp, bpv7.DestEndpointUnintelligible)
+	} else {
+		c.recordNDJSON(bp, NDJSONDelivered)
+
+		if daa, ok := c.routingAlgorithm().(DeliveryAwareAlgorithm); ok && !bp.MustBundle().IsAdministrativeRecord() {
+			daa.ReportDelivery(bp)
+		}
 	}
 
 	if bp.MustBundle().PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestDelivery) {
 		c.SendStatusReport(bp, bpv7.DeliveredBundle, bpv7.NoInformation)
 	}
 
+	c.disposeBundle(bp)
+}
+
+// reassemble a fragment addressed to this node. The fragment is held by the Reassembler and released from the store.
+// If this fragment completes its original bundle, the reassembled bundle's descriptor is returned together with true.
+func (c *Core) reassemble(bp BundleDescriptor) (BundleDescriptor, bool) {
+	c.expireReassemblies()
+
+	b, done, err := c.reassembler.Insert(*bp.MustBundle())
+	if err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Warn("Reassembling fragment erred")
+
+		c.bundleDeletion(bp, bpv7.NoInformation)
+		return bp, false
+	}
+
 	bp.PurgeConstraints()
 	_ = bp.Sync()
+
+	if !done {
+		log.WithField("bundle", bp.ID().String()).Info("Holding fragment for reassembly")
+		return bp, false
+	}
+
+	log.WithFields(log.Fields{
+		"fragment": bp.ID().String(),
+		"bundle":   b.ID().String(),
+	}).Info("Reassembled bundle from its fragments")
+
+	return NewBundleDescriptorFromBundle(*b, c.Store), true
+}
+
+// expireReassemblies discards incomplete reassemblies exceeding the reassembly timeout. As their fragments were
+// already released from the store, a DeletedBundle status report is sent directly for each fragment requesting one.
+func (c *Core) expireReassemblies() {
+	for _, frag := range c.reassembler.ExpireTimedOut() {
+		frag := frag
+
+		log.WithField("bundle", frag.ID().String()).Info("Discarding fragment of a timed out reassembly")
+
+		if frag.PrimaryBlock.BundleControlFlags.Has(bpv7.StatusRequestDeletion) {
+			bp := NewBundleDescriptor(frag.ID(), c.Store)
+			bp.bndl = &frag
+			c.SendStatusReport(bp, bpv7.DeletedBundle, bpv7.NoInformation)
+		}
+	}
+}
+
+// checkIdentityAssertion verifies a bundle's IdentityAssertionBlock if its source node's key is known. If this method
+// returns false, the bundle's source might be forged.
+func (c *Core) checkIdentityAssertion(bp BundleDescriptor) bool {
+	source := bp.MustBundle().PrimaryBlock.SourceNode
+
+	for node, key := range c.identityKeys {
+		if !node.SameNode(source) {
+			continue
+		}
+
+		if err := bp.MustBundle().VerifyIdentityAssertion(key); err != nil {
+			log.WithFields(log.Fields{
+				"bundle": bp.ID().String(),
+				"source": source,
+				"error":  err,
+			}).Warn("Bundle failed the identity assertion of its source node")
+			return false
+		}
+		return true
+	}
+	return true
+}
+
+// administrativeDelivery handles bundles addressed to this node's administrative endpoint, e.g., "ipn:23.0".
+// Those bundles are processed by the node itself and are never passed to an application agent.
+func (c *Core) administrativeDelivery(bp BundleDescriptor) {
+	log.WithField("bundle", bp.ID().String()).Info("Received bundle for the administrative endpoint")
+
+	if !bp.MustBundle().IsAdministrativeRecord() {
+		log.WithField("bundle", bp.ID().String()).Warn("Bundle for the administrative endpoint is no administrative record")
+
+		c.bundleDeletion(bp, bpv7.NoInformation)
+		return
+	}
+
+	if !c.checkAdministrativeRecord(bp) {
+		c.bundleDeletion(bp, bpv7.NoInformation)
+		return
+	}
+
+	c.disposeBundle(bp)
 }
 
 func (c *Core) bundleContraindicated(bp BundleDescriptor) {
@@ -472,8 +755,79 @@ func (c *Core) bundleDeletion(bp BundleDescriptor, reason bpv7.StatusReportReaso
 		c.SendStatusReport(bp, bpv7.DeletedBundle, reason)
 	}
 
-	bp.PurgeConstraints()
-	_ = bp.Sync()
+	c.deadLetterDelivery(bp, reason)
+
+	c.disposeBundle(bp)
 
 	log.WithField("bundle", bp.ID().String()).Info("Bundle was marked for deletion")
 }
+
+// deadLetterLifetime is the lifetime of bundles created for the dead-letter endpoint.
+const deadLetterLifetime = 24 * time.Hour
+
+// deadLetterDelivery passes a bundle, which is about to be dropped, to the optional dead-letter endpoint. Therefore,
+// the bundle is CBOR encoded as the payload of a new bundle from this node, addressed to the dead-letter endpoint.
+// Bundles which are already addressed to the dead-letter endpoint are not passed on again to avoid loops.
+func (c *Core) deadLetterDelivery(bp BundleDescriptor, reason bpv7.StatusReportReason) {
+	if c.deadLetter == bpv7.DtnNone() {
+		return
+	}
+
+	b, bErr := bp.Bundle()
+	if bErr != nil || b.PrimaryBlock.Destination == c.deadLetter {
+		return
+	}
+
+	logger := log.WithFields(log.Fields{
+		"bundle":      bp.ID().String(),
+		"dead-letter": c.deadLetter,
+		"reason":      reason,
+	})
+
+	if !c.agentManager.HasEndpoint(c.deadLetter) {
+		logger.Warn("No application agent is registered for the dead-letter endpoint")
+		return
+	}
+
+	logger.Info("Delivering dropped bundle to the dead-letter endpoint")
+
+	if err := c.encapsulatedDelivery(b, c.deadLetter, deadLetterLifetime); err != nil {
+		logger.WithError(err).Warn("Delivering bundle to the dead-letter endpoint erred")
+	}
+}
+
+// encapsulatedDelivery delivers a bundle to another local endpoint. Therefore, the bundle is CBOR encoded as the
+// payload of a new bundle from this node with the given lifetime.
+func (c *Core) encapsulatedDelivery(b *bpv7.Bundle, endpoint bpv7.EndpointID, lifetime time.Duration) error {
+	var buf bytes.Buffer
+	if err := b.MarshalCbor(&buf); err != nil {
+		return fmt.Errorf("serializing bundle failed: %v", err)
+	}
+
+	encapsulated, err := bpv7.Builder().
+		Source(c.NodeId).
+		Destination(endpoint).
+		CreationTimestampNow().
+		Lifetime(lifetime).
+		PayloadBlock(buf.Bytes()).
+		Build()
+	if err != nil {
+		return fmt.Errorf("creating bundle failed: %v", err)
+	}
+
+	// As for all other bundles created by this node, e.g., status reports, the IdKeeper assigns the sequence number
+	// before the new bundle is stored. Otherwise, two bundles might share a BundleID within the same millisecond.
+	idBp := BundleDescriptor{Id: encapsulated.ID(), bndl: &encapsulated}
+	if err := c.IdKeeper.update(&idBp); err != nil {
+		return fmt.Errorf("assigning a sequence number failed: %v", err)
+	}
+
+	// Without any constraints, the AgentManager removes the new bundle from the store after its delivery.
+	encapsulatedBp := NewBundleDescriptorFromBundle(encapsulated, c.Store)
+	if err := c.agentManager.Deliver(encapsulatedBp); err != nil {
+		encapsulatedBp.PurgeConstraints()
+		_ = encapsulatedBp.Sync()
+		return err
+	}
+	return nil
+}
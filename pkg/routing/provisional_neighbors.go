@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// provisionalNeighborTimeout is the default time a discovered neighbor stays within the routing state without an
+// established CLA connection.
+const provisionalNeighborTimeout = 30 * time.Second
+
+// provisionalNeighbors tracks neighbors which were discovered, but are not yet connected by a CLA. Each neighbor has
+// a timer which reports it as lost to a NeighborAwareAlgorithm, unless a CLA for it has appeared in the meantime.
+type provisionalNeighbors struct {
+	timeout time.Duration
+	timers  map[bpv7.EndpointID]*time.Timer
+	mutex   sync.Mutex
+}
+
+// newProvisionalNeighbors with a timeout after which an unconnected neighbor is lost.
+func newProvisionalNeighbors(timeout time.Duration) *provisionalNeighbors {
+	return &provisionalNeighbors{
+		timeout: timeout,
+		timers:  make(map[bpv7.EndpointID]*time.Timer),
+	}
+}
+
+// add a neighbor or resets its timer, if already known. The lost function is called when the timer expires.
+func (pn *provisionalNeighbors) add(peer bpv7.EndpointID, lost func()) {
+	pn.mutex.Lock()
+	defer pn.mutex.Unlock()
+
+	if timer, ok := pn.timers[peer]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(pn.timeout, func() {
+		pn.mutex.Lock()
+		current, ok := pn.timers[peer]
+		if ok && current == timer {
+			delete(pn.timers, peer)
+		}
+		pn.mutex.Unlock()
+
+		if ok && current == timer {
+			lost()
+		}
+	})
+	pn.timers[peer] = timer
+}
+
+// confirm a neighbor, whose CLA has appeared. Its timer is stopped and true is returned if it was provisional.
+func (pn *provisionalNeighbors) confirm(peer bpv7.EndpointID) bool {
+	pn.mutex.Lock()
+	defer pn.mutex.Unlock()
+
+	timer, ok := pn.timers[peer]
+	if ok {
+		timer.Stop()
+		delete(pn.timers, peer)
+	}
+	return ok
+}
+
+// close stops all timers without reporting any neighbor as lost.
+func (pn *provisionalNeighbors) close() {
+	pn.mutex.Lock()
+	defer pn.mutex.Unlock()
+
+	for peer, timer := range pn.timers {
+		timer.Stop()
+		delete(pn.timers, peer)
+	}
+}
+
+// ReportNeighborDiscovered provisionally adds a neighbor, e.g., found by the discovery, to a NeighborAwareAlgorithm's
+// state before its CLA connection is established. This speeds up the routing's convergence. If no CLA for this
+// neighbor appears within a timeout, the neighbor is reported as lost again.
+//
+// Neighbors without a known endpoint ID, this node itself, and already connected neighbors are ignored.
+func (c *Core) ReportNeighborDiscovered(peer bpv7.EndpointID) {
+	if peer == bpv7.DtnNone() || c.NodeId.SameNode(peer) || len(c.senderForDestination(peer)) > 0 {
+		return
+	}
+
+	algorithm, ok := c.routingAlgorithm().(NeighborAwareAlgorithm)
+	if !ok {
+		return
+	}
+
+	log.WithField("peer", peer).Debug("Provisionally adding discovered neighbor to routing")
+
+	c.provisionalNeighbors.add(peer, func() {
+		if len(c.senderForDestination(peer)) > 0 {
+			return
+		}
+
+		log.WithField("peer", peer).Debug("Discovered neighbor was not connected in time, removing it from routing")
+		algorithm.ReportNeighborLost(peer)
+	})
+	algorithm.ReportNeighborDiscovered(peer)
+}
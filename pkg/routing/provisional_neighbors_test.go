@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// dtlsrPeer returns the DTLSR's peer list entry for a peer and the peer list's length.
+func dtlsrPeer(dtlsr *DTLSR, peer bpv7.EndpointID) (timestamp bpv7.DtnTime, present bool, peers int) {
+	dtlsr.dataMutex.RLock()
+	defer dtlsr.dataMutex.RUnlock()
+
+	timestamp, present = dtlsr.peers.Peers[peer]
+	peers = len(dtlsr.peers.Peers)
+	return
+}
+
+func TestCoreNeighborDiscovered(t *testing.T) {
+	tests := []struct {
+		name      string
+		connect   bool
+		remaining bool
+	}{
+		{"connection failed", false, false},
+		{"connection established", true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://node/")
+			c.provisionalNeighbors = newProvisionalNeighbors(100 * time.Millisecond)
+
+			dtlsr := NewDTLSR(c, DTLSRConfig{RecomputeTime: "1m", BroadcastTime: "1m", PurgeTime: "1m"})
+			c.SetRoutingAlgorithm(dtlsr)
+
+			peer := bpv7.MustNewEndpointID("dtn://peer/")
+
+			// Neither this node itself nor an unknown endpoint ID are added.
+			c.ReportNeighborDiscovered(c.NodeId)
+			c.ReportNeighborDiscovered(bpv7.DtnNone())
+
+			c.ReportNeighborDiscovered(peer)
+
+			if timestamp, present, peers := dtlsrPeer(dtlsr, peer); !present || timestamp != 0 {
+				t.Fatalf("discovered peer is not a connected DTLSR peer before its CLA appeared: %t, %v", present, timestamp)
+			} else if peers != 1 {
+				t.Fatalf("expected one DTLSR peer, got %d", peers)
+			}
+
+			if test.connect {
+				c.claManager.Register(newMockSender("peer", peer.String(), cla.MTCP))
+
+				deadline := time.Now().Add(time.Second)
+				for len(c.senderForDestination(peer)) == 0 {
+					if time.Now().After(deadline) {
+						t.Fatal("CLA for the discovered peer did not appear")
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+
+			time.Sleep(300 * time.Millisecond)
+
+			if _, present, _ := dtlsrPeer(dtlsr, peer); present != test.remaining {
+				t.Fatalf("expected DTLSR peer's presence to be %t after the timeout", test.remaining)
+			}
+		})
+	}
+}
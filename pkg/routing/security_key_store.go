@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// SecurityKeyStore supplies the keys to verify a received bundle's BPSec blocks. It can be set by
+// Core.SetSecurityKeyStore.
+type SecurityKeyStore interface {
+	// IntegrityKey returns the key of a security source for a BIB of the given security context, e.g.,
+	// bpv7.SecConIdentBIBIOPHMACSHA, or false if this key is unknown.
+	IntegrityKey(securitySource bpv7.EndpointID, securityContext uint64) (key []byte, ok bool)
+}
+
+// StaticSecurityKeyStore is a SecurityKeyStore of fixed keys for each security source's node, used for all security
+// contexts.
+type StaticSecurityKeyStore map[bpv7.EndpointID][]byte
+
+// IntegrityKey returns the key of the security source's node.
+func (sks StaticSecurityKeyStore) IntegrityKey(securitySource bpv7.EndpointID, _ uint64) ([]byte, bool) {
+	for node, key := range sks {
+		if node.SameNode(securitySource) {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// SetSecurityKeyStore configures the keys to verify the BIBs of received bundles. Bundles failing a verification are
+// deleted, while BIBs of security sources without a known key are not verified. This should be configured before any
+// bundles are processed.
+func (c *Core) SetSecurityKeyStore(keyStore SecurityKeyStore) {
+	c.securityKeys = keyStore
+}
+
+// checkIntegrity verifies each BIB of a bundle whose security source's key is known by the SecurityKeyStore. If this
+// method returns false, some security target was altered or the BIB was forged.
+func (c *Core) checkIntegrity(bp BundleDescriptor) bool {
+	if c.securityKeys == nil {
+		return true
+	}
+
+	b := bp.MustBundle()
+	cbs, err := b.ExtensionBlocks(bpv7.ExtBlockTypeBlockIntegrityBlock)
+	if err != nil {
+		return true
+	}
+
+	for _, cb := range cbs {
+		bib, ok := cb.Value.(*bpv7.BIBIOPHMACSHA2)
+		if !ok {
+			continue
+		}
+
+		securitySource := bib.Asb.BundleSecuritySource(b)
+		key, ok := c.securityKeys.IntegrityKey(securitySource, bib.Asb.SecurityContextID)
+		if !ok {
+			log.WithFields(log.Fields{
+				"bundle":          bp.ID().String(),
+				"security source": securitySource,
+			}).Debug("Bundle's BIB has no known key for its security source")
+			continue
+		}
+
+		if err := bib.VerifyTargets(*b, cb.BlockNumber, key); err != nil {
+			log.WithFields(log.Fields{
+				"bundle":          bp.ID().String(),
+				"security source": securitySource,
+				"error":           err,
+			}).Warn("Bundle failed the verification of its BIB")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCoreIntegrityVerification(t *testing.T) {
+	reporterKey := bytes.Repeat([]byte{0x23}, 32)
+	malloryKey := bytes.Repeat([]byte{0x42}, 32)
+
+	tests := []struct {
+		name      string
+		keyStore  SecurityKeyStore
+		key       []byte
+		delivered bool
+	}{
+		{"no key store", nil, malloryKey, true},
+		{"no integrity block", StaticSecurityKeyStore{bpv7.MustNewEndpointID("dtn://reporter/"): reporterKey}, nil, true},
+		{"genuine integrity block", StaticSecurityKeyStore{bpv7.MustNewEndpointID("dtn://reporter/"): reporterKey}, reporterKey, true},
+		{"forged integrity block", StaticSecurityKeyStore{bpv7.MustNewEndpointID("dtn://reporter/"): reporterKey}, malloryKey, false},
+		{"unknown security source", StaticSecurityKeyStore{bpv7.MustNewEndpointID("dtn://other/"): reporterKey}, malloryKey, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCore(t, "dtn://node/")
+			if test.keyStore != nil {
+				c.SetSecurityKeyStore(test.keyStore)
+			}
+
+			reporter := newMockSender("reporter", "dtn://reporter/", cla.MTCP)
+			c.claManager.Register(reporter)
+
+			appAgent := newMockAgent(bpv7.MustNewEndpointID("dtn://node/app"))
+			c.RegisterApplicationAgent(appAgent)
+
+			bldr := bpv7.Builder().
+				CRC(bpv7.CRC32).
+				BundleCtrlFlags(bpv7.StatusRequestDeletion).
+				Source("dtn://reporter/app").
+				Destination("dtn://node/app").
+				ReportTo("dtn://reporter/").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world"))
+			if test.key != nil {
+				bldr = bldr.IntegrityBlockForPayload(test.key, bpv7.HMAC256SHA256)
+			}
+
+			b, err := bldr.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.receive(NewBundleDescriptorFromBundle(b, c.Store))
+
+			if _, delivered := appAgent.received(250 * time.Millisecond); delivered != test.delivered {
+				t.Fatalf("expected delivery = %t, got %t", test.delivered, delivered)
+			}
+
+			reporter.mutex.Lock()
+			defer reporter.mutex.Unlock()
+
+			if test.delivered {
+				if len(reporter.sent) != 0 {
+					t.Fatalf("expected no status report, got %d bundles", len(reporter.sent))
+				}
+				return
+			} else if len(reporter.sent) != 1 {
+				t.Fatalf("expected one status report, got %d bundles", len(reporter.sent))
+			}
+
+			ar, err := reporter.sent[0].AdministrativeRecord()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sr, ok := ar.(*bpv7.StatusReport); !ok {
+				t.Fatalf("administrative record is no status report: %T", ar)
+			} else if sr.ReportReason != bpv7.FailedSecurityOperation {
+				t.Fatalf("expected reason %v, got %v", bpv7.FailedSecurityOperation, sr.ReportReason)
+			} else if sips := sr.StatusInformations(); len(sips) != 1 || sips[0] != bpv7.DeletedBundle {
+				t.Fatalf("expected status %v, got %v", bpv7.DeletedBundle, sips)
+			}
+		})
+	}
+}
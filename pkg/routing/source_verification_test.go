@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestCoreSourceVerification(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		prevNode string
+		peer     string
+		accepted bool
+	}{
+		{"origin is peer", "dtn://peer/app", "", "dtn://peer/", true},
+		{"origin is peer with previous node block", "dtn://peer/app", "dtn://peer/", "dtn://peer/", true},
+		{"forwarded by peer", "dtn://origin/app", "dtn://peer/", "dtn://peer/", true},
+		{"unverified prefix", "ipn:23.1", "", "dtn://peer/", true},
+		{"spoofed source", "dtn://victim/app", "", "dtn://peer/", false},
+		{"spoofed source with previous node block", "dtn://victim/app", "dtn://victim/", "dtn://peer/", false},
+		{"unknown peer", "dtn://peer/app", "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", RoutingConf{
+				Algorithm:          "epidemic",
+				SourceVerification: []string{"dtn://"},
+			})
+
+			builder := bpv7.Builder().
+				Source(test.source).
+				Destination("dtn://elsewhere/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte("hello world"))
+			if test.prevNode != "" {
+				builder = builder.Canonical(bpv7.NewPreviousNodeBlock(bpv7.MustNewEndpointID(test.prevNode)))
+			}
+
+			b, err := builder.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bp := NewBundleDescriptorFromBundle(b, c.Store)
+			if test.peer != "" {
+				bp.PreviousNode = bpv7.MustNewEndpointID(test.peer)
+			}
+			_ = bp.Sync()
+
+			c.receive(bp)
+
+			if accepted := c.Store.KnowsBundle(b.ID()); accepted != test.accepted {
+				t.Fatalf("bundle was accepted: %t, expected %t", accepted, test.accepted)
+			}
+		})
+	}
+}
+
+func TestNewSourceVerificationInvalid(t *testing.T) {
+	if _, err := newSourceVerification([]string{"dtn://", ""}); err == nil {
+		t.Fatal("empty source prefix was accepted")
+	}
+}
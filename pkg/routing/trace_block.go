@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+// traceBlock returns a Bundle's TraceBlock, if present.
+func traceBlock(bp BundleDescriptor) (*bpv7.TraceBlock, bool) {
+	if cb, err := bp.MustBundle().ExtensionBlock(bpv7.ExtBlockTypeTraceBlock); err == nil {
+		tb, ok := cb.Value.(*bpv7.TraceBlock)
+		return tb, ok
+	}
+	return nil, false
+}
+
+// appendTrace adds this node to a forwarded Bundle's TraceBlock, limited to the RoutingConf's TraceBlockLimit. A
+// TraceBlock is attached if missing.
+func (c *Core) appendTrace(bp BundleDescriptor) {
+	if tb, ok := traceBlock(bp); ok {
+		tb.Append(c.NodeId, c.traceBlockLimit)
+		return
+	}
+
+	tb := bpv7.NewTraceBlock()
+	tb.Append(c.NodeId, c.traceBlockLimit)
+	if err := bp.MustBundle().AddExtensionBlock(bpv7.NewCanonicalBlock(0, 0, tb)); err != nil {
+		log.WithField("bundle", bp.ID().String()).WithError(err).Error("Error attaching TraceBlock")
+	}
+}
+
+// filterTraced excludes ConvergenceSenders whose peer is already part of a Bundle's TraceBlock, as forwarding the
+// Bundle to them would result in a loop. Bundles without a TraceBlock are not affected.
+func filterTraced(bp BundleDescriptor, css []cla.ConvergenceSender) []cla.ConvergenceSender {
+	tb, ok := traceBlock(bp)
+	if !ok || len(css) == 0 {
+		return css
+	}
+
+	var filtered []cla.ConvergenceSender
+	for _, cs := range css {
+		if tb.Contains(cs.GetPeerEndpointID()) {
+			log.WithFields(log.Fields{
+				"bundle":             bp.ID().String(),
+				"convergence-sender": cs,
+			}).Debug("TraceBlock excludes Convergence Sender for Bundle forwarding")
+			continue
+		}
+		filtered = append(filtered, cs)
+	}
+	return filtered
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestCoreTraceBlock(t *testing.T) {
+	// The TraceBlock is independent of a suppressed PreviousNodeBlock.
+	for _, suppressPrevNode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("suppress-previous-node=%t", suppressPrevNode), func(t *testing.T) {
+			c := newTestCoreConf(t, "dtn://node/", RoutingConf{
+				Algorithm:            "epidemic",
+				TraceBlockLimit:      2,
+				SuppressPreviousNode: suppressPrevNode,
+			})
+
+			traced := newMockSender("traced", "dtn://traced/", cla.MTCP)
+			other := newMockSender("other", "dtn://other/", cla.MTCP)
+			c.claManager.Register(traced)
+			c.claManager.Register(other)
+
+			b, err := bpv7.Builder().
+				Source("dtn://origin/app").
+				Destination("dtn://elsewhere/app").
+				CreationTimestampNow().
+				Lifetime("10m").
+				Canonical(bpv7.NewTraceBlock(
+					bpv7.MustNewEndpointID("dtn://origin/"), bpv7.MustNewEndpointID("dtn://traced/"))).
+				PayloadBlock([]byte("hello world")).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.dispatching(NewBundleDescriptorFromBundle(b, c.Store))
+
+			for i := 0; other.sentBundles() == 0; i++ {
+				if i == 100 {
+					t.Fatal("bundle was not forwarded")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			if n := traced.sentBundles(); n != 0 {
+				t.Fatalf("bundle was forwarded %d times to a traced node", n)
+			}
+
+			other.mutex.Lock()
+			sent := other.sent[0]
+			other.mutex.Unlock()
+
+			cb, err := sent.ExtensionBlock(bpv7.ExtBlockTypeTraceBlock)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://traced/"), bpv7.MustNewEndpointID("dtn://node/")}
+			if eids := cb.Value.(*bpv7.TraceBlock).Endpoints(); !reflect.DeepEqual(eids, expected) {
+				t.Fatalf("TraceBlock holds %v, expected %v", eids, expected)
+			}
+		})
+	}
+}
+
+func TestCoreTraceBlockLimitInvalid(t *testing.T) {
+	for _, limit := range []int{-1, bpv7.MaxTraceBlockLength + 1} {
+		conf := RoutingConf{Algorithm: "epidemic", TraceBlockLimit: limit}
+		if _, err := NewCore(t.TempDir(), bpv7.MustNewEndpointID("dtn://node/"), false, conf, nil); err == nil {
+			t.Fatalf("trace block limit %d was accepted", limit)
+		}
+	}
+}
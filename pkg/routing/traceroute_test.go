@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dtn7/dtn7-go/pkg/agent"
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+	"github.com/dtn7/dtn7-go/pkg/cla/mtcp"
+)
+
+// testListenAddress returns a currently free local TCP address.
+func testListenAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+func TestTracerouteLine(t *testing.T) {
+	// Create the line network a <-> b <-> c, connected via MTCP.
+	nodeIds := []string{"dtn://a/", "dtn://b/", "dtn://c/"}
+	cores := make([]*Core, len(nodeIds))
+	addrs := make([]string, len(nodeIds))
+
+	for i, nodeId := range nodeIds {
+		cores[i] = newTestCore(t, nodeId)
+		addrs[i] = testListenAddress(t)
+
+		cores[i].RegisterCLA(mtcp.NewMTCPServer(addrs[i], bpv7.MustNewEndpointID(nodeId), false), cla.MTCP, bpv7.MustNewEndpointID(nodeId))
+	}
+	time.Sleep(250 * time.Millisecond)
+
+	for i := 0; i < len(nodeIds)-1; i++ {
+		cores[i].RegisterConvergable(mtcp.NewMTCPClient(addrs[i+1], bpv7.MustNewEndpointID(nodeIds[i+1]), false))
+		cores[i+1].RegisterConvergable(mtcp.NewMTCPClient(addrs[i], bpv7.MustNewEndpointID(nodeIds[i]), false))
+	}
+	time.Sleep(250 * time.Millisecond)
+
+	tracer := bpv7.MustNewEndpointID("dtn://a/traceroute")
+	appAgent := newMockAgent(tracer)
+	cores[0].RegisterApplicationAgent(appAgent)
+
+	probe, err := agent.NewTracerouteProbe(tracer, bpv7.MustNewEndpointID("dtn://c/"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := agent.NewTraceroute(probe)
+	cores[0].SendBundle(&probe)
+
+	for !tr.Delivered() {
+		b, ok := appAgent.received(5 * time.Second)
+		if !ok {
+			t.Fatalf("probe's destination was not reached, hops: %v", tr.Hops())
+		}
+		_, _ = tr.Inspect(b)
+	}
+
+	hops := tr.Hops()
+	if len(hops) != 2 {
+		t.Fatalf("expected two hops, got %v", hops)
+	}
+
+	expected := []struct {
+		node   string
+		status bpv7.StatusInformationPos
+	}{
+		{"dtn://b/", bpv7.ForwardedBundle},
+		{"dtn://c/", bpv7.DeliveredBundle},
+	}
+	for i, e := range expected {
+		if hop := hops[i]; hop.Node != bpv7.MustNewEndpointID(e.node) || hop.Status != e.status {
+			t.Fatalf("hop %d: expected %s %v, got %v", i, e.node, e.status, hop)
+		} else if hop.Time == bpv7.DtnTimeEpoch {
+			t.Fatalf("hop %d has no reported time", i)
+		}
+	}
+
+	if hops[0].Latency > hops[1].Latency {
+		t.Fatalf("hops are not ordered by their latency: %v", hops)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// unknownEndpointPolicy handles Bundles addressed to this node, but to an endpoint without a registered application
+// agent, see RoutingConf's UnknownEndpointPolicy.
+type unknownEndpointPolicy int
+
+const (
+	// unknownEndpointBuffer holds such Bundles until their endpoint registers.
+	unknownEndpointBuffer unknownEndpointPolicy = iota
+
+	// unknownEndpointCatchAll passes such Bundles to a catch-all endpoint.
+	unknownEndpointCatchAll
+
+	// unknownEndpointReject deletes such Bundles.
+	unknownEndpointReject
+)
+
+func (p unknownEndpointPolicy) String() string {
+	switch p {
+	case unknownEndpointBuffer:
+		return "buffer"
+
+	case unknownEndpointCatchAll:
+		return "catch-all"
+
+	case unknownEndpointReject:
+		return "reject"
+
+	default:
+		return "unknown"
+	}
+}
+
+// setUnknownEndpointPolicy from a RoutingConf.
+func (c *Core) setUnknownEndpointPolicy(routingConf RoutingConf) error {
+	switch routingConf.UnknownEndpointPolicy {
+	case "", "buffer":
+		c.unknownEndpointPolicy = unknownEndpointBuffer
+
+	case "catch-all":
+		catchAll, err := bpv7.NewEndpointID(routingConf.UnknownEndpointCatchAll)
+		if err != nil {
+			return fmt.Errorf("unknown endpoint catch-all \"%s\" is invalid: %v", routingConf.UnknownEndpointCatchAll, err)
+		}
+
+		c.unknownEndpointPolicy = unknownEndpointCatchAll
+		c.unknownEndpointCatchAll = catchAll
+
+	case "reject":
+		c.unknownEndpointPolicy = unknownEndpointReject
+
+	default:
+		return fmt.Errorf("invalid unknown endpoint policy %s", routingConf.UnknownEndpointPolicy)
+	}
+
+	if routingConf.UnknownEndpointBuffer != "" {
+		if buffer, err := time.ParseDuration(routingConf.UnknownEndpointBuffer); err != nil {
+			return fmt.Errorf("unknown endpoint buffer \"%s\" is invalid: %v", routingConf.UnknownEndpointBuffer, err)
+		} else if buffer <= 0 {
+			return fmt.Errorf("unknown endpoint buffer \"%s\" is not positive", routingConf.UnknownEndpointBuffer)
+		} else {
+			c.unknownEndpointBuffer = buffer
+		}
+	}
+
+	return nil
+}
+
+// isUnknownLocalEndpoint checks if a Bundle is addressed to this node, e.g., "dtn://node/app" for "dtn://node/", but
+// to an endpoint without a registered application agent. Bundles for the Node ID itself, administrative records, and
+// fragments are always passed to the local delivery, as they are processed by the node itself.
+func (c *Core) isUnknownLocalEndpoint(b *bpv7.Bundle) bool {
+	destination := b.PrimaryBlock.Destination
+
+	return c.NodeId.SameNode(destination) &&
+		destination != c.NodeId &&
+		!destination.IsAdministrativeEndpoint() &&
+		!b.IsAdministrativeRecord() &&
+		!b.PrimaryBlock.BundleControlFlags.Has(bpv7.IsFragment) &&
+		!c.agentManager.HasEndpoint(destination)
+}
+
+// unknownEndpointDelivery handles a Bundle for an unknown local endpoint based on the unknownEndpointPolicy.
+func (c *Core) unknownEndpointDelivery(bp BundleDescriptor) {
+	logger := log.WithFields(log.Fields{
+		"bundle":      bp.ID().String(),
+		"destination": bp.MustBundle().PrimaryBlock.Destination,
+		"policy":      c.unknownEndpointPolicy,
+	})
+
+	switch c.unknownEndpointPolicy {
+	case unknownEndpointReject:
+		logger.Info("Rejecting bundle for an unknown local endpoint")
+
+		c.bundleDeletion(bp, bpv7.DestEndpointUnintelligible)
+		return
+
+	case unknownEndpointCatchAll:
+		b := bp.MustBundle()
+		lifetime := time.Until(b.ExpiryTime())
+
+		if !c.agentManager.HasEndpoint(c.unknownEndpointCatchAll) {
+			logger.Warn("No application agent is registered for the catch-all endpoint, buffering bundle")
+		} else if lifetime < time.Millisecond {
+			logger.Info("Bundle for an unknown local endpoint expired")
+
+			c.bundleDeletion(bp, bpv7.LifetimeExpired)
+			return
+		} else if err := c.encapsulatedDelivery(b, c.unknownEndpointCatchAll, lifetime); err != nil {
+			logger.WithError(err).Warn("Delivering bundle to the catch-all endpoint erred, buffering bundle")
+		} else {
+			logger.Info("Delivered bundle for an unknown local endpoint to the catch-all endpoint")
+
+			bp.PurgeConstraints()
+			_ = bp.Sync()
+			return
+		}
+	}
+
+	if c.unknownEndpointBuffer > 0 && time.Since(bp.Timestamp) > c.unknownEndpointBuffer {
+		logger.WithField("buffer", c.unknownEndpointBuffer).Info("Bundle for an unknown local endpoint exceeded the buffer")
+
+		c.bundleDeletion(bp, bpv7.DestEndpointUnintelligible)
+		return
+	}
+
+	// Contraindicated bundles are pending and dispatched again, e.g., by CheckPendingBundles, until their endpoint
+	// registers.
+	logger.Info("Buffering bundle for an unknown local endpoint")
+	c.bundleContraindicated(bp)
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+// chooseWeighted picks an index with a probability proportional to its weight, based on a random number generator
+// returning values in [0, 1), e.g., rand.Float64. Non-positive weights are never picked. If no weight is positive, -1
+// is returned.
+func chooseWeighted(weights []float64, random func() float64) int {
+	var total float64
+	for _, weight := range weights {
+		if weight > 0 {
+			total += weight
+		}
+	}
+
+	if total <= 0 {
+		return -1
+	}
+
+	threshold := random() * total
+	last := -1
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+
+		if threshold < weight {
+			return i
+		}
+		threshold -= weight
+		last = i
+	}
+
+	// Floating point inaccuracies might exceed the last weight.
+	return last
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package routing
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+	"github.com/dtn7/dtn7-go/pkg/cla"
+)
+
+func TestChooseWeightedFrequencies(t *testing.T) {
+	const trials = 100000
+
+	tests := []struct {
+		name    string
+		weights []float64
+	}{
+		{"uniform", []float64{1, 1, 1, 1}},
+		{"predictabilities", []float64{0.1, 0.2, 0.3, 0.4}},
+		{"dominant", []float64{0.9, 0.05, 0.05}},
+		{"ignored non-positive", []float64{0.5, 0, -1, 0.5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(23))
+
+			counts := make([]int, len(test.weights))
+			for i := 0; i < trials; i++ {
+				counts[chooseWeighted(test.weights, random.Float64)]++
+			}
+
+			var total float64
+			for _, weight := range test.weights {
+				if weight > 0 {
+					total += weight
+				}
+			}
+
+			for i, weight := range test.weights {
+				expected := math.Max(weight, 0) / total
+				if actual := float64(counts[i]) / trials; math.Abs(actual-expected) > 0.01 {
+					t.Fatalf("index %d was chosen with a frequency of %.4f, expected %.4f", i, actual, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestChooseWeightedNoCandidate(t *testing.T) {
+	for _, weights := range [][]float64{nil, {}, {0, 0}, {-1}} {
+		if i := chooseWeighted(weights, rand.Float64); i != -1 {
+			t.Fatalf("%v resulted in index %d", weights, i)
+		}
+	}
+}
+
+func TestProphetWeightedSelection(t *testing.T) {
+	c := newTestCore(t, "dtn://node/")
+
+	prophet := NewProphet(c, ProphetConfig{PInit: 0.75, Beta: 0.25, Gamma: 0.98, AgeInterval: "1m", WeightedSelection: true})
+	c.SetRoutingAlgorithm(prophet)
+
+	destination := bpv7.MustNewEndpointID("dtn://destination/")
+	predictabilities := map[string]float64{"dtn://peer-a/": 0.2, "dtn://peer-b/": 0.8, "dtn://peer-c/": 0}
+
+	for peerEid, pred := range predictabilities {
+		peer := newMockSender(peerEid, peerEid, cla.MTCP)
+		c.claManager.Register(peer)
+
+		prophet.dataMutex.Lock()
+		prophet.peerPredictabilities[peer.peer] = map[bpv7.EndpointID]float64{destination: pred}
+		prophet.dataMutex.Unlock()
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		b, err := bpv7.Builder().
+			CRC(bpv7.CRC32).
+			Source(fmt.Sprintf("dtn://node/app-%d", i)).
+			Destination(destination).
+			CreationTimestampNow().
+			Lifetime("10m").
+			PayloadBlock([]byte("hello world")).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		css, _ := prophet.SenderForBundle(NewBundleDescriptorFromBundle(b, c.Store))
+		if len(css) != 1 {
+			t.Fatalf("weighted selection resulted in %d senders", len(css))
+		}
+		counts[css[0].GetPeerEndpointID().String()]++
+	}
+
+	if counts["dtn://peer-c/"] != 0 {
+		t.Fatalf("peer without a better predictability was chosen %d times", counts["dtn://peer-c/"])
+	} else if counts["dtn://peer-a/"] == 0 || counts["dtn://peer-b/"] <= counts["dtn://peer-a/"] {
+		t.Fatalf("selection does not follow the predictabilities: %v", counts)
+	}
+}
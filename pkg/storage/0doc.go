@@ -2,5 +2,9 @@
 //
 // SPDX-License-Identifier: GPL-3.0-or-later
 
-// Package storage provides a Bundle Storage based on BadgerHold, a frontend for the badger NoSQL store.
+// Package storage provides a Bundle Storage, defined by the BundleStore interface.
+//
+// The Store is based on BadgerHold, a frontend for the badger NoSQL store, and keeps each Bundle in its own file. The
+// BBoltStore and the SQLiteStore keep both Bundles and their meta data in a single bbolt or SQLite database file. The
+// MemoryStore keeps both only in memory. NewBundleStore creates any of them by name.
 package storage
@@ -0,0 +1,301 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+var (
+	// bucketItems maps BundleItem IDs to their gob encoded BundleItems.
+	bucketItems = []byte("items")
+
+	// bucketBundles maps BundlePart Filenames to their serialized Bundles.
+	bucketBundles = []byte("bundles")
+
+	// bucketDestinations is the secondary index of the BundleItems' Destinations. Each key is the Destination and the
+	// BundleItem's ID, separated by a zero byte.
+	bucketDestinations = []byte("destinations")
+)
+
+// BBoltStore is a BundleStore, persisting both Bundles and their BundleItems in a single bbolt database file.
+//
+// BundleItems are gob encoded, as in the Store. Thus, types within their Properties must be registered at gob.
+type BBoltStore struct {
+	db *bolt.DB
+}
+
+// NewBBoltStore creates a new BBoltStore or opens an existing BBoltStore from the given database file.
+func NewBBoltStore(file string) (s *BBoltStore, err error) {
+	if err = os.MkdirAll(path.Dir(file), 0700); err != nil {
+		return
+	}
+
+	db, err := bolt.Open(file, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{bucketItems, bucketBundles, bucketDestinations} {
+			if _, bucketErr := tx.CreateBucketIfNotExists(bucket); bucketErr != nil {
+				return bucketErr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return
+	}
+
+	s = &BBoltStore{db: db}
+	return
+}
+
+// Close the BBoltStore. It must not be used afterwards.
+func (s *BBoltStore) Close() error {
+	return s.db.Close()
+}
+
+// getItem reads and decodes a BundleItem within a transaction. Its BundleParts are loaded from this BBoltStore.
+func (s *BBoltStore) getItem(tx *bolt.Tx, id string) (bi BundleItem, err error) {
+	data := tx.Bucket(bucketItems).Get([]byte(id))
+	if data == nil {
+		err = ErrNotFound
+		return
+	}
+
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&bi); err != nil {
+		return
+	}
+
+	for i := range bi.Parts {
+		bi.Parts[i].loader = s.loader(bi.Parts[i].Filename)
+	}
+	return
+}
+
+// putItem encodes and writes a BundleItem within a transaction. The destination index is updated as well.
+func (s *BBoltStore) putItem(tx *bolt.Tx, bi BundleItem) error {
+	if prev, err := s.getItem(tx, bi.Id); err == nil && prev.Destination != bi.Destination {
+		if err := tx.Bucket(bucketDestinations).Delete(destinationKey(prev.Destination, prev.Id)); err != nil {
+			return err
+		}
+	}
+
+	var buff bytes.Buffer
+	if err := gob.NewEncoder(&buff).Encode(bi); err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(bucketItems).Put([]byte(bi.Id), buff.Bytes()); err != nil {
+		return err
+	}
+	return tx.Bucket(bucketDestinations).Put(destinationKey(bi.Destination, bi.Id), []byte{})
+}
+
+// destinationKey of a BundleItem within the destination index.
+func destinationKey(destination, id string) []byte {
+	return append(append([]byte(destination), 0), id...)
+}
+
+// loader returns a function to load a serialized Bundle from this BBoltStore.
+func (s *BBoltStore) loader(key string) func(func(io.Reader) (bpv7.Bundle, error)) (bpv7.Bundle, error) {
+	return func(parse func(io.Reader) (bpv7.Bundle, error)) (b bpv7.Bundle, err error) {
+		err = s.db.View(func(tx *bolt.Tx) error {
+			data := tx.Bucket(bucketBundles).Get([]byte(key))
+			if data == nil {
+				return ErrNotFound
+			}
+
+			var parseErr error
+			b, parseErr = parse(bytes.NewReader(data))
+			return parseErr
+		})
+		return
+	}
+}
+
+// Push a new/received Bundle to the BBoltStore.
+func (s *BBoltStore) Push(b bpv7.Bundle) error {
+	bi := newBundleItem(b, "")
+	part := bi.Parts[0]
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		biStore, err := s.getItem(tx, bi.Id)
+		switch {
+		case err == ErrNotFound:
+			log.WithField("bundle", b.ID().String()).Info("Bundle ID is unknown, inserting BundleItem")
+
+		case err != nil:
+			return err
+
+		case !bi.Fragmented:
+			log.WithField("bundle", b.ID().String()).Debug("Bundle ID is known, ignoring push")
+			return nil
+
+		case !biStore.Fragmented:
+			log.WithField("bundle", b.ID().String()).Debug("Received bundle fragment, whole bundle is already stored")
+			return nil
+
+		default:
+			for _, storedPart := range biStore.Parts {
+				if storedPart.FragmentOffset == part.FragmentOffset &&
+					storedPart.TotalDataLength == part.TotalDataLength {
+					log.WithField("bundle", b.ID().String()).Debug("Received bundle fragment, which is already stored")
+					return nil
+				}
+			}
+
+			log.WithField("bundle", b.ID().String()).Info("Received new bundle fragment, updating BundleItem")
+
+			biStore.Parts = append(biStore.Parts, part)
+			bi = biStore
+		}
+
+		var buff bytes.Buffer
+		if err := b.WriteBundle(&buff); err != nil {
+			return err
+		}
+		if err := tx.Bucket(bucketBundles).Put([]byte(part.Filename), buff.Bytes()); err != nil {
+			return err
+		}
+
+		return s.putItem(tx, bi)
+	})
+}
+
+// Update an existing BundleItem.
+func (s *BBoltStore) Update(bi BundleItem) error {
+	log.WithField("bundle", bi.Id).Debug("Store updates BundleItem")
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketItems).Get([]byte(bi.Id)) == nil {
+			return ErrNotFound
+		}
+
+		return s.putItem(tx, bi)
+	})
+}
+
+// Delete a BundleItem, represented by the "scrubbed" BundleID.
+func (s *BBoltStore) Delete(bid bpv7.BundleID) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bi, err := s.getItem(tx, bid.Scrub().String())
+		if err == ErrNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		log.WithField("bundle", bid).Info("Store deletes BundleItem")
+
+		for _, bp := range bi.Parts {
+			if err := tx.Bucket(bucketBundles).Delete([]byte(bp.Filename)); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Bucket(bucketDestinations).Delete(destinationKey(bi.Destination, bi.Id)); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketItems).Delete([]byte(bi.Id))
+	})
+}
+
+// DeleteExpired removes all expired Bundles.
+func (s *BBoltStore) DeleteExpired() {
+	now := time.Now()
+	bis, err := s.query(func(bi BundleItem) bool { return bi.Expires.Before(now) })
+	if err != nil {
+		log.WithError(err).Warn("Failed to get expired Bundles")
+		return
+	}
+
+	for _, bi := range bis {
+		logger := log.WithField("bundle", bi.Id)
+		if err := s.Delete(bi.BId); err != nil {
+			logger.WithError(err).Warn("Failed to delete expired Bundle")
+		} else {
+			logger.Info("Deleted expired Bundle")
+		}
+	}
+}
+
+// query all BundleItems matching a filter.
+func (s *BBoltStore) query(filter func(BundleItem) bool) (bis []BundleItem, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketItems).ForEach(func(k, _ []byte) error {
+			bi, itemErr := s.getItem(tx, string(k))
+			if itemErr != nil {
+				return itemErr
+			}
+
+			if filter(bi) {
+				bis = append(bis, bi)
+			}
+			return nil
+		})
+	})
+	return
+}
+
+// QueryId fetches the BundleItem for the requested BundleID.
+func (s *BBoltStore) QueryId(bid bpv7.BundleID) (bi BundleItem, err error) {
+	err = s.db.View(func(tx *bolt.Tx) (itemErr error) {
+		bi, itemErr = s.getItem(tx, bid.Scrub().String())
+		return
+	})
+	return
+}
+
+// QueryPending fetches all pending Bundles.
+func (s *BBoltStore) QueryPending() ([]BundleItem, error) {
+	return s.query(func(bi BundleItem) bool { return bi.Pending })
+}
+
+// QueryDestination fetches all Bundles addressed to an Endpoint ID, based on the destination index.
+func (s *BBoltStore) QueryDestination(eid bpv7.EndpointID) (bis []BundleItem, err error) {
+	prefix := destinationKey(eid.String(), "")
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(bucketDestinations).Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			bi, itemErr := s.getItem(tx, string(k[len(prefix):]))
+			if itemErr != nil {
+				return itemErr
+			}
+			bis = append(bis, bi)
+		}
+		return nil
+	})
+	return
+}
+
+// QueryAll fetches all stored Bundles.
+func (s *BBoltStore) QueryAll() ([]BundleItem, error) {
+	return s.query(func(_ BundleItem) bool { return true })
+}
+
+// KnowsBundle checks if such a Bundle is known.
+func (s *BBoltStore) KnowsBundle(bid bpv7.BundleID) bool {
+	var known bool
+	_ = s.db.View(func(tx *bolt.Tx) error {
+		known = tx.Bucket(bucketItems).Get([]byte(bid.Scrub().String())) != nil
+		return nil
+	})
+	return known
+}
@@ -7,6 +7,7 @@ package storage
 import (
 	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"time"
@@ -23,6 +24,9 @@ type BundleItem struct {
 	Pending bool      `badgerholdIndex:"Pending"`
 	Expires time.Time `badgerholdIndex:"Expires"`
 
+	// Destination is the Bundle's destination Endpoint ID as a string, indexed for QueryDestination.
+	Destination string `badgerholdIndex:"Destination"`
+
 	Fragmented bool
 	Parts      []BundlePart
 
@@ -62,10 +66,15 @@ func (bi BundleItem) IsComplete() bool {
 // BundlePart links a BundleItem to a Bundle with possible information
 // regarding fragmentation.
 type BundlePart struct {
+	// Filename of the serialized Bundle. For a BBoltStore or SQLiteStore, this is the key within its database.
 	Filename string
 
 	FragmentOffset  uint64
 	TotalDataLength uint64
+
+	// loader reads the serialized Bundle with the given parse function if it is not stored as a file, e.g., by a
+	// BBoltStore.
+	loader func(parse func(io.Reader) (bpv7.Bundle, error)) (bpv7.Bundle, error)
 }
 
 // storeBundle serializes the Bundle of a BundleItem/BundlePart to the disk.
@@ -82,12 +91,28 @@ func (bp BundlePart) deleteBundle() error {
 	return os.Remove(bp.Filename)
 }
 
-// Load the Bundle struct from the disk.
+// Load the Bundle struct from the disk. An expired Bundle is still loaded, as it is stored until being deleted.
 func (bp BundlePart) Load() (b bpv7.Bundle, err error) {
+	return bp.load(bpv7.ParseBundleIgnoringLifetime)
+}
+
+// LoadValidated loads the Bundle struct from the disk, like Load, but skips its CheckValid. This must only be used for
+// a Bundle which was already validated before being stored, compare bpv7.ParseValidatedBundle.
+func (bp BundlePart) LoadValidated() (b bpv7.Bundle, err error) {
+	return bp.load(bpv7.ParseValidatedBundle)
+}
+
+// load the Bundle struct from the disk with the given parse function.
+func (bp BundlePart) load(parse func(io.Reader) (bpv7.Bundle, error)) (b bpv7.Bundle, err error) {
+	if bp.loader != nil {
+		return bp.loader(parse)
+	}
+
 	if f, fErr := os.Open(bp.Filename); fErr != nil {
 		err = fErr
 	} else {
-		b, err = bpv7.ParseBundle(f)
+		defer f.Close()
+		b, err = parse(f)
 	}
 	return
 }
@@ -116,6 +141,8 @@ func newBundleItem(b bpv7.Bundle, storagePath string) (bi BundleItem) {
 		Pending: false,
 		Expires: calcExpirationDate(b),
 
+		Destination: b.PrimaryBlock.Destination.String(),
+
 		Fragmented: b.PrimaryBlock.HasFragmentation(),
 
 		Properties: make(map[string]interface{}),
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/timshannon/badgerhold"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// ErrNotFound is returned by a BundleStore's QueryId for unknown Bundles.
+var ErrNotFound = badgerhold.ErrNotFound
+
+// BundleStore is a persistent storage for Bundles together with their meta data, wrapped in BundleItems.
+//
+// The Store, using BadgerHold and one file per Bundle, the BBoltStore and the SQLiteStore, using a single bbolt or
+// SQLite database file, and the volatile MemoryStore are implementations. NewBundleStore selects one of them by its
+// backend name.
+type BundleStore interface {
+	// Close the BundleStore. It must not be used afterwards.
+	Close() error
+
+	// Push a new/received Bundle or Bundle fragment to the BundleStore.
+	Push(b bpv7.Bundle) error
+
+	// Update an existing BundleItem.
+	Update(bi BundleItem) error
+
+	// Delete a BundleItem, represented by the "scrubbed" BundleID.
+	Delete(bid bpv7.BundleID) error
+
+	// DeleteExpired removes all expired Bundles.
+	DeleteExpired()
+
+	// QueryId fetches the BundleItem for the requested BundleID or returns ErrNotFound.
+	QueryId(bid bpv7.BundleID) (BundleItem, error)
+
+	// QueryPending fetches all pending Bundles.
+	QueryPending() ([]BundleItem, error)
+
+	// QueryDestination fetches all Bundles addressed to an Endpoint ID, based on a secondary index.
+	QueryDestination(eid bpv7.EndpointID) ([]BundleItem, error)
+
+	// QueryAll fetches all stored Bundles.
+	QueryAll() ([]BundleItem, error)
+
+	// KnowsBundle checks if such a Bundle is known.
+	KnowsBundle(bid bpv7.BundleID) bool
+}
+
+// Names of the BundleStore backends for NewBundleStore.
+const (
+	// BackendBadgerHold selects the Store, which is the default.
+	BackendBadgerHold = "badgerhold"
+
+	// BackendBBolt selects the BBoltStore.
+	BackendBBolt = "bbolt"
+
+	// BackendSQLite selects the SQLiteStore.
+	BackendSQLite = "sqlite"
+
+	// BackendMemory selects the MemoryStore.
+	BackendMemory = "memory"
+)
+
+const (
+	// bboltStoreFile is the BBoltStore's database file within a NewBundleStore's directory.
+	bboltStoreFile = "store.db"
+
+	// sqliteStoreFile is the SQLiteStore's database file within a NewBundleStore's directory.
+	sqliteStoreFile = "store.sqlite"
+)
+
+// NewBundleStore creates or opens a BundleStore of the named backend within the given directory. An empty backend
+// name selects the default BackendBadgerHold. The directory is ignored for the BackendMemory.
+func NewBundleStore(backend, dir string) (BundleStore, error) {
+	switch backend {
+	case "", BackendBadgerHold:
+		return NewStore(dir)
+
+	case BackendBBolt:
+		return NewBBoltStore(filepath.Join(dir, bboltStoreFile))
+
+	case BackendSQLite:
+		return NewSQLiteStore(filepath.Join(dir, sqliteStoreFile))
+
+	case BackendMemory:
+		return NewMemoryStore(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown store backend %s", backend)
+	}
+}
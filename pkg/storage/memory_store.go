@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// MemoryStore is a volatile BundleStore, keeping both Bundles and their BundleItems in memory. Its content is lost
+// when the MemoryStore is closed, e.g., by restarting the node.
+//
+// BundleItems are gob encoded, as in the Store, keeping stored BundleItems independent of returned ones. Thus, types
+// within their Properties must be registered at gob.
+type MemoryStore struct {
+	mutex sync.RWMutex
+
+	// items maps BundleItem IDs to their gob encoded BundleItems.
+	items map[string][]byte
+
+	// bundles maps BundlePart Filenames to their serialized Bundles.
+	bundles map[string][]byte
+
+	// destinations is the secondary index of the BundleItems' Destinations to their IDs.
+	destinations map[string]map[string]struct{}
+}
+
+// NewMemoryStore creates a new, empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		items:        make(map[string][]byte),
+		bundles:      make(map[string][]byte),
+		destinations: make(map[string]map[string]struct{}),
+	}
+}
+
+// Close the MemoryStore, dropping its content. It must not be used afterwards.
+func (s *MemoryStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.items = nil
+	s.bundles = nil
+	s.destinations = nil
+	return nil
+}
+
+// getItem decodes a BundleItem. Its BundleParts are loaded from this MemoryStore. The mutex must be held.
+func (s *MemoryStore) getItem(id string) (bi BundleItem, err error) {
+	data, ok := s.items[id]
+	if !ok {
+		err = ErrNotFound
+		return
+	}
+
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&bi); err != nil {
+		return
+	}
+
+	for i := range bi.Parts {
+		bi.Parts[i].loader = s.loader(bi.Parts[i].Filename)
+	}
+	return
+}
+
+// putItem encodes and stores a BundleItem. The destination index is updated as well. The mutex must be held.
+func (s *MemoryStore) putItem(bi BundleItem) error {
+	var buff bytes.Buffer
+	if err := gob.NewEncoder(&buff).Encode(bi); err != nil {
+		return err
+	}
+
+	if prev, err := s.getItem(bi.Id); err == nil && prev.Destination != bi.Destination {
+		s.removeDestination(prev)
+	}
+
+	s.items[bi.Id] = buff.Bytes()
+
+	if _, ok := s.destinations[bi.Destination]; !ok {
+		s.destinations[bi.Destination] = make(map[string]struct{})
+	}
+	s.destinations[bi.Destination][bi.Id] = struct{}{}
+	return nil
+}
+
+// removeDestination removes a BundleItem from the destination index. The mutex must be held.
+func (s *MemoryStore) removeDestination(bi BundleItem) {
+	if ids, ok := s.destinations[bi.Destination]; ok {
+		delete(ids, bi.Id)
+		if len(ids) == 0 {
+			delete(s.destinations, bi.Destination)
+		}
+	}
+}
+
+// loader returns a function to load a serialized Bundle from this MemoryStore.
+func (s *MemoryStore) loader(key string) func(func(io.Reader) (bpv7.Bundle, error)) (bpv7.Bundle, error) {
+	return func(parse func(io.Reader) (bpv7.Bundle, error)) (b bpv7.Bundle, err error) {
+		s.mutex.RLock()
+		data, ok := s.bundles[key]
+		s.mutex.RUnlock()
+
+		if !ok {
+			err = ErrNotFound
+			return
+		}
+		return parse(bytes.NewReader(data))
+	}
+}
+
+// Push a new/received Bundle to the MemoryStore.
+func (s *MemoryStore) Push(b bpv7.Bundle) error {
+	bi := newBundleItem(b, "")
+	part := bi.Parts[0]
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	biStore, err := s.getItem(bi.Id)
+	switch {
+	case err == ErrNotFound:
+		log.WithField("bundle", b.ID().String()).Info("Bundle ID is unknown, inserting BundleItem")
+
+	case err != nil:
+		return err
+
+	case !bi.Fragmented:
+		log.WithField("bundle", b.ID().String()).Debug("Bundle ID is known, ignoring push")
+		return nil
+
+	case !biStore.Fragmented:
+		log.WithField("bundle", b.ID().String()).Debug("Received bundle fragment, whole bundle is already stored")
+		return nil
+
+	default:
+		for _, storedPart := range biStore.Parts {
+			if storedPart.FragmentOffset == part.FragmentOffset &&
+				storedPart.TotalDataLength == part.TotalDataLength {
+				log.WithField("bundle", b.ID().String()).Debug("Received bundle fragment, which is already stored")
+				return nil
+			}
+		}
+
+		log.WithField("bundle", b.ID().String()).Info("Received new bundle fragment, updating BundleItem")
+
+		biStore.Parts = append(biStore.Parts, part)
+		bi = biStore
+	}
+
+	var buff bytes.Buffer
+	if err := b.WriteBundle(&buff); err != nil {
+		return err
+	}
+	s.bundles[part.Filename] = buff.Bytes()
+
+	return s.putItem(bi)
+}
+
+// Update an existing BundleItem.
+func (s *MemoryStore) Update(bi BundleItem) error {
+	log.WithField("bundle", bi.Id).Debug("Store updates BundleItem")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.items[bi.Id]; !ok {
+		return ErrNotFound
+	}
+	return s.putItem(bi)
+}
+
+// Delete a BundleItem, represented by the "scrubbed" BundleID.
+func (s *MemoryStore) Delete(bid bpv7.BundleID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bi, err := s.getItem(bid.Scrub().String())
+	if err == ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	log.WithField("bundle", bid).Info("Store deletes BundleItem")
+
+	for _, bp := range bi.Parts {
+		delete(s.bundles, bp.Filename)
+	}
+
+	s.removeDestination(bi)
+	delete(s.items, bi.Id)
+	return nil
+}
+
+// DeleteExpired removes all expired Bundles.
+func (s *MemoryStore) DeleteExpired() {
+	now := time.Now()
+	bis, err := s.query(func(bi BundleItem) bool { return bi.Expires.Before(now) })
+	if err != nil {
+		log.WithError(err).Warn("Failed to get expired Bundles")
+		return
+	}
+
+	for _, bi := range bis {
+		logger := log.WithField("bundle", bi.Id)
+		if err := s.Delete(bi.BId); err != nil {
+			logger.WithError(err).Warn("Failed to delete expired Bundle")
+		} else {
+			logger.Info("Deleted expired Bundle")
+		}
+	}
+}
+
+// query all BundleItems matching a filter.
+func (s *MemoryStore) query(filter func(BundleItem) bool) (bis []BundleItem, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for id := range s.items {
+		bi, itemErr := s.getItem(id)
+		if itemErr != nil {
+			return nil, itemErr
+		}
+
+		if filter(bi) {
+			bis = append(bis, bi)
+		}
+	}
+	return
+}
+
+// QueryId fetches the BundleItem for the requested BundleID.
+func (s *MemoryStore) QueryId(bid bpv7.BundleID) (BundleItem, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.getItem(bid.Scrub().String())
+}
+
+// QueryPending fetches all pending Bundles.
+func (s *MemoryStore) QueryPending() ([]BundleItem, error) {
+	return s.query(func(bi BundleItem) bool { return bi.Pending })
+}
+
+// QueryDestination fetches all Bundles addressed to an Endpoint ID, based on the destination index.
+func (s *MemoryStore) QueryDestination(eid bpv7.EndpointID) (bis []BundleItem, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for id := range s.destinations[eid.String()] {
+		bi, itemErr := s.getItem(id)
+		if itemErr != nil {
+			return nil, itemErr
+		}
+		bis = append(bis, bi)
+	}
+	return
+}
+
+// QueryAll fetches all stored Bundles.
+func (s *MemoryStore) QueryAll() ([]BundleItem, error) {
+	return s.query(func(_ BundleItem) bool { return true })
+}
+
+// KnowsBundle checks if such a Bundle is known.
+func (s *MemoryStore) KnowsBundle(bid bpv7.BundleID) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, known := s.items[bid.Scrub().String()]
+	return known
+}
@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/gob"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	_ "modernc.org/sqlite"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+// sqliteSchema creates the SQLiteStore's tables, if not existing.
+//
+// The items table holds the gob encoded BundleItems together with the columns being queried, while the bundles table
+// maps BundlePart Filenames to their serialized Bundles.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS items (
+	id          TEXT PRIMARY KEY,
+	destination TEXT NOT NULL,
+	pending     INTEGER NOT NULL,
+	expires     INTEGER NOT NULL,
+	item        BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS items_destination ON items (destination);
+CREATE TABLE IF NOT EXISTS bundles (
+	filename TEXT PRIMARY KEY,
+	data     BLOB NOT NULL
+);`
+
+// SQLiteStore is a BundleStore, persisting both Bundles and their BundleItems in a single SQLite database file.
+//
+// BundleItems are gob encoded, as in the Store. Thus, types within their Properties must be registered at gob.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a new SQLiteStore or opens an existing SQLiteStore from the given database file.
+func NewSQLiteStore(file string) (s *SQLiteStore, err error) {
+	if err = os.MkdirAll(path.Dir(file), 0700); err != nil {
+		return
+	}
+
+	db, err := sql.Open("sqlite", "file:"+file+"?_pragma=busy_timeout(1000)")
+	if err != nil {
+		return
+	}
+
+	// A single connection serializes all transactions, as SQLite allows only one writer at a time.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return
+	}
+
+	s = &SQLiteStore{db: db}
+	return
+}
+
+// Close the SQLiteStore. It must not be used afterwards.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// sqlQueryer is either the database or a transaction to query a single row from.
+type sqlQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// getItem reads and decodes a BundleItem, e.g., within a transaction. Its BundleParts are loaded from this SQLiteStore.
+func (s *SQLiteStore) getItem(q sqlQueryer, id string) (bi BundleItem, err error) {
+	var data []byte
+	if err = q.QueryRow(`SELECT item FROM items WHERE id = ?`, id).Scan(&data); err == sql.ErrNoRows {
+		err = ErrNotFound
+		return
+	} else if err != nil {
+		return
+	}
+
+	return s.decodeItem(data)
+}
+
+// decodeItem decodes a BundleItem, whose BundleParts are loaded from this SQLiteStore.
+func (s *SQLiteStore) decodeItem(data []byte) (bi BundleItem, err error) {
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&bi); err != nil {
+		return
+	}
+
+	for i := range bi.Parts {
+		bi.Parts[i].loader = s.loader(bi.Parts[i].Filename)
+	}
+	return
+}
+
+// putItem encodes and writes a BundleItem within a transaction, together with its queried columns.
+func (s *SQLiteStore) putItem(tx *sql.Tx, bi BundleItem) error {
+	var buff bytes.Buffer
+	if err := gob.NewEncoder(&buff).Encode(bi); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(
+		`INSERT OR REPLACE INTO items (id, destination, pending, expires, item) VALUES (?, ?, ?, ?, ?)`,
+		bi.Id, bi.Destination, bi.Pending, bi.Expires.UnixNano(), buff.Bytes())
+	return err
+}
+
+// loader returns a function to load a serialized Bundle from this SQLiteStore.
+func (s *SQLiteStore) loader(key string) func(func(io.Reader) (bpv7.Bundle, error)) (bpv7.Bundle, error) {
+	return func(parse func(io.Reader) (bpv7.Bundle, error)) (b bpv7.Bundle, err error) {
+		var data []byte
+		if err = s.db.QueryRow(`SELECT data FROM bundles WHERE filename = ?`, key).Scan(&data); err == sql.ErrNoRows {
+			err = ErrNotFound
+			return
+		} else if err != nil {
+			return
+		}
+		return parse(bytes.NewReader(data))
+	}
+}
+
+// update executes a function within a transaction, which is committed if the function succeeds.
+func (s *SQLiteStore) update(f func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Push a new/received Bundle to the SQLiteStore.
+func (s *SQLiteStore) Push(b bpv7.Bundle) error {
+	bi := newBundleItem(b, "")
+	part := bi.Parts[0]
+
+	return s.update(func(tx *sql.Tx) error {
+		biStore, err := s.getItem(tx, bi.Id)
+		switch {
+		case err == ErrNotFound:
+			log.WithField("bundle", b.ID().String()).Info("Bundle ID is unknown, inserting BundleItem")
+
+		case err != nil:
+			return err
+
+		case !bi.Fragmented:
+			log.WithField("bundle", b.ID().String()).Debug("Bundle ID is known, ignoring push")
+			return nil
+
+		case !biStore.Fragmented:
+			log.WithField("bundle", b.ID().String()).Debug("Received bundle fragment, whole bundle is already stored")
+			return nil
+
+		default:
+			for _, storedPart := range biStore.Parts {
+				if storedPart.FragmentOffset == part.FragmentOffset &&
+					storedPart.TotalDataLength == part.TotalDataLength {
+					log.WithField("bundle", b.ID().String()).Debug("Received bundle fragment, which is already stored")
+					return nil
+				}
+			}
+
+			log.WithField("bundle", b.ID().String()).Info("Received new bundle fragment, updating BundleItem")
+
+			biStore.Parts = append(biStore.Parts, part)
+			bi = biStore
+		}
+
+		var buff bytes.Buffer
+		if err := b.WriteBundle(&buff); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			`INSERT OR REPLACE INTO bundles (filename, data) VALUES (?, ?)`, part.Filename, buff.Bytes()); err != nil {
+			return err
+		}
+
+		return s.putItem(tx, bi)
+	})
+}
+
+// Update an existing BundleItem.
+func (s *SQLiteStore) Update(bi BundleItem) error {
+	log.WithField("bundle", bi.Id).Debug("Store updates BundleItem")
+
+	return s.update(func(tx *sql.Tx) error {
+		if _, err := s.getItem(tx, bi.Id); err != nil {
+			return err
+		}
+
+		return s.putItem(tx, bi)
+	})
+}
+
+// Delete a BundleItem, represented by the "scrubbed" BundleID.
+func (s *SQLiteStore) Delete(bid bpv7.BundleID) error {
+	return s.update(func(tx *sql.Tx) error {
+		bi, err := s.getItem(tx, bid.Scrub().String())
+		if err == ErrNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		log.WithField("bundle", bid).Info("Store deletes BundleItem")
+
+		for _, bp := range bi.Parts {
+			if _, err := tx.Exec(`DELETE FROM bundles WHERE filename = ?`, bp.Filename); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(`DELETE FROM items WHERE id = ?`, bi.Id)
+		return err
+	})
+}
+
+// DeleteExpired removes all expired Bundles.
+func (s *SQLiteStore) DeleteExpired() {
+	bis, err := s.query(`SELECT item FROM items WHERE expires < ?`, time.Now().UnixNano())
+	if err != nil {
+		log.WithError(err).Warn("Failed to get expired Bundles")
+		return
+	}
+
+	for _, bi := range bis {
+		logger := log.WithField("bundle", bi.Id)
+		if err := s.Delete(bi.BId); err != nil {
+			logger.WithError(err).Warn("Failed to delete expired Bundle")
+		} else {
+			logger.Info("Deleted expired Bundle")
+		}
+	}
+}
+
+// query all BundleItems selected by a SQL query on the items table.
+func (s *SQLiteStore) query(query string, args ...interface{}) (bis []BundleItem, err error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return
+		}
+
+		var bi BundleItem
+		if bi, err = s.decodeItem(data); err != nil {
+			return
+		}
+		bis = append(bis, bi)
+	}
+	err = rows.Err()
+	return
+}
+
+// QueryId fetches the BundleItem for the requested BundleID.
+func (s *SQLiteStore) QueryId(bid bpv7.BundleID) (BundleItem, error) {
+	return s.getItem(s.db, bid.Scrub().String())
+}
+
+// QueryPending fetches all pending Bundles.
+func (s *SQLiteStore) QueryPending() ([]BundleItem, error) {
+	return s.query(`SELECT item FROM items WHERE pending`)
+}
+
+// QueryDestination fetches all Bundles addressed to an Endpoint ID, based on the destination index.
+func (s *SQLiteStore) QueryDestination(eid bpv7.EndpointID) ([]BundleItem, error) {
+	return s.query(`SELECT item FROM items WHERE destination = ?`, eid.String())
+}
+
+// QueryAll fetches all stored Bundles.
+func (s *SQLiteStore) QueryAll() ([]BundleItem, error) {
+	return s.query(`SELECT item FROM items`)
+}
+
+// KnowsBundle checks if such a Bundle is known.
+func (s *SQLiteStore) KnowsBundle(bid bpv7.BundleID) bool {
+	var known bool
+	err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM items WHERE id = ?)`, bid.Scrub().String()).Scan(&known)
+	return err == nil && known
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 dtn7-go contributors
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"encoding/gob"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/dtn7/dtn7-go/pkg/bpv7"
+)
+
+func TestSQLiteStoreRestart(t *testing.T) {
+	gob.Register([]bpv7.EndpointID{})
+
+	file := path.Join(t.TempDir(), "store.sqlite")
+
+	b, err := bpv7.Builder().
+		Source("dtn://src/").
+		Destination("dtn://dest/").
+		CreationTimestampNow().
+		Lifetime("10m").
+		PayloadBlock([]byte("hello world")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := []bpv7.EndpointID{bpv7.MustNewEndpointID("dtn://peer/")}
+
+	store, err := NewSQLiteStore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Push(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if bi, err := store.QueryId(b.ID()); err != nil {
+		t.Fatal(err)
+	} else {
+		bi.Pending = true
+		bi.Properties["routing/prophet/sent"] = sent
+		if err := store.Update(bi); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restarted node must find both the Bundle and its meta data.
+	store, err = NewSQLiteStore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if !store.KnowsBundle(b.ID()) {
+		t.Fatal("Bundle is unknown after restart")
+	}
+
+	if bis, err := store.QueryPending(); err != nil {
+		t.Fatal(err)
+	} else if len(bis) != 1 {
+		t.Fatalf("Found %d pending BundleItems after restart, instead of 1", len(bis))
+	} else if props := bis[0].Properties["routing/prophet/sent"]; !reflect.DeepEqual(props, sent) {
+		t.Fatalf("Properties changed after restart: %v", props)
+	} else if b2, err := bis[0].Parts[0].Load(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(b, b2) {
+		t.Fatal("Bundle changed after restart")
+	}
+
+	if err := store.Delete(b.ID()); err != nil {
+		t.Fatal(err)
+	} else if store.KnowsBundle(b.ID()) {
+		t.Fatal("Deleted Bundle is still known")
+	} else if _, err := store.QueryId(b.ID()); err != ErrNotFound {
+		t.Fatalf("QueryId of a deleted Bundle returned %v", err)
+	}
+}
@@ -188,6 +188,18 @@ func (s *Store) QueryPending() (bis []BundleItem, err error) {
 	return
 }
 
+// QueryDestination fetches all Bundles addressed to an Endpoint ID, based on BadgerHold's Destination index.
+func (s *Store) QueryDestination(eid bpv7.EndpointID) (bis []BundleItem, err error) {
+	err = s.bh.Find(&bis, badgerhold.Where("Destination").Eq(eid.String()).Index("Destination"))
+	return
+}
+
+// QueryAll fetches all stored Bundles.
+func (s *Store) QueryAll() (bis []BundleItem, err error) {
+	err = s.bh.Find(&bis, nil)
+	return
+}
+
 // KnowsBundle checks if such a Bundle is known.
 func (s *Store) KnowsBundle(bid bpv7.BundleID) bool {
 	_, err := s.QueryId(bid)
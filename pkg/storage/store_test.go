@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"path"
 	"reflect"
 	"testing"
 	"time"
@@ -16,35 +17,50 @@ import (
 	"github.com/dtn7/dtn7-go/pkg/bpv7"
 )
 
-func testStore(t *testing.T, scenario func(store *Store)) {
-	filePath, err := ioutil.TempFile("", "store")
-	if err != nil {
-		t.Fatal(err)
-	} else if err = os.Remove(filePath.Name()); err != nil {
-		t.Fatal(err)
-	}
+// testStoreBackends creates a BundleStore of each backend within a temporary directory.
+var testStoreBackends = []struct {
+	name string
+	open func(dir string) (BundleStore, error)
+}{
+	{"badgerhold", func(dir string) (BundleStore, error) { return NewStore(dir) }},
+	{"bbolt", func(dir string) (BundleStore, error) { return NewBBoltStore(path.Join(dir, "store.db")) }},
+	{"sqlite", func(dir string) (BundleStore, error) { return NewSQLiteStore(path.Join(dir, "store.sqlite")) }},
+	{"memory", func(_ string) (BundleStore, error) { return NewMemoryStore(), nil }},
+}
 
-	dir := filePath.Name()
-	defer func() { _ = os.RemoveAll(dir) }()
+func testStore(t *testing.T, scenario func(t *testing.T, store BundleStore)) {
+	for _, backend := range testStoreBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			filePath, err := ioutil.TempFile("", "store")
+			if err != nil {
+				t.Fatal(err)
+			} else if err = os.Remove(filePath.Name()); err != nil {
+				t.Fatal(err)
+			}
 
-	store, err := NewStore(dir)
-	if err != nil {
-		t.Fatal(err)
-	}
+			dir := filePath.Name()
+			defer func() { _ = os.RemoveAll(dir) }()
 
-	scenario(store)
+			store, err := backend.open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
 
-	if err := store.Close(); err != nil {
-		t.Fatal(err)
+			scenario(t, store)
+
+			if err := store.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
 	}
 }
 
 func TestStoreNop(t *testing.T) {
-	testStore(t, func(_ *Store) {})
+	testStore(t, func(_ *testing.T, _ BundleStore) {})
 }
 
 func TestStoreBundleLife(t *testing.T) {
-	testStore(t, func(store *Store) {
+	testStore(t, func(t *testing.T, store BundleStore) {
 		b, bErr := bpv7.Builder().
 			Source("dtn://src/").
 			Destination("dtn://dest/").
@@ -113,7 +129,7 @@ func TestStoreBundleLife(t *testing.T) {
 }
 
 func TestStoreFragmented(t *testing.T) {
-	testStore(t, func(store *Store) {
+	testStore(t, func(t *testing.T, store BundleStore) {
 		payloadData := make([]byte, 1024)
 		rand.Seed(23)
 		_, _ = rand.Read(payloadData)
@@ -181,3 +197,99 @@ func TestStoreFragmented(t *testing.T) {
 		}
 	})
 }
+
+func TestStoreQueryDestination(t *testing.T) {
+	testStore(t, func(t *testing.T, store BundleStore) {
+		var bundles []bpv7.Bundle
+		for i, destination := range []string{"dtn://a/app", "dtn://b/app", "dtn://a/app"} {
+			b, err := bpv7.Builder().
+				Source("dtn://src/").
+				Destination(destination).
+				CreationTimestampNow().
+				Lifetime("10m").
+				PayloadBlock([]byte{byte(i)}).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.PrimaryBlock.CreationTimestamp = bpv7.NewCreationTimestamp(b.PrimaryBlock.CreationTimestamp.DtnTime(), uint64(i))
+
+			if err := store.Push(b); err != nil {
+				t.Fatal(err)
+			}
+			bundles = append(bundles, b)
+		}
+
+		queryIds := func(destination string) (ids []string) {
+			bis, err := store.QueryDestination(bpv7.MustNewEndpointID(destination))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, bi := range bis {
+				ids = append(ids, bi.Id)
+			}
+			return
+		}
+
+		if ids := queryIds("dtn://a/app"); len(ids) != 2 {
+			t.Fatalf("expected two bundles for dtn://a/app, got %v", ids)
+		} else if ids := queryIds("dtn://b/app"); len(ids) != 1 || ids[0] != bundles[1].ID().String() {
+			t.Fatalf("expected bundle %v for dtn://b/app, got %v", bundles[1].ID(), ids)
+		} else if ids := queryIds("dtn://c/app"); len(ids) != 0 {
+			t.Fatalf("expected no bundles for dtn://c/app, got %v", ids)
+		}
+
+		if bi, err := store.QueryId(bundles[0].ID()); err != nil {
+			t.Fatal(err)
+		} else {
+			bi.Pending = true
+			if err := store.Update(bi); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if ids := queryIds("dtn://a/app"); len(ids) != 2 {
+			t.Fatalf("expected two bundles for dtn://a/app after an update, got %v", ids)
+		}
+
+		if err := store.Delete(bundles[2].ID()); err != nil {
+			t.Fatal(err)
+		}
+
+		if ids := queryIds("dtn://a/app"); len(ids) != 1 || ids[0] != bundles[0].ID().String() {
+			t.Fatalf("expected bundle %v for dtn://a/app after a deletion, got %v", bundles[0].ID(), ids)
+		}
+	})
+}
+
+func TestNewBundleStore(t *testing.T) {
+	tests := []struct {
+		backend string
+		valid   bool
+	}{
+		{"", true},
+		{BackendBadgerHold, true},
+		{BackendBBolt, true},
+		{BackendSQLite, true},
+		{BackendMemory, true},
+		{"unknown", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			store, err := NewBundleStore(test.backend, t.TempDir())
+			if !test.valid {
+				if err == nil {
+					t.Fatal("unknown backend was accepted")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}